@@ -1,13 +1,13 @@
 package near
 
 import (
-	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
 	"math/big"
 	"strconv"
 
 	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/signer"
 	"github.com/YuxSccc/near-api-go/utils"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/near/borsh-go"
@@ -27,7 +27,7 @@ const txNonceRetryWaitBackoff = 1.5
 // Account defines access credentials for a NEAR account.
 type Account struct {
 	conn                      *Connection
-	kp                        *keystore.Ed25519KeyPair
+	signer                    signer.Signer
 	accessKeyByPublicKeyCache map[string]map[string]interface{}
 }
 
@@ -35,29 +35,43 @@ type Account struct {
 // connection c, and returns it.
 func LoadAccount(c *Connection, cfg *Config, receiverID string) (*Account, error) {
 	var (
-		a   Account
+		s   signer.Signer
 		err error
 	)
-	a.conn = c
 	if cfg.KeyPath != "" {
-		a.kp, err = keystore.LoadKeyPairFromPath(cfg.KeyPath, receiverID)
+		s, err = keystore.LoadSignerFromPath(cfg.KeyPath, receiverID)
+	} else if cfg.KeyStore != nil {
+		s, err = keystore.LoadSigner(cfg.KeyStore, cfg.NetworkID, receiverID)
 	} else {
-		a.kp, err = keystore.LoadKeyPair(cfg.NetworkID, receiverID)
+		var ks *keystore.FileSystemKeyStore
+		ks, err = keystore.NewDefaultFileSystemKeyStore()
+		if err != nil {
+			return nil, err
+		}
+		s, err = ks.Signer(cfg.NetworkID, receiverID)
 	}
 	if err != nil {
 		return nil, err
 	}
-	a.accessKeyByPublicKeyCache = make(map[string]map[string]interface{})
-	return &a, nil
+	return NewAccountWithSigner(c, s), nil
 }
 
-func NewAccount(key string, accountId string) *Account {
-	acc := &Account{
-		conn:                      nil,
-		kp:                        keystore.NewEd25519KeyPair(key, accountId),
+// NewAccountWithSigner returns an account which signs its transactions with s
+// and sends them via connection c.
+func NewAccountWithSigner(c *Connection, s signer.Signer) *Account {
+	return &Account{
+		conn:                      c,
+		signer:                    s,
 		accessKeyByPublicKeyCache: make(map[string]map[string]interface{}),
 	}
-	return acc
+}
+
+func NewAccount(key string, accountId string) *Account {
+	s, err := keystore.NewEd25519KeyPair(key, accountId).Signer()
+	if err != nil {
+		panic(err)
+	}
+	return NewAccountWithSigner(nil, s)
 }
 
 // SendMoney sends amount NEAR from account to receiverID.
@@ -107,7 +121,7 @@ func (a *Account) CreateAccount(
 func (a *Account) DeleteAccount(
 	beneficiaryID string,
 ) (map[string]interface{}, error) {
-	return a.SignAndSendTransaction(a.kp.AccountID, []Action{
+	return a.SignAndSendTransaction(a.signer.AccountID(), []Action{
 		{
 			Enum: 7,
 			DeleteAccount: DeleteAccount{
@@ -204,23 +218,22 @@ func (a *Account) signTransaction(
 	ak["nonce"] = json.Number(strconv.FormatInt(nonce, 10))
 
 	// sign transaction
-	return signTransaction(receiverID, uint64(nonce), actions, base58.Decode(blockHash),
-		a.kp.Ed25519PubKey, a.kp.Ed25519PrivKey, a.kp.AccountID)
+	return signTransaction(receiverID, uint64(nonce), actions, base58.Decode(blockHash), a.signer)
 
 }
 
-func (a *Account) findAccessKey() (publicKey ed25519.PublicKey, accessKey map[string]interface{}, err error) {
+func (a *Account) findAccessKey() (publicKey utils.PublicKey, accessKey map[string]interface{}, err error) {
 	// TODO: Find matching access key based on transaction
 	// TODO: use accountId and networkId?
-	pk := a.kp.Ed25519PubKey
-	if ak := a.accessKeyByPublicKeyCache[string(publicKey)]; ak != nil {
+	pk := a.signer.PublicKey()
+	if ak := a.accessKeyByPublicKeyCache[pk.String()]; ak != nil {
 		return pk, ak, nil
 	}
-	ak, err := a.conn.ViewAccessKey(a.kp.AccountID, a.kp.PublicKey)
+	ak, err := a.conn.ViewAccessKey(a.signer.AccountID(), pk.String())
 	if err != nil {
-		return nil, nil, err
+		return pk, nil, err
 	}
-	a.accessKeyByPublicKeyCache[string(publicKey)] = ak
+	a.accessKeyByPublicKeyCache[pk.String()] = ak
 	return pk, ak, nil
 }
 
@@ -241,8 +254,7 @@ func (a *Account) SignFunctionCall(contractID, methodName string,
 		},
 	}}
 
-	txHash, signedTx, err := signTransaction(contractID, uint64(nonce), actions, blockHash,
-		a.kp.Ed25519PubKey, a.kp.Ed25519PrivKey, a.kp.AccountID)
+	txHash, signedTx, err := signTransaction(contractID, uint64(nonce), actions, blockHash, a.signer)
 
 	if err != nil {
 		return nil, nil, err
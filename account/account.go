@@ -0,0 +1,224 @@
+// Package account provides Account, a NEAR account bound to an RPC client
+// and the signer of one of its access keys, with the common operations of
+// applications: transfers, contract calls and views, deployments, sub
+// accounts and access keys.
+//
+// Unlike transaction.Sender, the methods sending transactions return the
+// failure of the transaction as error (see rpc.FinalExecutionOutcome.Err),
+// along with the outcome.
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// Options configure an Account.
+type Options struct {
+	// WaitUntil is the execution level the methods sending transactions
+	// wait for, the node default (EXECUTED_OPTIMISTIC) if empty.
+	WaitUntil rpc.TxExecutionStatus
+	// SenderOptions configure the sender of the transactions.
+	SenderOptions transaction.SenderOptions
+}
+
+// Account is a NEAR account whose transactions are signed by a signer. It is
+// safe for concurrent use.
+type Account struct {
+	client    *rpc.Client
+	sender    *transaction.Sender
+	waitUntil rpc.TxExecutionStatus
+}
+
+// New returns the account of the signer s using client.
+func New(client *rpc.Client, s signer.Signer) *Account {
+	return NewWithOptions(client, s, Options{})
+}
+
+// NewWithOptions returns the account of the signer s using client configured
+// with opts.
+func NewWithOptions(client *rpc.Client, s signer.Signer, opts Options) *Account {
+	return &Account{
+		client:    client,
+		sender:    transaction.NewSenderWithOptions(client, s, opts.SenderOptions),
+		waitUntil: opts.WaitUntil,
+	}
+}
+
+// Load returns the account accountID signing with its key on networkID in ks.
+func Load(client *rpc.Client, ks keystore.KeyStore, networkID, accountID string) (*Account, error) {
+	s, err := keystore.LoadSigner(ks, networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return New(client, s), nil
+}
+
+// ID returns the account ID.
+func (a *Account) ID() string {
+	return a.sender.Signer().AccountID()
+}
+
+// PublicKey returns the public key of the access key signing the
+// transactions.
+func (a *Account) PublicKey() utils.PublicKey {
+	return a.sender.Signer().PublicKey()
+}
+
+// Client returns the RPC client of the account.
+func (a *Account) Client() *rpc.Client {
+	return a.client
+}
+
+// Sender returns the sender of the transactions of the account.
+func (a *Account) Sender() *transaction.Sender {
+	return a.sender
+}
+
+// SignAndSend sends a transaction executing actions on receiverID and
+// returns its outcome. If the transaction failed, the decoded failure is
+// returned as error along with the outcome.
+func (a *Account) SignAndSend(ctx context.Context, receiverID string, actions ...transaction.Action) (*rpc.FinalExecutionOutcome, error) {
+	outcome, err := a.sender.Send(ctx, receiverID, a.waitUntil, actions...)
+	if err != nil {
+		return outcome, err
+	}
+	return outcome, outcome.Err()
+}
+
+// SendMoney transfers amount yoctoNEAR to receiverID.
+func (a *Account) SendMoney(ctx context.Context, receiverID string, amount types.Balance) (*rpc.FinalExecutionOutcome, error) {
+	return a.SignAndSend(ctx, receiverID, transaction.Transfer(amount))
+}
+
+// FunctionCall calls method of contractID with gas and deposit attached.
+// Args are encoded with rpc.EncodeArgs: passed as is if they are a []byte,
+// Borsh encoded if wrapped by rpc.Borsh, otherwise JSON encoded (nil means no
+// args).
+func (a *Account) FunctionCall(ctx context.Context, contractID, method string, args interface{}, gas uint64, deposit types.Balance) (*rpc.FinalExecutionOutcome, error) {
+	data, err := rpc.EncodeArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("account: cannot encode args of %s: %v", method, err)
+	}
+	return a.SignAndSend(ctx, contractID, transaction.FunctionCall(method, data, gas, deposit))
+}
+
+// ViewFunction calls the view function method of contractID on the latest
+// final block, see rpc.Client.CallFunction for the encoding of args and
+// result.
+func (a *Account) ViewFunction(ctx context.Context, contractID, method string, args, result interface{}) (*rpc.CallResult, error) {
+	return a.ViewFunctionAt(ctx, contractID, method, args, result, rpc.Final())
+}
+
+// ViewFunctionAt calls the view function method of contractID at block, e.g.
+// a historical block rpc.AtHeight(h). Blocks garbage collected by regular
+// nodes are viewed on the archival endpoint of the client, see
+// rpc.Options.Archival.
+func (a *Account) ViewFunctionAt(ctx context.Context, contractID, method string, args, result interface{}, block rpc.BlockReference) (*rpc.CallResult, error) {
+	return a.client.CallFunction(ctx, contractID, method, args, result, block)
+}
+
+// DeployContract deploys the Wasm code to the account.
+func (a *Account) DeployContract(ctx context.Context, code []byte) (*rpc.FinalExecutionOutcome, error) {
+	return a.SignAndSend(ctx, a.ID(), transaction.DeployContract(code))
+}
+
+// ErrNotSubAccount is returned (wrapped) by CreateSubAccount if the new
+// account is not a direct sub account of the account.
+var ErrNotSubAccount = errors.New("account: not a direct sub account")
+
+// SubAccountID returns the ID of the direct sub account name of the account.
+// Name is either the new label, e.g. "app", or the full ID, e.g.
+// "app.alice.testnet".
+func (a *Account) SubAccountID(name string) (string, error) {
+	label := strings.TrimSuffix(name, "."+a.ID())
+	if label == "" || strings.Contains(label, ".") {
+		return "", fmt.Errorf("%w: %s of %s", ErrNotSubAccount, name, a.ID())
+	}
+	id, err := types.ParseAccountID(label + "." + a.ID())
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// CreateSubAccount creates the direct sub account name (see SubAccountID)
+// with the full access key publicKey, funded with initialBalance from the
+// account, in a single transaction.
+func (a *Account) CreateSubAccount(ctx context.Context, name string, initialBalance types.Balance, publicKey utils.PublicKey) (*rpc.FinalExecutionOutcome, error) {
+	id, err := a.SubAccountID(name)
+	if err != nil {
+		return nil, err
+	}
+	return a.SignAndSend(ctx, id,
+		transaction.CreateAccount(),
+		transaction.Transfer(initialBalance),
+		transaction.AddKey(publicKey, transaction.FullAccessKey()),
+	)
+}
+
+// CreateSubAccountWithKey is like CreateSubAccount with a new key pair, which
+// is stored in ks on networkID once the account is created. It fails
+// without sending a transaction if ks already holds a key of the new
+// account.
+func (a *Account) CreateSubAccountWithKey(ctx context.Context, ks keystore.KeyStore, networkID, name string, initialBalance types.Balance) (*keystore.Ed25519KeyPair, *rpc.FinalExecutionOutcome, error) {
+	id, err := a.SubAccountID(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := ks.Get(networkID, id); err == nil {
+		return nil, nil, fmt.Errorf("account: key store already holds a key of %s on %s", id, networkID)
+	} else if !errors.Is(err, keystore.ErrKeyNotFound) {
+		return nil, nil, err
+	}
+	kp, err := keystore.GenerateEd25519KeyPair(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	outcome, err := a.CreateSubAccount(ctx, id, initialBalance, utils.PublicKeyFromEd25519(kp.Ed25519PubKey))
+	if err != nil {
+		return nil, outcome, err
+	}
+	if err := ks.Put(networkID, kp); err != nil {
+		return nil, outcome, fmt.Errorf("account: created %s but could not store its key: %w", id, err)
+	}
+	return kp, outcome, nil
+}
+
+// State returns the account at the latest final block.
+func (a *Account) State(ctx context.Context) (*rpc.AccountView, error) {
+	return a.StateAt(ctx, rpc.Final())
+}
+
+// StateAt returns the account at block, see ViewFunctionAt for historical
+// blocks.
+func (a *Account) StateAt(ctx context.Context, block rpc.BlockReference) (*rpc.AccountView, error) {
+	return a.client.ViewAccount(ctx, a.ID(), block)
+}
+
+// ViewStateAt returns the contract state of the account with keys starting
+// with prefix (all state if empty) at block, see ViewFunctionAt for
+// historical blocks and TakeSnapshot for state exceeding the view limit of
+// nodes.
+func (a *Account) ViewStateAt(ctx context.Context, prefix []byte, block rpc.BlockReference) (*rpc.ContractState, error) {
+	return a.client.ViewState(ctx, a.ID(), prefix, block)
+}
+
+// GetAccessKeys returns the access keys of the account at the latest final
+// block.
+func (a *Account) GetAccessKeys(ctx context.Context) ([]rpc.AccessKeyInfoView, error) {
+	keys, err := a.client.ViewAccessKeyList(ctx, a.ID(), rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	return keys.Keys, nil
+}
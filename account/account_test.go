@@ -0,0 +1,270 @@
+package account
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func testSigner(t *testing.T, accountID string) signer.Signer {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewEd25519Signer(accountID, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// newTestServer returns a server answering account, access key and block
+// queries and successful send_tx calls.
+func newTestServer() *rpctest.Server {
+	srv := rpctest.NewServer()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce":      1,
+		"permission": "FullAccess",
+	}))
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{"amount": "1"}))
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(make([]byte, 32))},
+	}))
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+		"status":                 map[string]string{"SuccessValue": ""},
+	}))
+	return srv
+}
+
+// sentTransactions decodes the transactions of all send_tx calls.
+func sentTransactions(t *testing.T, srv *rpctest.Server) []*transaction.Transaction {
+	t.Helper()
+	var txs []*transaction.Transaction
+	for _, req := range srv.RequestsFor("send_tx") {
+		var p struct {
+			SignedTx string `json:"signed_tx_base64"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		data, err := base64.StdEncoding.DecodeString(p.SignedTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err := transaction.DeserializeSigned(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, &st.Transaction)
+	}
+	return txs
+}
+
+func TestAccountTransactions(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ctx := context.Background()
+	one := types.NewBalance(big.NewInt(1))
+	newKey := testSigner(t, "sub.alice.testnet").PublicKey()
+
+	if _, err := a.SendMoney(ctx, "bob.testnet", one); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.FunctionCall(ctx, "app.testnet", "set", map[string]int{"v": 1}, 10, one); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.FunctionCall(ctx, "app.testnet", "raw", []byte{1}, 10, types.Balance{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.DeployContract(ctx, []byte{0, 'a', 's', 'm'}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.CreateSubAccount(ctx, "sub", one, newKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.DeleteAccount(ctx, "bob.testnet"); err != nil {
+		t.Fatal(err)
+	}
+
+	txs := sentTransactions(t, srv)
+	if len(txs) != 6 {
+		t.Fatalf("sent %d transactions (want 6)", len(txs))
+	}
+	for i, want := range []struct {
+		receiverID string
+		kinds      []byte
+	}{
+		{"bob.testnet", []byte{byte(transaction.ActionTransfer)}},
+		{"app.testnet", []byte{byte(transaction.ActionFunctionCall)}},
+		{"app.testnet", []byte{byte(transaction.ActionFunctionCall)}},
+		{"alice.testnet", []byte{byte(transaction.ActionDeployContract)}},
+		{"sub.alice.testnet", []byte{byte(transaction.ActionCreateAccount), byte(transaction.ActionTransfer), byte(transaction.ActionAddKey)}},
+		{"alice.testnet", []byte{byte(transaction.ActionDeleteAccount)}},
+	} {
+		tx := txs[i]
+		var kinds []byte
+		for _, a := range tx.Actions {
+			kinds = append(kinds, byte(a.Kind))
+		}
+		if tx.SignerID != "alice.testnet" || tx.ReceiverID != want.receiverID || string(kinds) != string(want.kinds) {
+			t.Errorf("transaction %d is %+v (want %s with actions %v)", i, tx, want.receiverID, want.kinds)
+		}
+	}
+	if args := string(txs[1].Actions[0].FunctionCall.Args); args != `{"v":1}` {
+		t.Errorf("FunctionCall() sent args %s", args)
+	}
+	if !txs[4].Actions[2].AddKey.PublicKey.Equal(newKey) {
+		t.Errorf("CreateSubAccount() added key %s (want %s)", txs[4].Actions[2].AddKey.PublicKey, newKey)
+	}
+}
+
+func TestAccountFailure(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+		"status": map[string]interface{}{"Failure": map[string]interface{}{
+			"ActionError": map[string]interface{}{"index": 0, "kind": map[string]interface{}{
+				"AccountDoesNotExist": map[string]string{"account_id": "bob.testnet"},
+			}},
+		}},
+	}))
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	outcome, err := a.SendMoney(context.Background(), "bob.testnet", types.NewBalance(big.NewInt(1)))
+	var actionErr *rpc.ActionError
+	if !errors.As(err, &actionErr) || actionErr.Kind != rpc.ActionAccountDoesNotExist || outcome == nil {
+		t.Errorf("SendMoney() returned %v, %v (want AccountDoesNotExist with outcome)", outcome, err)
+	}
+}
+
+func TestAccountViews(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{"amount": "100", "storage_usage": 182}))
+	srv.Query("view_access_key_list", rpctest.Result(map[string]interface{}{"keys": []map[string]interface{}{
+		{"public_key": "ed25519:abc", "access_key": map[string]interface{}{"nonce": 1, "permission": "FullAccess"}},
+	}}))
+	srv.Query("call_function", rpctest.Result(map[string]interface{}{"result": []int{'"', 'v', '"'}, "logs": []string{}}))
+
+	ks := keystore.NewInMemoryKeyStore()
+	kp, err := keystore.GenerateEd25519KeyPair("alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	a, err := Load(srv.Client(), ks, "testnet", "alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID() != "alice.testnet" || a.PublicKey().String() != kp.PublicKey {
+		t.Errorf("Load() returned account %s with key %s", a.ID(), a.PublicKey())
+	}
+	ctx := context.Background()
+	state, err := a.State(ctx)
+	if err != nil || state.Amount.String() != "100" || state.StorageUsage != 182 {
+		t.Errorf("State() returned %+v, %v", state, err)
+	}
+	keys, err := a.GetAccessKeys(ctx)
+	if err != nil || len(keys) != 1 || keys[0].PublicKey != "ed25519:abc" {
+		t.Errorf("GetAccessKeys() returned %+v, %v", keys, err)
+	}
+	var v string
+	if _, err := a.ViewFunction(ctx, "app.testnet", "get", nil, &v); err != nil || v != "v" {
+		t.Errorf("ViewFunction() returned %q, %v", v, err)
+	}
+	if _, err := a.ViewFunctionAt(ctx, "app.testnet", "get", nil, &v, rpc.AtHeight(7)); err != nil {
+		t.Errorf("ViewFunctionAt() returned %v", err)
+	}
+	if _, err := a.StateAt(ctx, rpc.AtHeight(7)); err != nil {
+		t.Errorf("StateAt() returned %v", err)
+	}
+	queries := srv.RequestsFor("query")
+	for _, req := range queries[len(queries)-2:] {
+		var p map[string]interface{}
+		if err := json.Unmarshal(req.Params, &p); err != nil || p["block_id"] != 7.0 {
+			t.Errorf("%s sent params %v", req.Method, p)
+		}
+	}
+}
+
+func TestSubAccountID(t *testing.T) {
+	a := New(nil, testSigner(t, "alice.testnet"))
+	for _, tt := range []struct {
+		name, want string
+	}{
+		{"app", "app.alice.testnet"},
+		{"app.alice.testnet", "app.alice.testnet"},
+		{"", ""},
+		{"a.b", ""},
+		{"app.bob.testnet", ""},
+		{"a.b.alice.testnet", ""},
+		{strings.Repeat("a", 51), ""},
+	} {
+		id, err := a.SubAccountID(tt.name)
+		if id != tt.want || (err == nil) != (tt.want != "") {
+			t.Errorf("SubAccountID(%q) returned %q, %v (want %q)", tt.name, id, err, tt.want)
+		}
+	}
+}
+
+func TestCreateSubAccountWithKey(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ks := keystore.NewInMemoryKeyStore()
+	ctx := context.Background()
+	one := types.NewBalance(big.NewInt(1))
+
+	kp, _, err := a.CreateSubAccountWithKey(ctx, ks, "testnet", "app", one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ks.Get("testnet", "app.alice.testnet")
+	if err != nil || stored.PublicKey != kp.PublicKey {
+		t.Errorf("CreateSubAccountWithKey() stored %+v, %v (want %s)", stored, err, kp.PublicKey)
+	}
+	txs := sentTransactions(t, srv)
+	if len(txs) != 1 || txs[0].Actions[2].AddKey.PublicKey.String() != kp.PublicKey {
+		t.Errorf("CreateSubAccountWithKey() sent %+v", txs)
+	}
+
+	// existing keys are not replaced
+	if _, _, err := a.CreateSubAccountWithKey(ctx, ks, "testnet", "app", one); err == nil {
+		t.Error("CreateSubAccountWithKey() replaced existing key")
+	}
+	// failed creations store no key
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+		"status": map[string]interface{}{"Failure": map[string]interface{}{
+			"ActionError": map[string]interface{}{"index": 0, "kind": map[string]interface{}{
+				"AccountAlreadyExists": map[string]string{"account_id": "web.alice.testnet"},
+			}},
+		}},
+	}))
+	if _, _, err := a.CreateSubAccountWithKey(ctx, ks, "testnet", "web", one); err == nil {
+		t.Error("CreateSubAccountWithKey() succeeded for failed transaction")
+	}
+	if _, err := ks.Get("testnet", "web.alice.testnet"); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("Get() of failed account returned %v (want ErrKeyNotFound)", err)
+	}
+	if _, _, err := a.CreateSubAccountWithKey(ctx, ks, "testnet", "web.bob.testnet", one); !errors.Is(err, ErrNotSubAccount) {
+		t.Errorf("CreateSubAccountWithKey() returned %v (want ErrNotSubAccount)", err)
+	}
+}
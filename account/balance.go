@@ -0,0 +1,91 @@
+package account
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// Balance is the breakdown of the balance of an account in yoctoNEAR, see
+// GetBalance.
+type Balance struct {
+	// Total is the liquid and the staked balance.
+	Total types.Balance
+	// Staked is the balance locked for staking.
+	Staked types.Balance
+	// StateStaked is the balance locked for the storage used by the account.
+	StateStaked types.Balance
+	// Available is the liquid balance which is not needed for storage.
+	Available types.Balance
+}
+
+// ComputeBalance returns the balance breakdown of the account view with
+// storageAmountPerByte yoctoNEAR locked per byte of storage. The staked
+// balance counts towards the storage lock.
+func ComputeBalance(view *rpc.AccountView, storageAmountPerByte types.Balance) Balance {
+	var b Balance
+	b.Total.Add(&view.Amount.Int, &view.Locked.Int)
+	b.Staked.Set(&view.Locked.Int)
+	b.StateStaked = StorageCost(view.StorageUsage, storageAmountPerByte)
+	// the part of the storage lock not covered by the stake is taken from
+	// the liquid balance
+	uncovered := new(big.Int).Sub(&b.StateStaked.Int, &view.Locked.Int)
+	if uncovered.Sign() < 0 {
+		uncovered.SetInt64(0)
+	}
+	b.Available.Sub(&view.Amount.Int, uncovered)
+	if b.Available.Sign() < 0 {
+		b.Available.SetInt64(0)
+	}
+	return b
+}
+
+// GetBalance returns the balance breakdown of the account at the latest final
+// block.
+func (a *Account) GetBalance(ctx context.Context) (*Balance, error) {
+	cfg, err := a.client.ProtocolConfig(ctx, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	view, err := a.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b := ComputeBalance(view, cfg.RuntimeConfig.StorageAmountPerByte)
+	return &b, nil
+}
+
+// StorageCost returns the balance locked for bytes of storage with
+// storageAmountPerByte yoctoNEAR per byte, see
+// rpc.RuntimeConfigView.StorageAmountPerByte. Accounts whose balance (liquid
+// and staked) falls below the cost of their storage cannot execute
+// transactions; they fail with LackBalanceForState.
+func StorageCost(bytes uint64, storageAmountPerByte types.Balance) types.Balance {
+	var cost types.Balance
+	cost.Mul(new(big.Int).SetUint64(bytes), &storageAmountPerByte.Int)
+	return cost
+}
+
+// StorageStake returns the balance of the account locked for its storage
+// at the latest final block.
+func (a *Account) StorageStake(ctx context.Context) (types.Balance, error) {
+	b, err := a.GetBalance(ctx)
+	if err != nil {
+		return types.Balance{}, err
+	}
+	return b.StateStaked, nil
+}
+
+// AvailableToSpend returns the liquid balance of the account minus the
+// balance locked for its storage at the latest final block. Transfers,
+// deposits and gas beyond it fail. Transactions adding storage, e.g.
+// contract deployments, need additionally the StorageCost of the new bytes.
+func (a *Account) AvailableToSpend(ctx context.Context) (types.Balance, error) {
+	b, err := a.GetBalance(ctx)
+	if err != nil {
+		return types.Balance{}, err
+	}
+	return b.Available, nil
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func balance(t *testing.T, s string) types.Balance {
+	b, err := types.ParseBalance(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestComputeBalance(t *testing.T) {
+	perByte := balance(t, "10")
+	for _, tt := range []struct {
+		amount, locked string
+		storage        uint64
+		want           [4]string // total, staked, state staked, available
+	}{
+		{"1000", "0", 20, [4]string{"1000", "0", "200", "800"}},
+		{"1000", "150", 20, [4]string{"1150", "150", "200", "950"}},
+		{"1000", "500", 20, [4]string{"1500", "500", "200", "1000"}},
+		{"100", "0", 20, [4]string{"100", "0", "200", "0"}},
+	} {
+		b := ComputeBalance(&rpc.AccountView{
+			Amount:       balance(t, tt.amount),
+			Locked:       balance(t, tt.locked),
+			StorageUsage: tt.storage,
+		}, perByte)
+		got := [4]string{b.Total.String(), b.Staked.String(), b.StateStaked.String(), b.Available.String()}
+		if got != tt.want {
+			t.Errorf("ComputeBalance(%s, %s, %d) returned %v (want %v)", tt.amount, tt.locked, tt.storage, got, tt.want)
+		}
+	}
+}
+
+func TestGetBalance(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{
+		"amount":        "5000000000000000000000000",
+		"locked":        "0",
+		"storage_usage": 1000,
+	}))
+	srv.Handle("EXPERIMENTAL_protocol_config", rpctest.Result(map[string]interface{}{
+		"runtime_config": map[string]interface{}{"storage_amount_per_byte": "10000000000000000000"},
+	}))
+	b, err := New(srv.Client(), testSigner(t, "alice.testnet")).GetBalance(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Total.String() != "5000000000000000000000000" || b.StateStaked.String() != "10000000000000000000000" ||
+		b.Available.String() != "4990000000000000000000000" {
+		t.Errorf("GetBalance() returned %+v", b)
+	}
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	if stake, err := a.StorageStake(context.Background()); err != nil || stake.String() != "10000000000000000000000" {
+		t.Errorf("StorageStake() returned %s, %v", &stake, err)
+	}
+	if available, err := a.AvailableToSpend(context.Background()); err != nil || available.String() != "4990000000000000000000000" {
+		t.Errorf("AvailableToSpend() returned %s, %v", &available, err)
+	}
+}
+
+func TestStorageCost(t *testing.T) {
+	if cost := StorageCost(182, balance(t, "10000000000000000000")); cost.String() != "1820000000000000000000" {
+		t.Errorf("StorageCost() returned %s (want 1820000000000000000000)", &cost)
+	}
+	if cost := StorageCost(0, balance(t, "10")); cost.Sign() != 0 {
+		t.Errorf("StorageCost() of no storage returned %s", &cost)
+	}
+}
@@ -0,0 +1,143 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// ErrNotTopLevelAccount is returned (wrapped) if an account created through
+// the root contract or the helper service is not a named top-level account
+// like "alice.testnet".
+var ErrNotTopLevelAccount = errors.New("account: not a top-level account")
+
+// ErrAccountNotCreated is returned (wrapped) by CreateTopLevelAccount if the
+// root contract reported that the account was not created, e.g. because it
+// exists already. The deposit is refunded in that case.
+var ErrAccountNotCreated = errors.New("account: account not created")
+
+// CreateAccountGas is the gas attached to create_account calls of the root
+// contract, enough for the creation and its callback.
+const CreateAccountGas = 100_000_000_000_000
+
+// DefaultTopLevelFunding is the initial balance of accounts created by
+// CreateTopLevelAccount if none is given, 0.1 NEAR.
+var DefaultTopLevelFunding = types.NewBalance(new(big.Int).Exp(big.NewInt(10), big.NewInt(23), nil))
+
+// RootAccountID returns the root account of the top-level account
+// accountID, e.g. "testnet" for "alice.testnet". It fails if accountID is no
+// named top-level account.
+func RootAccountID(accountID string) (string, error) {
+	id, err := types.ParseAccountID(accountID)
+	if err != nil {
+		return "", err
+	}
+	if id.IsTopLevel() || !id.Parent().IsTopLevel() {
+		return "", fmt.Errorf("%w: %s", ErrNotTopLevelAccount, accountID)
+	}
+	return id.Parent().String(), nil
+}
+
+// CreateTopLevelAccount creates the top-level account newAccountID, e.g.
+// "bob.testnet", with the full access key publicKey by calling create_account
+// of the root contract ("testnet" or "near"). The account is funded with
+// initialBalance from the account, DefaultTopLevelFunding if it is zero.
+func (a *Account) CreateTopLevelAccount(ctx context.Context, newAccountID string, publicKey utils.PublicKey, initialBalance types.Balance) (*rpc.FinalExecutionOutcome, error) {
+	rootID, err := RootAccountID(newAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if initialBalance.Sign() == 0 {
+		initialBalance = DefaultTopLevelFunding
+	}
+	outcome, err := a.FunctionCall(ctx, rootID, "create_account", map[string]string{
+		"new_account_id": newAccountID,
+		"new_public_key": publicKey.String(),
+	}, CreateAccountGas, initialBalance)
+	if err != nil {
+		return outcome, err
+	}
+	// the callback of the root contract returns whether the account was
+	// created
+	var created bool
+	if err := outcome.DecodeReturnJSON(&created); err == nil && !created {
+		return outcome, fmt.Errorf("%w: %s", ErrAccountNotCreated, newAccountID)
+	}
+	return outcome, nil
+}
+
+// HelperConfig configures the connection to a NEAR helper service.
+type HelperConfig struct {
+	// URL of the helper service, e.g. config.Testnet.HelperURL.
+	URL string
+	// HTTPClient is used for all requests. If nil, a client with a 30 second
+	// timeout is used.
+	HTTPClient *http.Client
+}
+
+// Helper is a client of the helper service of a network, which creates and
+// funds top-level accounts free of charge on testnet.
+type Helper struct {
+	url string
+	c   *http.Client
+}
+
+// NewHelper returns a helper service client for cfg.
+func NewHelper(cfg HelperConfig) (*Helper, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("account: helper URL not configured")
+	}
+	h := &Helper{url: strings.TrimSuffix(cfg.URL, "/"), c: cfg.HTTPClient}
+	if h.c == nil {
+		h.c = &http.Client{Timeout: 30 * time.Second}
+	}
+	return h, nil
+}
+
+// CreateAccount asks the helper service to create the top-level account
+// accountID with the full access key publicKey. The helper funds the
+// account with the amount of its faucet.
+func (h *Helper) CreateAccount(ctx context.Context, accountID string, publicKey utils.PublicKey) error {
+	if _, err := RootAccountID(accountID); err != nil {
+		return err
+	}
+	return h.post(ctx, "/account", map[string]string{
+		"newAccountId":        accountID,
+		"newAccountPublicKey": publicKey.String(),
+	})
+}
+
+// post posts the JSON encoding of body to path of the helper service.
+func (h *Helper) post(ctx context.Context, path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("account: helper %s failed with status %d: %s",
+			path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
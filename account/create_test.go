@@ -0,0 +1,118 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestRootAccountID(t *testing.T) {
+	for _, tt := range []struct {
+		accountID, want string
+	}{
+		{"bob.testnet", "testnet"},
+		{"bob.near", "near"},
+		{"testnet", ""},
+		{"app.bob.testnet", ""},
+		{".near", ""},
+	} {
+		root, err := RootAccountID(tt.accountID)
+		if root != tt.want || (err == nil) != (tt.want != "") {
+			t.Errorf("RootAccountID(%q) returned %q, %v (want %q)", tt.accountID, root, err, tt.want)
+		}
+	}
+}
+
+func TestCreateTopLevelAccount(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Sequence(
+		rpctest.Result(map[string]interface{}{
+			"final_execution_status": "FINAL",
+			"status":                 map[string]string{"SuccessValue": "dHJ1ZQ=="}, // true
+		}),
+		rpctest.Result(map[string]interface{}{
+			"final_execution_status": "FINAL",
+			"status":                 map[string]string{"SuccessValue": "ZmFsc2U="}, // false
+		}),
+	))
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ctx := context.Background()
+	pk := testSigner(t, "bob.testnet").PublicKey()
+
+	if _, err := a.CreateTopLevelAccount(ctx, "bob.testnet", pk, types.Balance{}); err != nil {
+		t.Fatal(err)
+	}
+	two := types.NewBalance(big.NewInt(2))
+	if _, err := a.CreateTopLevelAccount(ctx, "carol.testnet", pk, two); !errors.Is(err, ErrAccountNotCreated) {
+		t.Errorf("CreateTopLevelAccount() returned %v (want ErrAccountNotCreated)", err)
+	}
+	if _, err := a.CreateTopLevelAccount(ctx, "app.bob.testnet", pk, two); !errors.Is(err, ErrNotTopLevelAccount) {
+		t.Errorf("CreateTopLevelAccount() returned %v (want ErrNotTopLevelAccount)", err)
+	}
+
+	txs := sentTransactions(t, srv)
+	if len(txs) != 2 {
+		t.Fatalf("sent %d transactions (want 2)", len(txs))
+	}
+	for i, want := range []struct {
+		accountID string
+		deposit   types.Balance
+	}{
+		{"bob.testnet", DefaultTopLevelFunding},
+		{"carol.testnet", two},
+	} {
+		call := txs[i].Actions[0].FunctionCall
+		var args map[string]string
+		if err := json.Unmarshal(call.Args, &args); err != nil {
+			t.Fatal(err)
+		}
+		if txs[i].ReceiverID != "testnet" || txs[i].Actions[0].Kind != transaction.ActionFunctionCall ||
+			call.MethodName != "create_account" || call.Deposit.Cmp(want.deposit.BigInt()) != 0 ||
+			args["new_account_id"] != want.accountID || args["new_public_key"] != pk.String() {
+			t.Errorf("transaction %d is %+v with args %v", i, txs[i], args)
+		}
+	}
+}
+
+func TestHelperCreateAccount(t *testing.T) {
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/account" {
+			t.Errorf("helper received %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if body["newAccountId"] == "taken.testnet" {
+			http.Error(w, "account exists", http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	h, err := NewHelper(HelperConfig{URL: srv.URL + "/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	pk := testSigner(t, "bob.testnet").PublicKey()
+	if err := h.CreateAccount(ctx, "bob.testnet", pk); err != nil {
+		t.Fatal(err)
+	}
+	if body["newAccountId"] != "bob.testnet" || body["newAccountPublicKey"] != pk.String() {
+		t.Errorf("CreateAccount() sent %v", body)
+	}
+	if err := h.CreateAccount(ctx, "taken.testnet", pk); err == nil {
+		t.Error("CreateAccount() ignored helper error")
+	}
+	if _, err := NewHelper(HelperConfig{}); err == nil {
+		t.Error("NewHelper() accepted empty URL")
+	}
+}
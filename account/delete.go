@@ -0,0 +1,123 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+)
+
+// ErrBeneficiaryNotFound is returned (wrapped) by DeleteAccount if the
+// beneficiary does not exist. The remaining balance would be burnt.
+var ErrBeneficiaryNotFound = errors.New("account: beneficiary does not exist")
+
+// ErrTokenHoldings is returned (wrapped) by DeleteAccount if the account is
+// registered with token contracts and the deletion was not confirmed.
+var ErrTokenHoldings = errors.New("account: account holds tokens")
+
+// TokenStandard is the standard of a token contract.
+type TokenStandard string
+
+// The token standards checked by TokenHoldings.
+const (
+	FungibleToken    TokenStandard = "NEP-141"
+	NonFungibleToken TokenStandard = "NEP-171"
+)
+
+// TokenHolding is the registration of an account with a token contract.
+type TokenHolding struct {
+	ContractID string
+	Standard   TokenStandard
+	// Balance is the FT balance or the number of NFTs of the account.
+	Balance string
+}
+
+func (h TokenHolding) String() string {
+	return fmt.Sprintf("%s %s (%s)", h.Balance, h.ContractID, h.Standard)
+}
+
+// DeleteOptions configure DeleteAccountWithOptions.
+type DeleteOptions struct {
+	// FungibleTokens are the FT contracts the account may be registered with.
+	FungibleTokens []string
+	// NonFungibleTokens are the NFT contracts the account may own tokens of.
+	NonFungibleTokens []string
+	// Confirm is called with the token holdings of the account, if any, and
+	// returns whether to delete the account nevertheless. If nil, accounts
+	// with holdings are not deleted.
+	Confirm func(holdings []TokenHolding) bool
+}
+
+// TokenHoldings returns the registrations of the account with the FT
+// contracts fts (NEP-145 storage registrations) and the NFTs it owns of the
+// NFT contracts nfts at the latest final block. Tokens are lost when the
+// account is deleted.
+func (a *Account) TokenHoldings(ctx context.Context, fts, nfts []string) ([]TokenHolding, error) {
+	args := map[string]string{"account_id": a.ID()}
+	var holdings []TokenHolding
+	for _, contractID := range fts {
+		var registration *struct {
+			Total string `json:"total"`
+		}
+		if _, err := a.client.CallFunction(ctx, contractID, "storage_balance_of", args, &registration, rpc.Final()); err != nil {
+			return nil, err
+		}
+		if registration == nil {
+			continue
+		}
+		var balance string
+		if _, err := a.client.CallFunction(ctx, contractID, "ft_balance_of", args, &balance, rpc.Final()); err != nil {
+			return nil, err
+		}
+		holdings = append(holdings, TokenHolding{ContractID: contractID, Standard: FungibleToken, Balance: balance})
+	}
+	for _, contractID := range nfts {
+		var supply string
+		if _, err := a.client.CallFunction(ctx, contractID, "nft_supply_for_owner", args, &supply, rpc.Final()); err != nil {
+			return nil, err
+		}
+		if supply != "0" {
+			holdings = append(holdings, TokenHolding{ContractID: contractID, Standard: NonFungibleToken, Balance: supply})
+		}
+	}
+	return holdings, nil
+}
+
+// DeleteAccount deletes the account, its remaining balance is transferred to
+// beneficiaryID. It fails without sending a transaction if the beneficiary
+// does not exist.
+func (a *Account) DeleteAccount(ctx context.Context, beneficiaryID string) (*rpc.FinalExecutionOutcome, error) {
+	return a.DeleteAccountWithOptions(ctx, beneficiaryID, DeleteOptions{})
+}
+
+// DeleteAccountWithOptions is like DeleteAccount, it additionally checks the
+// token holdings of the account at the contracts of opts (see
+// TokenHoldings) and only deletes an account with holdings if opts.Confirm
+// agrees.
+func (a *Account) DeleteAccountWithOptions(ctx context.Context, beneficiaryID string, opts DeleteOptions) (*rpc.FinalExecutionOutcome, error) {
+	if beneficiaryID == a.ID() {
+		return nil, fmt.Errorf("account: %s cannot be its own beneficiary", beneficiaryID)
+	}
+	exists, err := a.client.AccountExists(ctx, beneficiaryID, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrBeneficiaryNotFound, beneficiaryID)
+	}
+	holdings, err := a.TokenHoldings(ctx, opts.FungibleTokens, opts.NonFungibleTokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(holdings) > 0 && (opts.Confirm == nil || !opts.Confirm(holdings)) {
+		s := make([]string, len(holdings))
+		for i, h := range holdings {
+			s[i] = h.String()
+		}
+		return nil, fmt.Errorf("%w: %s", ErrTokenHoldings, strings.Join(s, ", "))
+	}
+	return a.SignAndSend(ctx, a.ID(), transaction.DeleteAccount(beneficiaryID))
+}
@@ -0,0 +1,104 @@
+package account
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+)
+
+// viewResult returns the call_function result with the JSON encoded value v.
+func viewResult(t *testing.T, v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make([]int, len(data))
+	for i, b := range data {
+		result[i] = int(b)
+	}
+	return map[string]interface{}{"result": result, "logs": []string{}}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", func(params json.RawMessage) (interface{}, error) {
+		var p map[string]string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if p["account_id"] == "nobody.testnet" {
+			return nil, rpctest.HandlerError("UNKNOWN_ACCOUNT")
+		}
+		return map[string]string{"amount": "1"}, nil
+	})
+	srv.Query("call_function", func(params json.RawMessage) (interface{}, error) {
+		var p map[string]string
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if args, _ := base64.StdEncoding.DecodeString(p["args_base64"]); string(args) != `{"account_id":"alice.testnet"}` {
+			t.Errorf("%s called with args %s", p["method_name"], args)
+		}
+		switch p["account_id"] + " " + p["method_name"] {
+		case "usdc.testnet storage_balance_of":
+			return viewResult(t, map[string]string{"total": "1250000000000000000000"}), nil
+		case "usdc.testnet ft_balance_of":
+			return viewResult(t, "5"), nil
+		case "wrap.testnet storage_balance_of":
+			return viewResult(t, nil), nil
+		case "art.testnet nft_supply_for_owner":
+			return viewResult(t, "2"), nil
+		case "pets.testnet nft_supply_for_owner":
+			return viewResult(t, "0"), nil
+		}
+		t.Errorf("unexpected call of %s on %s", p["method_name"], p["account_id"])
+		return nil, rpctest.HandlerError("CONTRACT_EXECUTION_ERROR")
+	})
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ctx := context.Background()
+
+	if _, err := a.DeleteAccount(ctx, "nobody.testnet"); !errors.Is(err, ErrBeneficiaryNotFound) {
+		t.Errorf("DeleteAccount() returned %v (want ErrBeneficiaryNotFound)", err)
+	}
+	if _, err := a.DeleteAccount(ctx, "alice.testnet"); err == nil {
+		t.Error("DeleteAccount() accepted the account as beneficiary")
+	}
+
+	opts := DeleteOptions{
+		FungibleTokens:    []string{"usdc.testnet", "wrap.testnet"},
+		NonFungibleTokens: []string{"art.testnet", "pets.testnet"},
+	}
+	if _, err := a.DeleteAccountWithOptions(ctx, "bob.testnet", opts); !errors.Is(err, ErrTokenHoldings) {
+		t.Errorf("DeleteAccountWithOptions() returned %v (want ErrTokenHoldings)", err)
+	}
+	if txs := sentTransactions(t, srv); len(txs) != 0 {
+		t.Fatalf("sent %d transactions (want 0)", len(txs))
+	}
+
+	var confirmed []TokenHolding
+	opts.Confirm = func(holdings []TokenHolding) bool {
+		confirmed = holdings
+		return true
+	}
+	if _, err := a.DeleteAccountWithOptions(ctx, "bob.testnet", opts); err != nil {
+		t.Fatal(err)
+	}
+	want := []TokenHolding{
+		{ContractID: "usdc.testnet", Standard: FungibleToken, Balance: "5"},
+		{ContractID: "art.testnet", Standard: NonFungibleToken, Balance: "2"},
+	}
+	if len(confirmed) != len(want) || confirmed[0] != want[0] || confirmed[1] != want[1] {
+		t.Errorf("Confirm() called with %v (want %v)", confirmed, want)
+	}
+	txs := sentTransactions(t, srv)
+	if len(txs) != 1 || txs[0].Actions[0].Kind != transaction.ActionDeleteAccount ||
+		txs[0].Actions[0].DeleteAccount.BeneficiaryID != "bob.testnet" {
+		t.Errorf("DeleteAccountWithOptions() sent %+v", txs)
+	}
+}
@@ -0,0 +1,91 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// ErrCodeHashMismatch is returned (wrapped) by DeployContractFromFile if the
+// code hash of the account does not match the deployed code.
+var ErrCodeHashMismatch = errors.New("account: code hash mismatch")
+
+// Defaults of DeployOptions.
+const (
+	DefaultDeployPollInterval = time.Second
+	DefaultDeployPollAttempts = 10
+)
+
+// DeployOptions configure the verification of DeployContractFromFile.
+type DeployOptions struct {
+	// PollInterval is the interval the code hash of the account is checked
+	// in, DefaultDeployPollInterval if zero.
+	PollInterval time.Duration
+	// PollAttempts is the number of checks before a mismatch is returned,
+	// DefaultDeployPollAttempts if zero.
+	PollAttempts int
+}
+
+// CodeHash returns the code hash of the Wasm code as reported by
+// rpc.AccountView: its base58 encoded sha256 hash.
+func CodeHash(code []byte) string {
+	hash := transaction.CodeHash(code)
+	return base58.Encode(hash[:])
+}
+
+// DeployContractFromFile deploys the Wasm code of the file path to the
+// account and verifies the deployment, see
+// DeployContractFromFileWithOptions.
+func (a *Account) DeployContractFromFile(ctx context.Context, path string) (*rpc.FinalExecutionOutcome, error) {
+	return a.DeployContractFromFileWithOptions(ctx, path, DeployOptions{})
+}
+
+// DeployContractFromFileWithOptions deploys the Wasm code of the file path to
+// the account and then polls the account until its code hash matches the
+// hash of the code. If it does not match after opts.PollAttempts checks an
+// error wrapping ErrCodeHashMismatch is returned along with the outcome.
+func (a *Account) DeployContractFromFileWithOptions(ctx context.Context, path string, opts DeployOptions) (*rpc.FinalExecutionOutcome, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	outcome, err := a.DeployContract(ctx, code)
+	if err != nil {
+		return outcome, err
+	}
+	return outcome, a.verifyCodeHash(ctx, CodeHash(code), opts)
+}
+
+// verifyCodeHash polls the account until its code hash is hash.
+func (a *Account) verifyCodeHash(ctx context.Context, hash string, opts DeployOptions) error {
+	interval, attempts := opts.PollInterval, opts.PollAttempts
+	if interval == 0 {
+		interval = DefaultDeployPollInterval
+	}
+	if attempts == 0 {
+		attempts = DefaultDeployPollAttempts
+	}
+	for i := 1; ; i++ {
+		state, err := a.State(ctx)
+		if err != nil {
+			return err
+		}
+		if state.CodeHash == hash {
+			return nil
+		}
+		if i >= attempts {
+			return fmt.Errorf("%w: %s has code %s (want %s)", ErrCodeHashMismatch, a.ID(), state.CodeHash, hash)
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return fmt.Errorf("account: code of %s not verified: %w", a.ID(), ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+)
+
+func TestCodeHash(t *testing.T) {
+	// sha256 of the empty string
+	if got := CodeHash(nil); got != "GKot5hBsd81kMupNCXHaqbhv3huEbxAFMLnpcX2hniwn" {
+		t.Errorf("CodeHash() returned %s", got)
+	}
+}
+
+func TestDeployContractFromFile(t *testing.T) {
+	code := []byte{0, 'a', 's', 'm', 1, 0, 0, 0}
+	path := filepath.Join(t.TempDir(), "contract.wasm")
+	if err := os.WriteFile(path, code, 0644); err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	opts := DeployOptions{PollInterval: time.Millisecond, PollAttempts: 3}
+
+	// the node reports the new code with a delay
+	srv.Query("view_account", rpctest.Sequence(
+		rpctest.Result(map[string]interface{}{"amount": "1", "code_hash": "11111111111111111111111111111111"}),
+		rpctest.Result(map[string]interface{}{"amount": "1", "code_hash": CodeHash(code)}),
+	))
+	if _, err := a.DeployContractFromFileWithOptions(ctx, path, opts); err != nil {
+		t.Fatalf("DeployContractFromFile() returned %v", err)
+	}
+	txs := sentTransactions(t, srv)
+	if len(txs) != 1 || txs[0].ReceiverID != "alice.testnet" || txs[0].Actions[0].Kind != transaction.ActionDeployContract ||
+		string(txs[0].Actions[0].DeployContract.Code) != string(code) {
+		t.Errorf("DeployContractFromFile() sent %+v", txs)
+	}
+
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{"amount": "1", "code_hash": CodeHash([]byte{1})}))
+	if _, err := a.DeployContractFromFileWithOptions(ctx, path, opts); !errors.Is(err, ErrCodeHashMismatch) {
+		t.Errorf("DeployContractFromFile() returned %v (want ErrCodeHashMismatch)", err)
+	}
+	if n := len(srv.RequestsFor("query")); n < 5 {
+		t.Errorf("sent %d queries (want at least 5)", n)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := a.DeployContractFromFile(ctx, path); err == nil {
+		t.Error("DeployContractFromFile() succeeded with cancelled context")
+	}
+	if _, err := a.DeployContractFromFile(context.Background(), filepath.Join(t.TempDir(), "missing.wasm")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("DeployContractFromFile() returned %v (want os.ErrNotExist)", err)
+	}
+}
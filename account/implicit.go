@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// ErrNotImplicit is returned (wrapped) by Activate for named accounts.
+var ErrNotImplicit = errors.New("account: not an implicit account")
+
+// DefaultActivationPollInterval is the interval Activate checks the account
+// in if ActivationOptions.PollInterval is zero.
+const DefaultActivationPollInterval = 2 * time.Second
+
+// StorageDepositGas is the gas attached to NEP-145 storage_deposit calls.
+const StorageDepositGas = 30_000_000_000_000
+
+// NewImplicitAccount generates the key pair of a new implicit account,
+// stores it in ks on networkID and returns the account. It exists on chain
+// once it receives a transfer, see Activate.
+func NewImplicitAccount(client *rpc.Client, ks keystore.KeyStore, networkID string, opts Options) (*Account, error) {
+	kp, err := keystore.GenerateImplicitAccount(ks, networkID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kp.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithOptions(client, s, opts), nil
+}
+
+// ActivationOptions configure Activate.
+type ActivationOptions struct {
+	// MinBalance is the balance the account needs to be activated, any
+	// balance if zero.
+	MinBalance types.Balance
+	// PollInterval is the interval the account is checked in,
+	// DefaultActivationPollInterval if zero.
+	PollInterval time.Duration
+	// Register are the contracts the account registers with once activated
+	// (NEP-145 storage_deposit), e.g. the FT contracts of deposits. The
+	// minimum storage balance of every contract is deposited.
+	Register []string
+	// RotateKey, if set, replaces the key of the account by this full access
+	// key after the registrations. The account can no longer sign with its
+	// generated key then.
+	RotateKey *utils.PublicKey
+}
+
+// Activate waits until the implicit account exists and holds
+// opts.MinBalance, e.g. after an inbound transfer to a deposit address, and
+// then performs the optional setup of opts. It returns the account once
+// activated. If ctx is done first, its error is returned.
+func (a *Account) Activate(ctx context.Context, opts ActivationOptions) (*rpc.AccountView, error) {
+	if !types.AccountID(a.ID()).IsImplicit() {
+		return nil, fmt.Errorf("%w: %s", ErrNotImplicit, a.ID())
+	}
+	state, err := a.waitForBalance(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, contractID := range opts.Register {
+		if err := a.register(ctx, contractID); err != nil {
+			return state, fmt.Errorf("account: cannot register %s with %s: %w", a.ID(), contractID, err)
+		}
+	}
+	if opts.RotateKey != nil {
+		if _, err := a.SignAndSend(ctx, a.ID(),
+			transaction.AddKey(*opts.RotateKey, transaction.FullAccessKey()),
+			transaction.DeleteKey(a.PublicKey()),
+		); err != nil {
+			return state, fmt.Errorf("account: cannot rotate key of %s: %w", a.ID(), err)
+		}
+	}
+	return state, nil
+}
+
+// waitForBalance polls the account until it exists with the minimum balance
+// of opts.
+func (a *Account) waitForBalance(ctx context.Context, opts ActivationOptions) (*rpc.AccountView, error) {
+	interval := opts.PollInterval
+	if interval == 0 {
+		interval = DefaultActivationPollInterval
+	}
+	for {
+		state, err := a.State(ctx)
+		if err == nil && state.Amount.Cmp(&opts.MinBalance.Int) >= 0 {
+			return state, nil
+		}
+		if err != nil && !errors.Is(err, rpc.ErrUnknownAccount) && ctx.Err() == nil {
+			return nil, err
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("account: %s not activated: %w", a.ID(), ctx.Err())
+		}
+	}
+}
+
+// register registers the account with contractID, depositing the minimum
+// storage balance of the contract.
+func (a *Account) register(ctx context.Context, contractID string) error {
+	var bounds struct {
+		Min types.Balance `json:"min"`
+	}
+	if _, err := a.ViewFunction(ctx, contractID, "storage_balance_bounds", nil, &bounds); err != nil {
+		return err
+	}
+	_, err := a.FunctionCall(ctx, contractID, "storage_deposit", map[string]interface{}{
+		"account_id":        a.ID(),
+		"registration_only": true,
+	}, StorageDepositGas, bounds.Min)
+	return err
+}
@@ -0,0 +1,82 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestActivate(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Sequence(
+		rpctest.Fail(rpctest.HandlerError("UNKNOWN_ACCOUNT")),
+		rpctest.Result(map[string]interface{}{"amount": "5"}),
+		rpctest.Result(map[string]interface{}{"amount": "10"}),
+	))
+	srv.Query("call_function", rpctest.Result(viewResult(t, map[string]interface{}{"min": "125", "max": nil})))
+	ks := keystore.NewInMemoryKeyStore()
+	a, err := NewImplicitAccount(srv.Client(), ks, "testnet", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !types.AccountID(a.ID()).IsImplicit() {
+		t.Fatalf("NewImplicitAccount() returned account %s", a.ID())
+	}
+	if kp, err := ks.Get("testnet", a.ID()); err != nil || kp.PublicKey != a.PublicKey().String() {
+		t.Errorf("NewImplicitAccount() stored %+v, %v", kp, err)
+	}
+	newKey := testSigner(t, a.ID()).PublicKey()
+	state, err := a.Activate(context.Background(), ActivationOptions{
+		MinBalance:   types.NewBalance(big.NewInt(10)),
+		PollInterval: time.Millisecond,
+		Register:     []string{"usdc.testnet"},
+		RotateKey:    &newKey,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Amount.String() != "10" {
+		t.Errorf("Activate() returned balance %s (want 10)", &state.Amount)
+	}
+
+	txs := sentTransactions(t, srv)
+	if len(txs) != 2 {
+		t.Fatalf("sent %d transactions (want 2)", len(txs))
+	}
+	deposit := txs[0].Actions[0].FunctionCall
+	if txs[0].ReceiverID != "usdc.testnet" || deposit.MethodName != "storage_deposit" || deposit.Deposit.String() != "125" ||
+		string(deposit.Args) != `{"account_id":"`+a.ID()+`","registration_only":true}` {
+		t.Errorf("Activate() registered with %+v", txs[0])
+	}
+	rotation := txs[1].Actions
+	if len(rotation) != 2 || rotation[0].Kind != transaction.ActionAddKey || !rotation[0].AddKey.PublicKey.Equal(newKey) ||
+		rotation[1].Kind != transaction.ActionDeleteKey || !rotation[1].DeleteKey.PublicKey.Equal(a.PublicKey()) {
+		t.Errorf("Activate() rotated key with %+v", rotation)
+	}
+}
+
+func TestActivateErrors(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Fail(rpctest.HandlerError("UNKNOWN_ACCOUNT")))
+	if _, err := New(srv.Client(), testSigner(t, "alice.testnet")).Activate(context.Background(), ActivationOptions{}); !errors.Is(err, ErrNotImplicit) {
+		t.Errorf("Activate() of named account returned %v (want ErrNotImplicit)", err)
+	}
+	a, err := NewImplicitAccount(srv.Client(), keystore.NewInMemoryKeyStore(), "testnet", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := a.Activate(ctx, ActivationOptions{PollInterval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Activate() of unfunded account returned %v (want DeadlineExceeded)", err)
+	}
+}
@@ -0,0 +1,88 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// AddFullAccessKey adds the full access key publicKey to the account.
+func (a *Account) AddFullAccessKey(ctx context.Context, publicKey utils.PublicKey) (*rpc.FinalExecutionOutcome, error) {
+	return a.SignAndSend(ctx, a.ID(), transaction.AddKey(publicKey, transaction.FullAccessKey()))
+}
+
+// AddFunctionCallKey adds the function call access key publicKey to the
+// account, which can call methods (all methods if empty) of contractID
+// without deposit and pay at most allowance for gas (unlimited if nil).
+func (a *Account) AddFunctionCallKey(ctx context.Context, publicKey utils.PublicKey, contractID string, methods []string, allowance *types.Balance) (*rpc.FinalExecutionOutcome, error) {
+	return a.SignAndSend(ctx, a.ID(),
+		transaction.AddKey(publicKey, transaction.FunctionCallAccessKey(contractID, methods, allowance)))
+}
+
+// DeleteKeyByPublicKey deletes the access key with the public key in
+// "<key type>:<base58>" format from the account.
+func (a *Account) DeleteKeyByPublicKey(ctx context.Context, publicKey string) (*rpc.FinalExecutionOutcome, error) {
+	pk, err := utils.ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return a.SignAndSend(ctx, a.ID(), transaction.DeleteKey(pk))
+}
+
+// KeyAudit is an access key of an account annotated with its local
+// availability.
+type KeyAudit struct {
+	rpc.AccessKeyInfoView
+	// Local reports whether the key pair is stored in the audited key
+	// store.
+	Local bool
+	// Signing reports whether the key signs the transactions of the
+	// account.
+	Signing bool
+}
+
+// keyLister is implemented by key stores holding several key pairs per
+// account, like keystore.FileSystemKeyStore.
+type keyLister interface {
+	ListKeys(networkID, accountID string) ([]string, error)
+}
+
+// AuditKeys returns all access keys of the account at the latest final
+// block, annotated with whether their key pairs are stored in ks on
+// networkID. Keys which are not stored locally nor known otherwise may be
+// leaked keys or keys of third parties.
+func (a *Account) AuditKeys(ctx context.Context, ks keystore.KeyStore, networkID string) ([]KeyAudit, error) {
+	keys, err := a.GetAccessKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]bool)
+	if l, ok := ks.(keyLister); ok {
+		pks, err := l.ListKeys(networkID, a.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, pk := range pks {
+			local[pk] = true
+		}
+	} else {
+		kp, err := ks.Get(networkID, a.ID())
+		if err != nil && !errors.Is(err, keystore.ErrKeyNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			local[kp.PublicKey] = true
+		}
+	}
+	signing := a.PublicKey().String()
+	audits := make([]KeyAudit, len(keys))
+	for i, k := range keys {
+		audits[i] = KeyAudit{AccessKeyInfoView: k, Local: local[k.PublicKey], Signing: k.PublicKey == signing}
+	}
+	return audits, nil
+}
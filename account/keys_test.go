@@ -0,0 +1,104 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+)
+
+func TestAccessKeyManagement(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ctx := context.Background()
+	pk := testSigner(t, "alice.testnet").PublicKey()
+	allowance := balance(t, "250000000000000000000000")
+
+	if _, err := a.AddFullAccessKey(ctx, pk); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.AddFunctionCallKey(ctx, pk, "app.testnet", []string{"set"}, &allowance); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.DeleteKeyByPublicKey(ctx, pk.String()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.DeleteKeyByPublicKey(ctx, "ed25519:invalid"); err == nil {
+		t.Error("DeleteKeyByPublicKey() accepted invalid key")
+	}
+
+	txs := sentTransactions(t, srv)
+	if len(txs) != 3 {
+		t.Fatalf("sent %d transactions (want 3)", len(txs))
+	}
+	full, call, del := txs[0].Actions[0], txs[1].Actions[0], txs[2].Actions[0]
+	if full.Kind != transaction.ActionAddKey || full.AddKey.AccessKey.Permission.Kind != transaction.PermissionFullAccess {
+		t.Errorf("AddFullAccessKey() sent %+v", full)
+	}
+	perm := call.AddKey.AccessKey.Permission
+	if call.Kind != transaction.ActionAddKey || perm.Kind != transaction.PermissionFunctionCall ||
+		perm.FunctionCall.ReceiverID != "app.testnet" || len(perm.FunctionCall.MethodNames) != 1 ||
+		perm.FunctionCall.Allowance.Cmp(&allowance.Int) != 0 {
+		t.Errorf("AddFunctionCallKey() sent %+v", call)
+	}
+	if del.Kind != transaction.ActionDeleteKey || !del.DeleteKey.PublicKey.Equal(pk) {
+		t.Errorf("DeleteKeyByPublicKey() sent %+v", del)
+	}
+}
+
+func TestAuditKeys(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	stored, err := keystore.GenerateEd25519KeyPair("alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra, err := keystore.GenerateEd25519KeyPair("alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signing := testSigner(t, "alice.testnet")
+	srv.Query("view_access_key_list", rpctest.Result(map[string]interface{}{"keys": []map[string]interface{}{
+		{"public_key": signing.PublicKey().String(), "access_key": map[string]interface{}{"nonce": 1, "permission": "FullAccess"}},
+		{"public_key": stored.PublicKey, "access_key": map[string]interface{}{"nonce": 1, "permission": "FullAccess"}},
+		{"public_key": extra.PublicKey, "access_key": map[string]interface{}{"nonce": 1, "permission": "FullAccess"}},
+	}}))
+	a := New(srv.Client(), signing)
+	ctx := context.Background()
+
+	mem := keystore.NewInMemoryKeyStore()
+	if err := mem.Put("testnet", stored); err != nil {
+		t.Fatal(err)
+	}
+	fs := keystore.NewFileSystemKeyStore(t.TempDir())
+	if err := fs.Put("testnet", stored); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.PutKey("testnet", extra); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name  string
+		ks    keystore.KeyStore
+		local []bool
+	}{
+		{"memory", mem, []bool{false, true, false}},
+		{"file system", fs, []bool{false, true, true}},
+	} {
+		audits, err := a.AuditKeys(ctx, tt.ks, "testnet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(audits) != 3 {
+			t.Fatalf("AuditKeys() of %s key store returned %d keys (want 3)", tt.name, len(audits))
+		}
+		for i, audit := range audits {
+			if audit.Local != tt.local[i] || audit.Signing != (i == 0) {
+				t.Errorf("AuditKeys() of %s key store returned %+v for key %d", tt.name, audit, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package account
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// MultisigGas is the gas attached to calls of the multisig contract.
+const MultisigGas = 100_000_000_000_000
+
+// MultisigChangeMethods are the methods of the multisig contract changing
+// requests, the methods of the function call keys of 2FA accounts.
+var MultisigChangeMethods = []string{"add_request", "add_request_and_confirm", "delete_request", "confirm"}
+
+// IsMultisig reports whether the multisig (2FA) contract is deployed to
+// the account. The contract is detected by its get_request_nonce view
+// method.
+func (a *Account) IsMultisig(ctx context.Context) (bool, error) {
+	acc, err := a.State(ctx)
+	if err != nil || !acc.HasContract() {
+		return false, err
+	}
+	var nonce uint32
+	_, err = a.ViewFunction(ctx, a.ID(), "get_request_nonce", nil, &nonce)
+	if errors.Is(err, rpc.ErrContractExecution) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// MultisigActions returns the JSON representation of actions in requests of
+// the multisig contract. Only the actions supported by the contract can be
+// converted: CreateAccount, DeployContract, FunctionCall, Transfer, AddKey
+// and DeleteKey.
+func MultisigActions(actions ...transaction.Action) ([]map[string]interface{}, error) {
+	converted := make([]map[string]interface{}, len(actions))
+	for i, a := range actions {
+		var m map[string]interface{}
+		switch a.Kind {
+		case transaction.ActionCreateAccount:
+			m = map[string]interface{}{"type": "CreateAccount"}
+		case transaction.ActionDeployContract:
+			m = map[string]interface{}{
+				"type": "DeployContract",
+				"code": base64.StdEncoding.EncodeToString(a.DeployContract.Code),
+			}
+		case transaction.ActionFunctionCall:
+			m = map[string]interface{}{
+				"type":        "FunctionCall",
+				"method_name": a.FunctionCall.MethodName,
+				"args":        base64.StdEncoding.EncodeToString(a.FunctionCall.Args),
+				"deposit":     a.FunctionCall.Deposit.String(),
+				"gas":         strconv.FormatUint(a.FunctionCall.Gas, 10),
+			}
+		case transaction.ActionTransfer:
+			m = map[string]interface{}{"type": "Transfer", "amount": a.Transfer.Deposit.String()}
+		case transaction.ActionAddKey:
+			m = map[string]interface{}{"type": "AddKey", "public_key": multisigPublicKey(a.AddKey.PublicKey.String())}
+			if p := a.AddKey.AccessKey.Permission; p.Kind == transaction.PermissionFunctionCall {
+				permission := map[string]interface{}{
+					"receiver_id":  p.FunctionCall.ReceiverID,
+					"method_names": append([]string{}, p.FunctionCall.MethodNames...),
+				}
+				if p.FunctionCall.Allowance != nil {
+					permission["allowance"] = p.FunctionCall.Allowance.String()
+				}
+				m["permission"] = permission
+			}
+		case transaction.ActionDeleteKey:
+			m = map[string]interface{}{"type": "DeleteKey", "public_key": multisigPublicKey(a.DeleteKey.PublicKey.String())}
+		default:
+			return nil, fmt.Errorf("account: action %d is not supported by the multisig contract", a.Kind)
+		}
+		converted[i] = m
+	}
+	return converted, nil
+}
+
+// multisigPublicKey returns the public key in the format of the multisig
+// contract, which omits the ed25519 key type.
+func multisigPublicKey(pk string) string {
+	return strings.TrimPrefix(pk, "ed25519:")
+}
+
+// Confirmer provides the second factor of a multisig account by confirming
+// its requests.
+type Confirmer interface {
+	// Confirm confirms the request requestID of the multisig account a.
+	Confirm(ctx context.Context, a *Account, requestID uint32) error
+}
+
+// KeyConfirmer confirms requests with another access key of the multisig
+// account.
+type KeyConfirmer struct {
+	// Account is the multisig account signing with the confirming key.
+	Account *Account
+}
+
+// Confirm implements Confirmer.
+func (c KeyConfirmer) Confirm(ctx context.Context, a *Account, requestID uint32) error {
+	if c.Account.ID() != a.ID() {
+		return fmt.Errorf("account: key of %s cannot confirm requests of %s", c.Account.ID(), a.ID())
+	}
+	_, err := c.Account.FunctionCall(ctx, a.ID(), "confirm", map[string]uint32{"request_id": requestID}, MultisigGas, types.Balance{})
+	return err
+}
+
+// TwoFactorMethod is the delivery method of the security codes of the
+// helper service, e.g. {Kind: "2fa-email"}.
+type TwoFactorMethod struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// TwoFactorConfirmer confirms requests with the key of the helper service
+// after verifying a security code (NEAR wallet 2FA).
+type TwoFactorConfirmer struct {
+	Helper *Helper
+	Method TwoFactorMethod
+	// SecurityCode returns the code the user received for requestID.
+	SecurityCode func(ctx context.Context, requestID uint32) (string, error)
+}
+
+// Confirm implements Confirmer.
+func (c TwoFactorConfirmer) Confirm(ctx context.Context, a *Account, requestID uint32) error {
+	if err := c.Helper.SendSecurityCode(ctx, a, requestID, c.Method); err != nil {
+		return err
+	}
+	code, err := c.SecurityCode(ctx, requestID)
+	if err != nil {
+		return err
+	}
+	return c.Helper.VerifySecurityCode(ctx, a, requestID, code)
+}
+
+// helperAuth returns the authentication of a for helper service requests:
+// the latest final block height signed by its key.
+func helperAuth(ctx context.Context, a *Account) (map[string]interface{}, error) {
+	block, err := a.client.Block(ctx, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	height := strconv.FormatUint(block.Header.Height, 10)
+	hash := sha256.Sum256([]byte(height))
+	sig, err := a.sender.Signer().SignBytes(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"accountId":            a.ID(),
+		"blockNumber":          height,
+		"blockNumberSignature": base64.StdEncoding.EncodeToString(sig),
+	}, nil
+}
+
+// SendSecurityCode asks the helper service to send the security code for
+// the request requestID of the multisig account a with method.
+func (h *Helper) SendSecurityCode(ctx context.Context, a *Account, requestID uint32, method TwoFactorMethod) error {
+	body, err := helperAuth(ctx, a)
+	if err != nil {
+		return err
+	}
+	body["method"] = method
+	body["requestId"] = requestID
+	return h.post(ctx, "/2fa/send", body)
+}
+
+// VerifySecurityCode verifies the security code for the request requestID of
+// the multisig account a, the helper service confirms the request then.
+func (h *Helper) VerifySecurityCode(ctx context.Context, a *Account, requestID uint32, securityCode string) error {
+	body, err := helperAuth(ctx, a)
+	if err != nil {
+		return err
+	}
+	body["requestId"] = requestID
+	body["securityCode"] = securityCode
+	return h.post(ctx, "/2fa/verify", body)
+}
+
+// Multisig is an account with the multisig (2FA) contract, whose
+// transactions are requests of the contract which execute once confirmed.
+type Multisig struct {
+	account   *Account
+	confirmer Confirmer
+}
+
+// NewMultisig returns the multisig account a, whose requests are confirmed
+// by c.
+func NewMultisig(a *Account, c Confirmer) *Multisig {
+	return &Multisig{account: a, confirmer: c}
+}
+
+// Account returns the underlying account.
+func (m *Multisig) Account() *Account {
+	return m.account
+}
+
+// AddRequest adds and confirms with the key of the account the request
+// executing actions on receiverID and returns its ID. The request executes
+// once it has the confirmations required by the contract.
+func (m *Multisig) AddRequest(ctx context.Context, receiverID string, actions ...transaction.Action) (uint32, *rpc.FinalExecutionOutcome, error) {
+	converted, err := MultisigActions(actions...)
+	if err != nil {
+		return 0, nil, err
+	}
+	outcome, err := m.account.FunctionCall(ctx, m.account.ID(), "add_request_and_confirm", map[string]interface{}{
+		"request": map[string]interface{}{"receiver_id": receiverID, "actions": converted},
+	}, MultisigGas, types.Balance{})
+	if err != nil {
+		return 0, outcome, err
+	}
+	var requestID uint32
+	if err := outcome.DecodeReturnJSON(&requestID); err != nil {
+		return 0, outcome, fmt.Errorf("account: invalid multisig request ID: %w", err)
+	}
+	return requestID, outcome, nil
+}
+
+// SignAndSend adds the request executing actions on receiverID (see
+// AddRequest) and confirms it with the second factor. It returns the
+// request ID.
+func (m *Multisig) SignAndSend(ctx context.Context, receiverID string, actions ...transaction.Action) (uint32, error) {
+	requestID, _, err := m.AddRequest(ctx, receiverID, actions...)
+	if err != nil {
+		return 0, err
+	}
+	if err := m.confirmer.Confirm(ctx, m.account, requestID); err != nil {
+		return requestID, fmt.Errorf("account: cannot confirm multisig request %d: %w", requestID, err)
+	}
+	return requestID, nil
+}
+
+// DeleteRequest deletes the pending request requestID.
+func (m *Multisig) DeleteRequest(ctx context.Context, requestID uint32) (*rpc.FinalExecutionOutcome, error) {
+	return m.account.FunctionCall(ctx, m.account.ID(), "delete_request", map[string]uint32{"request_id": requestID}, MultisigGas, types.Balance{})
+}
+
+// RequestIDs returns the IDs of the pending requests at the latest final
+// block.
+func (m *Multisig) RequestIDs(ctx context.Context) ([]uint32, error) {
+	var ids []uint32
+	if _, err := m.account.ViewFunction(ctx, m.account.ID(), "list_request_ids", nil, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// NumConfirmations returns the number of confirmations requests need at
+// the latest final block.
+func (m *Multisig) NumConfirmations(ctx context.Context) (uint32, error) {
+	var n uint32
+	if _, err := m.account.ViewFunction(ctx, m.account.ID(), "get_num_confirmations", nil, &n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
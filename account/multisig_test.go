@@ -0,0 +1,156 @@
+package account
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestMultisigActions(t *testing.T) {
+	pk := testSigner(t, "alice.testnet").PublicKey()
+	allowance := types.NewBalance(big.NewInt(5))
+	actions, err := MultisigActions(
+		transaction.CreateAccount(),
+		transaction.DeployContract([]byte{0, 'a', 's', 'm'}),
+		transaction.FunctionCall("set", []byte("{}"), 10, types.NewBalance(big.NewInt(1))),
+		transaction.Transfer(types.NewBalance(big.NewInt(2))),
+		transaction.AddKey(pk, transaction.FullAccessKey()),
+		transaction.AddKey(pk, transaction.FunctionCallAccessKey("app.testnet", nil, &allowance)),
+		transaction.DeleteKey(pk),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := pk.String()[len("ed25519:"):]
+	want := `[{"type":"CreateAccount"},` +
+		`{"code":"AGFzbQ==","type":"DeployContract"},` +
+		`{"args":"e30=","deposit":"1","gas":"10","method_name":"set","type":"FunctionCall"},` +
+		`{"amount":"2","type":"Transfer"},` +
+		`{"public_key":"` + key + `","type":"AddKey"},` +
+		`{"permission":{"allowance":"5","method_names":[],"receiver_id":"app.testnet"},"public_key":"` + key + `","type":"AddKey"},` +
+		`{"public_key":"` + key + `","type":"DeleteKey"}]`
+	if string(data) != want {
+		t.Errorf("MultisigActions() returned %s (want %s)", data, want)
+	}
+	if _, err := MultisigActions(transaction.DeleteAccount("bob.testnet")); err == nil {
+		t.Error("MultisigActions() accepted DeleteAccount")
+	}
+}
+
+func TestIsMultisig(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	ctx := context.Background()
+	if ok, err := a.IsMultisig(ctx); err != nil || ok {
+		t.Errorf("IsMultisig() without contract returned %v, %v (want false)", ok, err)
+	}
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{"amount": "1", "code_hash": "8Phe5NYBPyE2ZcRjYmR2XtBSpB3tEqBpCUuo8NYfhuAh"}))
+	srv.Query("call_function", rpctest.Fail(rpctest.HandlerError("CONTRACT_EXECUTION_ERROR")))
+	if ok, err := a.IsMultisig(ctx); err != nil || ok {
+		t.Errorf("IsMultisig() with other contract returned %v, %v (want false)", ok, err)
+	}
+	srv.Query("call_function", rpctest.Result(viewResult(t, 3)))
+	if ok, err := a.IsMultisig(ctx); err != nil || !ok {
+		t.Errorf("IsMultisig() with multisig contract returned %v, %v (want true)", ok, err)
+	}
+}
+
+func TestMultisigKeyConfirmer(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+		"status":                 map[string]string{"SuccessValue": "Nw=="}, // 7
+	}))
+	a := New(srv.Client(), testSigner(t, "alice.testnet"))
+	second := New(srv.Client(), testSigner(t, "alice.testnet"))
+	m := NewMultisig(a, KeyConfirmer{Account: second})
+	id, err := m.SignAndSend(context.Background(), "bob.testnet", transaction.Transfer(types.NewBalance(big.NewInt(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 7 {
+		t.Errorf("SignAndSend() returned request %d (want 7)", id)
+	}
+	txs := sentTransactions(t, srv)
+	if len(txs) != 2 {
+		t.Fatalf("sent %d transactions (want 2)", len(txs))
+	}
+	request, confirm := txs[0], txs[1]
+	if request.ReceiverID != "alice.testnet" || request.Actions[0].FunctionCall.MethodName != "add_request_and_confirm" ||
+		string(request.Actions[0].FunctionCall.Args) != `{"request":{"actions":[{"amount":"1","type":"Transfer"}],"receiver_id":"bob.testnet"}}` {
+		t.Errorf("SignAndSend() sent request %+v", request)
+	}
+	if !confirm.PublicKey.Equal(second.PublicKey()) || confirm.Actions[0].FunctionCall.MethodName != "confirm" ||
+		string(confirm.Actions[0].FunctionCall.Args) != `{"request_id":7}` {
+		t.Errorf("SignAndSend() sent confirmation %+v", confirm)
+	}
+
+	bob := New(srv.Client(), testSigner(t, "bob.testnet"))
+	if err := (KeyConfirmer{Account: bob}).Confirm(context.Background(), a, 7); err == nil {
+		t.Error("Confirm() accepted key of other account")
+	}
+}
+
+func TestTwoFactorConfirmer(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	s := testSigner(t, "alice.testnet")
+	a := New(srv.Client(), s)
+
+	var paths []string
+	helper := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		sig, _ := base64.StdEncoding.DecodeString(body["blockNumberSignature"].(string))
+		hash := sha256.Sum256([]byte(body["blockNumber"].(string)))
+		if body["accountId"] != "alice.testnet" || body["requestId"] != 7.0 ||
+			!ed25519.Verify(s.PublicKey().Bytes(), hash[:], sig) {
+			t.Errorf("%s received %v", r.URL.Path, body)
+		}
+		if r.URL.Path == "/2fa/verify" && body["securityCode"] != "123456" {
+			http.Error(w, "invalid code", http.StatusUnauthorized)
+		}
+	}))
+	defer helper.Close()
+	h, err := NewHelper(HelperConfig{URL: helper.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := "123456"
+	c := TwoFactorConfirmer{
+		Helper: h,
+		Method: TwoFactorMethod{Kind: "2fa-email"},
+		SecurityCode: func(context.Context, uint32) (string, error) {
+			return code, nil
+		},
+	}
+	if err := c.Confirm(context.Background(), a, 7); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != "/2fa/send" || paths[1] != "/2fa/verify" {
+		t.Errorf("Confirm() called %v", paths)
+	}
+	code = "000000"
+	if err := c.Confirm(context.Background(), a, 7); err == nil {
+		t.Error("Confirm() accepted invalid security code")
+	}
+}
@@ -0,0 +1,158 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// maxSnapshotPrefix is the longest key prefix TakeSnapshot splits state at.
+const maxSnapshotPrefix = 64
+
+// Snapshot is the key/value state of a contract account at a block, sorted
+// by key. Its JSON encoding is the file format of WriteFile.
+type Snapshot struct {
+	AccountID   string          `json:"account_id"`
+	BlockHash   string          `json:"block_hash"`
+	BlockHeight uint64          `json:"block_height"`
+	Values      []rpc.StateItem `json:"values"`
+	// Uncovered are the key prefixes whose state was too large to be viewed
+	// at once. A key equal to one of them cannot be viewed and is missing
+	// from Values if it exists.
+	Uncovered [][]byte `json:"uncovered,omitempty"`
+}
+
+// TakeSnapshot returns the full state of the contract accountID at block.
+// State exceeding the view limit of the node is paginated: it is viewed by
+// key prefixes, which are extended by one byte until the state of every
+// prefix can be viewed. Keys equal to an extended prefix cannot be viewed
+// this way, these prefixes are reported in Uncovered, see Complete.
+func TakeSnapshot(ctx context.Context, client *rpc.Client, accountID string, block rpc.BlockReference) (*Snapshot, error) {
+	// all pages are viewed at the same block
+	b, err := client.Block(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{AccountID: accountID, BlockHash: b.Header.Hash, BlockHeight: b.Header.Height}
+	if err := s.view(ctx, client, nil); err != nil {
+		return nil, err
+	}
+	sort.Slice(s.Values, func(i, j int) bool { return bytes.Compare(s.Values[i].Key, s.Values[j].Key) < 0 })
+	return s, nil
+}
+
+func (s *Snapshot) view(ctx context.Context, client *rpc.Client, prefix []byte) error {
+	state, err := client.ViewState(ctx, s.AccountID, prefix, rpc.AtHash(s.BlockHash))
+	if errors.Is(err, rpc.ErrTooLargeContractState) && len(prefix) < maxSnapshotPrefix {
+		// the empty key does not exist
+		if len(prefix) > 0 {
+			s.Uncovered = append(s.Uncovered, prefix)
+		}
+		for i := 0; i < 256; i++ {
+			if err := s.view(ctx, client, append(prefix[:len(prefix):len(prefix)], byte(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.Values = append(s.Values, state.Values...)
+	return nil
+}
+
+// Complete reports whether the snapshot certainly contains all keys, i.e.
+// no key may be missing at an uncovered prefix.
+func (s *Snapshot) Complete() bool {
+	return len(s.Uncovered) == 0
+}
+
+// Snapshot returns the full state of the account at block, see
+// TakeSnapshot.
+func (a *Account) Snapshot(ctx context.Context, block rpc.BlockReference) (*Snapshot, error) {
+	return TakeSnapshot(ctx, a.client, a.ID(), block)
+}
+
+// WriteFile writes the snapshot as JSON to the file path.
+func (s *Snapshot) WriteFile(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReadSnapshot reads the snapshot written by WriteFile to the file path.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("account: invalid snapshot %s: %v", path, err)
+	}
+	sort.Slice(s.Values, func(i, j int) bool { return bytes.Compare(s.Values[i].Key, s.Values[j].Key) < 0 })
+	return &s, nil
+}
+
+// StateChangeKind is the kind of a StateChange.
+type StateChangeKind string
+
+// The kinds of state changes.
+const (
+	StateAdded    StateChangeKind = "added"
+	StateRemoved  StateChangeKind = "removed"
+	StateModified StateChangeKind = "modified"
+)
+
+// StateChange is a difference of the value of a key between two snapshots.
+type StateChange struct {
+	Kind StateChangeKind
+	Key  []byte
+	// Old is the value in the old snapshot, nil if the key was added.
+	Old []byte
+	// New is the value in the new snapshot, nil if the key was removed.
+	New []byte
+}
+
+// DiffSnapshots returns the changes from the snapshot old to new, sorted by
+// key.
+func DiffSnapshots(old, new *Snapshot) []StateChange {
+	var changes []StateChange
+	i, j := 0, 0
+	for i < len(old.Values) || j < len(new.Values) {
+		var c int
+		switch {
+		case i == len(old.Values):
+			c = 1
+		case j == len(new.Values):
+			c = -1
+		default:
+			c = bytes.Compare(old.Values[i].Key, new.Values[j].Key)
+		}
+		switch {
+		case c < 0:
+			changes = append(changes, StateChange{Kind: StateRemoved, Key: old.Values[i].Key, Old: old.Values[i].Value})
+			i++
+		case c > 0:
+			changes = append(changes, StateChange{Kind: StateAdded, Key: new.Values[j].Key, New: new.Values[j].Value})
+			j++
+		default:
+			if !bytes.Equal(old.Values[i].Value, new.Values[j].Value) {
+				changes = append(changes, StateChange{Kind: StateModified, Key: old.Values[i].Key,
+					Old: old.Values[i].Value, New: new.Values[j].Value})
+			}
+			i++
+			j++
+		}
+	}
+	return changes
+}
@@ -0,0 +1,96 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+)
+
+func TestSnapshot(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	state := []rpc.StateItem{
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("STATE"), Value: []byte("s")},
+		{Key: []byte("a1"), Value: []byte("1")},
+	}
+	srv.Query("view_state", func(params json.RawMessage) (interface{}, error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if p["block_id"] != "11111111111111111111111111111111" || p["account_id"] != "app.testnet" {
+			t.Errorf("view_state called with %v", p)
+		}
+		prefix, _ := base64.StdEncoding.DecodeString(p["prefix_base64"].(string))
+		// the full state and the state of "a" are too large
+		if len(prefix) == 0 || string(prefix) == "a" {
+			return nil, rpctest.HandlerError("TOO_LARGE_CONTRACT_STATE")
+		}
+		values := []rpc.StateItem{}
+		for _, item := range state {
+			if bytes.HasPrefix(item.Key, prefix) {
+				values = append(values, item)
+			}
+		}
+		return map[string]interface{}{"values": values}, nil
+	})
+	a := New(srv.Client(), testSigner(t, "app.testnet"))
+	s, err := a.Snapshot(context.Background(), rpc.Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []rpc.StateItem{state[1], state[2], state[0]}
+	if s.AccountID != "app.testnet" || s.BlockHash != "11111111111111111111111111111111" || !reflect.DeepEqual(s.Values, want) {
+		t.Errorf("Snapshot() returned %+v (want values %v)", s, want)
+	}
+	if n := len(srv.RequestsFor("query")); n != 513 {
+		t.Errorf("Snapshot() made %d queries (want 513)", n)
+	}
+	if s.Complete() || !reflect.DeepEqual(s.Uncovered, [][]byte{[]byte("a")}) {
+		t.Errorf("Snapshot() returned uncovered prefixes %q", s.Uncovered)
+	}
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := s.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, s) {
+		t.Errorf("ReadSnapshot() returned %+v (want %+v)", read, s)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	old := &Snapshot{Values: []rpc.StateItem{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c"), Value: []byte("3")},
+	}}
+	new := &Snapshot{Values: []rpc.StateItem{
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c"), Value: []byte("4")},
+		{Key: []byte("d"), Value: []byte("5")},
+	}}
+	want := []StateChange{
+		{Kind: StateRemoved, Key: []byte("a"), Old: []byte("1")},
+		{Kind: StateModified, Key: []byte("c"), Old: []byte("3"), New: []byte("4")},
+		{Kind: StateAdded, Key: []byte("d"), New: []byte("5")},
+	}
+	if changes := DiffSnapshots(old, new); !reflect.DeepEqual(changes, want) {
+		t.Errorf("DiffSnapshots() returned %+v (want %+v)", changes, want)
+	}
+	if changes := DiffSnapshots(new, new); len(changes) != 0 {
+		t.Errorf("DiffSnapshots() of equal snapshots returned %+v", changes)
+	}
+}
@@ -0,0 +1,74 @@
+// Package borsh implements the Borsh binary serialization format used by NEAR
+// for transactions and contract state, see https://borsh.io.
+//
+// Go values are mapped to Borsh types as follows:
+//
+//   - bool, int8 to int64 and uint8 to uint64 are encoded as little-endian
+//     integers of the same size, int and uint as 64 bit integers
+//   - float32 and float64 are encoded as IEEE 754 little-endian, NaN is
+//     rejected
+//   - big.Int is a u128, *big.Int an Option<u128>
+//   - strings are UTF-8 bytes with u32 length prefix
+//   - slices are Vec with u32 length prefix, arrays are fixed-size arrays
+//     without prefix
+//   - maps are HashMap with u32 length prefix and entries sorted by key
+//   - pointers are Option: a 0 byte for nil, a 1 byte followed by the value
+//   - structs are their fields in order, unexported fields and fields tagged
+//     `borsh:"-"` (or `borsh_skip:"true"`) are skipped
+//
+// Structs whose first field has kind uint8 and is tagged `borsh_enum:"true"`
+// are enums: the first field is the discriminant and selects the following
+// field holding the data of the variant. Fields of type Enum or struct{}
+// mark variants without data:
+//
+//	type Action struct {
+//		Kind          borsh.Enum `borsh_enum:"true"`
+//		CreateAccount borsh.Enum
+//		Transfer      Transfer
+//	}
+//
+// Types implementing Marshaler and Unmarshaler customize their encoding.
+package borsh
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Enum is the discriminant of enums and the type of variants without data.
+type Enum uint8
+
+// Marshaler is implemented by types with a custom Borsh encoding.
+type Marshaler interface {
+	MarshalBorsh(e *Encoder) error
+}
+
+// Unmarshaler is implemented by types with a custom Borsh decoding.
+type Unmarshaler interface {
+	UnmarshalBorsh(d *Decoder) error
+}
+
+// ErrUnexpectedEOF is returned if data ends before the value was decoded.
+var ErrUnexpectedEOF = errors.New("borsh: unexpected end of data")
+
+// Serialize returns the Borsh encoding of v.
+func Serialize(v interface{}) ([]byte, error) {
+	var e Encoder
+	if err := e.Encode(v); err != nil {
+		return nil, err
+	}
+	return e.Bytes(), nil
+}
+
+// Deserialize decodes the Borsh encoded data into v, which must be a
+// non-nil pointer. It is an error if data has bytes left after the value.
+func Deserialize(data []byte, v interface{}) error {
+	d := NewDecoder(data)
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if n := d.Len(); n > 0 {
+		return fmt.Errorf("borsh: %d bytes left after decoding %T", n, v)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package borsh
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	nearborsh "github.com/near/borsh-go"
+)
+
+type testPublicKey struct {
+	KeyType nearborsh.Enum `borsh_enum:"true"`
+	ED25519 struct{ Data [32]byte }
+}
+
+type testTransfer struct {
+	Deposit big.Int
+}
+
+type testAction struct {
+	Enum          Enum `borsh_enum:"true"`
+	CreateAccount Enum
+	Deploy        struct{ Code []byte }
+	Call          struct{}
+	Transfer      testTransfer
+}
+
+type testTransaction struct {
+	SignerID   string
+	PublicKey  testPublicKey
+	Nonce      uint64
+	ReceiverID string
+	BlockHash  [32]byte
+	Actions    []testAction
+}
+
+type testAll struct {
+	Bool    bool
+	I8      int8
+	I16     int16
+	I32     int32
+	I64     int64
+	U8      uint8
+	U16     uint16
+	U32     uint32
+	U64     uint64
+	F32     float32
+	F64     float64
+	String  string
+	Bytes   []byte
+	Array   [3]uint16
+	Slice   []string
+	Map     map[string]uint32
+	Option  *uint64
+	None    *string
+	U128    big.Int
+	Option2 *big.Int
+	Skipped string `borsh:"-"`
+	skipped string
+}
+
+func TestRoundTrip(t *testing.T) {
+	one := uint64(1)
+	v := testAll{
+		Bool:    true,
+		I8:      -1,
+		I16:     -300,
+		I32:     -70000,
+		I64:     math.MinInt64,
+		U8:      255,
+		U16:     65535,
+		U32:     math.MaxUint32,
+		U64:     math.MaxUint64,
+		F32:     1.5,
+		F64:     -2.25,
+		String:  "near",
+		Bytes:   []byte{1, 2, 3},
+		Array:   [3]uint16{1, 2, 3},
+		Slice:   []string{"a", "b"},
+		Map:     map[string]uint32{"b": 2, "a": 1},
+		Option:  &one,
+		U128:    *new(big.Int).Lsh(big.NewInt(1), 127),
+		Option2: big.NewInt(42),
+		Skipped: "skipped",
+		skipped: "skipped",
+	}
+	data, err := Serialize(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got testAll
+	if err := Deserialize(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	v.Skipped, v.skipped = "", ""
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("Deserialize() returned %+v (want %+v)", got, v)
+	}
+	// fields are encoded in order, maps sorted by key
+	want := "01" + "ff" + "d4fe" + "90eefeff" + "0000000000000080" +
+		"ff" + "ffff" + "ffffffff" + "ffffffffffffffff" +
+		"0000c03f" + "00000000000002c0" +
+		"040000006e656172" + "03000000010203" + "010002000300" +
+		"02000000" + "0100000061" + "0100000062" +
+		"02000000" + "0100000061" + "01000000" + "0100000062" + "02000000" +
+		"01" + "0100000000000000" + "00" +
+		"00000000000000000000000000000080" +
+		"012a000000000000000000000000000000"
+	if hex.EncodeToString(data) != want {
+		t.Errorf("Serialize() returned\n%x\n(want\n%s)", data, want)
+	}
+}
+
+func TestCompatibility(t *testing.T) {
+	tx := testTransaction{
+		SignerID:   "alice.testnet",
+		PublicKey:  testPublicKey{ED25519: struct{ Data [32]byte }{Data: [32]byte{1, 2, 3}}},
+		Nonce:      42,
+		ReceiverID: "bob.testnet",
+		BlockHash:  [32]byte{4, 5, 6},
+		Actions: []testAction{
+			{Enum: 0},
+			{Enum: 1, Deploy: struct{ Code []byte }{Code: []byte{0, 97, 115, 109}}},
+			{Enum: 3, Transfer: testTransfer{Deposit: *big.NewInt(1000)}},
+		},
+	}
+	data, err := Serialize(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := nearborsh.Serialize(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Serialize() returned\n%x\n(want\n%x)", data, want)
+	}
+	var got testTransaction
+	if err := Deserialize(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, tx) {
+		t.Errorf("Deserialize() returned %+v (want %+v)", got, tx)
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	if _, err := Serialize(testAction{Enum: 4}); err == nil {
+		t.Error("Serialize() accepted invalid variant")
+	}
+	var a testAction
+	if err := Deserialize([]byte{4}, &a); err == nil {
+		t.Error("Deserialize() accepted invalid variant")
+	}
+	// unit variants have no data
+	if err := Deserialize([]byte{2}, &a); err != nil || a.Enum != 2 {
+		t.Errorf("Deserialize() returned %+v, %v", a, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, v := range []interface{}{
+		math.NaN(),
+		*big.NewInt(-1),
+		*new(big.Int).Lsh(big.NewInt(1), 128),
+		map[[2]string]bool{{"a"}: true},
+		func() {},
+		nil,
+	} {
+		if _, err := Serialize(v); err == nil {
+			t.Errorf("Serialize(%v) succeeded", v)
+		}
+	}
+	var s string
+	if err := Deserialize([]byte{5, 0, 0, 0, 'a'}, &s); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Errorf("Deserialize() of short string returned %v (want ErrUnexpectedEOF)", err)
+	}
+	if err := Deserialize([]byte{1, 0, 0, 0, 0xff}, &s); err == nil {
+		t.Error("Deserialize() accepted invalid UTF-8")
+	}
+	var n uint8
+	if err := Deserialize([]byte{1, 2}, &n); err == nil {
+		t.Error("Deserialize() accepted trailing bytes")
+	}
+	if err := Deserialize([]byte{1}, n); err == nil {
+		t.Error("Deserialize() accepted non-pointer")
+	}
+	var b bool
+	if err := Deserialize([]byte{2}, &b); err == nil {
+		t.Error("Deserialize() accepted invalid bool")
+	}
+	var p *uint8
+	if err := Deserialize([]byte{2, 1}, &p); err == nil {
+		t.Error("Deserialize() accepted invalid option")
+	}
+	// huge lengths do not allocate
+	var l []uint64
+	if err := Deserialize([]byte{0xff, 0xff, 0xff, 0xff}, &l); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Errorf("Deserialize() of short slice returned %v (want ErrUnexpectedEOF)", err)
+	}
+	var str string
+	if err := Deserialize([]byte{0xff, 0xff, 0xff, 0xff}, &str); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Errorf("Deserialize() of short string returned %v (want ErrUnexpectedEOF)", err)
+	}
+	var m map[uint8]uint8
+	if err := Deserialize([]byte{0xff, 0xff, 0xff, 0xff, 1, 2}, &m); !errors.Is(err, ErrUnexpectedEOF) {
+		t.Errorf("Deserialize() of short map returned %v (want ErrUnexpectedEOF)", err)
+	}
+	// huge lengths of elements which read no data do not loop
+	var units []struct{}
+	if err := Deserialize([]byte{0xff, 0xff, 0xff, 0xff}, &units); err == nil {
+		t.Error("Deserialize() accepted 2^32-1 elements of zero size")
+	}
+	if err := Deserialize([]byte{3, 0, 0, 0}, &units); err != nil || len(units) != 3 {
+		t.Errorf("Deserialize() of 3 elements of zero size returned %d, %v", len(units), err)
+	}
+}
+
+// u24 has a custom encoding as 3 bytes.
+type u24 uint32
+
+func (n u24) MarshalBorsh(e *Encoder) error {
+	_, err := e.Write([]byte{byte(n), byte(n >> 8), byte(n >> 16)})
+	return err
+}
+
+func (n *u24) UnmarshalBorsh(d *Decoder) error {
+	var b [3]byte
+	if _, err := d.Read(b[:]); err != nil {
+		return err
+	}
+	*n = u24(b[0]) | u24(b[1])<<8 | u24(b[2])<<16
+	return nil
+}
+
+func TestMarshaler(t *testing.T) {
+	v := struct {
+		N   u24
+		Opt *u24
+		All []u24
+	}{N: 0x010203, All: []u24{1, 2}}
+	data, err := Serialize(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "030201" + "00" + "02000000" + "010000" + "020000"; hex.EncodeToString(data) != want {
+		t.Errorf("Serialize() returned %x (want %s)", data, want)
+	}
+	got := v
+	got.N = 0
+	if err := Deserialize(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("Deserialize() returned %+v (want %+v)", got, v)
+	}
+}
@@ -0,0 +1,325 @@
+package borsh
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"unicode/utf8"
+)
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// Decoder reads Borsh encoded values from data.
+type Decoder struct {
+	data []byte
+}
+
+// NewDecoder returns a new decoder reading from data.
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Len returns the number of bytes which were not read yet.
+func (d *Decoder) Len() int {
+	return len(d.data)
+}
+
+// Read reads exactly len(p) raw bytes into p. It returns ErrUnexpectedEOF if
+// there are not enough bytes.
+func (d *Decoder) Read(p []byte) (int, error) {
+	if len(p) > len(d.data) {
+		return 0, ErrUnexpectedEOF
+	}
+	n := copy(p, d.data)
+	d.data = d.data[n:]
+	return n, nil
+}
+
+// Decode decodes the next value into v, which must be a non-nil pointer.
+func (d *Decoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("borsh: cannot decode into non-pointer %T", v)
+	}
+	return d.decode(rv.Elem())
+}
+
+// maxZeroSizeLen is the longest sequence of elements which read no data, like
+// struct{}, that is decoded. Their length cannot be checked against the
+// data left.
+const maxZeroSizeLen = 1 << 16
+
+// next returns the next n bytes. The length is not truncated to int, which
+// is 32 bits on some platforms.
+func (d *Decoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)) {
+		return nil, ErrUnexpectedEOF
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
+
+func (d *Decoder) readU32() (uint32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// checkLen returns an error if n elements of the types ts cannot be decoded
+// from the data left: each element of non-zero size reads at least one byte.
+func (d *Decoder) checkLen(n uint32, ts ...reflect.Type) error {
+	for _, t := range ts {
+		if t.Size() != 0 {
+			if uint64(n) > uint64(len(d.data)) {
+				return ErrUnexpectedEOF
+			}
+			return nil
+		}
+	}
+	if n > maxZeroSizeLen {
+		return fmt.Errorf("borsh: %d elements of zero size exceed the limit", n)
+	}
+	return nil
+}
+
+func (d *Decoder) decode(v reflect.Value) error {
+	if v.Kind() != reflect.Ptr && reflect.PtrTo(v.Type()).Implements(unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalBorsh(d)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := d.next(1)
+		if err != nil {
+			return err
+		}
+		if b[0] > 1 {
+			return fmt.Errorf("borsh: invalid bool %d", b[0])
+		}
+		v.SetBool(b[0] == 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		n, err := d.uint(v.Type())
+		if err != nil {
+			return err
+		}
+		switch v.Kind() {
+		case reflect.Int8:
+			v.SetInt(int64(int8(n)))
+		case reflect.Int16:
+			v.SetInt(int64(int16(n)))
+		case reflect.Int32:
+			v.SetInt(int64(int32(n)))
+		default:
+			v.SetInt(int64(n))
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		n, err := d.uint(v.Type())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32:
+		b, err := d.next(4)
+		if err != nil {
+			return err
+		}
+		f := math.Float32frombits(binary.LittleEndian.Uint32(b))
+		if math.IsNaN(float64(f)) {
+			return fmt.Errorf("borsh: invalid NaN")
+		}
+		v.SetFloat(float64(f))
+	case reflect.Float64:
+		b, err := d.next(8)
+		if err != nil {
+			return err
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(b))
+		if math.IsNaN(f) {
+			return fmt.Errorf("borsh: invalid NaN")
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		n, err := d.readU32()
+		if err != nil {
+			return err
+		}
+		b, err := d.next(uint64(n))
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return fmt.Errorf("borsh: invalid UTF-8 string")
+		}
+		v.SetString(string(b))
+	case reflect.Array:
+		if isBytes(v.Type()) {
+			b, err := d.next(uint64(v.Len()))
+			if err != nil {
+				return err
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		n, err := d.readU32()
+		if err != nil {
+			return err
+		}
+		if isBytes(v.Type()) {
+			b, err := d.next(uint64(n))
+			if err != nil {
+				return err
+			}
+			s := reflect.MakeSlice(v.Type(), len(b), len(b))
+			reflect.Copy(s, reflect.ValueOf(b))
+			v.Set(s)
+			return nil
+		}
+		if err := d.checkLen(n, v.Type().Elem()); err != nil {
+			return err
+		}
+		// do not trust the length for the allocation
+		capacity := int(n)
+		if capacity > len(d.data) {
+			capacity = len(d.data)
+		}
+		s := reflect.MakeSlice(v.Type(), 0, capacity)
+		for i := uint32(0); i < n; i++ {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(elem); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		v.Set(s)
+	case reflect.Map:
+		n, err := d.readU32()
+		if err != nil {
+			return err
+		}
+		if err := d.checkLen(n, v.Type().Key(), v.Type().Elem()); err != nil {
+			return err
+		}
+		m := reflect.MakeMap(v.Type())
+		for i := uint32(0); i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := d.decode(key); err != nil {
+				return err
+			}
+			val := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(val); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.Ptr:
+		b, err := d.next(1)
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case 0:
+			v.Set(reflect.Zero(v.Type()))
+		case 1:
+			p := reflect.New(v.Type().Elem())
+			if err := d.decode(p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+		default:
+			return fmt.Errorf("borsh: invalid option tag %d", b[0])
+		}
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			return d.decodeU128(v)
+		}
+		return d.decodeStruct(v)
+	default:
+		return fmt.Errorf("borsh: cannot decode %s", v.Type())
+	}
+	return nil
+}
+
+// uint reads an unsigned integer of the size of the integer type t.
+func (d *Decoder) uint(t reflect.Type) (uint64, error) {
+	size := uint64(t.Size())
+	if t.Kind() == reflect.Int || t.Kind() == reflect.Uint {
+		size = 8
+	}
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b)), nil
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (d *Decoder) decodeU128(v reflect.Value) error {
+	b, err := d.next(16)
+	if err != nil {
+		return err
+	}
+	var be [16]byte
+	for i := range b {
+		be[15-i] = b[i]
+	}
+	v.Addr().Interface().(*big.Int).SetBytes(be[:])
+	return nil
+}
+
+func (d *Decoder) decodeStruct(v reflect.Value) error {
+	t := v.Type()
+	if isEnum(t) {
+		b, err := d.next(1)
+		if err != nil {
+			return err
+		}
+		variant := int(b[0])
+		if variant+1 >= t.NumField() {
+			return fmt.Errorf("borsh: invalid variant %d of enum %s", variant, t)
+		}
+		v.Set(reflect.Zero(t))
+		v.Field(0).SetUint(uint64(variant))
+		if f := v.Field(variant + 1); !isUnitVariant(f.Type()) {
+			return d.decode(f)
+		}
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if skipField(t.Field(i)) {
+			continue
+		}
+		if err := d.decode(v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBytes reports whether the array or slice type t holds plain bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Elem().Kind() == reflect.Uint8 && !hasMarshaler(t.Elem())
+}
+
+// hasMarshaler reports whether t has a custom encoding or decoding.
+func hasMarshaler(t reflect.Type) bool {
+	p := reflect.PtrTo(t)
+	return t.Implements(marshalerType) || p.Implements(marshalerType) || p.Implements(unmarshalerType)
+}
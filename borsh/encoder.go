@@ -0,0 +1,253 @@
+package borsh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+var (
+	bigIntType    = reflect.TypeOf(big.Int{})
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+)
+
+// Encoder writes Borsh encoded values to a buffer. The zero value is ready
+// to use.
+type Encoder struct {
+	buf bytes.Buffer
+}
+
+// Bytes returns the encoded data.
+func (e *Encoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// Write appends raw bytes to the encoded data, it never fails.
+func (e *Encoder) Write(p []byte) (int, error) {
+	return e.buf.Write(p)
+}
+
+// Encode appends the encoding of v.
+func (e *Encoder) Encode(v interface{}) error {
+	return e.encode(reflect.ValueOf(v))
+}
+
+func (e *Encoder) writeU32(n int) error {
+	if uint64(n) > math.MaxUint32 {
+		return fmt.Errorf("borsh: length %d exceeds u32", n)
+	}
+	e.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(n)))
+	return nil
+}
+
+func (e *Encoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		return fmt.Errorf("borsh: cannot encode nil interface")
+	}
+	// pointers are options of the value they point to
+	if k := v.Kind(); k != reflect.Ptr && k != reflect.Interface {
+		if v.Type().Implements(marshalerType) {
+			return v.Interface().(Marshaler).MarshalBorsh(e)
+		}
+		if reflect.PtrTo(v.Type()).Implements(marshalerType) {
+			if !v.CanAddr() {
+				p := reflect.New(v.Type())
+				p.Elem().Set(v)
+				v = p.Elem()
+			}
+			return v.Addr().Interface().(Marshaler).MarshalBorsh(e)
+		}
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+	case reflect.Int8:
+		e.buf.WriteByte(byte(v.Int()))
+	case reflect.Int16:
+		e.buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(v.Int())))
+	case reflect.Int32:
+		e.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(v.Int())))
+	case reflect.Int64, reflect.Int:
+		e.buf.Write(binary.LittleEndian.AppendUint64(nil, uint64(v.Int())))
+	case reflect.Uint8:
+		e.buf.WriteByte(byte(v.Uint()))
+	case reflect.Uint16:
+		e.buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(v.Uint())))
+	case reflect.Uint32:
+		e.buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(v.Uint())))
+	case reflect.Uint64, reflect.Uint:
+		e.buf.Write(binary.LittleEndian.AppendUint64(nil, v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) {
+			return fmt.Errorf("borsh: cannot encode NaN")
+		}
+		if v.Kind() == reflect.Float32 {
+			e.buf.Write(binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(f))))
+		} else {
+			e.buf.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)))
+		}
+	case reflect.String:
+		if err := e.writeU32(v.Len()); err != nil {
+			return err
+		}
+		e.buf.WriteString(v.String())
+	case reflect.Array:
+		if isBytes(v.Type()) {
+			for i := 0; i < v.Len(); i++ {
+				e.buf.WriteByte(byte(v.Index(i).Uint()))
+			}
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if err := e.writeU32(v.Len()); err != nil {
+			return err
+		}
+		if isBytes(v.Type()) {
+			e.buf.Write(v.Bytes())
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		return e.encodeMap(v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			e.buf.WriteByte(0)
+			return nil
+		}
+		e.buf.WriteByte(1)
+		return e.encode(v.Elem())
+	case reflect.Struct:
+		if v.Type() == bigIntType {
+			n := v.Interface().(big.Int)
+			return e.encodeU128(&n)
+		}
+		return e.encodeStruct(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("borsh: cannot encode nil interface")
+		}
+		return e.encode(v.Elem())
+	default:
+		return fmt.Errorf("borsh: cannot encode %s", v.Type())
+	}
+	return nil
+}
+
+// encodeU128 writes the u128 n.
+func (e *Encoder) encodeU128(n *big.Int) error {
+	if n.Sign() < 0 || n.BitLen() > 128 {
+		return fmt.Errorf("borsh: %s out of range of u128", n)
+	}
+	var data [16]byte
+	n.FillBytes(data[:])
+	for i, j := 0, 15; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	e.buf.Write(data[:])
+	return nil
+}
+
+func (e *Encoder) encodeStruct(v reflect.Value) error {
+	t := v.Type()
+	if isEnum(t) {
+		variant := int(v.Field(0).Uint())
+		if variant+1 >= t.NumField() {
+			return fmt.Errorf("borsh: invalid variant %d of enum %s", variant, t)
+		}
+		e.buf.WriteByte(byte(variant))
+		if f := v.Field(variant + 1); !isUnitVariant(f.Type()) {
+			return e.encode(f)
+		}
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if skipField(t.Field(i)) {
+			continue
+		}
+		if err := e.encode(v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeMap(v reflect.Value) error {
+	if err := e.writeU32(v.Len()); err != nil {
+		return err
+	}
+	keys := v.MapKeys()
+	less, err := keyLess(v.Type().Key())
+	if err != nil {
+		return err
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+	for _, k := range keys {
+		if err := e.encode(k); err != nil {
+			return err
+		}
+		if err := e.encode(v.MapIndex(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyLess returns the ordering of map keys of type t, which matches the
+// ordering of the corresponding Rust types.
+func keyLess(t reflect.Type) (func(a, b reflect.Value) bool, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() < b.Int() }, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }, nil
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() < b.String() }, nil
+	case reflect.Bool:
+		return func(a, b reflect.Value) bool { return !a.Bool() && b.Bool() }, nil
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return func(a, b reflect.Value) bool {
+				for i := 0; i < a.Len(); i++ {
+					if x, y := a.Index(i).Uint(), b.Index(i).Uint(); x != y {
+						return x < y
+					}
+				}
+				return false
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("borsh: unsupported map key type %s", t)
+}
+
+// isEnum reports whether the struct type t is an enum.
+func isEnum(t reflect.Type) bool {
+	return t.NumField() > 0 && t.Field(0).Type.Kind() == reflect.Uint8 && t.Field(0).Tag.Get("borsh_enum") == "true"
+}
+
+// isUnitVariant reports whether enum variant fields of type t have no data.
+func isUnitVariant(t reflect.Type) bool {
+	return (t.Kind() == reflect.Uint8 && t.Name() == "Enum") || (t.Kind() == reflect.Struct && t.NumField() == 0)
+}
+
+// skipField reports whether the struct field f is not encoded.
+func skipField(f reflect.StructField) bool {
+	return f.PkgPath != "" || f.Tag.Get("borsh") == "-" || f.Tag.Get("borsh_skip") == "true"
+}
@@ -0,0 +1,61 @@
+// nearabigen generates a typed Go client of a contract from its ABI file
+// (cargo near abi), similar to abigen of go-ethereum:
+//
+//	nearabigen -abi counter_abi.json -pkg counter -out counter.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/YuxSccc/near-api-go/contract"
+)
+
+var (
+	abiFile  string
+	pkg      string
+	typeName string
+	outFile  string
+)
+
+func init() {
+	flag.StringVar(&abiFile, "abi", "", "ABI file of the contract")
+	flag.StringVar(&pkg, "pkg", "", "Package of the generated code (default: lower case type)")
+	flag.StringVar(&typeName, "type", "", "Name of the generated client (default: contract name of the ABI)")
+	flag.StringVar(&outFile, "out", "", "File to write the generated code to (default: stdout)")
+}
+
+func generate() ([]byte, error) {
+	data, err := os.ReadFile(abiFile)
+	if err != nil {
+		return nil, err
+	}
+	abi, err := contract.ParseABI(data)
+	if err != nil {
+		return nil, err
+	}
+	return contract.Bind(abi, contract.BindOptions{Package: pkg, Type: typeName})
+}
+
+func main() {
+	flag.Parse()
+	if abiFile == "" {
+		_, _ = fmt.Fprintf(os.Stderr, "Missing parameters\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+	code, err := generate()
+	if err == nil {
+		if outFile == "" {
+			_, err = os.Stdout.Write(code)
+		} else {
+			err = os.WriteFile(outFile, code, 0644)
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
@@ -9,7 +9,7 @@ import (
 	"math/big"
 	"os"
 
-	"github.com/aurora-is-near/near-api-go"
+	"github.com/YuxSccc/near-api-go"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/davecgh/go-spew/spew"
 )
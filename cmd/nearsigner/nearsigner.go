@@ -0,0 +1,67 @@
+// nearsigner is a reference signing service for the remote signer. It serves
+// the keys of the given accounts from the unencrypted file system key store.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/signer/remote"
+)
+
+var (
+	network  = "testnet"
+	addr     = "localhost:8398"
+	keyDir   = ""
+	token    = ""
+	certFile = ""
+	keyFile  = ""
+)
+
+func init() {
+	flag.StringVar(&network, "network", "testnet", "NEAR network of the served keys")
+	flag.StringVar(&addr, "addr", "localhost:8398", "address to listen on")
+	flag.StringVar(&keyDir, "keydir", "", "key store directory (default ~/.near-credentials)")
+	flag.StringVar(&token, "token", "", "bearer token required from clients (or env NEARSIGNER_TOKEN)")
+	flag.StringVar(&certFile, "cert", "", "TLS certificate file")
+	flag.StringVar(&keyFile, "key", "", "TLS key file")
+}
+
+func main() {
+	flag.Parse()
+	if len(flag.Args()) == 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage: nearsigner [flags] accountID...\n")
+		os.Exit(1)
+	}
+	if token == "" {
+		token = os.Getenv("NEARSIGNER_TOKEN")
+	}
+	ks, err := keystore.New(keystore.BackendFileSystem, keystore.Options{Dir: keyDir})
+	if err != nil {
+		log.Fatal(err)
+	}
+	var h *remote.Handler
+	if token != "" {
+		h = remote.NewHandler("Authorization", "Bearer "+token)
+	} else {
+		h = remote.NewHandler("", "")
+	}
+	for _, accountID := range flag.Args() {
+		s, err := keystore.LoadSigner(ks, network, accountID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		h.Add(s)
+		log.Printf("serving %s (%s)", accountID, s.PublicKey())
+	}
+	if certFile != "" {
+		err = http.ListenAndServeTLS(addr, certFile, keyFile, h)
+	} else {
+		err = http.ListenAndServe(addr, h)
+	}
+	log.Fatal(err)
+}
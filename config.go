@@ -3,6 +3,9 @@ package near
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/YuxSccc/near-api-go/config"
+	"github.com/YuxSccc/near-api-go/keystore"
 )
 
 // A Config for the NEAR network.
@@ -10,6 +13,9 @@ type Config struct {
 	NetworkID string
 	NodeURL   string
 	KeyPath   string
+	// KeyStore is used to load account credentials if KeyPath is empty. If it
+	// is nil, the ~/.near-credentials file system key store is used.
+	KeyStore keystore.KeyStore
 }
 
 var home string
@@ -31,17 +37,17 @@ func GetConfig() *Config {
 	case "mainnet":
 		return &Config{
 			NetworkID: "mainnet",
-			NodeURL:   "https://rpc.mainnet.near.org",
+			NodeURL:   config.Mainnet.RPCURL,
 		}
 	case "betanet":
 		return &Config{
 			NetworkID: "betanet",
-			NodeURL:   "https://rpc.betanet.near.org",
+			NodeURL:   config.Betanet.RPCURL,
 		}
 	case "local":
 		return &Config{
 			NetworkID: "local",
-			NodeURL:   "http://localhost:3030",
+			NodeURL:   config.Localnet.RPCURL,
 			KeyPath:   filepath.Join(home, ".near", "validator_key.json"),
 		}
 	case "development":
@@ -51,7 +57,7 @@ func GetConfig() *Config {
 	default:
 		return &Config{
 			NetworkID: "default",
-			NodeURL:   "https://rpc.testnet.near.org",
+			NodeURL:   config.Testnet.RPCURL,
 		}
 	}
 }
@@ -0,0 +1,105 @@
+// Package config provides the configuration presets of the public NEAR
+// networks, mirroring the connection configs of near-api-js.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ErrUnknownNetwork is returned (wrapped) if there is no preset for a network.
+var ErrUnknownNetwork = errors.New("config: unknown network")
+
+// Network is the configuration of a NEAR network.
+type Network struct {
+	// NetworkID is the ID of the network, it is also the name of the network
+	// directory of file system key stores.
+	NetworkID string
+	RPCURL    string
+	// ArchivalRPCURL is the RPC endpoint of archival nodes keeping the full
+	// history, empty if there is none.
+	ArchivalRPCURL string
+	WalletURL      string
+	HelperURL      string
+	ExplorerURL    string
+}
+
+// The presets of all known networks.
+var (
+	Mainnet = Network{
+		NetworkID:      "mainnet",
+		RPCURL:         "https://rpc.mainnet.near.org",
+		ArchivalRPCURL: "https://archival-rpc.mainnet.near.org",
+		WalletURL:      "https://app.mynearwallet.com",
+		HelperURL:      "https://helper.mainnet.near.org",
+		ExplorerURL:    "https://nearblocks.io",
+	}
+	Testnet = Network{
+		NetworkID:      "testnet",
+		RPCURL:         "https://rpc.testnet.near.org",
+		ArchivalRPCURL: "https://archival-rpc.testnet.near.org",
+		WalletURL:      "https://testnet.mynearwallet.com",
+		HelperURL:      "https://helper.testnet.near.org",
+		ExplorerURL:    "https://testnet.nearblocks.io",
+	}
+	Betanet = Network{
+		NetworkID:   "betanet",
+		RPCURL:      "https://rpc.betanet.near.org",
+		WalletURL:   "https://wallet.betanet.near.org",
+		HelperURL:   "https://helper.betanet.near.org",
+		ExplorerURL: "https://explorer.betanet.near.org",
+	}
+	Localnet = Network{
+		NetworkID:   "local",
+		RPCURL:      "http://localhost:3030",
+		WalletURL:   "http://localhost:4000/wallet",
+		HelperURL:   "http://localhost:3000",
+		ExplorerURL: "http://localhost:9001",
+	}
+)
+
+// aliases maps network names (including the NEAR_ENV values of near-cli) to
+// presets.
+var aliases = map[string]*Network{
+	"mainnet":     &Mainnet,
+	"production":  &Mainnet,
+	"testnet":     &Testnet,
+	"development": &Testnet,
+	"default":     &Testnet,
+	"betanet":     &Betanet,
+	"localnet":    &Localnet,
+	"local":       &Localnet,
+}
+
+// Get returns a copy of the preset for the network name. Besides the network
+// IDs the names "production" (mainnet), "development" and "default"
+// (testnet) and "localnet" are supported.
+func Get(name string) (Network, error) {
+	n, ok := aliases[name]
+	if !ok {
+		return Network{}, fmt.Errorf("%w: %s", ErrUnknownNetwork, name)
+	}
+	return *n, nil
+}
+
+// FromEnv returns the preset for the network selected by the NEAR_ENV
+// environment variable, testnet if it is not set.
+func FromEnv() (Network, error) {
+	name := os.Getenv("NEAR_ENV")
+	if name == "" {
+		return Testnet, nil
+	}
+	return Get(name)
+}
+
+// Names returns the sorted names supported by Get.
+func Names() []string {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	for _, name := range Names() {
+		n, err := Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n.NetworkID == "" || n.RPCURL == "" || n.WalletURL == "" || n.HelperURL == "" || n.ExplorerURL == "" {
+			t.Errorf("Get(%s) returned incomplete preset %+v", name, n)
+		}
+	}
+	n, err := Get("production")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != Mainnet {
+		t.Errorf("Get(production) returned %+v (want mainnet)", n)
+	}
+	n.RPCURL = "http://localhost"
+	if Mainnet.RPCURL == n.RPCURL {
+		t.Error("Get() returned shared preset")
+	}
+	if _, err := Get("devnet"); !errors.Is(err, ErrUnknownNetwork) {
+		t.Errorf("Get(devnet) returned %v (want ErrUnknownNetwork)", err)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("NEAR_ENV", "")
+	n, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != Testnet {
+		t.Errorf("FromEnv() returned %+v (want testnet)", n)
+	}
+	t.Setenv("NEAR_ENV", "local")
+	n, err = FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != Localnet {
+		t.Errorf("FromEnv() returned %+v (want localnet)", n)
+	}
+}
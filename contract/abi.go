@@ -0,0 +1,271 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors of ABI validation, returned wrapped with details.
+var (
+	// ErrUnknownMethod is returned for methods which are not part of the
+	// ABI.
+	ErrUnknownMethod = errors.New("contract: method not in ABI")
+	// ErrNotView is returned for views of change methods.
+	ErrNotView = errors.New("contract: not a view method")
+	// ErrInvalidArgs is returned for args which do not match the
+	// parameters of a method.
+	ErrInvalidArgs = errors.New("contract: invalid args")
+	// ErrInvalidResult is returned for return values which do not match
+	// the result type of a method.
+	ErrInvalidResult = errors.New("contract: invalid result")
+	// ErrCompressedABI is returned by FetchABI for compressed embedded ABIs
+	// if no decompressor is given.
+	ErrCompressedABI = errors.New("contract: embedded ABI is compressed")
+)
+
+// FunctionKind is the kind of a contract function.
+type FunctionKind string
+
+// The kinds of contract functions.
+const (
+	FunctionView FunctionKind = "view"
+	FunctionCall FunctionKind = "call"
+)
+
+// The modifiers of contract functions.
+const (
+	ModifierInit    = "init"
+	ModifierPayable = "payable"
+	ModifierPrivate = "private"
+)
+
+// The serialization types of parameters and results.
+const (
+	SerializationJSON  = "json"
+	SerializationBorsh = "borsh"
+)
+
+// ABI is a contract ABI in the near-abi format, as generated by cargo near
+// and embedded into contracts.
+//
+// For details see https://github.com/near/abi
+type ABI struct {
+	SchemaVersion string      `json:"schema_version"`
+	Metadata      ABIMetadata `json:"metadata"`
+	Body          ABIBody     `json:"body"`
+
+	root *schema
+}
+
+// ABIMetadata describes the contract of an ABI.
+type ABIMetadata struct {
+	Name     string          `json:"name,omitempty"`
+	Version  string          `json:"version,omitempty"`
+	Authors  []string        `json:"authors,omitempty"`
+	Build    json.RawMessage `json:"build,omitempty"`
+	WasmHash string          `json:"wasm_hash,omitempty"`
+}
+
+// ABIBody are the functions of an ABI and the definitions of their types.
+type ABIBody struct {
+	Functions []ABIFunction `json:"functions"`
+	// RootSchema is the JSON schema holding the definitions referenced by
+	// the type schemas of JSON parameters and results.
+	RootSchema json.RawMessage `json:"root_schema"`
+}
+
+// ABIFunction is a function of a contract.
+type ABIFunction struct {
+	Name      string       `json:"name"`
+	Doc       string       `json:"doc,omitempty"`
+	Kind      FunctionKind `json:"kind"`
+	Modifiers []string     `json:"modifiers,omitempty"`
+	// Params are nil for functions without parameters.
+	Params       *ABIParams `json:"params,omitempty"`
+	Callbacks    []ABIType  `json:"callbacks,omitempty"`
+	CallbacksVec *ABIType   `json:"callbacks_vec,omitempty"`
+	// Result is nil for functions without return value.
+	Result *ABIType `json:"result,omitempty"`
+}
+
+// HasModifier reports whether f has the modifier m, e.g. ModifierPayable.
+func (f *ABIFunction) HasModifier(m string) bool {
+	for _, mod := range f.Modifiers {
+		if mod == m {
+			return true
+		}
+	}
+	return false
+}
+
+// ABIParams are the parameters of a function.
+type ABIParams struct {
+	SerializationType string   `json:"serialization_type"`
+	Args              []ABIArg `json:"args"`
+}
+
+// ABIArg is a parameter of a function.
+type ABIArg struct {
+	Name string `json:"name"`
+	// TypeSchema is a JSON schema for JSON parameters and a Borsh schema
+	// for Borsh parameters.
+	TypeSchema json.RawMessage `json:"type_schema"`
+}
+
+// ABIType is the type of a result or callback.
+type ABIType struct {
+	SerializationType string          `json:"serialization_type"`
+	TypeSchema        json.RawMessage `json:"type_schema"`
+}
+
+// ParseABI parses an ABI in the near-abi JSON format. Only schema versions
+// 0.x are supported.
+func ParseABI(data []byte) (*ABI, error) {
+	var abi ABI
+	if err := json.Unmarshal(data, &abi); err != nil {
+		return nil, fmt.Errorf("contract: invalid ABI: %v", err)
+	}
+	if !strings.HasPrefix(abi.SchemaVersion, "0.") {
+		return nil, fmt.Errorf("contract: unsupported ABI schema version '%s'", abi.SchemaVersion)
+	}
+	abi.root = &schema{}
+	if len(abi.Body.RootSchema) > 0 {
+		if err := json.Unmarshal(abi.Body.RootSchema, abi.root); err != nil {
+			return nil, fmt.Errorf("contract: invalid ABI root schema: %v", err)
+		}
+	}
+	return &abi, nil
+}
+
+// Function returns the function name of the ABI.
+func (a *ABI) Function(name string) (*ABIFunction, error) {
+	for i := range a.Body.Functions {
+		if a.Body.Functions[i].Name == name {
+			return &a.Body.Functions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMethod, name)
+}
+
+// typeSchema parses the JSON type schema data.
+func (a *ABI) typeSchema(data json.RawMessage) (*schema, error) {
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("contract: invalid ABI type schema: %v", err)
+	}
+	return &s, nil
+}
+
+// ValidateArgs checks the JSON encoded args of method against its
+// parameters: unknown and missing parameters and values not matching their
+// type are reported with an error wrapping ErrInvalidArgs. Borsh
+// parameters are not validated.
+func (a *ABI) ValidateArgs(method string, args []byte) error {
+	f, err := a.Function(method)
+	if err != nil {
+		return err
+	}
+	if f.Params == nil {
+		if len(bytes.TrimSpace(args)) > 0 && !bytes.Equal(bytes.TrimSpace(args), []byte("{}")) {
+			return fmt.Errorf("%w: %s takes no args", ErrInvalidArgs, method)
+		}
+		return nil
+	}
+	if f.Params.SerializationType != SerializationJSON {
+		return nil
+	}
+	values := map[string]interface{}{}
+	if len(bytes.TrimSpace(args)) > 0 {
+		v, err := decodeJSON(args)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidArgs, method, err)
+		}
+		var ok bool
+		if values, ok = v.(map[string]interface{}); !ok {
+			return fmt.Errorf("%w: %s takes an object of args", ErrInvalidArgs, method)
+		}
+	}
+	known := make(map[string]bool, len(f.Params.Args))
+	for _, arg := range f.Params.Args {
+		known[arg.Name] = true
+		s, err := a.typeSchema(arg.TypeSchema)
+		if err != nil {
+			return err
+		}
+		v, ok := values[arg.Name]
+		if !ok {
+			if s.allowsNull(a.root) {
+				continue
+			}
+			return fmt.Errorf("%w: %s misses parameter %s", ErrInvalidArgs, method, arg.Name)
+		}
+		if err := s.validate(a.root, v, arg.Name); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidArgs, method, err)
+		}
+	}
+	for name := range values {
+		if !known[name] {
+			return fmt.Errorf("%w: %s has no parameter %s", ErrInvalidArgs, method, name)
+		}
+	}
+	return nil
+}
+
+// ValidateResult checks the JSON encoded return value of method against its
+// result type, mismatches are reported with an error wrapping
+// ErrInvalidResult. Borsh results are not validated.
+func (a *ABI) ValidateResult(method string, result []byte) error {
+	f, err := a.Function(method)
+	if err != nil {
+		return err
+	}
+	if f.Result == nil {
+		if len(result) > 0 {
+			return fmt.Errorf("%w: %s returns no value", ErrInvalidResult, method)
+		}
+		return nil
+	}
+	if f.Result.SerializationType != SerializationJSON {
+		return nil
+	}
+	s, err := a.typeSchema(f.Result.TypeSchema)
+	if err != nil {
+		return err
+	}
+	v, err := decodeJSON(result)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidResult, method, err)
+	}
+	if err := s.validate(a.root, v, "result"); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidResult, method, err)
+	}
+	return nil
+}
+
+// zstdMagic starts zstd compressed data.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// FetchABI returns the ABI embedded into the contract, the return value of
+// its __contract_abi view function. cargo near compresses embedded ABIs
+// with zstd, they are decompressed with decompress, which may be nil for
+// uncompressed ABIs.
+func FetchABI(ctx context.Context, c *Contract, decompress func([]byte) ([]byte, error)) (*ABI, error) {
+	var data []byte
+	if _, err := c.View(ctx, "__contract_abi", nil, &data); err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, zstdMagic) {
+		if decompress == nil {
+			return nil, fmt.Errorf("%w: %s", ErrCompressedABI, c.ID())
+		}
+		var err error
+		if data, err = decompress(data); err != nil {
+			return nil, fmt.Errorf("contract: cannot decompress ABI of %s: %v", c.ID(), err)
+		}
+	}
+	return ParseABI(data)
+}
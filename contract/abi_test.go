@@ -0,0 +1,218 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// testABI is the ABI of a counter contract as generated by cargo near.
+const testABI = `{
+  "schema_version": "0.4.0",
+  "metadata": {"name": "counter", "version": "0.1.0", "build": {"compiler": "rustc 1.79.0"}},
+  "body": {
+    "functions": [
+      {
+        "name": "get_num",
+        "kind": "view",
+        "params": {
+          "serialization_type": "json",
+          "args": [
+            {"name": "of", "type_schema": {"$ref": "#/definitions/AccountId"}},
+            {"name": "scale", "type_schema": {"type": ["integer", "null"], "format": "uint8", "minimum": 0.0}}
+          ]
+        },
+        "result": {"serialization_type": "json", "type_schema": {"type": "integer", "format": "int8"}}
+      },
+      {
+        "name": "increment",
+        "kind": "call",
+        "modifiers": ["payable"],
+        "params": {
+          "serialization_type": "json",
+          "args": [{"name": "by", "type_schema": {"type": "integer", "minimum": 1, "maximum": 10}}]
+        },
+        "result": {"serialization_type": "json", "type_schema": {"type": "integer"}}
+      },
+      {"name": "reset", "kind": "call"},
+      {
+        "name": "set_raw",
+        "kind": "call",
+        "params": {"serialization_type": "borsh", "args": [{"name": "value", "type_schema": {"declaration": "u8"}}]}
+      }
+    ],
+    "root_schema": {
+      "$schema": "http://json-schema.org/draft-07/schema#",
+      "title": "String",
+      "type": "string",
+      "definitions": {"AccountId": {"type": "string"}}
+    }
+  }
+}`
+
+func parseTestABI(t *testing.T) *ABI {
+	t.Helper()
+	abi, err := ParseABI([]byte(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return abi
+}
+
+func TestParseABI(t *testing.T) {
+	abi := parseTestABI(t)
+	if abi.Metadata.Name != "counter" || len(abi.Body.Functions) != 4 {
+		t.Errorf("ParseABI() returned %+v", abi)
+	}
+	f, err := abi.Function("increment")
+	if err != nil || f.Kind != FunctionCall || !f.HasModifier(ModifierPayable) || f.HasModifier(ModifierInit) {
+		t.Errorf("Function() returned %+v, %v (want payable call)", f, err)
+	}
+	if _, err := abi.Function("decrement"); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("Function() returned %v (want ErrUnknownMethod)", err)
+	}
+	if _, err := ParseABI([]byte(`{"schema_version": "1.0.0", "body": {}}`)); err == nil {
+		t.Error("ParseABI() accepted schema version 1.0.0")
+	}
+	if _, err := ParseABI([]byte(`[]`)); err == nil {
+		t.Error("ParseABI() accepted an array")
+	}
+}
+
+func TestABIValidateArgs(t *testing.T) {
+	abi := parseTestABI(t)
+	for _, test := range []struct {
+		method, args string
+		want         error
+	}{
+		{"get_num", `{"of": "alice.near"}`, nil},
+		{"get_num", `{"of": "alice.near", "scale": 3}`, nil},
+		{"get_num", `{"of": "alice.near", "scale": null}`, nil},
+		{"get_num", `{"of": 1}`, ErrInvalidArgs},
+		{"get_num", `{"of": "alice.near", "scale": 1.5}`, ErrInvalidArgs},
+		{"get_num", `{"of": "alice.near", "scale": -1}`, ErrInvalidArgs},
+		{"get_num", `{"scale": 1}`, ErrInvalidArgs},
+		{"get_num", `{"of": "alice.near", "from": "bob.near"}`, ErrInvalidArgs},
+		{"get_num", `["alice.near"]`, ErrInvalidArgs},
+		{"get_num", `{`, ErrInvalidArgs},
+		{"get_num", ``, ErrInvalidArgs},
+		{"increment", `{"by": 10}`, nil},
+		{"increment", `{"by": 11}`, ErrInvalidArgs},
+		{"reset", ``, nil},
+		{"reset", `{}`, nil},
+		{"reset", `{"by": 1}`, ErrInvalidArgs},
+		{"set_raw", "\x01", nil},
+		{"decrement", `{}`, ErrUnknownMethod},
+	} {
+		if err := abi.ValidateArgs(test.method, []byte(test.args)); !errors.Is(err, test.want) || (test.want == nil) != (err == nil) {
+			t.Errorf("ValidateArgs(%s, %s) returned %v (want %v)", test.method, test.args, err, test.want)
+		}
+	}
+}
+
+func TestABIValidateResult(t *testing.T) {
+	abi := parseTestABI(t)
+	for _, test := range []struct {
+		method, result string
+		want           error
+	}{
+		{"get_num", `-3`, nil},
+		{"get_num", `"3"`, ErrInvalidResult},
+		{"get_num", ``, ErrInvalidResult},
+		{"reset", ``, nil},
+		{"reset", `1`, ErrInvalidResult},
+		{"decrement", `1`, ErrUnknownMethod},
+	} {
+		if err := abi.ValidateResult(test.method, []byte(test.result)); !errors.Is(err, test.want) || (test.want == nil) != (err == nil) {
+			t.Errorf("ValidateResult(%s, %s) returned %v (want %v)", test.method, test.result, err, test.want)
+		}
+	}
+}
+
+func TestContractWithABI(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	result := []int{'4', '2'}
+	srv.Query("call_function", func(json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"result": result, "logs": []string{}}, nil
+	})
+	ctx := context.Background()
+	c := NewWithOptions(srv.Client(), "counter.testnet", Options{ABI: parseTestABI(t)})
+
+	var n int
+	if _, err := c.View(ctx, "get_num", map[string]string{"of": "alice.near"}, &n); err != nil || n != 42 {
+		t.Errorf("View() returned %d, %v (want 42)", n, err)
+	}
+	if _, err := c.View(ctx, "get_num", map[string]int{"of": 1}, &n); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("View() returned %v (want ErrInvalidArgs)", err)
+	}
+	if _, err := c.View(ctx, "increment", map[string]int{"by": 1}, &n); !errors.Is(err, ErrNotView) {
+		t.Errorf("View() returned %v (want ErrNotView)", err)
+	}
+	if _, err := c.View(ctx, "get_sum", nil, &n); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("View() returned %v (want ErrUnknownMethod)", err)
+	}
+	if n := len(srv.RequestsFor("query")); n != 1 {
+		t.Errorf("sent %d queries (want 1)", n)
+	}
+	result = []int{'"', '"'}
+	if _, err := c.View(ctx, "get_num", map[string]string{"of": "alice.near"}, &n); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("View() returned %v (want ErrInvalidResult)", err)
+	}
+
+	caller := testAccount(t, srv.Client(), "alice.testnet")
+	if _, err := c.Call(ctx, caller, "increment", map[string]int{"by": 2}, 0, types.Balance{}, &n); err != nil || n != 42 {
+		t.Errorf("Call() returned %d, %v (want 42)", n, err)
+	}
+	if _, err := c.Call(ctx, caller, "increment", map[string]int{"by": 20}, 0, types.Balance{}, nil); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Call() returned %v (want ErrInvalidArgs)", err)
+	}
+	if _, err := c.Call(ctx, caller, "reset", nil, 0, types.Balance{}, nil); !errors.Is(err, ErrInvalidResult) {
+		t.Errorf("Call() returned %v (want ErrInvalidResult)", err)
+	}
+	if n := len(sentCalls(t, srv)); n != 2 {
+		t.Errorf("sent %d calls (want 2)", n)
+	}
+	// calls not waited for have no return value to validate
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "INCLUDED"}))
+	if _, err := c.Call(ctx, caller, "reset", nil, 0, types.Balance{}, nil); err != nil {
+		t.Errorf("Call() of included transaction returned %v", err)
+	}
+}
+
+func TestFetchABI(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var result []byte
+	srv.Query("call_function", func(json.RawMessage) (interface{}, error) {
+		ints := make([]int, len(result))
+		for i, b := range result {
+			ints[i] = int(b)
+		}
+		return map[string]interface{}{"result": ints, "logs": []string{}}, nil
+	})
+	ctx := context.Background()
+	c := New(srv.Client(), "counter.testnet")
+
+	result = []byte(testABI)
+	if abi, err := FetchABI(ctx, c, nil); err != nil || abi.Metadata.Name != "counter" {
+		t.Errorf("FetchABI() returned %v, %v (want counter ABI)", abi, err)
+	}
+	result = append(append([]byte{}, zstdMagic...), 1, 2, 3)
+	if _, err := FetchABI(ctx, c, nil); !errors.Is(err, ErrCompressedABI) {
+		t.Errorf("FetchABI() returned %v (want ErrCompressedABI)", err)
+	}
+	decompress := func(data []byte) ([]byte, error) {
+		if string(data[len(zstdMagic):]) != "\x01\x02\x03" {
+			t.Errorf("decompressing %v", data)
+		}
+		return []byte(testABI), nil
+	}
+	if abi, err := FetchABI(ctx, c, decompress); err != nil || abi.Metadata.Version != "0.1.0" {
+		t.Errorf("FetchABI() returned %v, %v (want counter ABI)", abi, err)
+	}
+}
@@ -0,0 +1,419 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// BindOptions configure Bind.
+type BindOptions struct {
+	// Package is the package of the generated code, the lower case Type if
+	// empty.
+	Package string
+	// Type is the name of the generated client, derived from the contract
+	// name of the ABI metadata if empty.
+	Type string
+}
+
+// Bind generates the Go source of a typed client of the contract described
+// by abi, as done by the nearabigen command. The client embeds a *Contract
+// and has one method per function of the contract, taking the parameters
+// and returning the result of the function typed, and a struct, string
+// enum or alias for every type definition of the ABI.
+//
+// View methods take a context and their parameters, call methods also the
+// calling account and CallOptions with the attached gas and deposit, and
+// return the outcome of the transaction. Borsh parameters and results are
+// passed as encoded []byte.
+func Bind(abi *ABI, opts BindOptions) ([]byte, error) {
+	typeName := opts.Type
+	if typeName == "" {
+		typeName = goName(abi.Metadata.Name, true)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("contract: invalid client type name '%s'", typeName)
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = strings.ToLower(typeName)
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("contract: invalid package name '%s'", pkg)
+	}
+	g := &generator{abi: abi, imports: map[string]bool{
+		"github.com/YuxSccc/near-api-go/contract": true,
+		"github.com/YuxSccc/near-api-go/rpc":      true,
+	}}
+	var body bytes.Buffer
+	g.out = &body
+	if err := g.client(typeName); err != nil {
+		return nil, err
+	}
+	g.definitions()
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by nearabigen. DO NOT EDIT.\n\n")
+	if abi.Metadata.Name != "" {
+		fmt.Fprintf(&src, "// Package %s is the client of the %s contract", pkg, abi.Metadata.Name)
+		if abi.Metadata.Version != "" {
+			fmt.Fprintf(&src, " version %s", abi.Metadata.Version)
+		}
+		fmt.Fprintf(&src, ".\n")
+	}
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg)
+	var std, imports []string
+	for path := range g.imports {
+		if strings.Contains(path, ".") {
+			imports = append(imports, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(imports)
+	for _, path := range std {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, "\n")
+	for _, path := range imports {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n")
+	src.Write(body.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("contract: cannot format generated code: %v", err)
+	}
+	return formatted, nil
+}
+
+// generator writes the code of Bind.
+type generator struct {
+	abi     *ABI
+	out     *bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.out, format, args...)
+}
+
+// client writes the client type typeName and its methods.
+func (g *generator) client(typeName string) error {
+	name := g.abi.Metadata.Name
+	if name == "" {
+		name = typeName
+	}
+	g.printf("\n// %s is the client of the %s contract.\n", typeName, name)
+	g.printf("type %s struct {\n\t*contract.Contract\n}\n", typeName)
+	g.printf("\n// New%s returns the client of the %s contract deployed to contractID.\n", typeName, name)
+	g.printf("func New%s(client *rpc.Client, contractID string, opts contract.Options) *%s {\n", typeName, typeName)
+	g.printf("\treturn &%s{Contract: contract.NewWithOptions(client, contractID, opts)}\n}\n", typeName)
+	for i := range g.abi.Body.Functions {
+		if err := g.method(typeName, &g.abi.Body.Functions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reservedParams are the names of the parameters and variables of the
+// generated methods, which contract parameters must not use.
+var reservedParams = map[string]bool{"c": true, "ctx": true, "caller": true, "opts": true, "result": true, "outcome": true, "err": true}
+
+// method writes the client method of the function f.
+func (g *generator) method(typeName string, f *ABIFunction) error {
+	name := goName(f.Name, true)
+	if name == "" || strings.HasPrefix(f.Name, "__") {
+		// internal functions like __contract_abi
+		return nil
+	}
+	if name == "Contract" {
+		// the embedded *Contract
+		name += "Method"
+	}
+	params := []string{"ctx context.Context"}
+	g.imports["context"] = true
+	if f.Kind == FunctionCall {
+		params = append(params, "caller *account.Account")
+		g.imports["github.com/YuxSccc/near-api-go/account"] = true
+	}
+	args := "nil"
+	if f.Params != nil && f.Params.SerializationType == SerializationBorsh {
+		params = append(params, "args []byte")
+		args = "args"
+	} else if f.Params != nil && len(f.Params.Args) > 0 {
+		var fields []string
+		for _, arg := range f.Params.Args {
+			var s schema
+			if err := json.Unmarshal(arg.TypeSchema, &s); err != nil {
+				return fmt.Errorf("contract: invalid type of parameter %s of %s: %v", arg.Name, f.Name, err)
+			}
+			param := goName(arg.Name, false)
+			if param == "" || reservedParams[param] || token.IsKeyword(param) || types.Universe.Lookup(param) != nil {
+				param += "Arg"
+			}
+			params = append(params, param+" "+g.goType(&s))
+			fields = append(fields, fmt.Sprintf("%q: %s", arg.Name, param))
+		}
+		args = "map[string]interface{}{" + strings.Join(fields, ", ") + "}"
+	}
+	if f.Kind == FunctionCall {
+		params = append(params, "opts contract.CallOptions")
+	}
+
+	resultType := ""
+	if f.Result != nil {
+		resultType = "[]byte"
+		if f.Result.SerializationType != SerializationBorsh {
+			var s schema
+			if err := json.Unmarshal(f.Result.TypeSchema, &s); err != nil {
+				return fmt.Errorf("contract: invalid result type of %s: %v", f.Name, err)
+			}
+			resultType = g.goType(&s)
+		}
+	}
+
+	if f.Kind == FunctionCall {
+		g.printf("\n// %s calls the change method %s.\n", name, f.Name)
+	} else {
+		g.printf("\n// %s calls the view method %s.\n", name, f.Name)
+	}
+	g.comment(f.Doc)
+	signature := fmt.Sprintf("func (c *%s) %s(%s)", typeName, name, strings.Join(params, ", "))
+	switch {
+	case f.Kind == FunctionCall && resultType == "":
+		g.printf("%s (*rpc.FinalExecutionOutcome, error) {\n", signature)
+		g.printf("\treturn c.Contract.Call(ctx, caller, %q, %s, opts.Gas, opts.Deposit, nil)\n}\n", f.Name, args)
+	case f.Kind == FunctionCall:
+		g.printf("%s (%s, *rpc.FinalExecutionOutcome, error) {\n", signature, resultType)
+		g.printf("\tvar result %s\n", resultType)
+		g.printf("\toutcome, err := c.Contract.Call(ctx, caller, %q, %s, opts.Gas, opts.Deposit, &result)\n", f.Name, args)
+		g.printf("\treturn result, outcome, err\n}\n")
+	case resultType == "":
+		g.printf("%s error {\n", signature)
+		g.printf("\t_, err := c.Contract.View(ctx, %q, %s, nil)\n\treturn err\n}\n", f.Name, args)
+	default:
+		g.printf("%s (%s, error) {\n", signature, resultType)
+		g.printf("\tvar result %s\n", resultType)
+		g.printf("\t_, err := c.Contract.View(ctx, %q, %s, &result)\n", f.Name, args)
+		g.printf("\treturn result, err\n}\n")
+	}
+	return nil
+}
+
+// definitions writes the types of the definitions of the root schema.
+func (g *generator) definitions() {
+	defs := g.abi.root.Definitions
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		def := defs[name]
+		typeName := goName(name, true)
+		g.printf("\n// %s is the type %s of the contract.\n", typeName, name)
+		g.comment(def.Description)
+		switch {
+		case def.boolean == nil && def.Ref == "" && def.Properties != nil:
+			g.printf("type %s struct {\n", typeName)
+			props := make([]string, 0, len(def.Properties))
+			for prop := range def.Properties {
+				props = append(props, prop)
+			}
+			sort.Strings(props)
+			for _, prop := range props {
+				s := def.Properties[prop]
+				g.fieldComment(s.Description)
+				t, tag := g.goType(s), prop
+				if !contains(def.Required, prop) {
+					t, tag = nullable(t), prop+",omitempty"
+				}
+				g.printf("%s %s `json:%q`\n", goName(prop, true), t, tag)
+			}
+			g.printf("}\n")
+		case isStringEnum(def):
+			g.printf("type %s string\n\n", typeName)
+			g.printf("// The values of %s.\nconst (\n", typeName)
+			for _, v := range def.Enum {
+				g.printf("%s%s %s = %q\n", typeName, goName(v.(string), true), typeName, v)
+			}
+			g.printf(")\n")
+		default:
+			g.printf("type %s = %s\n", typeName, g.goType(def))
+		}
+	}
+}
+
+// comment writes the documentation text of the contract as paragraph of a
+// doc comment.
+func (g *generator) comment(text string) {
+	if text = strings.TrimSpace(text); text != "" {
+		g.printf("//\n")
+		g.fieldComment(text)
+	}
+}
+
+// fieldComment writes the documentation text of the contract as comment.
+func (g *generator) fieldComment(text string) {
+	if text = strings.TrimSpace(text); text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		g.printf("// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// goType returns the Go type of values of s.
+func (g *generator) goType(s *schema) string {
+	if s == nil || s.boolean != nil {
+		return g.rawType()
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		if _, ok := g.abi.root.Definitions[name]; !ok || name == s.Ref {
+			return g.rawType()
+		}
+		return goName(name, true)
+	}
+	// Option<T> of a defined type T
+	if len(s.Type) == 0 && len(s.AnyOf) == 2 {
+		for i, alt := range s.AnyOf {
+			if isNull(alt) {
+				return nullable(g.goType(s.AnyOf[1-i]))
+			}
+		}
+	}
+	if len(s.Type) == 0 && len(s.AllOf) == 1 {
+		return g.goType(s.AllOf[0])
+	}
+	var nonNull []string
+	for _, t := range s.Type {
+		if t != "null" {
+			nonNull = append(nonNull, t)
+		}
+	}
+	if len(nonNull) != 1 {
+		return g.rawType()
+	}
+	var t string
+	switch nonNull[0] {
+	case "boolean":
+		t = "bool"
+	case "string":
+		t = "string"
+	case "number":
+		t = "float64"
+	case "integer":
+		t = integerType(s.Format)
+	case "array":
+		if s.Items.all == nil {
+			return "[]" + g.rawType()
+		}
+		t = "[]" + g.goType(s.Items.all)
+	case "object":
+		if s.AdditionalProperties == nil || s.AdditionalProperties.boolean != nil {
+			return "map[string]" + g.rawType()
+		}
+		t = "map[string]" + g.goType(s.AdditionalProperties)
+	default:
+		return g.rawType()
+	}
+	if len(nonNull) < len(s.Type) {
+		return nullable(t)
+	}
+	return t
+}
+
+// rawType returns the type of values without Go type.
+func (g *generator) rawType() string {
+	g.imports["encoding/json"] = true
+	return "json.RawMessage"
+}
+
+// integerType returns the Go type of integers of the schemars format.
+func integerType(format string) string {
+	switch format {
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return format
+	case "uint":
+		return "uint64"
+	}
+	return "int64"
+}
+
+// nullable returns the type of t or null values.
+func nullable(t string) string {
+	if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "json.RawMessage" {
+		return t
+	}
+	return "*" + t
+}
+
+func isNull(s *schema) bool {
+	return len(s.Type) == 1 && s.Type[0] == "null"
+}
+
+func isStringEnum(s *schema) bool {
+	if len(s.Enum) == 0 || len(s.Type) != 1 || s.Type[0] != "string" {
+		return false
+	}
+	for _, v := range s.Enum {
+		if v, ok := v.(string); !ok || goName(v, true) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{"api": true, "ft": true, "id": true, "json": true, "nft": true, "uri": true, "url": true}
+
+// goName converts the snake case (or camel case) name to a Go identifier,
+// exported or not. It returns "" if name has no letters or digits.
+func goName(name string, exported bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, w := range words {
+		switch {
+		case i == 0 && !exported:
+			if initialisms[w] {
+				b.WriteString(w)
+			} else {
+				b.WriteString(strings.ToLower(w[:1]) + w[1:])
+			}
+		case initialisms[w]:
+			b.WriteString(strings.ToUpper(w))
+		default:
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	s := b.String()
+	if s != "" && unicode.IsDigit(rune(s[0])) {
+		if exported {
+			s = "X" + s
+		} else {
+			s = "x" + s
+		}
+	}
+	return s
+}
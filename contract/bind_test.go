@@ -0,0 +1,141 @@
+package contract
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestBind(t *testing.T) {
+	abi := parseTestABI(t)
+	code, err := Bind(abi, BindOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "counter.go", code, 0)
+	if err != nil {
+		t.Fatalf("Bind() returned invalid code: %v\n%s", err, code)
+	}
+	if f.Name.Name != "counter" {
+		t.Errorf("Bind() generated package %s (want counter)", f.Name.Name)
+	}
+	for _, want := range []string{
+		"// Code generated by nearabigen. DO NOT EDIT.",
+		"type Counter struct {\n\t*contract.Contract\n}",
+		"func NewCounter(client *rpc.Client, contractID string, opts contract.Options) *Counter {",
+		"func (c *Counter) GetNum(ctx context.Context, of AccountId, scale *uint8) (int8, error) {",
+		`c.Contract.View(ctx, "get_num", map[string]interface{}{"of": of, "scale": scale}, &result)`,
+		"func (c *Counter) Increment(ctx context.Context, caller *account.Account, by int64, opts contract.CallOptions) (int64, *rpc.FinalExecutionOutcome, error) {",
+		"func (c *Counter) Reset(ctx context.Context, caller *account.Account, opts contract.CallOptions) (*rpc.FinalExecutionOutcome, error) {",
+		`c.Contract.Call(ctx, caller, "reset", nil, opts.Gas, opts.Deposit, nil)`,
+		"func (c *Counter) SetRaw(ctx context.Context, caller *account.Account, args []byte, opts contract.CallOptions) (*rpc.FinalExecutionOutcome, error) {",
+		"type AccountId = string",
+	} {
+		if !strings.Contains(flatten(string(code)), flatten(want)) {
+			t.Errorf("Bind() generated code without %q:\n%s", want, code)
+		}
+	}
+
+	code, err = Bind(abi, BindOptions{Package: "clients", Type: "Num"})
+	if err != nil || !strings.Contains(string(code), "package clients\n") || !strings.Contains(string(code), "func NewNum(") {
+		t.Errorf("Bind() returned %v and code:\n%s", err, code)
+	}
+	if _, err := Bind(abi, BindOptions{Type: "num"}); err == nil {
+		t.Error("Bind() accepted unexported type name")
+	}
+	if _, err := Bind(&ABI{root: &schema{}}, BindOptions{}); err == nil {
+		t.Error("Bind() accepted ABI without contract name")
+	}
+}
+
+func TestBindTypes(t *testing.T) {
+	abi, err := ParseABI([]byte(`{
+	  "schema_version": "0.4.0",
+	  "metadata": {"name": "market"},
+	  "body": {
+	    "functions": [
+	      {"name": "__contract_abi", "kind": "view"},
+	      {"name": "contract", "kind": "view"},
+	      {"name": "nft_token", "doc": " Returns the token.\n Or null.", "kind": "view",
+	       "params": {"serialization_type": "json", "args": [{"name": "type", "type_schema": {"type": "string"}}]},
+	       "result": {"serialization_type": "json", "type_schema": {"anyOf": [{"$ref": "#/definitions/Token"}, {"type": "null"}]}}},
+	      {"name": "dump", "kind": "view", "result": {"serialization_type": "borsh", "type_schema": {"declaration": "Vec<u8>"}}}
+	    ],
+	    "root_schema": {
+	      "definitions": {
+	        "Color": {"type": "string", "enum": ["Red", "dark_green"]},
+	        "Meta": {"oneOf": [{"type": "string"}, {"type": "integer"}]},
+	        "Token": {"description": "A token.", "type": "object", "required": ["token_id", "tags"], "properties": {
+	          "token_id": {"type": "string"},
+	          "tags": {"type": "array", "items": {"type": "string"}},
+	          "color": {"anyOf": [{"$ref": "#/definitions/Color"}, {"type": "null"}]},
+	          "approvals": {"description": " Approval IDs.", "type": ["object", "null"], "additionalProperties": {"type": "integer", "format": "uint64"}},
+	          "weight": {"type": "number"}
+	        }}
+	      }
+	    }
+	  }
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := Bind(abi, BindOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "market.go", code, 0); err != nil {
+		t.Fatalf("Bind() returned invalid code: %v\n%s", err, code)
+	}
+	for _, want := range []string{
+		"\"encoding/json\"",
+		"func (c *Market) ContractMethod(ctx context.Context) error {",
+		"// NFTToken calls the view method nft_token.\n//\n// Returns the token.\n// Or null.\n",
+		"func (c *Market) NFTToken(ctx context.Context, typeArg string) (*Token, error) {",
+		`map[string]interface{}{"type": typeArg}`,
+		"func (c *Market) Dump(ctx context.Context) ([]byte, error) {",
+		"type Color string",
+		`ColorDarkGreen Color = "dark_green"`,
+		"type Meta = json.RawMessage",
+		"// Token is the type Token of the contract.\n//\n// A token.\ntype Token struct {",
+		"// Approval IDs.\n\tApprovals map[string]uint64 `json:\"approvals,omitempty\"`",
+		"Color *Color `json:\"color,omitempty\"`",
+		"Tags []string `json:\"tags\"`",
+		"TokenID string `json:\"token_id\"`",
+		"Weight *float64 `json:\"weight,omitempty\"`",
+	} {
+		if !strings.Contains(flatten(string(code)), flatten(want)) {
+			t.Errorf("Bind() generated code without %q:\n%s", want, code)
+		}
+	}
+	if strings.Contains(string(code), "ContractAbi") {
+		t.Errorf("Bind() generated method of __contract_abi:\n%s", code)
+	}
+}
+
+// flatten collapses the whitespace of code, ignoring the alignment of gofmt.
+func flatten(code string) string {
+	return strings.Join(strings.Fields(code), " ")
+}
+
+func TestGoName(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		exported bool
+		want     string
+	}{
+		{"get_num", true, "GetNum"},
+		{"get_num", false, "getNum"},
+		{"ft_balance_of", true, "FTBalanceOf"},
+		{"account_id", false, "accountID"},
+		{"id", false, "id"},
+		{"AccountId", true, "AccountId"},
+		{"Pagination_for_U64", true, "PaginationForU64"},
+		{"2fa", true, "X2fa"},
+		{"__", true, ""},
+	} {
+		if got := goName(test.name, test.exported); got != test.want {
+			t.Errorf("goName(%s, %t) returned %s (want %s)", test.name, test.exported, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package contract provides Contract, a client of the methods of a contract
+// deployed to a NEAR account:
+//
+//	c := contract.New(client, "counter.testnet")
+//	var count int
+//	_, err := c.View(ctx, "get_num", nil, &count)
+//	...
+//	_, err = c.Call(ctx, caller, "increment", nil, 0, types.Balance{}, nil)
+//
+// Contracts taking Borsh args or returning Borsh results are called with the
+// values wrapped by rpc.Borsh:
+//
+//	_, err = c.View(ctx, "get_point", rpc.Borsh(key), rpc.Borsh(&point))
+//
+// View returns typed JSON results:
+//
+//	count, err := contract.View[int](ctx, c, "get_num", nil)
+package contract
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/account"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// DefaultGas is the gas attached to calls if none is given, 30 TGas.
+const DefaultGas = 30_000_000_000_000
+
+// Options configure a Contract.
+type Options struct {
+	// Gas is attached to calls without gas, DefaultGas if zero.
+	Gas uint64
+	// Block is the block views are made against, the latest final block if
+	// zero.
+	Block rpc.BlockReference
+	// ABI, if set, validates the args and return values of methods, see
+	// ABI.ValidateArgs and ABI.ValidateResult.
+	ABI *ABI
+}
+
+// CallOptions are the gas and deposit attached to a call, used by the
+// clients generated by nearabigen. Zero gas means the default gas of the
+// contract.
+type CallOptions struct {
+	Gas     uint64
+	Deposit types.Balance
+}
+
+// Contract is the contract of an account. It is safe for concurrent use.
+type Contract struct {
+	client *rpc.Client
+	id     string
+	gas    uint64
+	block  rpc.BlockReference
+	abi    *ABI
+}
+
+// New returns the contract of contractID using client for views.
+func New(client *rpc.Client, contractID string) *Contract {
+	return NewWithOptions(client, contractID, Options{})
+}
+
+// NewWithOptions returns the contract of contractID using client for views
+// configured with opts.
+func NewWithOptions(client *rpc.Client, contractID string, opts Options) *Contract {
+	c := &Contract{client: client, id: contractID, gas: opts.Gas, block: opts.Block, abi: opts.ABI}
+	if c.gas == 0 {
+		c.gas = DefaultGas
+	}
+	return c
+}
+
+// At returns a copy of the contract whose views are made against block, e.g.
+// a historical block rpc.AtHeight(h). Blocks garbage collected by regular
+// nodes are viewed on the archival endpoint of the client, see
+// rpc.Options.Archival.
+func (c *Contract) At(block rpc.BlockReference) *Contract {
+	pinned := *c
+	pinned.block = block
+	return &pinned
+}
+
+// Block returns the block views are made against.
+func (c *Contract) Block() rpc.BlockReference {
+	return c.block
+}
+
+// ID returns the account ID of the contract.
+func (c *Contract) ID() string {
+	return c.id
+}
+
+// View calls the view method. Args are passed as is if they are a []byte,
+// Borsh encoded if wrapped by rpc.Borsh, otherwise they are JSON encoded (nil
+// means no args). If result is not nil the return value is decoded into it,
+// JSON unless wrapped by rpc.Borsh, or a *[]byte receives the raw return
+// value. The encodings of args and result are selected independently.
+func (c *Contract) View(ctx context.Context, method string, args, result interface{}) (*rpc.CallResult, error) {
+	if c.abi == nil {
+		return c.client.CallFunction(ctx, c.id, method, args, result, c.block)
+	}
+	f, err := c.abi.Function(method)
+	if err != nil {
+		return nil, err
+	}
+	if f.Kind != FunctionView {
+		return nil, fmt.Errorf("%w: %s", ErrNotView, method)
+	}
+	data, err := c.validateArgs(method, args)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.CallFunction(ctx, c.id, method, data, nil, c.block)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.abi.ValidateResult(method, res.Result); err != nil {
+		return res, err
+	}
+	return res, decodeResult(method, res.Result, result)
+}
+
+// View calls the view method of c like Contract.View and returns its
+// result decoded into a T, a []byte receives the raw return value.
+func View[T any](ctx context.Context, c *Contract, method string, args interface{}) (T, error) {
+	var result T
+	_, err := c.View(ctx, method, args, &result)
+	return result, err
+}
+
+// Call calls the change method signed by caller with gas (the default gas
+// of the contract if zero) and deposit attached. Args are encoded like by
+// View. If result is not nil, the return value is decoded into it like by
+// View. Failures of the call are returned as error along with the outcome.
+// If the caller does not wait for the execution of the call, see
+// account.Options, result must be nil.
+func (c *Contract) Call(ctx context.Context, caller *account.Account, method string, args interface{}, gas uint64, deposit types.Balance, result interface{}) (*rpc.FinalExecutionOutcome, error) {
+	if gas == 0 {
+		gas = c.gas
+	}
+	if c.abi != nil {
+		var err error
+		if args, err = c.validateArgs(method, args); err != nil {
+			return nil, err
+		}
+	}
+	outcome, err := caller.FunctionCall(ctx, c.id, method, args, gas, deposit)
+	// without final status, e.g. for accounts waiting until NONE or
+	// INCLUDED, there is no return value to validate
+	if err != nil || (result == nil && (c.abi == nil || outcome.Status.Kind == "")) {
+		return outcome, err
+	}
+	value, err := outcome.ReturnValue()
+	if err != nil {
+		return outcome, err
+	}
+	if c.abi != nil {
+		if err := c.abi.ValidateResult(method, value); err != nil {
+			return outcome, err
+		}
+	}
+	return outcome, decodeResult(method, value, result)
+}
+
+// validateArgs encodes args with rpc.EncodeArgs and validates them
+// against the ABI of the contract.
+func (c *Contract) validateArgs(method string, args interface{}) ([]byte, error) {
+	data, err := rpc.EncodeArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("contract: cannot encode args of %s: %v", method, err)
+	}
+	if err := c.abi.ValidateArgs(method, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// decodeResult decodes the return value data of method into result, see
+// View.
+func decodeResult(method string, data []byte, result interface{}) error {
+	if err := rpc.DecodeResult(data, result); err != nil {
+		return fmt.Errorf("contract: cannot decode result of %s: %v", method, err)
+	}
+	return nil
+}
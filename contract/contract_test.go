@@ -0,0 +1,230 @@
+package contract
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/account"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func testAccount(t *testing.T, client *rpc.Client, accountID string) *account.Account {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewEd25519Signer(accountID, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account.New(client, s)
+}
+
+// newTestServer returns a server answering access key and block queries and
+// send_tx calls with the return value "42".
+func newTestServer() *rpctest.Server {
+	srv := rpctest.NewServer()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce":      1,
+		"permission": "FullAccess",
+	}))
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(make([]byte, 32))},
+	}))
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+		"status":                 map[string]string{"SuccessValue": base64.StdEncoding.EncodeToString([]byte("42"))},
+	}))
+	return srv
+}
+
+// sentTransactions decodes the transactions of all send_tx calls.
+func sentTransactions(t *testing.T, srv *rpctest.Server) []*transaction.Transaction {
+	t.Helper()
+	var txs []*transaction.Transaction
+	for _, req := range srv.RequestsFor("send_tx") {
+		var p struct {
+			SignedTx string `json:"signed_tx_base64"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		data, err := base64.StdEncoding.DecodeString(p.SignedTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err := transaction.DeserializeSigned(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, &st.Transaction)
+	}
+	return txs
+}
+
+// sentCalls returns the function calls of all send_tx calls.
+func sentCalls(t *testing.T, srv *rpctest.Server) []transaction.FunctionCallAction {
+	t.Helper()
+	var calls []transaction.FunctionCallAction
+	for _, tx := range sentTransactions(t, srv) {
+		if tx.ReceiverID != "counter.testnet" {
+			t.Errorf("transaction sent to %s", tx.ReceiverID)
+		}
+		calls = append(calls, tx.Actions[0].FunctionCall)
+	}
+	return calls
+}
+
+func TestView(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var params map[string]interface{}
+	srv.Query("call_function", func(p json.RawMessage) (interface{}, error) {
+		params = nil
+		if err := json.Unmarshal(p, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"result": []int{'4', '2'}, "logs": []string{}}, nil
+	})
+	ctx := context.Background()
+	c := NewWithOptions(srv.Client(), "counter.testnet", Options{Block: rpc.AtHeight(7)})
+	var n int
+	if _, err := c.View(ctx, "get_num", map[string]string{"of": "alice"}, &n); err != nil || n != 42 {
+		t.Errorf("View() returned %d, %v (want 42)", n, err)
+	}
+	args, _ := base64.StdEncoding.DecodeString(params["args_base64"].(string))
+	if params["account_id"] != "counter.testnet" || params["method_name"] != "get_num" ||
+		params["block_id"] != 7.0 || string(args) != `{"of":"alice"}` {
+		t.Errorf("View() sent params %v with args %s", params, args)
+	}
+	var raw []byte
+	if _, err := New(srv.Client(), "counter.testnet").View(ctx, "get_num", nil, &raw); err != nil || string(raw) != "42" {
+		t.Errorf("View() returned %q, %v (want 42)", raw, err)
+	}
+	if params["finality"] != "final" || params["args_base64"] != "" {
+		t.Errorf("View() sent params %v", params)
+	}
+}
+
+func TestViewTyped(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("call_function", rpctest.Result(map[string]interface{}{"result": []int{'[', '4', '2', ']'}, "logs": []string{}}))
+	ctx := context.Background()
+	c := New(srv.Client(), "counter.testnet")
+	if nums, err := View[[]int](ctx, c, "get_nums", nil); err != nil || len(nums) != 1 || nums[0] != 42 {
+		t.Errorf("View() returned %v, %v (want [42])", nums, err)
+	}
+	if raw, err := View[[]byte](ctx, c, "get_nums", nil); err != nil || string(raw) != "[42]" {
+		t.Errorf("View() returned %q, %v (want [42])", raw, err)
+	}
+	if _, err := View[string](ctx, c, "get_nums", nil); err == nil {
+		t.Error("View() decoded [42] into a string")
+	}
+}
+
+func TestViewAt(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var params map[string]interface{}
+	srv.Query("call_function", func(p json.RawMessage) (interface{}, error) {
+		params = nil
+		if err := json.Unmarshal(p, &params); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"result": []int{'4', '2'}, "logs": []string{}}, nil
+	})
+	ctx := context.Background()
+	c := New(srv.Client(), "counter.testnet")
+	pinned := c.At(rpc.AtHeight(7))
+	if pinned.Block() != rpc.AtHeight(7) || c.Block() != (rpc.BlockReference{}) {
+		t.Errorf("At() pinned %v and left %v", pinned.Block(), c.Block())
+	}
+	var n int
+	if _, err := pinned.View(ctx, "get_num", nil, &n); err != nil || n != 42 {
+		t.Errorf("View() returned %d, %v (want 42)", n, err)
+	}
+	if params["block_id"] != 7.0 {
+		t.Errorf("View() sent params %v", params)
+	}
+}
+
+func TestCall(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	caller := testAccount(t, srv.Client(), "alice.testnet")
+	c := New(srv.Client(), "counter.testnet")
+
+	var n int
+	if _, err := c.Call(ctx, caller, "increment", map[string]int{"by": 2}, 0, types.Balance{}, &n); err != nil || n != 42 {
+		t.Errorf("Call() returned %d, %v (want 42)", n, err)
+	}
+	var raw []byte
+	one := types.NewBalance(big.NewInt(1))
+	if _, err := c.Call(ctx, caller, "reset", []byte{1}, 10, one, &raw); err != nil || string(raw) != "42" {
+		t.Errorf("Call() returned %q, %v (want 42)", raw, err)
+	}
+	if _, err := NewWithOptions(srv.Client(), "counter.testnet", Options{Gas: 5}).Call(ctx, caller, "ping", nil, 0, types.Balance{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var s string
+	if _, err := c.Call(ctx, caller, "increment", nil, 0, types.Balance{}, &s); err == nil {
+		t.Error("Call() decoded number into string")
+	}
+
+	calls := sentCalls(t, srv)
+	if len(calls) != 4 {
+		t.Fatalf("sent %d calls (want 4)", len(calls))
+	}
+	for i, want := range []struct {
+		method, args string
+		gas          uint64
+		deposit      string
+	}{
+		{"increment", `{"by":2}`, DefaultGas, "0"},
+		{"reset", "\x01", 10, "1"},
+		{"ping", "", 5, "0"},
+		{"increment", "", DefaultGas, "0"},
+	} {
+		call := calls[i]
+		if call.MethodName != want.method || string(call.Args) != want.args || call.Gas != want.gas || call.Deposit.String() != want.deposit {
+			t.Errorf("call %d is %+v (want %+v)", i, call, want)
+		}
+	}
+}
+
+func TestCallBorsh(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	ctx := context.Background()
+	caller := testAccount(t, srv.Client(), "alice.testnet")
+	c := New(srv.Client(), "counter.testnet")
+
+	// the return value "42" decoded as u16
+	var n uint16
+	if _, err := c.Call(ctx, caller, "set", rpc.Borsh(struct{ Value uint32 }{7}), 0, types.Balance{}, rpc.Borsh(&n)); err != nil || n != 0x3234 {
+		t.Errorf("Call() returned %d, %v (want %d)", n, err, 0x3234)
+	}
+	var wide uint64
+	if _, err := c.Call(ctx, caller, "set", nil, 0, types.Balance{}, rpc.Borsh(&wide)); err == nil {
+		t.Error("Call() decoded 2 bytes into u64")
+	}
+	// JSON args with a Borsh result
+	if _, err := c.Call(ctx, caller, "set", map[string]int{"value": 7}, 0, types.Balance{}, rpc.Borsh(&n)); err != nil {
+		t.Fatal(err)
+	}
+	calls := sentCalls(t, srv)
+	if len(calls) != 3 || string(calls[0].Args) != "\x07\x00\x00\x00" || string(calls[2].Args) != `{"value":7}` {
+		t.Errorf("sent calls %+v", calls)
+	}
+}
@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// SourceMetadata is the NEP-330 source metadata of a contract, the return
+// value of its contract_source_metadata view function.
+type SourceMetadata struct {
+	// Version is the version of the contract code, e.g. a semver or a git
+	// commit hash.
+	Version string `json:"version,omitempty"`
+	// Link is the URL of the source code, e.g. of a repository.
+	Link string `json:"link,omitempty"`
+	// Standards are the standards the contract implements.
+	Standards []Standard `json:"standards,omitempty"`
+	// BuildInfo describes how to reproduce the deployed code, nil if the
+	// contract was not built reproducibly.
+	BuildInfo *BuildInfo `json:"build_info,omitempty"`
+}
+
+// Standard is a NEP implemented by a contract.
+type Standard struct {
+	// Standard is the name of the standard, e.g. "nep141".
+	Standard string `json:"standard"`
+	// Version is the implemented version of the standard.
+	Version string `json:"version"`
+}
+
+// BuildInfo are the parameters of a reproducible build, added by NEP-330
+// version 1.2.0.
+type BuildInfo struct {
+	// BuildEnvironment is the reference of the Docker image the code was
+	// built in, including its digest.
+	BuildEnvironment string `json:"build_environment"`
+	// BuildCommand is the command run to build the code.
+	BuildCommand []string `json:"build_command"`
+	// SourceCodeSnapshot is the reference of the source code the code was
+	// built from, e.g. "git+https://github.com/org/repo?rev=<commit>".
+	SourceCodeSnapshot string `json:"source_code_snapshot"`
+	// ContractPath is the path of the contract in the source code, empty for
+	// its root.
+	ContractPath string `json:"contract_path"`
+	// OutputWasmPath is the path of the built code, if not the default one.
+	OutputWasmPath string `json:"output_wasm_path,omitempty"`
+}
+
+// Supports reports whether the contract implements the standard, e.g.
+// "nep171", in any version.
+func (md *SourceMetadata) Supports(standard string) bool {
+	for _, s := range md.Standards {
+		if s.Standard == standard {
+			return true
+		}
+	}
+	return false
+}
+
+// SourceMetadata returns the NEP-330 source metadata of the contract.
+// Contracts not implementing NEP-330 fail to execute the view.
+func (c *Contract) SourceMetadata(ctx context.Context) (*SourceMetadata, error) {
+	var md SourceMetadata
+	if _, err := c.client.CallFunction(ctx, c.id, "contract_source_metadata", nil, &md, c.block); err != nil {
+		return nil, err
+	}
+	return &md, nil
+}
+
+// GetContractMetadata returns the NEP-330 source metadata of the contract
+// deployed to accountID at the latest final block.
+func GetContractMetadata(ctx context.Context, client *rpc.Client, accountID string) (*SourceMetadata, error) {
+	return New(client, accountID).SourceMetadata(ctx)
+}
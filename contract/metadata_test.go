@@ -0,0 +1,49 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+const testSourceMetadata = `{
+	"version": "1.0.0",
+	"link": "https://github.com/near/counter",
+	"standards": [{"standard": "nep330", "version": "1.2.0"}],
+	"build_info": {
+		"build_environment": "sourcescan/cargo-near:0.13.0-rust-1.83.0@sha256:abc",
+		"build_command": ["cargo", "near", "build", "non-reproducible-wasm"],
+		"contract_path": "",
+		"source_code_snapshot": "git+https://github.com/near/counter?rev=0123"
+	}
+}`
+
+func TestGetContractMetadata(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	var params map[string]interface{}
+	srv.Query("call_function", func(p json.RawMessage) (interface{}, error) {
+		if err := json.Unmarshal(p, &params); err != nil {
+			return nil, err
+		}
+		ints := make([]int, len(testSourceMetadata))
+		for i, b := range []byte(testSourceMetadata) {
+			ints[i] = int(b)
+		}
+		return map[string]interface{}{"result": ints, "logs": []string{}}, nil
+	})
+	md, err := GetContractMetadata(context.Background(), srv.Client(), "counter.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["account_id"] != "counter.testnet" || params["method_name"] != "contract_source_metadata" {
+		t.Errorf("GetContractMetadata() sent params %v", params)
+	}
+	if md.Version != "1.0.0" || md.Link != "https://github.com/near/counter" || md.BuildInfo == nil ||
+		len(md.BuildInfo.BuildCommand) != 4 || md.BuildInfo.SourceCodeSnapshot != "git+https://github.com/near/counter?rev=0123" {
+		t.Errorf("GetContractMetadata() returned %+v", md)
+	}
+	if !md.Supports("nep330") || md.Supports("nep141") {
+		t.Errorf("Supports() of %v is wrong", md.Standards)
+	}
+}
@@ -0,0 +1,300 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// schema is the subset of JSON Schema (draft 7) generated by schemars for
+// the type schemas of near-abi.
+type schema struct {
+	// boolean is set for the boolean schemas true (any value) and false (no
+	// value).
+	boolean *bool
+
+	Ref                  string             `json:"$ref"`
+	Description          string             `json:"description"`
+	Type                 schemaTypes        `json:"type"`
+	Format               string             `json:"format"`
+	Enum                 []interface{}      `json:"enum"`
+	Const                interface{}        `json:"const"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Items                schemaItems        `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	Minimum              json.Number        `json:"minimum"`
+	Maximum              json.Number        `json:"maximum"`
+	AnyOf                []*schema          `json:"anyOf"`
+	OneOf                []*schema          `json:"oneOf"`
+	AllOf                []*schema          `json:"allOf"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting boolean schemas.
+func (s *schema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = schema{boolean: &b}
+		return nil
+	}
+	type plain schema
+	var p plain
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&p); err != nil {
+		return err
+	}
+	*s = schema(p)
+	return nil
+}
+
+// schemaTypes is the type keyword, a single type or a list of types.
+type schemaTypes []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// schemaItems is the items keyword, the schema of all items or the schemas
+// of the items of a tuple.
+type schemaItems struct {
+	all   *schema
+	tuple []*schema
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *schemaItems) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return json.Unmarshal(data, &i.tuple)
+	}
+	return json.Unmarshal(data, &i.all)
+}
+
+// allowsNull reports whether s accepts null, resolving references with
+// root.
+func (s *schema) allowsNull(root *schema) bool {
+	return s.validate(root, nil, "") == nil
+}
+
+// validate checks the JSON value v, decoded with json.Decoder.UseNumber,
+// against s. References are resolved against the definitions of root; path
+// locates v in error messages.
+func (s *schema) validate(root *schema, v interface{}, path string) error {
+	if s.boolean != nil {
+		if !*s.boolean {
+			return fmt.Errorf("%s: no value allowed", path)
+		}
+		return nil
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		def, ok := root.Definitions[name]
+		if !ok || name == s.Ref {
+			return fmt.Errorf("%s: unresolvable reference %s", path, s.Ref)
+		}
+		return def.validate(root, v, path)
+	}
+	if len(s.Type) > 0 {
+		ok := false
+		for _, t := range s.Type {
+			if hasType(v, t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: %s is not of type %s", path, describe(v), strings.Join(s.Type, " or "))
+		}
+	}
+	if s.Enum != nil {
+		ok := false
+		for _, e := range s.Enum {
+			if jsonEqual(e, v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: %s is not one of %v", path, describe(v), s.Enum)
+		}
+	}
+	if s.Const != nil && !jsonEqual(s.Const, v) {
+		return fmt.Errorf("%s: %s is not %v", path, describe(v), s.Const)
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if err := s.validateObject(root, v, path); err != nil {
+			return err
+		}
+	case []interface{}:
+		if err := s.validateArray(root, v, path); err != nil {
+			return err
+		}
+	case json.Number:
+		n, ok := new(big.Rat).SetString(v.String())
+		if !ok {
+			return fmt.Errorf("%s: invalid number %s", path, v)
+		}
+		if min, ok := new(big.Rat).SetString(s.Minimum.String()); ok && n.Cmp(min) < 0 {
+			return fmt.Errorf("%s: %s is less than %s", path, v, s.Minimum)
+		}
+		if max, ok := new(big.Rat).SetString(s.Maximum.String()); ok && n.Cmp(max) > 0 {
+			return fmt.Errorf("%s: %s is greater than %s", path, v, s.Maximum)
+		}
+	}
+	for _, sub := range s.AllOf {
+		if err := sub.validate(root, v, path); err != nil {
+			return err
+		}
+	}
+	if len(s.AnyOf) > 0 {
+		var first error
+		for _, sub := range s.AnyOf {
+			err := sub.validate(root, v, path)
+			if err == nil {
+				first = nil
+				break
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		if first != nil {
+			return fmt.Errorf("%s: %s matches no alternative (%v)", path, describe(v), first)
+		}
+	}
+	if len(s.OneOf) > 0 {
+		matches := 0
+		for _, sub := range s.OneOf {
+			if sub.validate(root, v, path) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: %s matches %d instead of one alternative", path, describe(v), matches)
+		}
+	}
+	return nil
+}
+
+func (s *schema) validateObject(root *schema, v map[string]interface{}, path string) error {
+	for _, name := range s.Required {
+		if _, ok := v[name]; !ok {
+			return fmt.Errorf("%s: missing property %s", path, name)
+		}
+	}
+	for name, value := range v {
+		if p, ok := s.Properties[name]; ok {
+			if err := p.validate(root, value, path+"."+name); err != nil {
+				return err
+			}
+		} else if s.AdditionalProperties != nil {
+			if err := s.AdditionalProperties.validate(root, value, path+"."+name); err != nil {
+				return fmt.Errorf("%s: unknown property %s", path, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *schema) validateArray(root *schema, v []interface{}, path string) error {
+	if s.MinItems != nil && len(v) < *s.MinItems {
+		return fmt.Errorf("%s: has %d items, less than %d", path, len(v), *s.MinItems)
+	}
+	if s.MaxItems != nil && len(v) > *s.MaxItems {
+		return fmt.Errorf("%s: has %d items, more than %d", path, len(v), *s.MaxItems)
+	}
+	for i, item := range v {
+		sub := s.Items.all
+		if s.Items.tuple != nil {
+			if i >= len(s.Items.tuple) {
+				break
+			}
+			sub = s.Items.tuple[i]
+		}
+		if sub == nil {
+			continue
+		}
+		if err := sub.validate(root, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasType reports whether the JSON value v is of the JSON Schema type t.
+func hasType(v interface{}, t string) bool {
+	switch v := v.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case []interface{}:
+		return t == "array"
+	case map[string]interface{}:
+		return t == "object"
+	case json.Number:
+		if t == "number" {
+			return true
+		}
+		n, ok := new(big.Rat).SetString(v.String())
+		return t == "integer" && ok && n.IsInt()
+	}
+	return false
+}
+
+// jsonEqual reports whether the JSON values a and b are equal.
+func jsonEqual(a, b interface{}) bool {
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := new(big.Rat).SetString(x.String())
+		ry, oky := new(big.Rat).SetString(y.String())
+		return okx && oky && rx.Cmp(ry) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// describe returns a short description of the JSON value v for errors.
+func describe(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(data) > 40 {
+		return string(data[:37]) + "..."
+	}
+	return string(data)
+}
+
+// decodeJSON decodes data preserving numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
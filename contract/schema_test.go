@@ -0,0 +1,109 @@
+package contract
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	var root schema
+	if err := json.Unmarshal([]byte(`{
+	  "definitions": {
+	    "U128": {"type": "string"},
+	    "Token": {
+	      "type": "object",
+	      "required": ["id", "owner"],
+	      "properties": {
+	        "id": {"type": "string"},
+	        "owner": {"$ref": "#/definitions/U128"},
+	        "tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2}
+	      },
+	      "additionalProperties": false
+	    },
+	    "Color": {"type": "string", "enum": ["Red", "Green"]},
+	    "Shape": {
+	      "oneOf": [
+	        {"type": "string", "enum": ["Point"]},
+	        {"type": "object", "required": ["Circle"], "properties": {"Circle": {"type": "number"}}, "additionalProperties": false}
+	      ]
+	    },
+	    "Pair": {"type": "array", "items": [{"type": "boolean"}, {"type": "integer"}], "minItems": 2, "maxItems": 2}
+	  }
+	}`), &root); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		schema, value string
+		valid         bool
+	}{
+		{`true`, `{"any": 1}`, true},
+		{`false`, `1`, false},
+		{`{}`, `null`, true},
+		{`{"type": "integer"}`, `3`, true},
+		{`{"type": "integer"}`, `3.5`, false},
+		{`{"type": "integer"}`, `340282366920938463463374607431768211455`, true},
+		{`{"type": "number", "minimum": 0, "maximum": 1.5}`, `1.5`, true},
+		{`{"type": "number", "minimum": 0, "maximum": 1.5}`, `1.6`, false},
+		{`{"type": "number", "minimum": 0, "maximum": 1.5}`, `-0.1`, false},
+		{`{"type": ["string", "null"]}`, `null`, true},
+		{`{"type": ["string", "null"]}`, `false`, false},
+		{`{"const": 2}`, `2.0`, true},
+		{`{"const": 2}`, `"2"`, false},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1", "owner": "alice.near", "tags": ["a", "b"]}`, true},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1"}`, false},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1", "owner": 2}`, false},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1", "owner": "alice.near", "name": "x"}`, false},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1", "owner": "alice.near", "tags": ["a", "b", "c"]}`, false},
+		{`{"$ref": "#/definitions/Token"}`, `{"id": "1", "owner": "alice.near", "tags": [1]}`, false},
+		{`{"$ref": "#/definitions/Missing"}`, `1`, false},
+		{`{"$ref": "other.json"}`, `1`, false},
+		{`{"$ref": "#/definitions/Color"}`, `"Red"`, true},
+		{`{"$ref": "#/definitions/Color"}`, `"Blue"`, false},
+		{`{"$ref": "#/definitions/Shape"}`, `"Point"`, true},
+		{`{"$ref": "#/definitions/Shape"}`, `{"Circle": 1.5}`, true},
+		{`{"$ref": "#/definitions/Shape"}`, `{"Square": 1}`, false},
+		{`{"$ref": "#/definitions/Pair"}`, `[true, 1]`, true},
+		{`{"$ref": "#/definitions/Pair"}`, `[1, true]`, false},
+		{`{"$ref": "#/definitions/Pair"}`, `[true]`, false},
+		{`{"anyOf": [{"$ref": "#/definitions/Color"}, {"type": "null"}]}`, `null`, true},
+		{`{"anyOf": [{"$ref": "#/definitions/Color"}, {"type": "null"}]}`, `"Blue"`, false},
+		{`{"allOf": [{"$ref": "#/definitions/Color"}]}`, `"Green"`, true},
+		{`{"allOf": [{"$ref": "#/definitions/Color"}]}`, `"Blue"`, false},
+		{`{"type": "object", "additionalProperties": {"type": "integer"}}`, `{"a": 1, "b": 2}`, true},
+		{`{"type": "object", "additionalProperties": {"type": "integer"}}`, `{"a": "1"}`, false},
+	} {
+		var s schema
+		if err := json.Unmarshal([]byte(test.schema), &s); err != nil {
+			t.Fatalf("invalid schema %s: %v", test.schema, err)
+		}
+		v, err := decodeJSON([]byte(test.value))
+		if err != nil {
+			t.Fatalf("invalid value %s: %v", test.value, err)
+		}
+		if err := s.validate(&root, v, "value"); (err == nil) != test.valid {
+			t.Errorf("validate(%s, %s) returned %v (want valid %t)", test.schema, test.value, err, test.valid)
+		}
+	}
+}
+
+func TestSchemaAllowsNull(t *testing.T) {
+	root := &schema{Definitions: map[string]*schema{"Opt": {Type: schemaTypes{"string", "null"}}}}
+	for _, test := range []struct {
+		schema string
+		want   bool
+	}{
+		{`{"type": "string"}`, false},
+		{`{"type": ["string", "null"]}`, true},
+		{`{"$ref": "#/definitions/Opt"}`, true},
+		{`{"anyOf": [{"type": "string"}, {"type": "null"}]}`, true},
+		{`{}`, true},
+	} {
+		var s schema
+		if err := json.Unmarshal([]byte(test.schema), &s); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.allowsNull(root); got != test.want {
+			t.Errorf("allowsNull(%s) returned %t (want %t)", test.schema, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package contract
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// ErrInvalidState is returned if contract state does not match the storage
+// layout it is decoded with.
+var ErrInvalidState = errors.New("contract: invalid state")
+
+// RootKey is the key near-sdk-rs stores the contract struct at.
+const RootKey = "STATE"
+
+// State is the key/value state of a contract, e.g. the values of a
+// rpc.ContractState or account.Snapshot. Its methods decode the storage
+// layouts of the near-sdk-rs collections, whose elements are Borsh encoded
+// and stored under the prefix of the collection:
+//
+//	var counts map[string]uint64
+//	err := state.LookupMap([]byte("c"), &counts)
+//
+// Collections only store their elements, the prefixes are part of the Borsh
+// encoding of the collection in the contract struct, see Root.
+type State []rpc.StateItem
+
+// State returns the state of the contract with keys starting with prefix
+// (all state if empty) at the block of the contract, see TakeSnapshot in
+// package account for state exceeding the view limit of nodes.
+func (c *Contract) State(ctx context.Context, prefix []byte) (State, error) {
+	res, err := c.client.ViewState(ctx, c.id, prefix, c.block)
+	if err != nil {
+		return nil, err
+	}
+	return State(res.Values), nil
+}
+
+// Get returns the value of key, false if the key is not set.
+func (s State) Get(key []byte) ([]byte, bool) {
+	for _, item := range s {
+		if bytes.Equal(item.Key, key) {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Root decodes the contract struct stored at RootKey into v.
+func (s State) Root(v interface{}) error {
+	ok, err := s.LazyOption([]byte(RootKey), v)
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: no %s key", ErrInvalidState, RootKey)
+	}
+	return err
+}
+
+// LazyOption decodes the value of the LazyOption with prefix into v. It
+// returns false if the option is None.
+func (s State) LazyOption(prefix []byte, v interface{}) (bool, error) {
+	value, ok := s.Get(prefix)
+	if !ok {
+		return false, nil
+	}
+	if err := borsh.Deserialize(value, v); err != nil {
+		return false, fmt.Errorf("%w: value of %q: %v", ErrInvalidState, prefix, err)
+	}
+	return true, nil
+}
+
+// LookupMap decodes the entries of the LookupMap (or LookupSet with a
+// map[K]struct{}) with prefix into the map m points to. The keys of the
+// entries are the Borsh encoded map keys appended to the prefix, as stored by
+// near_sdk::collections and by near_sdk::store with the default identity
+// hasher. Entries of other collections whose prefix starts with prefix fail
+// to decode.
+func (s State) LookupMap(prefix []byte, m interface{}) error {
+	mv, err := mapValue(m)
+	if err != nil {
+		return err
+	}
+	kt, vt := mv.Type().Key(), mv.Type().Elem()
+	for _, item := range s {
+		if !bytes.HasPrefix(item.Key, prefix) {
+			continue
+		}
+		k, v := reflect.New(kt), reflect.New(vt)
+		if err := borsh.Deserialize(item.Key[len(prefix):], k.Interface()); err != nil {
+			return fmt.Errorf("%w: key %q of map %q: %v", ErrInvalidState, item.Key, prefix, err)
+		}
+		if err := borsh.Deserialize(item.Value, v.Interface()); err != nil {
+			return fmt.Errorf("%w: value of %q in map %q: %v", ErrInvalidState, item.Key, prefix, err)
+		}
+		mv.SetMapIndex(k.Elem(), v.Elem())
+	}
+	return nil
+}
+
+// Vector decodes the elements of the near_sdk::collections::Vector with
+// prefix into the slice p points to. The key of the element at index i is
+// the u64 little-endian i appended to the prefix, the elements must have
+// contiguous indices starting at 0. Elements with u32 indices fail to
+// decode, see StoreVector.
+func (s State) Vector(prefix []byte, p interface{}) error {
+	values, err := s.vector(prefix, 8)
+	if err != nil {
+		return err
+	}
+	return decodeSlice(prefix, values, p)
+}
+
+// StoreVector decodes the elements of the near_sdk::store::Vector with
+// prefix into the slice p points to. It is like Vector, but the indices are
+// u32.
+func (s State) StoreVector(prefix []byte, p interface{}) error {
+	values, err := s.vector(prefix, 4)
+	if err != nil {
+		return err
+	}
+	return decodeSlice(prefix, values, p)
+}
+
+// UnorderedMap decodes the entries of the near_sdk::collections::UnorderedMap
+// with prefix into the map m points to. The keys and values are stored in the
+// Vectors with the prefix followed by 'k' and 'v', the index of the keys in
+// the LookupMap with the prefix followed by 'i' is not needed for decoding.
+func (s State) UnorderedMap(prefix []byte, m interface{}) error {
+	mv, err := mapValue(m)
+	if err != nil {
+		return err
+	}
+	keys, err := s.vector(appendPrefix(prefix, 'k'), 8)
+	if err != nil {
+		return err
+	}
+	values, err := s.vector(appendPrefix(prefix, 'v'), 8)
+	if err != nil {
+		return err
+	}
+	if len(keys) != len(values) {
+		return fmt.Errorf("%w: map %q has %d keys and %d values", ErrInvalidState, prefix, len(keys), len(values))
+	}
+	kt, vt := mv.Type().Key(), mv.Type().Elem()
+	for i := range keys {
+		k, v := reflect.New(kt), reflect.New(vt)
+		if err := borsh.Deserialize(keys[i], k.Interface()); err != nil {
+			return fmt.Errorf("%w: key %d of map %q: %v", ErrInvalidState, i, prefix, err)
+		}
+		if err := borsh.Deserialize(values[i], v.Interface()); err != nil {
+			return fmt.Errorf("%w: value %d of map %q: %v", ErrInvalidState, i, prefix, err)
+		}
+		mv.SetMapIndex(k.Elem(), v.Elem())
+	}
+	return nil
+}
+
+// UnorderedSet decodes the elements of the near_sdk::collections::UnorderedSet
+// with prefix into the slice p points to. The elements are stored in the
+// Vector with the prefix followed by 'e'.
+func (s State) UnorderedSet(prefix []byte, p interface{}) error {
+	return s.Vector(appendPrefix(prefix, 'e'), p)
+}
+
+// vector returns the raw elements of the vector with prefix and indices of
+// width bytes in order. Keys with indices of the other width, 4 or 8 bytes,
+// are rejected rather than ignored.
+func (s State) vector(prefix []byte, width int) ([][]byte, error) {
+	type element struct {
+		index uint64
+		value []byte
+	}
+	var elements []element
+	for _, item := range s {
+		if !bytes.HasPrefix(item.Key, prefix) {
+			continue
+		}
+		switch len(item.Key) - len(prefix) {
+		case width:
+			var index uint64
+			if width == 4 {
+				index = uint64(binary.LittleEndian.Uint32(item.Key[len(prefix):]))
+			} else {
+				index = binary.LittleEndian.Uint64(item.Key[len(prefix):])
+			}
+			elements = append(elements, element{index, item.Value})
+		case 12 - width:
+			return nil, fmt.Errorf("%w: vector %q has key %q with %d byte index (want %d)",
+				ErrInvalidState, prefix, item.Key, 12-width, width)
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool { return elements[i].index < elements[j].index })
+	values := make([][]byte, len(elements))
+	for i, e := range elements {
+		if e.index != uint64(i) {
+			return nil, fmt.Errorf("%w: vector %q misses element %d", ErrInvalidState, prefix, i)
+		}
+		values[i] = e.value
+	}
+	return values, nil
+}
+
+// decodeSlice decodes the raw elements of the collection with prefix into
+// the slice p points to.
+func decodeSlice(prefix []byte, values [][]byte, p interface{}) error {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("contract: cannot decode vector into %T", p)
+	}
+	slice := reflect.MakeSlice(v.Elem().Type(), len(values), len(values))
+	for i, value := range values {
+		if err := borsh.Deserialize(value, slice.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("%w: element %d of %q: %v", ErrInvalidState, i, prefix, err)
+		}
+	}
+	v.Elem().Set(slice)
+	return nil
+}
+
+// mapValue returns the map m points to, made if nil.
+func mapValue(m interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Map {
+		return reflect.Value{}, fmt.Errorf("contract: cannot decode map into %T", m)
+	}
+	if v.Elem().IsNil() {
+		v.Elem().Set(reflect.MakeMap(v.Elem().Type()))
+	}
+	return v.Elem(), nil
+}
+
+func appendPrefix(prefix []byte, b byte) []byte {
+	return append(prefix[:len(prefix):len(prefix)], b)
+}
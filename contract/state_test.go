@@ -0,0 +1,128 @@
+package contract
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+)
+
+// testState returns the state of a contract with a root struct, a Vector
+// "v", a LookupMap "m", an UnorderedMap "u", a LazyOption "o" and a
+// store::Vector "s".
+func testState(t *testing.T) State {
+	t.Helper()
+	var s State
+	put := func(key []byte, v interface{}) {
+		value, err := borsh.Serialize(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s = append(s, rpc.StateItem{Key: key, Value: value})
+	}
+	index := func(prefix string, i uint64) []byte {
+		return binary.LittleEndian.AppendUint64([]byte(prefix), i)
+	}
+	key := func(prefix, k string) []byte {
+		data, err := borsh.Serialize(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return append([]byte(prefix), data...)
+	}
+	put([]byte(RootKey), struct{ Owner string }{"alice.testnet"})
+	put(index("v", 1), "b")
+	put(index("v", 0), "a")
+	put(key("m", "alice"), uint64(1))
+	put(key("m", "bob"), uint64(2))
+	put(index("uk", 0), "x")
+	put(index("uv", 0), uint32(7))
+	put(key("ui", "x"), uint64(0))
+	put([]byte("o"), "opt")
+	put(binary.LittleEndian.AppendUint32([]byte("s"), 0), "c")
+	return s
+}
+
+func TestState(t *testing.T) {
+	s := testState(t)
+	var root struct{ Owner string }
+	if err := s.Root(&root); err != nil || root.Owner != "alice.testnet" {
+		t.Errorf("Root() returned %+v, %v", root, err)
+	}
+	var v []string
+	if err := s.Vector([]byte("v"), &v); err != nil || !reflect.DeepEqual(v, []string{"a", "b"}) {
+		t.Errorf("Vector() returned %v, %v", v, err)
+	}
+	var m map[string]uint64
+	if err := s.LookupMap([]byte("m"), &m); err != nil || !reflect.DeepEqual(m, map[string]uint64{"alice": 1, "bob": 2}) {
+		t.Errorf("LookupMap() returned %v, %v", m, err)
+	}
+	var u map[string]uint32
+	if err := s.UnorderedMap([]byte("u"), &u); err != nil || !reflect.DeepEqual(u, map[string]uint32{"x": 7}) {
+		t.Errorf("UnorderedMap() returned %v, %v", u, err)
+	}
+	var sv []string
+	if err := s.StoreVector([]byte("s"), &sv); err != nil || !reflect.DeepEqual(sv, []string{"c"}) {
+		t.Errorf("StoreVector() returned %v, %v", sv, err)
+	}
+	var o string
+	if ok, err := s.LazyOption([]byte("o"), &o); !ok || err != nil || o != "opt" {
+		t.Errorf("LazyOption() returned %q, %v, %v", o, ok, err)
+	}
+	if ok, err := s.LazyOption([]byte("none"), &o); ok || err != nil {
+		t.Errorf("LazyOption(none) returned %v, %v", ok, err)
+	}
+}
+
+func TestStateInvalid(t *testing.T) {
+	s := testState(t)
+	// element 0 is missing
+	var v []string
+	if err := (State{s[1]}).Vector([]byte("v"), &v); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Vector() returned %v (want ErrInvalidState)", err)
+	}
+	// the index widths of the two vector layouts differ
+	if err := s.Vector([]byte("s"), &v); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Vector() of store::Vector returned %v (want ErrInvalidState)", err)
+	}
+	if err := s.StoreVector([]byte("v"), &v); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("StoreVector() of collections::Vector returned %v (want ErrInvalidState)", err)
+	}
+	var m map[string]string
+	if err := s.LookupMap([]byte("m"), &m); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("LookupMap() returned %v (want ErrInvalidState)", err)
+	}
+	if err := s[1:].Root(&struct{}{}); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Root() returned %v (want ErrInvalidState)", err)
+	}
+	if err := s.Vector([]byte("v"), v); err == nil {
+		t.Error("Vector() decoded into non-pointer")
+	}
+}
+
+func TestContractState(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+	srv.Query("view_state", rpctest.Result(map[string]interface{}{"values": testState(t)}))
+	s, err := NewWithOptions(srv.Client(), "counter.testnet", Options{Block: rpc.AtHeight(7)}).State(context.Background(), []byte("v"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v []string
+	if err := s.Vector([]byte("v"), &v); err != nil || len(v) != 2 {
+		t.Errorf("Vector() returned %v, %v", v, err)
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(srv.RequestsFor("query")[0].Params, &params); err != nil {
+		t.Fatal(err)
+	}
+	if params["account_id"] != "counter.testnet" || params["prefix_base64"] != "dg==" || params["block_id"] != 7.0 {
+		t.Errorf("State() sent params %v", params)
+	}
+}
@@ -0,0 +1,106 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/account"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// DefaultMigrateMethod is the state migration method called by Upgrade if
+// UpgradeOptions.MigrateMethod is empty.
+const DefaultMigrateMethod = "migrate"
+
+// ErrSanityCheck is returned (wrapped) by Upgrade if the sanity check of the
+// upgraded contract fails.
+var ErrSanityCheck = errors.New("contract: sanity check failed")
+
+// UpgradeOptions configure Upgrade.
+type UpgradeOptions struct {
+	// MigrateMethod is the method migrating the state of the old code,
+	// DefaultMigrateMethod if empty.
+	MigrateMethod string
+	// MigrateArgs are the args of the migrate method, encoded like by View.
+	MigrateArgs interface{}
+	// MigrateGas is the gas of the migrate method, the default gas of the
+	// contract if zero.
+	MigrateGas uint64
+	// NoMigration deploys the code without calling a migrate method.
+	NoMigration bool
+	// Check is the view called on the upgraded contract, none if nil.
+	Check *SanityCheck
+}
+
+// SanityCheck is a view call verifying an upgraded contract.
+type SanityCheck struct {
+	Method string
+	// Args are encoded like by View.
+	Args interface{}
+	// Verify checks the raw return value of the view, any value passes if
+	// nil.
+	Verify func(result []byte) error
+}
+
+// Upgrade deploys code to the contract and calls its migrate method in the
+// same transaction signed by caller, the contract account, so that a failed
+// migration reverts the deployment. The upgrade is then verified at the
+// block the transaction executed in: the code hash of the account must
+// match code (else an error wrapping account.ErrCodeHashMismatch is
+// returned) and the sanity check of opts must pass (else an error wrapping
+// ErrSanityCheck is returned). The outcome is returned along with errors
+// after sending the transaction.
+func (c *Contract) Upgrade(ctx context.Context, caller *account.Account, code []byte, opts UpgradeOptions) (*rpc.FinalExecutionOutcome, error) {
+	if caller.ID() != c.id {
+		return nil, fmt.Errorf("contract: %s cannot deploy to %s", caller.ID(), c.id)
+	}
+	actions := []transaction.Action{transaction.DeployContract(code)}
+	if !opts.NoMigration {
+		method, gas := opts.MigrateMethod, opts.MigrateGas
+		if method == "" {
+			method = DefaultMigrateMethod
+		}
+		if gas == 0 {
+			gas = c.gas
+		}
+		args, err := rpc.EncodeArgs(opts.MigrateArgs)
+		if err != nil {
+			return nil, fmt.Errorf("contract: cannot encode args of %s: %v", method, err)
+		}
+		actions = append(actions, transaction.FunctionCall(method, args, gas, types.Balance{}))
+	}
+	outcome, err := caller.SignAndSend(ctx, c.id, actions...)
+	if err != nil {
+		return outcome, err
+	}
+
+	// the state after the last receipt, which the node returning the outcome
+	// knows regardless of the finality of views
+	block := c.block
+	if n := len(outcome.ReceiptsOutcome); n > 0 {
+		block = rpc.AtHash(outcome.ReceiptsOutcome[n-1].BlockHash)
+	}
+	state, err := c.client.ViewAccount(ctx, c.id, block)
+	if err != nil {
+		return outcome, err
+	}
+	if hash := account.CodeHash(code); state.CodeHash != hash {
+		return outcome, fmt.Errorf("%w: %s has code %s (want %s)", account.ErrCodeHashMismatch, c.id, state.CodeHash, hash)
+	}
+	if opts.Check == nil {
+		return outcome, nil
+	}
+	var result []byte
+	if _, err := c.client.CallFunction(ctx, c.id, opts.Check.Method, opts.Check.Args, &result, block); err != nil {
+		return outcome, fmt.Errorf("%w: %s: %v", ErrSanityCheck, opts.Check.Method, err)
+	}
+	if opts.Check.Verify != nil {
+		if err := opts.Check.Verify(result); err != nil {
+			return outcome, fmt.Errorf("%w: %s: %v", ErrSanityCheck, opts.Check.Method, err)
+		}
+	}
+	return outcome, nil
+}
@@ -0,0 +1,94 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/account"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestUpgrade(t *testing.T) {
+	code := []byte{0, 'a', 's', 'm', 1, 0, 0, 0}
+	receiptBlock := base58.Encode(append(make([]byte, 31), 9))
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "EXECUTED_OPTIMISTIC",
+		"status":                 map[string]string{"SuccessValue": ""},
+		"receipts_outcome": []map[string]interface{}{
+			{"id": "1", "block_hash": base58.Encode(make([]byte, 32))},
+			{"id": "2", "block_hash": receiptBlock},
+		},
+	}))
+	codeHash := account.CodeHash(code)
+	var views []map[string]interface{}
+	srv.Query("view_account", func(p json.RawMessage) (interface{}, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(p, &params); err != nil {
+			return nil, err
+		}
+		views = append(views, params)
+		return map[string]interface{}{"amount": "1", "code_hash": codeHash}, nil
+	})
+	srv.Query("call_function", func(p json.RawMessage) (interface{}, error) {
+		var params map[string]interface{}
+		if err := json.Unmarshal(p, &params); err != nil {
+			return nil, err
+		}
+		views = append(views, params)
+		return map[string]interface{}{"result": []int{'2'}, "logs": []string{}}, nil
+	})
+	ctx := context.Background()
+	caller := testAccount(t, srv.Client(), "counter.testnet")
+	c := New(srv.Client(), "counter.testnet")
+
+	check := &SanityCheck{Method: "version", Verify: func(result []byte) error {
+		if string(result) != "2" {
+			return fmt.Errorf("version %s", result)
+		}
+		return nil
+	}}
+	if _, err := c.Upgrade(ctx, caller, code, UpgradeOptions{MigrateArgs: map[string]int{"from": 1}, Check: check}); err != nil {
+		t.Fatalf("Upgrade() returned %v", err)
+	}
+	if len(views) != 2 || views[0]["block_id"] != receiptBlock || views[1]["block_id"] != receiptBlock || views[1]["method_name"] != "version" {
+		t.Errorf("Upgrade() viewed %v", views)
+	}
+	txs := sentTransactions(t, srv)
+	if len(txs) != 1 || len(txs[0].Actions) != 2 || txs[0].Actions[0].Kind != transaction.ActionDeployContract ||
+		string(txs[0].Actions[0].DeployContract.Code) != string(code) {
+		t.Fatalf("Upgrade() sent %+v", txs)
+	}
+	if call := txs[0].Actions[1].FunctionCall; call.MethodName != DefaultMigrateMethod || string(call.Args) != `{"from":1}` || call.Gas != DefaultGas {
+		t.Errorf("Upgrade() sent migration %+v", call)
+	}
+
+	check.Method = "version_v3"
+	check.Verify = func([]byte) error { return errors.New("old version") }
+	if _, err := c.Upgrade(ctx, caller, code, UpgradeOptions{NoMigration: true, Check: check}); !errors.Is(err, ErrSanityCheck) {
+		t.Errorf("Upgrade() returned %v (want ErrSanityCheck)", err)
+	}
+	if txs := sentTransactions(t, srv); len(txs) != 2 || len(txs[1].Actions) != 1 {
+		t.Errorf("Upgrade() sent %+v", txs)
+	}
+	if _, err := c.Upgrade(ctx, caller, code, UpgradeOptions{MigrateMethod: "migrate_v2", MigrateGas: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if call := sentTransactions(t, srv)[2].Actions[1].FunctionCall; call.MethodName != "migrate_v2" || call.Gas != 7 || len(call.Args) != 0 {
+		t.Errorf("Upgrade() sent migration %+v", call)
+	}
+
+	codeHash = account.CodeHash([]byte{1})
+	if _, err := c.Upgrade(ctx, caller, code, UpgradeOptions{}); !errors.Is(err, account.ErrCodeHashMismatch) {
+		t.Errorf("Upgrade() returned %v (want ErrCodeHashMismatch)", err)
+	}
+	if _, err := c.Upgrade(ctx, testAccount(t, srv.Client(), "alice.testnet"), code, UpgradeOptions{}); err == nil {
+		t.Error("Upgrade() deployed with another account")
+	}
+}
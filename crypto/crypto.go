@@ -0,0 +1,108 @@
+// Package crypto implements verification of NEAR signatures for all
+// supported key types.
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/near/borsh-go"
+)
+
+// Length of the signature data for all supported key types.
+const (
+	Ed25519SignatureLength   = ed25519.SignatureSize
+	Secp256k1SignatureLength = 65
+)
+
+// Signature is a signature of one of the supported key types. Ed25519
+// signatures are 64 bytes, secp256k1 signatures are 65 bytes (r, s and the
+// recovery ID v).
+type Signature struct {
+	KeyType borsh.Enum
+	Data    []byte
+}
+
+// ParseSignature parses a signature in the "<key type>:<base58>" format used
+// by NEAR RPC.
+func ParseSignature(s string) (Signature, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return Signature{}, fmt.Errorf("crypto: invalid signature '%s': missing key type", s)
+	}
+	keyType, err := utils.ParseKeyTypeName(s[:i])
+	if err != nil {
+		return Signature{}, fmt.Errorf("crypto: invalid signature '%s': %v", s, err)
+	}
+	return NewSignature(keyType, base58.Decode(s[i+1:]))
+}
+
+// NewSignature returns the signature of keyType with the raw data and
+// validates its length.
+func NewSignature(keyType borsh.Enum, data []byte) (Signature, error) {
+	var length int
+	switch keyType {
+	case utils.ED25519:
+		length = Ed25519SignatureLength
+	case utils.SECP256K1:
+		length = Secp256k1SignatureLength
+	default:
+		return Signature{}, fmt.Errorf("crypto: unknown key type %d", keyType)
+	}
+	if len(data) != length {
+		return Signature{}, fmt.Errorf("crypto: %s signature has invalid length %d",
+			utils.KeyTypeName(keyType), len(data))
+	}
+	return Signature{KeyType: keyType, Data: append([]byte{}, data...)}, nil
+}
+
+// String returns the signature in the "<key type>:<base58>" format used by
+// NEAR RPC.
+func (sig Signature) String() string {
+	return utils.KeyTypeName(sig.KeyType) + ":" + base58.Encode(sig.Data)
+}
+
+// Verify reports whether sig is a valid signature of msg by pubKey. The key
+// types of sig and pubKey must match.
+func (sig Signature) Verify(pubKey utils.PublicKey, msg []byte) bool {
+	return sig.KeyType == pubKey.KeyType && Verify(pubKey, msg, sig.Data)
+}
+
+// Verify reports whether sig is a valid raw signature of msg by pubKey, as
+// returned by signer.Signer.SignBytes.
+//
+// Like nearcore, secp256k1 signatures are verified over msg as 32 byte
+// digest, by recovering the public key from the signature.
+func Verify(pubKey utils.PublicKey, msg, sig []byte) bool {
+	switch pubKey.KeyType {
+	case utils.ED25519:
+		return len(sig) == Ed25519SignatureLength &&
+			ed25519.Verify(pubKey.ED25519.Data[:], msg, sig)
+	case utils.SECP256K1:
+		if len(msg) != sha256.Size || len(sig) != Secp256k1SignatureLength || sig[64] > 3 {
+			return false
+		}
+		compact := append([]byte{27 + sig[64]}, sig[:64]...)
+		recovered, _, err := btcec.RecoverCompact(btcec.S256(), compact, msg)
+		if err != nil {
+			return false
+		}
+		return bytes.Equal(recovered.SerializeUncompressed()[1:], pubKey.SECP256K1.Data[:])
+	default:
+		return false
+	}
+}
+
+// VerifyTransaction reports whether sig is a valid signature of the Borsh
+// serialized transaction tx by pubKey. Transactions are signed by signing
+// the SHA-256 hash of their serialization.
+func VerifyTransaction(pubKey utils.PublicKey, tx []byte, sig Signature) bool {
+	hash := sha256.Sum256(tx)
+	return sig.Verify(pubKey, hash[:])
+}
@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func testSigners(t *testing.T) []signer.Signer {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed, err := signer.NewEd25519Signer("test-account.testnet", priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []signer.Signer{ed, signer.NewSecp256k1Signer("test-account.testnet", privKey)}
+}
+
+func TestVerify(t *testing.T) {
+	tx := []byte("serialized transaction")
+	hash := sha256.Sum256(tx)
+	signers := testSigners(t)
+	for i, s := range signers {
+		raw, err := s.SignBytes(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Verify(s.PublicKey(), hash[:], raw) {
+			t.Errorf("Verify() rejected %s signature", utils.KeyTypeName(s.PublicKey().KeyType))
+		}
+		sig, err := ParseSignature(utils.KeyTypeName(s.PublicKey().KeyType) + ":" + base58.Encode(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyTransaction(s.PublicKey(), tx, sig) {
+			t.Error("VerifyTransaction() rejected signature")
+		}
+		parsed, err := ParseSignature(sig.String())
+		if err != nil || !bytes.Equal(parsed.Data, raw) {
+			t.Errorf("ParseSignature(%s) returned %v, %v", sig, parsed, err)
+		}
+		// wrong message, wrong key
+		other := sha256.Sum256([]byte("other"))
+		if Verify(s.PublicKey(), other[:], raw) {
+			t.Error("Verify() accepted signature of other message")
+		}
+		if sig.Verify(signers[1-i].PublicKey(), hash[:]) {
+			t.Error("Verify() accepted signature of other key")
+		}
+	}
+	if _, err := ParseSignature("ed25519:abc"); err == nil {
+		t.Error("ParseSignature() accepted short signature")
+	}
+	if _, err := ParseSignature("rsa:abc"); err == nil {
+		t.Error("ParseSignature() accepted unknown key type")
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &MessagePayload{Message: "login", Recipient: "example.near"}
+	copy(p.Nonce[:], bytes.Repeat([]byte{1}, 32))
+	// tag || string message || nonce || string recipient || none
+	var want []byte
+	want = append(want, 0x9d, 0x01, 0x00, 0x80)
+	want = append(want, 5, 0, 0, 0)
+	want = append(want, "login"...)
+	want = append(want, p.Nonce[:]...)
+	want = append(want, 12, 0, 0, 0)
+	want = append(want, "example.near"...)
+	want = append(want, 0)
+	wantHash := sha256.Sum256(want)
+	hash, err := p.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(hash, wantHash[:]) {
+		t.Fatalf("Hash() returned %x (want %x)", hash, wantHash)
+	}
+	pubKey := utils.PublicKeyFromEd25519(priv.Public().(ed25519.PublicKey))
+	sig := ed25519.Sign(priv, hash)
+	if !VerifyMessage(pubKey, p, sig) {
+		t.Error("VerifyMessage() rejected signature")
+	}
+	callback := "https://example.com"
+	p.CallbackURL = &callback
+	if VerifyMessage(pubKey, p, sig) {
+		t.Error("VerifyMessage() accepted signature of other payload")
+	}
+}
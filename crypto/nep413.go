@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/near/borsh-go"
+)
+
+// nep413Tag is the prefix of NEP-413 messages, 2^31 + 413, which makes sure
+// a signed message can never be a valid transaction.
+const nep413Tag = 1<<31 + 413
+
+// MessagePayload is a message signed off-chain by a wallet according to
+// NEP-413 (signMessage).
+type MessagePayload struct {
+	Message     string
+	Nonce       [32]byte
+	Recipient   string
+	CallbackURL *string
+}
+
+// Hash returns the SHA-256 hash of the tagged Borsh serialization of p, which
+// is the data signed by the wallet.
+func (p *MessagePayload) Hash() ([]byte, error) {
+	buf, err := borsh.Serialize(*p)
+	if err != nil {
+		return nil, err
+	}
+	var tag [4]byte
+	binary.LittleEndian.PutUint32(tag[:], nep413Tag)
+	hash := sha256.Sum256(append(tag[:], buf...))
+	return hash[:], nil
+}
+
+// VerifyMessage reports whether sig, as returned by a wallet for signMessage
+// (base64 decoded), is a valid NEP-413 signature of p by pubKey. The caller
+// must additionally check that pubKey is a full access key of the account.
+func VerifyMessage(pubKey utils.PublicKey, p *MessagePayload, sig []byte) bool {
+	hash, err := p.Hash()
+	if err != nil {
+		return false
+	}
+	return Verify(pubKey, hash, sig)
+}
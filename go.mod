@@ -3,8 +3,36 @@ module github.com/YuxSccc/near-api-go
 go 1.19
 
 require (
+	filippo.io/edwards25519 v1.0.0
 	github.com/aurora-is-near/go-jsonrpc/v3 v3.1.1
+	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/btcsuite/btcutil v1.0.2
-	github.com/davecgh/go-spew v0.0.0-20171005155431-ecdeabc65495
+	github.com/davecgh/go-spew v1.1.1
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/near/borsh-go v0.3.0
+	github.com/prometheus/client_golang v1.17.0
+	github.com/zalando/go-keyring v0.2.3
+	go.opentelemetry.io/otel v1.16.0
+	go.opentelemetry.io/otel/sdk v1.16.0
+	go.opentelemetry.io/otel/trace v1.16.0
+	golang.org/x/crypto v0.14.0
+	golang.org/x/sys v0.13.0
+	golang.org/x/text v0.13.0
+)
+
+require (
+	github.com/alessio/shellescape v1.4.1 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/danieljoos/wincred v1.2.0 // indirect
+	github.com/go-logr/logr v1.2.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/godbus/dbus/v5 v5.1.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.4 // indirect
+	github.com/prometheus/client_model v0.4.1-0.20230718164431-9a2bf3000d16 // indirect
+	github.com/prometheus/common v0.44.0 // indirect
+	github.com/prometheus/procfs v0.11.1 // indirect
+	go.opentelemetry.io/otel/metric v1.16.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
 )
@@ -0,0 +1,107 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrAllowanceLow is returned (wrapped) by RefreshAllowance if the remaining
+// allowance of a function call access key is below LowAllowance.
+var ErrAllowanceLow = errors.New("keystore: access key allowance nearly exhausted")
+
+// ErrKeyExpired is returned (wrapped) by RefreshAllowance if the key expired
+// according to its metadata.
+var ErrKeyExpired = errors.New("keystore: key expired")
+
+// LowAllowance is the remaining allowance in yoctoNEAR below which
+// RefreshAllowance reports a function call access key as nearly exhausted,
+// 0.05 NEAR by default. It must not be changed concurrently with
+// RefreshAllowance.
+var LowAllowance, _ = new(big.Int).SetString("50000000000000000000000", 10)
+
+// AccessKeyViewer queries an access key via RPC, it is implemented by
+// *near.Connection.
+type AccessKeyViewer interface {
+	ViewAccessKey(accountID, publicKey string) (map[string]interface{}, error)
+}
+
+// RefreshAllowance queries the access key of the key pair stored for
+// accountID on networkID, records its permission and remaining allowance in
+// the key metadata and stores the key pair again. The refreshed metadata is
+// returned.
+//
+// If the remaining allowance is below LowAllowance the metadata is stored
+// nevertheless and an error wrapping ErrAllowanceLow is returned along with
+// it, so callers can warn the operator. Expired keys are refreshed as well
+// and reported with an error wrapping ErrKeyExpired.
+func RefreshAllowance(v AccessKeyViewer, ks KeyStore, networkID, accountID string) (*KeyMetadata, error) {
+	kp, err := ks.Get(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	ak, err := v.ViewAccessKey(accountID, kp.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	md := kp.Metadata.clone()
+	if md == nil {
+		md = new(KeyMetadata)
+	}
+	if err := md.setPermission(ak["permission"]); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	md.RefreshedAt = &now
+	kp.Metadata = md
+	if err := ks.Put(networkID, kp); err != nil {
+		return nil, err
+	}
+	if md.Expired(now) {
+		return md, fmt.Errorf("%w: %s of %s on %s at %s", ErrKeyExpired,
+			kp.PublicKey, accountID, networkID, md.ExpiresAt.Format(time.RFC3339))
+	}
+	if md.Allowance != "" {
+		remaining, _ := new(big.Int).SetString(md.Allowance, 10)
+		if remaining.Cmp(LowAllowance) < 0 {
+			return md, fmt.Errorf("%w: %s of %s on %s has %s yoctoNEAR left", ErrAllowanceLow,
+				kp.PublicKey, accountID, networkID, md.Allowance)
+		}
+	}
+	return md, nil
+}
+
+// setPermission records the access key permission as returned by the
+// view_access_key query.
+func (md *KeyMetadata) setPermission(permission interface{}) error {
+	md.ReceiverID, md.MethodNames, md.Allowance = "", nil, ""
+	switch p := permission.(type) {
+	case string:
+		if p == "FullAccess" {
+			return nil
+		}
+	case map[string]interface{}:
+		fc, ok := p["FunctionCall"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		md.ReceiverID, _ = fc["receiver_id"].(string)
+		methodNames, _ := fc["method_names"].([]interface{})
+		for _, m := range methodNames {
+			name, ok := m.(string)
+			if !ok {
+				return errors.New("keystore: invalid method name in access key permission")
+			}
+			md.MethodNames = append(md.MethodNames, name)
+		}
+		if allowance, ok := fc["allowance"].(string); ok {
+			if _, ok := new(big.Int).SetString(allowance, 10); !ok {
+				return fmt.Errorf("keystore: invalid allowance '%s'", allowance)
+			}
+			md.Allowance = allowance
+		}
+		return nil
+	}
+	return fmt.Errorf("keystore: unknown access key permission %v", permission)
+}
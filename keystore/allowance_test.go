@@ -0,0 +1,97 @@
+package keystore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeViewer map[string]interface{}
+
+func (v fakeViewer) ViewAccessKey(accountID, publicKey string) (map[string]interface{}, error) {
+	ak, ok := v[accountID+"/"+publicKey].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("access key does not exist")
+	}
+	return ak, nil
+}
+
+func TestRefreshAllowance(t *testing.T) {
+	ks := NewInMemoryKeyStore()
+	accountID := "test-account.testnet"
+	kp, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp.Metadata.AllowanceHint = "250000000000000000000000"
+	if err := ks.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	v := fakeViewer{
+		accountID + "/" + kp.PublicKey: map[string]interface{}{
+			"nonce": 1.0,
+			"permission": map[string]interface{}{
+				"FunctionCall": map[string]interface{}{
+					"receiver_id":  "contract.testnet",
+					"method_names": []interface{}{"ping", "pong"},
+					"allowance":    "100000000000000000000000",
+				},
+			},
+		},
+	}
+	md, err := RefreshAllowance(v, ks, "testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.ReceiverID != "contract.testnet" || !reflect.DeepEqual(md.MethodNames, []string{"ping", "pong"}) ||
+		md.Allowance != "100000000000000000000000" || md.RefreshedAt == nil {
+		t.Errorf("RefreshAllowance() returned %+v", md)
+	}
+	if md.AllowanceHint != kp.Metadata.AllowanceHint {
+		t.Error("RefreshAllowance() did not preserve metadata")
+	}
+	stored, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored.Metadata, md) {
+		t.Errorf("RefreshAllowance() stored %+v (want %+v)", stored.Metadata, md)
+	}
+	// nearly exhausted allowance
+	v[accountID+"/"+kp.PublicKey].(map[string]interface{})["permission"].(map[string]interface{})["FunctionCall"].(map[string]interface{})["allowance"] = "1000"
+	md, err = RefreshAllowance(v, ks, "testnet", accountID)
+	if !errors.Is(err, ErrAllowanceLow) {
+		t.Fatalf("RefreshAllowance() returned %v (want ErrAllowanceLow)", err)
+	}
+	if md == nil || md.Allowance != "1000" {
+		t.Errorf("RefreshAllowance() returned %+v", md)
+	}
+	// full access keys clear the function call permission
+	v[accountID+"/"+kp.PublicKey].(map[string]interface{})["permission"] = "FullAccess"
+	md, err = RefreshAllowance(v, ks, "testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.ReceiverID != "" || md.MethodNames != nil || md.Allowance != "" {
+		t.Errorf("RefreshAllowance() returned %+v for full access key", md)
+	}
+	// expired keys
+	kp, err = ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiresAt := time.Now().Add(-time.Hour)
+	kp.Metadata.ExpiresAt = &expiresAt
+	if err := ks.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RefreshAllowance(v, ks, "testnet", accountID); !errors.Is(err, ErrKeyExpired) {
+		t.Errorf("RefreshAllowance() returned %v (want ErrKeyExpired)", err)
+	}
+	// deleted keys
+	delete(v, accountID+"/"+kp.PublicKey)
+	if _, err := RefreshAllowance(v, ks, "testnet", accountID); err == nil {
+		t.Error("RefreshAllowance() succeeded for deleted key")
+	}
+}
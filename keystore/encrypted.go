@@ -0,0 +1,318 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/YuxSccc/near-api-go/signer"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDF selects the key derivation function used to derive the encryption key
+// of an encrypted key pair from its passphrase.
+type KDF string
+
+// All supported key derivation functions.
+const (
+	KDFScrypt   KDF = "scrypt"
+	KDFArgon2id KDF = "argon2id"
+)
+
+const (
+	encryptedVersion = 1
+	encryptedCipher  = "aes-256-gcm"
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+
+	keyLen  = 32
+	saltLen = 32
+
+	// maxKDFMemory bounds the memory the key derivation of an encrypted key
+	// pair may use, maxKDFPasses its passes over the memory.
+	maxKDFMemory = 1 << 30
+	maxKDFPasses = 16
+)
+
+// ErrDecrypt is returned if an encrypted key pair cannot be decrypted, usually
+// because of a wrong passphrase.
+var ErrDecrypt = errors.New("keystore: could not decrypt key pair (wrong passphrase?)")
+
+// PassphraseFunc returns the passphrase protecting the key pair for accountID
+// on networkID. It can be used to prompt the user.
+type PassphraseFunc func(networkID, accountID string) ([]byte, error)
+
+// StaticPassphrase returns a PassphraseFunc which always returns passphrase.
+func StaticPassphrase(passphrase string) PassphraseFunc {
+	return func(networkID, accountID string) ([]byte, error) {
+		return []byte(passphrase), nil
+	}
+}
+
+// encryptedKeyPair is the JSON envelope of an encrypted key pair, the key
+// pair is encrypted with AES-GCM, which authenticates it as well.
+type encryptedKeyPair struct {
+	Version   int          `json:"version"`
+	AccountID string       `json:"account_id"`
+	PublicKey string       `json:"public_key"`
+	Crypto    cryptoParams `json:"crypto"`
+}
+
+type cryptoParams struct {
+	Cipher       string       `json:"cipher"`
+	CipherText   string       `json:"ciphertext"`
+	CipherParams cipherParams `json:"cipherparams"`
+	KDF          KDF          `json:"kdf"`
+	KDFParams    kdfParams    `json:"kdfparams"`
+}
+
+type cipherParams struct {
+	Nonce string `json:"nonce"`
+}
+
+type kdfParams struct {
+	DKLen   int    `json:"dklen"`
+	Salt    string `json:"salt"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// check returns an error if the params of kdf are out of bounds, they are
+// read from key files and must neither make the derivation panic nor
+// exhaust memory.
+func (params kdfParams) check(kdf KDF) error {
+	if params.DKLen != keyLen {
+		return fmt.Errorf("keystore: invalid kdf key length %d", params.DKLen)
+	}
+	switch kdf {
+	case KDFScrypt:
+		if params.N < 2 || params.N&(params.N-1) != 0 || params.R < 1 || params.P < 1 ||
+			params.P > maxKDFPasses || params.N > maxKDFMemory/128/params.R {
+			return fmt.Errorf("keystore: invalid scrypt params n=%d r=%d p=%d", params.N, params.R, params.P)
+		}
+	case KDFArgon2id:
+		if params.Time < 1 || params.Time > maxKDFPasses || params.Threads < 1 ||
+			params.Memory > maxKDFMemory/1024 {
+			return fmt.Errorf("keystore: invalid argon2id params time=%d memory=%d threads=%d",
+				params.Time, params.Memory, params.Threads)
+		}
+	}
+	return nil
+}
+
+func deriveKey(passphrase []byte, kdf KDF, params kdfParams) ([]byte, error) {
+	if err := params.check(kdf); err != nil {
+		return nil, err
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, err
+	}
+	switch kdf {
+	case KDFScrypt:
+		return scrypt.Key(passphrase, salt, params.N, params.R, params.P, params.DKLen)
+	case KDFArgon2id:
+		return argon2.IDKey(passphrase, salt, params.Time, params.Memory, params.Threads,
+			uint32(params.DKLen)), nil
+	default:
+		return nil, fmt.Errorf("keystore: unsupported kdf '%s'", kdf)
+	}
+}
+
+// EncryptKeyPair encrypts the key pair kp with passphrase and returns the JSON
+// envelope. The encryption key is derived from passphrase with kdf.
+func EncryptKeyPair(kp *Ed25519KeyPair, passphrase []byte, kdf KDF) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params := kdfParams{DKLen: keyLen, Salt: hex.EncodeToString(salt)}
+	switch kdf {
+	case KDFScrypt:
+		params.N, params.R, params.P = scryptN, scryptR, scryptP
+	case KDFArgon2id:
+		params.Time, params.Memory, params.Threads = argon2Time, argon2Memory, argon2Threads
+	}
+	key, err := deriveKey(passphrase, kdf, params)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := json.Marshal(kp)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// authenticate the unencrypted account ID as additional data
+	ciphertext := aead.Seal(nil, nonce, plaintext, []byte(kp.AccountID))
+	return json.Marshal(&encryptedKeyPair{
+		Version:   encryptedVersion,
+		AccountID: kp.AccountID,
+		PublicKey: kp.PublicKey,
+		Crypto: cryptoParams{
+			Cipher:       encryptedCipher,
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: cipherParams{Nonce: hex.EncodeToString(nonce)},
+			KDF:          kdf,
+			KDFParams:    params,
+		},
+	})
+}
+
+// DecryptKeyPair decrypts the JSON envelope data with passphrase and returns
+// the contained key pair, which must belong to accountID.
+func DecryptKeyPair(data, passphrase []byte, accountID string) (*Ed25519KeyPair, error) {
+	var ekp encryptedKeyPair
+	if err := json.Unmarshal(data, &ekp); err != nil {
+		return nil, err
+	}
+	if ekp.Version != encryptedVersion {
+		return nil, fmt.Errorf("keystore: unsupported encrypted key pair version %d", ekp.Version)
+	}
+	if ekp.Crypto.Cipher != encryptedCipher {
+		return nil, fmt.Errorf("keystore: unsupported cipher '%s'", ekp.Crypto.Cipher)
+	}
+	key, err := deriveKey(passphrase, ekp.Crypto.KDF, ekp.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(ekp.Crypto.CipherParams.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := hex.DecodeString(ekp.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(ekp.AccountID))
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return parseKeyPair(plaintext, accountID, "encrypted key pair "+ekp.AccountID)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedFileSystemKeyStore is a KeyStore with the same directory layout as
+// FileSystemKeyStore, but every key pair is encrypted with a passphrase.
+type EncryptedFileSystemKeyStore struct {
+	fs         *FileSystemKeyStore
+	kdf        KDF
+	passphrase PassphraseFunc
+}
+
+// NewEncryptedFileSystemKeyStore returns a new encrypted file system key store
+// rooted at dir. Passphrases are obtained via passphrase and new key pairs are
+// encrypted with a key derived by kdf.
+func NewEncryptedFileSystemKeyStore(dir string, kdf KDF, passphrase PassphraseFunc) *EncryptedFileSystemKeyStore {
+	return &EncryptedFileSystemKeyStore{
+		fs:         NewFileSystemKeyStore(dir),
+		kdf:        kdf,
+		passphrase: passphrase,
+	}
+}
+
+// Get implements KeyStore.
+func (ks *EncryptedFileSystemKeyStore) Get(networkID, accountID string) (*Ed25519KeyPair, error) {
+	// the lock is not held during the slow key derivation
+	unlock, err := ks.fs.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(ks.fs.filename(networkID, accountID))
+	unlock()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	} else if err != nil {
+		return nil, err
+	}
+	passphrase, err := ks.passphrase(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKeyPair(data, passphrase, accountID)
+}
+
+// Put implements KeyStore.
+func (ks *EncryptedFileSystemKeyStore) Put(networkID string, kp *Ed25519KeyPair) error {
+	passphrase, err := ks.passphrase(networkID, kp.AccountID)
+	if err != nil {
+		return err
+	}
+	data, err := EncryptKeyPair(kp, passphrase, ks.kdf)
+	if err != nil {
+		return err
+	}
+	unlock, err := ks.fs.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return writeFile(ks.fs.filename(networkID, kp.AccountID), data, !ks.fs.noOverwrite)
+}
+
+// Signer returns a signer for the key pair of accountID on networkID.
+func (ks *EncryptedFileSystemKeyStore) Signer(networkID, accountID string) (signer.Signer, error) {
+	kp, err := ks.Get(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return kp.Signer()
+}
+
+// SetOverwrite sets whether writes may replace existing key files, see
+// FileSystemKeyStore.SetOverwrite.
+func (ks *EncryptedFileSystemKeyStore) SetOverwrite(overwrite bool) {
+	ks.fs.SetOverwrite(overwrite)
+}
+
+// Delete implements KeyStore.
+func (ks *EncryptedFileSystemKeyStore) Delete(networkID, accountID string) error {
+	return ks.fs.Delete(networkID, accountID)
+}
+
+// ListAccounts implements KeyStore.
+func (ks *EncryptedFileSystemKeyStore) ListAccounts(networkID string) ([]string, error) {
+	return ks.fs.ListAccounts(networkID)
+}
+
+// ListNetworks implements KeyStore.
+func (ks *EncryptedFileSystemKeyStore) ListNetworks() ([]string, error) {
+	return ks.fs.ListNetworks()
+}
@@ -0,0 +1,107 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEncryptDecryptKeyPair(t *testing.T) {
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kdf := range []KDF{KDFScrypt, KDFArgon2id} {
+		data, err := EncryptKeyPair(kp1, []byte("secret"), kdf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kp2, err := DecryptKeyPair(data, []byte("secret"), accountID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(kp1, kp2) {
+			t.Errorf("%s: kp1 != kp2", kdf)
+		}
+		if _, err := DecryptKeyPair(data, []byte("wrong"), accountID); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: DecryptKeyPair() with wrong passphrase returned %v (want ErrDecrypt)", kdf, err)
+		}
+	}
+}
+
+func TestDecryptKeyPairInvalidKDFParams(t *testing.T) {
+	kp, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		kdf    KDF
+		params func(*kdfParams)
+	}{
+		{KDFScrypt, func(p *kdfParams) { p.DKLen = -1 }},
+		{KDFScrypt, func(p *kdfParams) { p.N = 1 << 30 }},
+		{KDFScrypt, func(p *kdfParams) { p.N = 3 }},
+		{KDFArgon2id, func(p *kdfParams) { p.Time = 0 }},
+		{KDFArgon2id, func(p *kdfParams) { p.Threads = 0 }},
+		{KDFArgon2id, func(p *kdfParams) { p.Memory = 1 << 31 }},
+	} {
+		data, err := EncryptKeyPair(kp, []byte("secret"), tc.kdf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ekp encryptedKeyPair
+		if err := json.Unmarshal(data, &ekp); err != nil {
+			t.Fatal(err)
+		}
+		tc.params(&ekp.Crypto.KDFParams)
+		if data, err = json.Marshal(&ekp); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := DecryptKeyPair(data, []byte("secret"), kp.AccountID); err == nil {
+			t.Errorf("DecryptKeyPair() accepted %s params %+v", tc.kdf, ekp.Crypto.KDFParams)
+		}
+	}
+}
+
+func TestEncryptedFileSystemKeyStore(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := os.Mkdir(filepath.Join(tmpdir, "testnet"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	ks := NewEncryptedFileSystemKeyStore(tmpdir, KDFScrypt, StaticPassphrase("secret"))
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	// the unencrypted key store must not be able to read the key pair
+	if _, err := NewFileSystemKeyStore(tmpdir).Get("testnet", accountID); err == nil {
+		t.Error("unencrypted Get() of encrypted key pair succeeded")
+	}
+	kp2, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("kp1 != kp2")
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{accountID}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+}
@@ -0,0 +1,9 @@
+package keystore
+
+import (
+	"errors"
+)
+
+// ErrKeyNotFound is returned if a key store has no key pair for the
+// requested network and account.
+var ErrKeyNotFound = errors.New("keystore: key not found")
@@ -0,0 +1,203 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/near/borsh-go"
+)
+
+// Format is an encoding of a private key used by ExportKey and ImportKey.
+type Format string
+
+// All supported key formats.
+const (
+	// FormatCredentials is the JSON key file format of near-cli, as stored in
+	// ~/.near-credentials: {"account_id", "public_key", "private_key"}.
+	FormatCredentials Format = "credentials"
+	// FormatKeyFile is the JSON key file format of nearcore, e.g.
+	// validator_key.json: {"account_id", "public_key", "secret_key"}.
+	FormatKeyFile Format = "key-file"
+	// FormatBase58 is the "<key type>:<base58>" private key string.
+	FormatBase58 Format = "base58"
+	// FormatHex is the hex encoded raw private key. Since raw keys do not
+	// carry their type, secp256k1 keys are prefixed with "secp256k1:".
+	// Ed25519 keys are accepted both as 64 byte private key and as 32 byte
+	// seed.
+	FormatHex Format = "hex"
+	// FormatPEM is the PEM encoded PKCS #8 private key for Ed25519 keys and
+	// the PEM encoded SEC 1 private key for secp256k1 keys, as used by
+	// OpenSSL.
+	FormatPEM Format = "pem"
+)
+
+var oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+// ecPrivateKey is the SEC 1 ASN.1 structure of an EC private key.
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type keyFile struct {
+	AccountID  string `json:"account_id"`
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key,omitempty"`
+	SecretKey  string `json:"secret_key,omitempty"`
+}
+
+// Key returns the private key of the key pair.
+func (kp *Ed25519KeyPair) Key() utils.PrivateKey {
+	return utils.PrivateKeyFromEd25519(kp.Ed25519PrivKey)
+}
+
+// Key returns the private key of the key pair.
+func (kp *Secp256k1KeyPair) Key() utils.PrivateKey {
+	return utils.PrivateKeyFromSecp256k1(kp.Secp256k1PrivKey)
+}
+
+// ExportKey encodes the private key key of accountID in format. The account
+// ID is only included in the JSON formats.
+func ExportKey(key utils.PrivateKey, accountID string, format Format) ([]byte, error) {
+	switch format {
+	case FormatCredentials, FormatKeyFile:
+		kf := keyFile{
+			AccountID: accountID,
+			PublicKey: key.PublicKey().String(),
+		}
+		if format == FormatCredentials {
+			kf.PrivateKey = key.String()
+		} else {
+			kf.SecretKey = key.String()
+		}
+		return json.MarshalIndent(kf, "", "  ")
+	case FormatBase58:
+		return []byte(key.String()), nil
+	case FormatHex:
+		enc := hex.EncodeToString(key.Data)
+		if key.KeyType == utils.SECP256K1 {
+			enc = secp256k1Prefix + enc
+		}
+		return []byte(enc), nil
+	case FormatPEM:
+		if key.KeyType == utils.SECP256K1 {
+			der, err := asn1.Marshal(ecPrivateKey{
+				Version:       1,
+				PrivateKey:    key.Data,
+				NamedCurveOID: oidSecp256k1,
+				PublicKey: asn1.BitString{
+					Bytes:     key.Secp256k1().PubKey().SerializeUncompressed(),
+					BitLength: 8 * btcec.PubKeyBytesLenUncompressed,
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key.Ed25519())
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+	default:
+		return nil, fmt.Errorf("keystore: unknown key format '%s'", format)
+	}
+}
+
+// ImportKey decodes a private key encoded in format. For the JSON formats
+// the account ID stored in data is returned and the stored public key is
+// validated, for all other formats the returned account ID is empty.
+func ImportKey(data []byte, format Format) (utils.PrivateKey, string, error) {
+	switch format {
+	case FormatCredentials, FormatKeyFile:
+		var kf keyFile
+		if err := json.Unmarshal(data, &kf); err != nil {
+			return utils.PrivateKey{}, "", err
+		}
+		privateKey := kf.PrivateKey
+		if privateKey == "" {
+			privateKey = kf.SecretKey
+		}
+		key, err := utils.ParsePrivateKey(privateKey)
+		if err != nil {
+			return utils.PrivateKey{}, "", err
+		}
+		if key.PublicKey().String() != kf.PublicKey {
+			return utils.PrivateKey{}, "", fmt.Errorf("keystore: public_key does not match private_key: key pair %s", kf.AccountID)
+		}
+		return key, kf.AccountID, nil
+	case FormatBase58:
+		key, err := utils.ParsePrivateKey(strings.TrimSpace(string(data)))
+		return key, "", err
+	case FormatHex:
+		s := strings.TrimSpace(string(data))
+		var keyType borsh.Enum = utils.ED25519
+		if strings.HasPrefix(s, secp256k1Prefix) {
+			keyType = utils.SECP256K1
+			s = strings.TrimPrefix(s, secp256k1Prefix)
+		}
+		raw, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return utils.PrivateKey{}, "", fmt.Errorf("keystore: invalid hex private key: %v", err)
+		}
+		key, err := utils.NewPrivateKey(keyType, raw)
+		return key, "", err
+	case FormatPEM:
+		key, err := importPEM(data)
+		return key, "", err
+	default:
+		return utils.PrivateKey{}, "", fmt.Errorf("keystore: unknown key format '%s'", format)
+	}
+}
+
+func importPEM(data []byte) (utils.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return utils.PrivateKey{}, errors.New("keystore: no PEM data found")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return utils.PrivateKey{}, fmt.Errorf("keystore: invalid PKCS #8 private key: %v", err)
+		}
+		privKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return utils.PrivateKey{}, fmt.Errorf("keystore: unsupported PKCS #8 private key type %T", key)
+		}
+		return utils.PrivateKeyFromEd25519(privKey), nil
+	case "EC PRIVATE KEY":
+		var key ecPrivateKey
+		if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+			return utils.PrivateKey{}, fmt.Errorf("keystore: invalid SEC 1 private key: %v", err)
+		}
+		if !key.NamedCurveOID.Equal(oidSecp256k1) {
+			return utils.PrivateKey{}, fmt.Errorf("keystore: unsupported EC curve %v", key.NamedCurveOID)
+		}
+		privKey, err := utils.NewPrivateKey(utils.SECP256K1, key.PrivateKey)
+		if err != nil {
+			return utils.PrivateKey{}, err
+		}
+		if len(key.PublicKey.Bytes) > 0 {
+			pubKey, err := btcec.ParsePubKey(key.PublicKey.Bytes, btcec.S256())
+			if err != nil || !pubKey.IsEqual(privKey.Secp256k1().PubKey()) {
+				return utils.PrivateKey{}, errors.New("keystore: public key does not match private key")
+			}
+		}
+		return privKey, nil
+	default:
+		return utils.PrivateKey{}, fmt.Errorf("keystore: unsupported PEM block type '%s'", block.Type)
+	}
+}
@@ -0,0 +1,64 @@
+package keystore
+
+import (
+	"fmt"
+)
+
+// Backend identifies a KeyStore implementation, see New.
+type Backend string
+
+// All key store backends supported by New.
+const (
+	BackendFileSystem          Backend = "file"
+	BackendEncryptedFileSystem Backend = "encrypted-file"
+	BackendInMemory            Backend = "memory"
+	BackendKeychain            Backend = "keychain"
+)
+
+// Options configure the key store created by New. Only the options relevant
+// for the selected backend are used.
+type Options struct {
+	// Dir is the root directory of file system key stores. If empty,
+	// ~/.near-credentials is used.
+	Dir string
+	// KDF is the key derivation function of encrypted key stores. If empty,
+	// KDFScrypt is used.
+	KDF KDF
+	// Passphrase returns the passphrase of encrypted key pairs.
+	Passphrase PassphraseFunc
+	// Service is the service name of the keychain key store. If empty,
+	// DefaultKeychainService is used.
+	Service string
+}
+
+// New returns a new key store for the given backend configured with opts.
+func New(backend Backend, opts Options) (KeyStore, error) {
+	switch backend {
+	case BackendFileSystem, BackendEncryptedFileSystem:
+		dir := opts.Dir
+		if dir == "" {
+			ks, err := NewDefaultFileSystemKeyStore()
+			if err != nil {
+				return nil, err
+			}
+			dir = ks.Dir()
+		}
+		if backend == BackendFileSystem {
+			return NewFileSystemKeyStore(dir), nil
+		}
+		if opts.Passphrase == nil {
+			return nil, fmt.Errorf("keystore: backend '%s' requires a passphrase", backend)
+		}
+		kdf := opts.KDF
+		if kdf == "" {
+			kdf = KDFScrypt
+		}
+		return NewEncryptedFileSystemKeyStore(dir, kdf, opts.Passphrase), nil
+	case BackendInMemory:
+		return NewInMemoryKeyStore(), nil
+	case BackendKeychain:
+		return NewKeychainKeyStore(opts.Service), nil
+	default:
+		return nil, fmt.Errorf("keystore: unknown backend '%s'", backend)
+	}
+}
@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrKeyExists is returned if a key pair should be written to a file which
+// already exists and overwriting is not allowed.
+var ErrKeyExists = errors.New("keystore: key already exists")
+
+// writeFile atomically writes data to filename with permissions 0600. The
+// parent directories are created if necessary. The data is written to a
+// temporary file in the same directory, synced to disk and then moved into
+// place, so a crash never leaves a partially written key file behind. If
+// overwrite is false and filename exists an error wrapping ErrKeyExists is
+// returned.
+func writeFile(filename string, data []byte, overwrite bool) error {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpname := tmp.Name()
+	defer os.Remove(tmpname) // no-op after successful rename
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if overwrite {
+		err = os.Rename(tmpname, filename)
+	} else {
+		// os.Link fails if filename exists, which makes the check atomic
+		err = os.Link(tmpname, filename)
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrKeyExists, filename)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// syncDir makes a rename in dir durable. Errors are ignored, because syncing
+// directories is not supported on all platforms.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,389 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// KeyStore is implemented by all key pair storage backends. Key pairs are
+// stored per networkID and accountID.
+type KeyStore interface {
+	// Get returns the key pair for accountID on networkID. It returns an error
+	// wrapping ErrKeyNotFound if no such key pair exists.
+	Get(networkID, accountID string) (*Ed25519KeyPair, error)
+	// Put stores the key pair kp for kp.AccountID on networkID.
+	Put(networkID string, kp *Ed25519KeyPair) error
+	// Delete removes the key pair for accountID on networkID.
+	Delete(networkID, accountID string) error
+	// ListAccounts returns the IDs of all accounts with a stored key pair on
+	// networkID.
+	ListAccounts(networkID string) ([]string, error)
+	// ListNetworks returns the IDs of all networks with stored key pairs.
+	ListNetworks() ([]string, error)
+}
+
+// FileSystemKeyStore is an unencrypted KeyStore which stores every key pair
+// in a JSON file <dir>/<networkID>/<accountID>.json, the layout used by
+// near-cli in ~/.near-credentials.
+//
+// Additional access keys of an account can be stored in the near-cli-rs
+// layout <dir>/<networkID>/<accountID>/<keyType>_<publicKey>.json, see PutKey.
+//
+// All writes create missing directories and replace files atomically.
+// Access is serialized within the process and, with an advisory lock on the
+// file <dir>/.lock, across processes sharing the directory.
+type FileSystemKeyStore struct {
+	dir         string
+	noOverwrite bool
+}
+
+// NewFileSystemKeyStore returns a new file system key store rooted at dir.
+// By default existing key pairs are overwritten, see SetOverwrite.
+func NewFileSystemKeyStore(dir string) *FileSystemKeyStore {
+	return &FileSystemKeyStore{dir: dir}
+}
+
+// SetOverwrite sets whether writes may replace existing key files. If
+// overwrite is false, writing a key pair which already exists fails with an
+// error wrapping ErrKeyExists and the key pair has to be deleted explicitly
+// first. SetOverwrite must not be called concurrently with writes.
+func (ks *FileSystemKeyStore) SetOverwrite(overwrite bool) {
+	ks.noOverwrite = !overwrite
+}
+
+// NewDefaultFileSystemKeyStore returns a new file system key store rooted at
+// ~/.near-credentials.
+func NewDefaultFileSystemKeyStore() (*FileSystemKeyStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewFileSystemKeyStore(filepath.Join(home, ".near-credentials")), nil
+}
+
+// Dir returns the root directory of the key store.
+func (ks *FileSystemKeyStore) Dir() string {
+	return ks.dir
+}
+
+func (ks *FileSystemKeyStore) filename(networkID, accountID string) string {
+	return filepath.Join(ks.dir, networkID, accountID+".json")
+}
+
+func (ks *FileSystemKeyStore) accountDir(networkID, accountID string) string {
+	return filepath.Join(ks.dir, networkID, accountID)
+}
+
+func (ks *FileSystemKeyStore) keyFilename(networkID, accountID, publicKey string) string {
+	return filepath.Join(ks.accountDir(networkID, accountID),
+		strings.Replace(publicKey, ":", "_", 1)+".json")
+}
+
+func notFound(err error, networkID, accountID string) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	}
+	return err
+}
+
+// Get implements KeyStore. If there is no <accountID>.json file the first
+// Ed25519 key pair in the account directory is returned.
+func (ks *FileSystemKeyStore) Get(networkID, accountID string) (*Ed25519KeyPair, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	kp, err := LoadKeyPairFromPath(ks.filename(networkID, accountID), accountID)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return kp, err
+	}
+	keys, err := ks.listKeys(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, publicKey := range keys {
+		if pk, err := utils.ParsePublicKey(publicKey); err == nil && pk.KeyType == utils.ED25519 {
+			return ks.getKey(networkID, accountID, publicKey)
+		}
+	}
+	return nil, notFound(fs.ErrNotExist, networkID, accountID)
+}
+
+// Put implements KeyStore.
+func (ks *FileSystemKeyStore) Put(networkID string, kp *Ed25519KeyPair) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return kp.writeFile(ks.filename(networkID, kp.AccountID), !ks.noOverwrite)
+}
+
+// Delete implements KeyStore. It removes the <accountID>.json file and all
+// key pairs in the account directory.
+func (ks *FileSystemKeyStore) Delete(networkID, accountID string) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = os.Remove(ks.filename(networkID, accountID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	deleted := err == nil
+	dir := ks.accountDir(networkID, accountID)
+	if _, err := os.Stat(dir); err == nil {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		deleted = true
+	}
+	if !deleted {
+		return notFound(fs.ErrNotExist, networkID, accountID)
+	}
+	return nil
+}
+
+// ListAccounts implements KeyStore.
+func (ks *FileSystemKeyStore) ListAccounts(networkID string) ([]string, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	entries, err := os.ReadDir(filepath.Join(ks.dir, networkID))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var accounts []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		var accountID string
+		if entry.IsDir() {
+			accountID = entry.Name()
+		} else if strings.HasSuffix(entry.Name(), ".json") {
+			accountID = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		if accountID != "" && !seen[accountID] {
+			seen[accountID] = true
+			accounts = append(accounts, accountID)
+		}
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+// ListNetworks implements KeyStore.
+func (ks *FileSystemKeyStore) ListNetworks() ([]string, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	entries, err := os.ReadDir(ks.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var networks []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			networks = append(networks, entry.Name())
+		}
+	}
+	sort.Strings(networks)
+	return networks, nil
+}
+
+// Signer returns a signer for the key pair of accountID on networkID. In
+// contrast to Get it supports secp256k1 key pairs as well. If there is no
+// <accountID>.json file the first key pair in the account directory is used.
+func (ks *FileSystemKeyStore) Signer(networkID, accountID string) (signer.Signer, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	s, err := LoadSignerFromPath(ks.filename(networkID, accountID), accountID)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return s, err
+	}
+	keys, err := ks.listKeys(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, notFound(fs.ErrNotExist, networkID, accountID)
+	}
+	return ks.keySigner(networkID, accountID, keys[0])
+}
+
+// PutSecp256k1 stores the secp256k1 key pair kp for kp.AccountID on networkID.
+func (ks *FileSystemKeyStore) PutSecp256k1(networkID string, kp *Secp256k1KeyPair) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return kp.writeFile(ks.filename(networkID, kp.AccountID), !ks.noOverwrite)
+}
+
+// PutKey stores the key pair kp as an additional access key of kp.AccountID
+// on networkID in the account directory.
+func (ks *FileSystemKeyStore) PutKey(networkID string, kp *Ed25519KeyPair) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return kp.writeFile(ks.keyFilename(networkID, kp.AccountID, kp.PublicKey), !ks.noOverwrite)
+}
+
+// PutSecp256k1Key stores the secp256k1 key pair kp as an additional access key
+// of kp.AccountID on networkID in the account directory.
+func (ks *FileSystemKeyStore) PutSecp256k1Key(networkID string, kp *Secp256k1KeyPair) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return kp.writeFile(ks.keyFilename(networkID, kp.AccountID, kp.PublicKey), !ks.noOverwrite)
+}
+
+// ListKeys returns the public keys of all key pairs stored for accountID on
+// networkID, both from the <accountID>.json file and the account directory.
+func (ks *FileSystemKeyStore) ListKeys(networkID, accountID string) ([]string, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return ks.listKeys(networkID, accountID)
+}
+
+func (ks *FileSystemKeyStore) listKeys(networkID, accountID string) ([]string, error) {
+	var keys []string
+	buf, err := os.ReadFile(ks.filename(networkID, accountID))
+	if err == nil {
+		var kp struct {
+			PublicKey string `json:"public_key"`
+		}
+		if err := json.Unmarshal(buf, &kp); err != nil {
+			return nil, err
+		}
+		keys = append(keys, kp.PublicKey)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	entries, err := os.ReadDir(ks.accountDir(networkID, accountID))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var dirKeys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		publicKey := strings.Replace(strings.TrimSuffix(name, ".json"), "_", ":", 1)
+		if len(keys) > 0 && keys[0] == publicKey {
+			continue
+		}
+		dirKeys = append(dirKeys, publicKey)
+	}
+	sort.Strings(dirKeys)
+	return append(keys, dirKeys...), nil
+}
+
+// GetKey returns the Ed25519 key pair of accountID on networkID with the given
+// publicKey.
+func (ks *FileSystemKeyStore) GetKey(networkID, accountID, publicKey string) (*Ed25519KeyPair, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return ks.getKey(networkID, accountID, publicKey)
+}
+
+func (ks *FileSystemKeyStore) getKey(networkID, accountID, publicKey string) (*Ed25519KeyPair, error) {
+	filename, err := ks.findKey(networkID, accountID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return LoadKeyPairFromPath(filename, accountID)
+}
+
+// KeySigner returns a signer for the key pair of accountID on networkID with
+// the given publicKey.
+func (ks *FileSystemKeyStore) KeySigner(networkID, accountID, publicKey string) (signer.Signer, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return ks.keySigner(networkID, accountID, publicKey)
+}
+
+func (ks *FileSystemKeyStore) keySigner(networkID, accountID, publicKey string) (signer.Signer, error) {
+	filename, err := ks.findKey(networkID, accountID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSignerFromPath(filename, accountID)
+}
+
+// DeleteKey removes the key pair of accountID on networkID with the given
+// publicKey.
+func (ks *FileSystemKeyStore) DeleteKey(networkID, accountID, publicKey string) error {
+	unlock, err := ks.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	filename, err := ks.findKey(networkID, accountID, publicKey)
+	if err != nil {
+		return err
+	}
+	return os.Remove(filename)
+}
+
+// findKey returns the name of the file storing the key pair with publicKey.
+func (ks *FileSystemKeyStore) findKey(networkID, accountID, publicKey string) (string, error) {
+	filename := ks.keyFilename(networkID, accountID, publicKey)
+	if _, err := os.Stat(filename); err == nil {
+		return filename, nil
+	}
+	filename = ks.filename(networkID, accountID)
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return "", notFound(err, networkID, accountID)
+	}
+	var kp struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.Unmarshal(buf, &kp); err != nil {
+		return "", err
+	}
+	if kp.PublicKey != publicKey {
+		return "", fmt.Errorf("%w: %s of %s on %s", ErrKeyNotFound, publicKey, accountID, networkID)
+	}
+	return filename, nil
+}
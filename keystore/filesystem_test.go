@@ -0,0 +1,263 @@
+package keystore
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileSystemKeyStore(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := os.Mkdir(filepath.Join(tmpdir, "testnet"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	var ks KeyStore = NewFileSystemKeyStore(tmpdir)
+	accountID := "test-account.testnet"
+	if _, err := ks.Get("testnet", accountID); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Get() of missing key returned %v (want ErrKeyNotFound)", err)
+	}
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("kp1 != kp2")
+	}
+	networks, err := ks.ListNetworks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(networks, []string{"testnet"}) {
+		t.Errorf("ListNetworks() returned %v", networks)
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{accountID}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+	if err := ks.Delete("testnet", accountID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Get("testnet", accountID); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Get() of deleted key returned %v (want ErrKeyNotFound)", err)
+	}
+}
+
+func TestFileSystemKeyStoreMultipleKeys(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	if err := os.Mkdir(filepath.Join(tmpdir, "testnet"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	ks := NewFileSystemKeyStore(tmpdir)
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp3, err := GenerateSecp256k1KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.PutKey("testnet", kp2); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.PutSecp256k1Key("testnet", kp3); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ks.ListKeys("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 || keys[0] != kp1.PublicKey {
+		t.Fatalf("ListKeys() returned %v", keys)
+	}
+	kp, err := ks.GetKey("testnet", accountID, kp2.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp, kp2) {
+		t.Error("GetKey() returned wrong key pair")
+	}
+	s, err := ks.KeySigner("testnet", accountID, kp3.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.PublicKey().String() != kp3.PublicKey {
+		t.Error("KeySigner() returned signer for wrong key")
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{accountID}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+	// without <accountID>.json the first key in the account directory is used
+	if err := ks.DeleteKey("testnet", accountID, kp1.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+	kp, err = ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp, kp2) {
+		t.Error("Get() did not fall back to account directory")
+	}
+	if err := ks.Delete("testnet", accountID); err != nil {
+		t.Fatal(err)
+	}
+	if keys, _ := ks.ListKeys("testnet", accountID); len(keys) != 0 {
+		t.Errorf("ListKeys() after Delete() returned %v", keys)
+	}
+}
+
+func TestFileSystemKeyStoreOverwrite(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	// the network directory does not exist yet
+	ks := NewFileSystemKeyStore(filepath.Join(tmpdir, "credentials"))
+	ks.SetOverwrite(false)
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp2); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("Put() of existing key returned %v (want ErrKeyExists)", err)
+	}
+	kp, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp, kp1) {
+		t.Error("refused Put() modified the key file")
+	}
+	ks.SetOverwrite(true)
+	if err := ks.Put("testnet", kp2); err != nil {
+		t.Fatal(err)
+	}
+	kp, err = ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp, kp2) {
+		t.Error("forced Put() did not replace the key file")
+	}
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(tmpdir, "credentials", "testnet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("network directory contains %d entries (want 1)", len(entries))
+	}
+}
+
+func TestFileSystemKeyStoreConcurrent(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	accountID := "test-account.testnet"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// separate instances share the in-process lock
+			ks := NewFileSystemKeyStore(tmpdir)
+			for j := 0; j < 10; j++ {
+				kp, err := GenerateEd25519KeyPair(accountID)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if err := ks.Put("testnet", kp); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := ks.Get("testnet", accountID); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFileSystemKeyStoreLock(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	// simulate another process holding the lock
+	f, err := os.OpenFile(filepath.Join(tmpdir, lockFilename), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := lockFile(f, true); err != nil {
+		t.Fatal(err)
+	}
+	kp, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- NewFileSystemKeyStore(tmpdir).Put("testnet", kp)
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Put() did not wait for lock: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if err := unlockFile(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,27 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+)
+
+// ImplicitAccountID returns the implicit account ID of the Ed25519 public key
+// pubKey, the lowercase hex encoding of the key (64 characters).
+func ImplicitAccountID(pubKey ed25519.PublicKey) string {
+	return hex.EncodeToString(pubKey)
+}
+
+// GenerateImplicitAccount generates a new Ed25519 key pair for the implicit
+// account derived from its public key and stores it in ks for networkID. The
+// account exists on chain as soon as it receives a transfer.
+func GenerateImplicitAccount(ks KeyStore, networkID string) (*Ed25519KeyPair, error) {
+	kp, err := GenerateEd25519KeyPair("")
+	if err != nil {
+		return nil, err
+	}
+	kp.AccountID = ImplicitAccountID(kp.Ed25519PubKey)
+	if err := ks.Put(networkID, kp); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
@@ -0,0 +1,36 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestImplicitAccountID(t *testing.T) {
+	// example from the NEAR documentation
+	pubKey := base58.Decode("BGCCDDHfysuuVnaNVtEhhqeT4k9Muyem3Kpgq2U1m9HX")
+	want := "98793cd91a3f870fb126f66285808c7e094afcfc4eda8a970f6648cdf0dbd6de"
+	if id := ImplicitAccountID(ed25519.PublicKey(pubKey)); id != want {
+		t.Errorf("ImplicitAccountID() returned %s (want %s)", id, want)
+	}
+}
+
+func TestGenerateImplicitAccount(t *testing.T) {
+	ks := NewInMemoryKeyStore()
+	kp, err := GenerateImplicitAccount(ks, "testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kp.AccountID) != 64 || kp.AccountID != ImplicitAccountID(kp.Ed25519PubKey) {
+		t.Errorf("GenerateImplicitAccount() returned account ID %s", kp.AccountID)
+	}
+	stored, err := ks.Get("testnet", kp.AccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, kp) {
+		t.Error("stored key pair differs")
+	}
+}
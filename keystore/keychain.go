@@ -0,0 +1,155 @@
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// DefaultKeychainService is the service name under which key pairs are stored
+// in the OS credential store if no other service is configured.
+const DefaultKeychainService = "near-api-go"
+
+// keychainIndexUser is the credential store user holding the list of stored
+// networks and accounts, since credential stores cannot be enumerated.
+const keychainIndexUser = "_index"
+
+// KeychainKeyStore is a KeyStore which keeps key pairs in the native OS
+// credential store: the macOS Keychain, the Windows Credential Manager or the
+// Secret Service on Linux. Every key pair is stored as a JSON secret for the
+// user <networkID>/<accountID> of the configured service.
+type KeychainKeyStore struct {
+	mtx     sync.Mutex
+	service string
+}
+
+// NewKeychainKeyStore returns a new key store using the OS credential store
+// with the given service name (DefaultKeychainService if empty).
+func NewKeychainKeyStore(service string) *KeychainKeyStore {
+	if service == "" {
+		service = DefaultKeychainService
+	}
+	return &KeychainKeyStore{service: service}
+}
+
+func keychainUser(networkID, accountID string) string {
+	return networkID + "/" + accountID
+}
+
+// Get implements KeyStore.
+func (ks *KeychainKeyStore) Get(networkID, accountID string) (*Ed25519KeyPair, error) {
+	secret, err := keyring.Get(ks.service, keychainUser(networkID, accountID))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	} else if err != nil {
+		return nil, err
+	}
+	return parseKeyPair([]byte(secret), accountID, "keychain "+ks.service)
+}
+
+// Put implements KeyStore.
+func (ks *KeychainKeyStore) Put(networkID string, kp *Ed25519KeyPair) error {
+	data, err := json.Marshal(kp)
+	if err != nil {
+		return err
+	}
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	if err := keyring.Set(ks.service, keychainUser(networkID, kp.AccountID), string(data)); err != nil {
+		return err
+	}
+	return ks.updateIndex(func(index map[string][]string) {
+		for _, accountID := range index[networkID] {
+			if accountID == kp.AccountID {
+				return
+			}
+		}
+		index[networkID] = append(index[networkID], kp.AccountID)
+		sort.Strings(index[networkID])
+	})
+}
+
+// Delete implements KeyStore.
+func (ks *KeychainKeyStore) Delete(networkID, accountID string) error {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	err := keyring.Delete(ks.service, keychainUser(networkID, accountID))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	} else if err != nil {
+		return err
+	}
+	return ks.updateIndex(func(index map[string][]string) {
+		accounts := index[networkID][:0]
+		for _, id := range index[networkID] {
+			if id != accountID {
+				accounts = append(accounts, id)
+			}
+		}
+		if len(accounts) == 0 {
+			delete(index, networkID)
+		} else {
+			index[networkID] = accounts
+		}
+	})
+}
+
+// ListAccounts implements KeyStore.
+func (ks *KeychainKeyStore) ListAccounts(networkID string) ([]string, error) {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	index, err := ks.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index[networkID], nil
+}
+
+// ListNetworks implements KeyStore.
+func (ks *KeychainKeyStore) ListNetworks() ([]string, error) {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	index, err := ks.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	var networks []string
+	for networkID := range index {
+		networks = append(networks, networkID)
+	}
+	sort.Strings(networks)
+	return networks, nil
+}
+
+// readIndex returns the stored accounts per network. ks.mtx must be held.
+func (ks *KeychainKeyStore) readIndex() (map[string][]string, error) {
+	index := make(map[string][]string)
+	secret, err := keyring.Get(ks.service, keychainIndexUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return index, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(secret), &index); err != nil {
+		return nil, fmt.Errorf("keystore: cannot parse keychain index: %v", err)
+	}
+	return index, nil
+}
+
+// updateIndex applies fn to the stored index. ks.mtx must be held.
+func (ks *KeychainKeyStore) updateIndex(fn func(index map[string][]string)) error {
+	index, err := ks.readIndex()
+	if err != nil {
+		return err
+	}
+	fn(index)
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(ks.service, keychainIndexUser, string(data))
+}
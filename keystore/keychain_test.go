@@ -0,0 +1,64 @@
+package keystore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeychainKeyStore(t *testing.T) {
+	keyring.MockInit()
+	ks, err := New(BackendKeychain, Options{Service: "near-api-go-test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountID := "test-account.testnet"
+	if _, err := ks.Get("testnet", accountID); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Get() of missing key returned %v (want ErrKeyNotFound)", err)
+	}
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("kp1 != kp2")
+	}
+	networks, err := ks.ListNetworks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(networks, []string{"testnet"}) {
+		t.Errorf("ListNetworks() returned %v", networks)
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{accountID}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+	if err := ks.Delete("testnet", accountID); err != nil {
+		t.Fatal(err)
+	}
+	if networks, _ := ks.ListNetworks(); len(networks) != 0 {
+		t.Errorf("ListNetworks() after Delete() returned %v", networks)
+	}
+}
+
+func TestNewUnknownBackend(t *testing.T) {
+	if _, err := New("unknown", Options{}); err == nil {
+		t.Error("New() accepted unknown backend")
+	}
+	if _, err := New(BackendEncryptedFileSystem, Options{}); err == nil {
+		t.Error("New() accepted encrypted backend without passphrase")
+	}
+}
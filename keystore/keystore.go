@@ -1,78 +1,99 @@
-// Package keystore implements an unencrypted file system key store.
+// Package keystore defines the KeyStore interface for NEAR key pair storage
+// and implements an unencrypted file system key store.
 package keystore
 
 import (
-	"bytes"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
-	"strings"
 
-	"github.com/btcsuite/btcutil/base58"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
 )
 
-const ed25519Prefix = "ed25519:"
-
 // Ed25519KeyPair is a Ed25519 key pair.
 type Ed25519KeyPair struct {
 	AccountID      string             `json:"account_id"`
 	PublicKey      string             `json:"public_key"`
 	PrivateKey     string             `json:"private_key,omitempty"`
 	SecretKey      string             `json:"secret_key,omitempty"`
+	Metadata       *KeyMetadata       `json:"metadata,omitempty"`
 	Ed25519PubKey  ed25519.PublicKey  `json:"-"`
 	Ed25519PrivKey ed25519.PrivateKey `json:"-"`
 }
 
 func NewEd25519KeyPair(privateKey string, accountId string) *Ed25519KeyPair {
 	pri := ed25519.PrivateKey(privateKey)
-	pub := ed25519.PublicKey(pri.Public().([]byte))
-	kp := &Ed25519KeyPair{
-		AccountID:      accountId,
-		PublicKey:      ed25519Prefix + base58.Encode(pub),
-		PrivateKey:     ed25519Prefix + base58.Encode(pri),
-		SecretKey:      "",
-		Ed25519PubKey:  pub,
-		Ed25519PrivKey: pri,
+	if len(pri) == ed25519.SeedSize {
+		pri = ed25519.NewKeyFromSeed(pri)
 	}
-	return kp
+	return newEd25519KeyPair(accountId, pri)
 }
 
-// GenerateEd25519KeyPair generates a new Ed25519 key pair for accountID.
+// GenerateEd25519KeyPair generates a new Ed25519 key pair for accountID. The
+// creation time is recorded in the metadata.
 func GenerateEd25519KeyPair(accountID string) (*Ed25519KeyPair, error) {
-	var (
-		kp  Ed25519KeyPair
-		err error
-	)
-	kp.Ed25519PubKey, kp.Ed25519PrivKey, err = ed25519.GenerateKey(rand.Reader)
+	return GenerateEd25519KeyPairWithRand(accountID, rand.Reader)
+}
+
+// GenerateEd25519KeyPairWithRand generates a new Ed25519 key pair for
+// accountID like GenerateEd25519KeyPair, reading randomness from r. Tests can
+// pass a deterministic reader to create stable keys.
+func GenerateEd25519KeyPairWithRand(accountID string, r io.Reader) (*Ed25519KeyPair, error) {
+	_, privKey, err := ed25519.GenerateKey(r)
 	if err != nil {
 		return nil, err
 	}
-	kp.AccountID = accountID
-	kp.PublicKey = ed25519Prefix + base58.Encode(kp.Ed25519PubKey)
-	kp.PrivateKey = ed25519Prefix + base58.Encode(kp.Ed25519PrivKey)
-	return &kp, nil
+	kp := newEd25519KeyPair(accountID, privKey)
+	kp.Metadata = newKeyMetadata()
+	return kp, nil
+}
+
+// GenerateEd25519KeyPairFromSeed deterministically derives the Ed25519 key pair
+// for accountID from the 32 byte seed, e.g. for reproducible test fixtures.
+func GenerateEd25519KeyPairFromSeed(accountID string, seed []byte) (*Ed25519KeyPair, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("keystore: seed has invalid length %d", len(seed))
+	}
+	return newEd25519KeyPair(accountID, ed25519.NewKeyFromSeed(seed)), nil
+}
+
+func newEd25519KeyPair(accountID string, privKey ed25519.PrivateKey) *Ed25519KeyPair {
+	pubKey := privKey.Public().(ed25519.PublicKey)
+	return &Ed25519KeyPair{
+		AccountID:      accountID,
+		PublicKey:      utils.PublicKeyFromEd25519(pubKey).String(),
+		PrivateKey:     utils.PrivateKeyFromEd25519(privKey).String(),
+		Ed25519PubKey:  pubKey,
+		Ed25519PrivKey: privKey,
+	}
 }
 
 func (kp *Ed25519KeyPair) write(filename string) error {
+	return kp.writeFile(filename, true)
+}
+
+func (kp *Ed25519KeyPair) writeFile(filename string, overwrite bool) error {
 	data, err := json.Marshal(kp)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filename, data, 0600)
+	return writeFile(filename, data, overwrite)
 }
 
 // Write the Ed25519 key pair to the unencrypted file system key store with
-// networkID and return the filename of the written file.
+// networkID and return the filename of the written file. The network
+// directory is created if necessary and an existing key file is replaced
+// atomically.
 func (kp *Ed25519KeyPair) Write(networkID string) (string, error) {
-	home, err := os.UserHomeDir()
+	ks, err := NewDefaultFileSystemKeyStore()
 	if err != nil {
 		return "", err
 	}
-	filename := filepath.Join(home, ".near-credentials", networkID, kp.AccountID+".json")
-	return filename, kp.write(filename)
+	return ks.filename(networkID, kp.AccountID), ks.Put(networkID, kp)
 }
 
 // LoadKeyPair reads the Ed25519 key pair for the given ccountID from path
@@ -82,8 +103,20 @@ func LoadKeyPairFromPath(path, accountID string) (*Ed25519KeyPair, error) {
 	if err != nil {
 		return nil, err
 	}
+	return parseKeyPair(buf, accountID, path)
+}
+
+// ParseKeyPair parses the Ed25519 key pair for accountID from data in the JSON
+// key file format and validates it.
+func ParseKeyPair(data []byte, accountID string) (*Ed25519KeyPair, error) {
+	return parseKeyPair(data, accountID, "key pair "+accountID)
+}
+
+// parseKeyPair parses the JSON encoded Ed25519 key pair for accountID from buf
+// and validates it. The source is used in error messages.
+func parseKeyPair(buf []byte, accountID, source string) (*Ed25519KeyPair, error) {
 	var kp Ed25519KeyPair
-	err = json.Unmarshal(buf, &kp)
+	err := json.Unmarshal(buf, &kp)
 	if err != nil {
 		return nil, err
 	}
@@ -93,34 +126,36 @@ func LoadKeyPairFromPath(path, accountID string) (*Ed25519KeyPair, error) {
 			kp.AccountID, accountID)
 	}
 	// public key
-	if !strings.HasPrefix(kp.PublicKey, ed25519Prefix) {
+	pubKey, err := utils.ParsePublicKey(kp.PublicKey)
+	if err != nil || pubKey.KeyType != utils.ED25519 {
 		return nil, fmt.Errorf("keystore: parsed public_key '%s' is not an Ed25519 key",
 			kp.PublicKey)
 	}
-	pubKey := base58.Decode(strings.TrimPrefix(kp.PublicKey, ed25519Prefix))
-	kp.Ed25519PubKey = ed25519.PublicKey(pubKey)
+	kp.Ed25519PubKey = ed25519.PublicKey(pubKey.ED25519.Data[:])
 	// private key
-	var privateKey []byte
+	var name, privateKey string
 	if len(kp.PrivateKey) > 0 && len(kp.SecretKey) > 0 {
-		return nil, fmt.Errorf("keystore: private_key and secret_key are defined at the same time: %s", path)
+		return nil, fmt.Errorf("keystore: private_key and secret_key are defined at the same time: %s", source)
 	} else if len(kp.PrivateKey) > 0 {
-		if !strings.HasPrefix(kp.PrivateKey, ed25519Prefix) {
-			return nil, fmt.Errorf("keystore: parsed private_key '%s' is not an Ed25519 key",
-				kp.PrivateKey)
-		}
-		privateKey = base58.Decode(strings.TrimPrefix(kp.PrivateKey, ed25519Prefix))
-	} else { // secret_key
-		if !strings.HasPrefix(kp.SecretKey, ed25519Prefix) {
-			return nil, fmt.Errorf("keystore: parsed secret_key '%s' is not an Ed25519 key",
-				kp.SecretKey)
-		}
-		privateKey = base58.Decode(strings.TrimPrefix(kp.SecretKey, ed25519Prefix))
-	}
-	kp.Ed25519PrivKey = ed25519.PrivateKey(privateKey)
+		name, privateKey = "private_key", kp.PrivateKey
+	} else {
+		name, privateKey = "secret_key", kp.SecretKey
+	}
+	// Some tools export only the 32 byte seed instead of the 64 byte expanded
+	// private key, ParsePrivateKey accepts both. The private key string is
+	// kept as is, so writing the key pair preserves the original format.
+	privKey, err := utils.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: parsed %s is invalid: %v: %s", name, err, source)
+	}
+	if privKey.KeyType != utils.ED25519 {
+		return nil, fmt.Errorf("keystore: parsed %s is not an Ed25519 key: %s", name, source)
+	}
+	kp.Ed25519PrivKey = privKey.Ed25519()
 
 	// make sure keys match
-	if !bytes.Equal(pubKey, kp.Ed25519PrivKey.Public().(ed25519.PublicKey)) {
-		return nil, fmt.Errorf("keystore: public_key does not match private_key: %s", path)
+	if !privKey.PublicKey().Equal(pubKey) {
+		return nil, fmt.Errorf("keystore: public_key does not match private_key: %s", source)
 	}
 	return &kp, nil
 }
@@ -128,10 +163,53 @@ func LoadKeyPairFromPath(path, accountID string) (*Ed25519KeyPair, error) {
 // LoadKeyPair reads the Ed25519 key pair for the given networkID and
 // accountID from the unencrypted file system key store and returns it.
 func LoadKeyPair(networkID, accountID string) (*Ed25519KeyPair, error) {
-	home, err := os.UserHomeDir()
+	ks, err := NewDefaultFileSystemKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	return ks.Get(networkID, accountID)
+}
+
+// Signer returns a signer for the account of the key pair.
+func (kp *Ed25519KeyPair) Signer() (signer.Signer, error) {
+	return signer.NewEd25519Signer(kp.AccountID, kp.Ed25519PrivKey)
+}
+
+// SignerKeyStore is implemented by key stores which can provide signers for
+// key types other than Ed25519.
+type SignerKeyStore interface {
+	Signer(networkID, accountID string) (signer.Signer, error)
+}
+
+// LoadSigner loads the key pair for accountID on networkID from ks and returns
+// a signer for it.
+func LoadSigner(ks KeyStore, networkID, accountID string) (signer.Signer, error) {
+	if sks, ok := ks.(SignerKeyStore); ok {
+		return sks.Signer(networkID, accountID)
+	}
+	kp, err := ks.Get(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return kp.Signer()
+}
+
+// ListNetworks returns the IDs of all networks with key pairs in the
+// unencrypted file system key store.
+func ListNetworks() ([]string, error) {
+	ks, err := NewDefaultFileSystemKeyStore()
+	if err != nil {
+		return nil, err
+	}
+	return ks.ListNetworks()
+}
+
+// ListAccounts returns the IDs of all accounts with key pairs for the given
+// networkID in the unencrypted file system key store.
+func ListAccounts(networkID string) ([]string, error) {
+	ks, err := NewDefaultFileSystemKeyStore()
 	if err != nil {
 		return nil, err
 	}
-	filename := filepath.Join(home, ".near-credentials", networkID, accountID+".json")
-	return LoadKeyPairFromPath(filename, accountID)
+	return ks.ListAccounts(networkID)
 }
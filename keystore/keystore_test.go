@@ -1,11 +1,16 @@
 package keystore
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
 )
 
 func TestLoadAccessKey(t *testing.T) {
@@ -59,3 +64,167 @@ func TestGenerateWriteRead(t *testing.T) {
 		t.Fatal("kp1 != kp2")
 	}
 }
+
+func TestListNetworksAccounts(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	t.Setenv("HOME", tmpdir)
+	for _, dir := range []string{"mainnet", "testnet", ".git"} {
+		if err := os.MkdirAll(filepath.Join(tmpdir, ".near-credentials", dir), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kp, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kp.Write("testnet"); err != nil {
+		t.Fatal(err)
+	}
+	networks, err := ListNetworks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(networks, []string{"mainnet", "testnet"}) {
+		t.Errorf("ListNetworks() returned %v", networks)
+	}
+	accounts, err := ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{"test-account.testnet"}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+}
+
+func TestLoadSeedKeyPair(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	accountID := "seed-key.testnet"
+	kp1, err := LoadKeyPairFromPath(filepath.Join("testdata", accountID+".json"), accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kp1.Ed25519PrivKey) != ed25519.PrivateKeySize {
+		t.Fatalf("LoadKeyPairFromPath() returned private key of length %d", len(kp1.Ed25519PrivKey))
+	}
+	// writing preserves the seed format
+	filename := filepath.Join(tmpdir, accountID+".json")
+	if err := kp1.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := LoadKeyPairFromPath(filename, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("kp1 != kp2")
+	}
+	// invalid length
+	kp1.PrivateKey = "ed25519:" + base58.Encode(kp1.Ed25519PrivKey[:40])
+	data, err := json.Marshal(kp1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseKeyPair(data, accountID); err == nil {
+		t.Error("ParseKeyPair() accepted private key of invalid length")
+	}
+}
+
+func TestKeyMetadata(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp1.Metadata == nil || kp1.Metadata.CreatedAt == nil {
+		t.Fatal("GenerateEd25519KeyPair() did not record creation time")
+	}
+	kp1.Metadata.Label = "deployer"
+	kp1.Metadata.Purpose = "deploy"
+	kp1.Metadata.AllowanceHint = "250000000000000000000000"
+	filename := filepath.Join(tmpdir, accountID+".json")
+	if err := kp1.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := LoadKeyPairFromPath(filename, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1.Metadata, kp2.Metadata) {
+		t.Errorf("LoadKeyPairFromPath() returned metadata %+v (want %+v)", kp2.Metadata, kp1.Metadata)
+	}
+	// secp256k1 key pairs preserve metadata as well
+	skp1, err := GenerateSecp256k1KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skp1.Metadata.Label = "secp256k1"
+	if err := skp1.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	skp2, err := LoadSecp256k1KeyPairFromPath(filename, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(skp1.Metadata, skp2.Metadata) {
+		t.Errorf("LoadSecp256k1KeyPairFromPath() returned metadata %+v (want %+v)", skp2.Metadata, skp1.Metadata)
+	}
+	// key files without metadata stay without
+	kp3, err := LoadKeyPairFromPath(filepath.Join("testdata", accountID+".json"), accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp3.Metadata != nil {
+		t.Error("LoadKeyPairFromPath() returned metadata for key file without")
+	}
+}
+
+func TestDeterministicKeyPair(t *testing.T) {
+	accountID := "test-account.testnet"
+	seed := bytes.Repeat([]byte{0x42}, ed25519.SeedSize)
+	kp1, err := GenerateEd25519KeyPairFromSeed(accountID, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPairFromSeed(accountID, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("GenerateEd25519KeyPairFromSeed() is not deterministic")
+	}
+	if _, err := GenerateEd25519KeyPairFromSeed(accountID, seed[1:]); err == nil {
+		t.Error("GenerateEd25519KeyPairFromSeed() accepted short seed")
+	}
+	// injected randomness
+	kp3, err := GenerateEd25519KeyPairWithRand(accountID, bytes.NewReader(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp3.PublicKey != kp1.PublicKey {
+		t.Errorf("GenerateEd25519KeyPair() returned %s (want %s)", kp3.PublicKey, kp1.PublicKey)
+	}
+	var secpKeys []string
+	for i := 0; i < 2; i++ {
+		kp, err := GenerateSecp256k1KeyPairWithRand(accountID, bytes.NewReader(seed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		secpKeys = append(secpKeys, kp.PublicKey)
+	}
+	if secpKeys[0] != secpKeys[1] {
+		t.Error("GenerateSecp256k1KeyPair() is not deterministic")
+	}
+}
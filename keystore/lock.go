@@ -0,0 +1,70 @@
+package keystore
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// lockFilename is the name of the lock file in the root directory of a file
+// system key store. It is hidden, so it is never listed as network.
+const lockFilename = ".lock"
+
+// dirLocks contains a *sync.RWMutex per key store directory, which
+// serializes access of all FileSystemKeyStore instances of the process.
+var dirLocks sync.Map
+
+func dirLock(dir string) *sync.RWMutex {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	mtx, _ := dirLocks.LoadOrStore(dir, new(sync.RWMutex))
+	return mtx.(*sync.RWMutex)
+}
+
+// lock locks the key store for reading (shared) or writing (exclusive), both
+// within the process and across processes with an advisory lock on the lock
+// file. The returned function releases the lock.
+func (ks *FileSystemKeyStore) lock(exclusive bool) (func(), error) {
+	mtx := dirLock(ks.dir)
+	if exclusive {
+		mtx.Lock()
+	} else {
+		mtx.RLock()
+	}
+	unlockMtx := func() {
+		if exclusive {
+			mtx.Unlock()
+		} else {
+			mtx.RUnlock()
+		}
+	}
+	flag := os.O_RDONLY
+	if exclusive {
+		if err := os.MkdirAll(ks.dir, 0700); err != nil {
+			unlockMtx()
+			return nil, err
+		}
+		flag = os.O_RDWR | os.O_CREATE
+	}
+	f, err := os.OpenFile(filepath.Join(ks.dir, lockFilename), flag, 0600)
+	if !exclusive && errors.Is(err, fs.ErrNotExist) {
+		// nothing was ever written to the key store, no writer to wait for
+		return unlockMtx, nil
+	} else if err != nil {
+		unlockMtx()
+		return nil, err
+	}
+	if err := lockFile(f, exclusive); err != nil {
+		f.Close()
+		unlockMtx()
+		return nil, err
+	}
+	return func() {
+		_ = unlockFile(f)
+		f.Close()
+		unlockMtx()
+	}, nil
+}
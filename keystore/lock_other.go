@@ -0,0 +1,17 @@
+//go:build !unix && !windows
+
+package keystore
+
+import (
+	"os"
+)
+
+// lockFile is a no-op on platforms without advisory file locks, only the
+// in-process lock is taken.
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
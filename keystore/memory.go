@@ -0,0 +1,87 @@
+package keystore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// InMemoryKeyStore is a KeyStore which keeps all key pairs in memory. It is
+// safe for concurrent use and intended for tests and ephemeral signers.
+type InMemoryKeyStore struct {
+	mtx  sync.RWMutex
+	keys map[string]map[string]Ed25519KeyPair
+}
+
+// NewInMemoryKeyStore returns a new empty in-memory key store.
+func NewInMemoryKeyStore() *InMemoryKeyStore {
+	return &InMemoryKeyStore{
+		keys: make(map[string]map[string]Ed25519KeyPair),
+	}
+}
+
+// Get implements KeyStore.
+func (ks *InMemoryKeyStore) Get(networkID, accountID string) (*Ed25519KeyPair, error) {
+	ks.mtx.RLock()
+	defer ks.mtx.RUnlock()
+	kp, ok := ks.keys[networkID][accountID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	}
+	kp.Metadata = kp.Metadata.clone()
+	return &kp, nil
+}
+
+// Put implements KeyStore.
+func (ks *InMemoryKeyStore) Put(networkID string, kp *Ed25519KeyPair) error {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	accounts, ok := ks.keys[networkID]
+	if !ok {
+		accounts = make(map[string]Ed25519KeyPair)
+		ks.keys[networkID] = accounts
+	}
+	c := *kp
+	c.Metadata = kp.Metadata.clone()
+	accounts[kp.AccountID] = c
+	return nil
+}
+
+// Delete implements KeyStore.
+func (ks *InMemoryKeyStore) Delete(networkID, accountID string) error {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	accounts := ks.keys[networkID]
+	if _, ok := accounts[accountID]; !ok {
+		return fmt.Errorf("%w: %s on %s", ErrKeyNotFound, accountID, networkID)
+	}
+	delete(accounts, accountID)
+	if len(accounts) == 0 {
+		delete(ks.keys, networkID)
+	}
+	return nil
+}
+
+// ListAccounts implements KeyStore.
+func (ks *InMemoryKeyStore) ListAccounts(networkID string) ([]string, error) {
+	ks.mtx.RLock()
+	defer ks.mtx.RUnlock()
+	var accounts []string
+	for accountID := range ks.keys[networkID] {
+		accounts = append(accounts, accountID)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+// ListNetworks implements KeyStore.
+func (ks *InMemoryKeyStore) ListNetworks() ([]string, error) {
+	ks.mtx.RLock()
+	defer ks.mtx.RUnlock()
+	var networks []string
+	for networkID := range ks.keys {
+		networks = append(networks, networkID)
+	}
+	sort.Strings(networks)
+	return networks, nil
+}
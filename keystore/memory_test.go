@@ -0,0 +1,61 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestInMemoryKeyStore(t *testing.T) {
+	var ks KeyStore = NewInMemoryKeyStore()
+	var wg sync.WaitGroup
+	for _, networkID := range []string{"mainnet", "testnet"} {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(networkID, accountID string) {
+				defer wg.Done()
+				kp, err := GenerateEd25519KeyPair(accountID)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if err := ks.Put(networkID, kp); err != nil {
+					t.Error(err)
+				}
+			}(networkID, fmt.Sprintf("account%d.%s", i, networkID))
+		}
+	}
+	wg.Wait()
+	networks, err := ks.ListNetworks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(networks, []string{"mainnet", "testnet"}) {
+		t.Errorf("ListNetworks() returned %v", networks)
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 10 {
+		t.Errorf("ListAccounts() returned %d accounts (want 10)", len(accounts))
+	}
+	kp, err := ks.Get("testnet", "account3.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp.AccountID != "account3.testnet" {
+		t.Errorf("Get() returned key pair for %s", kp.AccountID)
+	}
+	if err := ks.Delete("testnet", "account3.testnet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Get("testnet", "account3.testnet"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get() of deleted key returned %v (want ErrKeyNotFound)", err)
+	}
+	if _, err := ks.Get("mainnet", "account3.testnet"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Get() of missing key returned %v (want ErrKeyNotFound)", err)
+	}
+}
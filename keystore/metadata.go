@@ -0,0 +1,67 @@
+package keystore
+
+import (
+	"time"
+)
+
+// KeyMetadata is optional operational information about a key pair. It is
+// stored in the "metadata" object of the JSON key file, which is ignored by
+// other NEAR tooling, and preserved when key pairs are loaded and written.
+type KeyMetadata struct {
+	// CreatedAt is the time the key pair was generated.
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Label is a short human readable name of the key.
+	Label string `json:"label,omitempty"`
+	// Purpose describes why the key exists, e.g. "deploy" or "relayer".
+	Purpose string `json:"purpose,omitempty"`
+	// AllowanceHint is the allowance in yoctoNEAR the access key was or
+	// should be created with. It is informational only, the allowance on
+	// chain is authoritative.
+	AllowanceHint string `json:"allowance_hint,omitempty"`
+	// ExpiresAt is the time after which the key should no longer be used.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// The permission of function call access keys, see RefreshAllowance.
+	// ReceiverID is the contract the key may call and MethodNames the
+	// methods (all methods if empty).
+	ReceiverID  string   `json:"receiver_id,omitempty"`
+	MethodNames []string `json:"method_names,omitempty"`
+	// Allowance is the remaining allowance in yoctoNEAR at RefreshedAt, empty
+	// for full access keys and unlimited allowances.
+	Allowance   string     `json:"allowance,omitempty"`
+	RefreshedAt *time.Time `json:"refreshed_at,omitempty"`
+}
+
+// Expired reports whether the key expired before now.
+func (md *KeyMetadata) Expired(now time.Time) bool {
+	return md != nil && md.ExpiresAt != nil && now.After(*md.ExpiresAt)
+}
+
+// newKeyMetadata returns the metadata of a key pair generated now.
+func newKeyMetadata() *KeyMetadata {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &KeyMetadata{CreatedAt: &now}
+}
+
+// clone returns a deep copy of md.
+func (md *KeyMetadata) clone() *KeyMetadata {
+	if md == nil {
+		return nil
+	}
+	c := *md
+	c.CreatedAt = cloneTime(md.CreatedAt)
+	c.ExpiresAt = cloneTime(md.ExpiresAt)
+	c.RefreshedAt = cloneTime(md.RefreshedAt)
+	if md.MethodNames != nil {
+		c.MethodNames = append([]string{}, md.MethodNames...)
+	}
+	return &c
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
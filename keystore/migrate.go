@@ -0,0 +1,212 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// localStoragePrefix is the prefix of the localStorage keys used by the
+// near-api-js BrowserLocalStorageKeyStore:
+// near-api-js:keystore:<accountID>:<networkID>.
+const localStoragePrefix = "near-api-js:keystore:"
+
+// ParseLocalStorage parses a JSON export of the browser localStorage (e.g.
+// JSON.stringify(localStorage)) and returns an in-memory key store with all
+// key pairs stored by near-api-js. All other entries are ignored.
+func ParseLocalStorage(data []byte) (*InMemoryKeyStore, error) {
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	ks := NewInMemoryKeyStore()
+	for key, value := range entries {
+		if !strings.HasPrefix(key, localStoragePrefix) {
+			continue
+		}
+		// account IDs cannot contain colons, network IDs might
+		rest := strings.TrimPrefix(key, localStoragePrefix)
+		i := strings.IndexByte(rest, ':')
+		if i <= 0 || i == len(rest)-1 {
+			return nil, fmt.Errorf("keystore: invalid localStorage key '%s'", key)
+		}
+		accountID, networkID := rest[:i], rest[i+1:]
+		privKey, err := utils.ParsePrivateKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: %s on %s: %w", accountID, networkID, err)
+		}
+		if privKey.KeyType != utils.ED25519 {
+			return nil, fmt.Errorf("keystore: %s on %s: unsupported key type %s",
+				accountID, networkID, utils.KeyTypeName(privKey.KeyType))
+		}
+		if err := ks.Put(networkID, NewEd25519KeyPair(string(privKey.Data), accountID)); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+// MigratedKey identifies a key pair copied by Migrate.
+type MigratedKey struct {
+	NetworkID string
+	AccountID string
+	PublicKey string
+}
+
+// migration is a single key pair to migrate. Additional keys are stored in
+// the account directory of a file system key store.
+type migration struct {
+	networkID  string
+	ed25519    *Ed25519KeyPair
+	secp256k1  *Secp256k1KeyPair
+	additional bool
+}
+
+func (m *migration) key() MigratedKey {
+	k := MigratedKey{NetworkID: m.networkID}
+	if m.ed25519 != nil {
+		k.AccountID, k.PublicKey = m.ed25519.AccountID, m.ed25519.PublicKey
+	} else {
+		k.AccountID, k.PublicKey = m.secp256k1.AccountID, m.secp256k1.PublicKey
+	}
+	return k
+}
+
+// Migrate copies all key pairs of all networks from src to dst and returns
+// the copied key pairs.
+//
+// Sources in the near-cli layout (~/.near-credentials or the legacy neardev
+// project directory) and the near-cli-rs layout with one directory per
+// account are read with a FileSystemKeyStore, browser exports with
+// ParseLocalStorage; every other KeyStore works as well. Every key pair is
+// validated before anything is written, so either all key pairs are copied
+// or, on a validation error, none. Additional keys of an account and
+// secp256k1 keys can only be migrated to a FileSystemKeyStore.
+func Migrate(src, dst KeyStore) ([]MigratedKey, error) {
+	migrations, err := collectMigrations(src)
+	if err != nil {
+		return nil, err
+	}
+	dstFS, _ := dst.(*FileSystemKeyStore)
+	for _, m := range migrations {
+		if err := m.validate(); err != nil {
+			k := m.key()
+			return nil, fmt.Errorf("keystore: cannot migrate %s on %s: %w", k.AccountID, k.NetworkID, err)
+		}
+		if dstFS == nil && (m.additional || m.secp256k1 != nil) {
+			k := m.key()
+			return nil, fmt.Errorf("keystore: cannot migrate %s of %s on %s: destination supports only one Ed25519 key per account",
+				k.PublicKey, k.AccountID, k.NetworkID)
+		}
+	}
+	var migrated []MigratedKey
+	for _, m := range migrations {
+		switch {
+		case m.ed25519 != nil && !m.additional:
+			err = dst.Put(m.networkID, m.ed25519)
+		case m.ed25519 != nil:
+			err = dstFS.PutKey(m.networkID, m.ed25519)
+		case !m.additional:
+			err = dstFS.PutSecp256k1(m.networkID, m.secp256k1)
+		default:
+			err = dstFS.PutSecp256k1Key(m.networkID, m.secp256k1)
+		}
+		if err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, m.key())
+	}
+	return migrated, nil
+}
+
+// collectMigrations reads all key pairs of src.
+func collectMigrations(src KeyStore) ([]migration, error) {
+	networks, err := src.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+	srcFS, _ := src.(*FileSystemKeyStore)
+	var migrations []migration
+	for _, networkID := range networks {
+		accounts, err := src.ListAccounts(networkID)
+		if err != nil {
+			return nil, err
+		}
+		for _, accountID := range accounts {
+			if srcFS == nil {
+				kp, err := src.Get(networkID, accountID)
+				if err != nil {
+					return nil, err
+				}
+				migrations = append(migrations, migration{networkID: networkID, ed25519: kp})
+				continue
+			}
+			ms, err := srcFS.collectMigrations(networkID, accountID)
+			if err != nil {
+				return nil, err
+			}
+			migrations = append(migrations, ms...)
+		}
+	}
+	return migrations, nil
+}
+
+// collectMigrations reads all key pairs of accountID on networkID, the first
+// one is the primary key pair.
+func (ks *FileSystemKeyStore) collectMigrations(networkID, accountID string) ([]migration, error) {
+	unlock, err := ks.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	keys, err := ks.listKeys(networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []migration
+	for i, publicKey := range keys {
+		filename, err := ks.findKey(networkID, accountID, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		m := migration{networkID: networkID, additional: i > 0}
+		if pk, err := utils.ParsePublicKey(publicKey); err == nil && pk.KeyType == utils.SECP256K1 {
+			m.secp256k1, err = LoadSecp256k1KeyPairFromPath(filename, accountID)
+		} else {
+			m.ed25519, err = LoadKeyPairFromPath(filename, accountID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+// validate makes sure the key pair is consistent. Key pairs read from files
+// are validated while parsing, but other key stores may contain anything.
+func (m *migration) validate() error {
+	if m.secp256k1 != nil {
+		if m.secp256k1.AccountID == "" || m.secp256k1.Secp256k1PrivKey == nil {
+			return fmt.Errorf("incomplete secp256k1 key pair")
+		}
+		return nil
+	}
+	kp := m.ed25519
+	if kp.AccountID == "" {
+		return fmt.Errorf("empty account ID")
+	}
+	if len(kp.Ed25519PrivKey) != ed25519.PrivateKeySize {
+		return fmt.Errorf("private key has invalid length %d", len(kp.Ed25519PrivKey))
+	}
+	pubKey := kp.Ed25519PrivKey.Public().(ed25519.PublicKey)
+	if !bytes.Equal(pubKey, kp.Ed25519PubKey) ||
+		kp.PublicKey != utils.PublicKeyFromEd25519(pubKey).String() {
+		return fmt.Errorf("public key does not match private key")
+	}
+	return nil
+}
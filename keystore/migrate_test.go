@@ -0,0 +1,137 @@
+package keystore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMigrateLocalStorage(t *testing.T) {
+	kp1, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPair("test-account.near")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(map[string]string{
+		"near-api-js:keystore:test-account.testnet:testnet": kp1.PrivateKey,
+		"near-api-js:keystore:test-account.near:mainnet":    kp2.PrivateKey,
+		"undefined_wallet_auth_key":                         `{"accountId":"test-account.testnet"}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := ParseLocalStorage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	dst := NewFileSystemKeyStore(tmpdir)
+	migrated, err := Migrate(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrated) != 2 {
+		t.Errorf("Migrate() returned %v", migrated)
+	}
+	for networkID, kp := range map[string]*Ed25519KeyPair{"testnet": kp1, "mainnet": kp2} {
+		stored, err := dst.Get(networkID, kp.AccountID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kp.Metadata = nil // not part of the localStorage export
+		if !reflect.DeepEqual(stored, kp) {
+			t.Errorf("migrated key pair of %s differs", kp.AccountID)
+		}
+	}
+	if _, err := ParseLocalStorage([]byte(`{"near-api-js:keystore:test-account.testnet:testnet": "ed25519:invalid"}`)); err == nil {
+		t.Error("ParseLocalStorage() accepted invalid key")
+	}
+}
+
+func TestMigrateFileSystem(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	// near-cli-rs layout with additional keys
+	src := NewFileSystemKeyStore(tmpdir + "/src")
+	accountID := "test-account.testnet"
+	kp1, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp3, err := GenerateSecp256k1KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.PutKey("testnet", kp2); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.PutSecp256k1Key("testnet", kp3); err != nil {
+		t.Fatal(err)
+	}
+	// additional keys cannot be migrated to a key store without them
+	mem := NewInMemoryKeyStore()
+	if _, err := Migrate(src, mem); err == nil {
+		t.Error("Migrate() to in-memory key store succeeded")
+	}
+	if accounts, _ := mem.ListAccounts("testnet"); len(accounts) != 0 {
+		t.Error("failed Migrate() wrote key pairs")
+	}
+	dst := NewFileSystemKeyStore(tmpdir + "/dst")
+	migrated, err := Migrate(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrated) != 3 {
+		t.Errorf("Migrate() returned %v", migrated)
+	}
+	srcKeys, err := src.ListKeys("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstKeys, err := dst.ListKeys("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(srcKeys, dstKeys) {
+		t.Errorf("migrated keys %v (want %v)", dstKeys, srcKeys)
+	}
+}
+
+func TestMigrateInvalid(t *testing.T) {
+	kp1, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp1.PublicKey = kp2.PublicKey
+	src := NewInMemoryKeyStore()
+	if err := src.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	dst := NewInMemoryKeyStore()
+	if _, err := Migrate(src, dst); err == nil {
+		t.Error("Migrate() accepted inconsistent key pair")
+	}
+}
@@ -0,0 +1,171 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const secp256k1Prefix = "secp256k1:"
+
+// Secp256k1KeyPair is a secp256k1 key pair. The public key is the 64 byte
+// uncompressed key without the leading 0x04 byte, the private key is the 32
+// byte scalar, both base58 encoded.
+type Secp256k1KeyPair struct {
+	AccountID        string            `json:"account_id"`
+	PublicKey        string            `json:"public_key"`
+	PrivateKey       string            `json:"private_key"`
+	Metadata         *KeyMetadata      `json:"metadata,omitempty"`
+	Secp256k1PrivKey *btcec.PrivateKey `json:"-"`
+}
+
+func newSecp256k1KeyPair(accountID string, privKey *btcec.PrivateKey) *Secp256k1KeyPair {
+	return &Secp256k1KeyPair{
+		AccountID:        accountID,
+		PublicKey:        utils.PublicKeyFromSecp256k1(privKey.PubKey().SerializeUncompressed()[1:]).String(),
+		PrivateKey:       utils.PrivateKeyFromSecp256k1(privKey).String(),
+		Secp256k1PrivKey: privKey,
+	}
+}
+
+// GenerateSecp256k1KeyPair generates a new secp256k1 key pair for accountID.
+// The creation time is recorded in the metadata.
+func GenerateSecp256k1KeyPair(accountID string) (*Secp256k1KeyPair, error) {
+	return GenerateSecp256k1KeyPairWithRand(accountID, rand.Reader)
+}
+
+// GenerateSecp256k1KeyPairWithRand generates a new secp256k1 key pair for
+// accountID like GenerateSecp256k1KeyPair, reading randomness from r.
+func GenerateSecp256k1KeyPairWithRand(accountID string, r io.Reader) (*Secp256k1KeyPair, error) {
+	privKey, err := generateSecp256k1PrivateKey(r)
+	if err != nil {
+		return nil, err
+	}
+	kp := newSecp256k1KeyPair(accountID, privKey)
+	kp.Metadata = newKeyMetadata()
+	return kp, nil
+}
+
+// generateSecp256k1PrivateKey generates a private key from r. In contrast to
+// ecdsa.GenerateKey the result only depends on the bytes read from r.
+func generateSecp256k1PrivateKey(r io.Reader) (*btcec.PrivateKey, error) {
+	n := btcec.S256().N
+	buf := make([]byte, btcec.PrivKeyBytesLen)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		// rejection sampling keeps the distribution uniform
+		d := new(big.Int).SetBytes(buf)
+		if d.Sign() > 0 && d.Cmp(n) < 0 {
+			privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), buf)
+			return privKey, nil
+		}
+	}
+}
+
+func (kp *Secp256k1KeyPair) write(filename string) error {
+	return kp.writeFile(filename, true)
+}
+
+func (kp *Secp256k1KeyPair) writeFile(filename string, overwrite bool) error {
+	data, err := json.Marshal(kp)
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, data, overwrite)
+}
+
+// Signer returns a signer for the account of the key pair.
+func (kp *Secp256k1KeyPair) Signer() (signer.Signer, error) {
+	return signer.NewSecp256k1Signer(kp.AccountID, kp.Secp256k1PrivKey), nil
+}
+
+// LoadSecp256k1KeyPairFromPath reads the secp256k1 key pair for the given
+// accountID from path and returns it.
+func LoadSecp256k1KeyPairFromPath(path, accountID string) (*Secp256k1KeyPair, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSecp256k1KeyPair(buf, accountID, path)
+}
+
+func parseSecp256k1KeyPair(buf []byte, accountID, source string) (*Secp256k1KeyPair, error) {
+	var kp struct {
+		Secp256k1KeyPair
+		SecretKey string `json:"secret_key"`
+	}
+	if err := json.Unmarshal(buf, &kp); err != nil {
+		return nil, err
+	}
+	// account ID
+	if kp.AccountID != accountID {
+		return nil, fmt.Errorf("keystore: parsed account_id '%s' does not match with accountID '%s'",
+			kp.AccountID, accountID)
+	}
+	// public key
+	pubKey, err := utils.ParsePublicKey(kp.PublicKey)
+	if err != nil || pubKey.KeyType != utils.SECP256K1 {
+		return nil, fmt.Errorf("keystore: parsed public_key '%s' is not a secp256k1 key",
+			kp.PublicKey)
+	}
+	// private key
+	privateKey := kp.PrivateKey
+	if privateKey == "" {
+		privateKey = kp.SecretKey
+	}
+	privKey, err := utils.ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: parsed private_key is invalid: %v: %s", err, source)
+	}
+	if privKey.KeyType != utils.SECP256K1 {
+		return nil, fmt.Errorf("keystore: parsed private_key is not a secp256k1 key: %s", source)
+	}
+
+	// make sure keys match
+	if !privKey.PublicKey().Equal(pubKey) {
+		return nil, fmt.Errorf("keystore: public_key does not match private_key: %s", source)
+	}
+	res := newSecp256k1KeyPair(kp.AccountID, privKey.Secp256k1())
+	res.Metadata = kp.Metadata
+	return res, nil
+}
+
+// LoadSignerFromPath reads the key pair for the given accountID from path and
+// returns a signer for it. Both Ed25519 and secp256k1 key pairs are supported.
+func LoadSignerFromPath(path, accountID string) (signer.Signer, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSigner(buf, accountID, path)
+}
+
+func parseSigner(buf []byte, accountID, source string) (signer.Signer, error) {
+	var kp struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.Unmarshal(buf, &kp); err != nil {
+		return nil, err
+	}
+	if pk, err := utils.ParsePublicKey(kp.PublicKey); err == nil && pk.KeyType == utils.SECP256K1 {
+		kp, err := parseSecp256k1KeyPair(buf, accountID, source)
+		if err != nil {
+			return nil, err
+		}
+		return kp.Signer()
+	}
+	ed25519KeyPair, err := parseKeyPair(buf, accountID, source)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519KeyPair.Signer()
+}
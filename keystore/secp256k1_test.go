@@ -0,0 +1,61 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func TestSecp256k1GenerateWriteSign(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "keystore_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	defer os.RemoveAll(tmpdir)
+	// generate
+	accountID := "test-account.testnet"
+	kp, err := GenerateSecp256k1KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write
+	filename := filepath.Join(tmpdir, accountID+".json")
+	if err := kp.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	// read
+	s, err := LoadSignerFromPath(filename, accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := s.PublicKey()
+	if pk.KeyType != utils.SECP256K1 {
+		t.Fatalf("loaded signer has key type %d", pk.KeyType)
+	}
+	if pk.String() != kp.PublicKey {
+		t.Errorf("loaded public key %s != %s", pk, kp.PublicKey)
+	}
+	// sign and recover
+	hash := sha256.Sum256([]byte("message"))
+	sig, err := s.SignBytes(hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != 65 {
+		t.Fatalf("signature has length %d", len(sig))
+	}
+	compact := append([]byte{27 + sig[64]}, sig[:64]...)
+	recovered, _, err := btcec.RecoverCompact(btcec.S256(), compact, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(recovered.SerializeUncompressed()[1:], pk.Bytes()) {
+		t.Error("recovered public key does not match")
+	}
+}
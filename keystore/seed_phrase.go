@@ -0,0 +1,80 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultSeedPhrasePath is the HD derivation path used by NEAR wallets.
+const DefaultSeedPhrasePath = "m/44'/397'/0'"
+
+const hardenedOffset = 0x80000000
+
+// FromSeedPhrase derives the Ed25519 key pair from the BIP39 mnemonic along
+// the HD derivation path (see DefaultSeedPhrasePath, used if path is empty).
+// The derivation follows SLIP-0010 and matches near-seed-phrase, so wallets
+// created in MyNearWallet or Meteor can be imported. The AccountID of the
+// returned key pair is empty.
+func FromSeedPhrase(mnemonic, path string) (*Ed25519KeyPair, error) {
+	if path == "" {
+		path = DefaultSeedPhrasePath
+	}
+	seed := mnemonicToSeed(normalizeSeedPhrase(mnemonic), "")
+	key, err := deriveEd25519Path(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return newEd25519KeyPair("", ed25519.NewKeyFromSeed(key)), nil
+}
+
+// normalizeSeedPhrase lower cases the mnemonic and collapses all whitespace.
+func normalizeSeedPhrase(mnemonic string) string {
+	return strings.ToLower(strings.Join(strings.Fields(mnemonic), " "))
+}
+
+// mnemonicToSeed converts the BIP39 mnemonic into a 64 byte seed.
+func mnemonicToSeed(mnemonic, passphrase string) []byte {
+	password := norm.NFKD.String(mnemonic)
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), 2048, 64, sha512.New)
+}
+
+// deriveEd25519Path derives the 32 byte Ed25519 seed along path from the
+// master seed according to SLIP-0010. Ed25519 only supports hardened
+// derivation, so every path segment must be hardened.
+func deriveEd25519Path(seed []byte, path string) ([]byte, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("keystore: invalid derivation path '%s'", path)
+	}
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+	for _, segment := range segments[1:] {
+		if !strings.HasSuffix(segment, "'") {
+			return nil, fmt.Errorf("keystore: derivation path '%s' contains non-hardened segment '%s'",
+				path, segment)
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: invalid derivation path segment '%s': %v", segment, err)
+		}
+		data := make([]byte, 37)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[33:], uint32(index)+hardenedOffset)
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+	return key, nil
+}
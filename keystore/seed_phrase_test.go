@@ -0,0 +1,63 @@
+package keystore
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	// BIP39 test vector
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	want := "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"
+	seed := mnemonicToSeed(mnemonic, "")
+	if hex.EncodeToString(seed) != want {
+		t.Errorf("mnemonicToSeed() returned %x (want %s)", seed, want)
+	}
+}
+
+func TestDeriveEd25519Path(t *testing.T) {
+	// SLIP-0010 test vector 1 for ed25519
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path    string
+		private string
+		public  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			"a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"},
+		{"m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			"8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c"},
+	}
+	for _, test := range tests {
+		key, err := deriveEd25519Path(seed, test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(key) != test.private {
+			t.Errorf("deriveEd25519Path(%s) returned %x (want %s)", test.path, key, test.private)
+		}
+		pub := ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey)
+		if hex.EncodeToString(pub) != test.public {
+			t.Errorf("public key for %s is %x (want %s)", test.path, pub, test.public)
+		}
+	}
+	if _, err := deriveEd25519Path(seed, "m/44'/397'/0"); err == nil {
+		t.Error("deriveEd25519Path() accepted non-hardened path")
+	}
+}
+
+func TestFromSeedPhrase(t *testing.T) {
+	kp1, err := FromSeedPhrase("  Abandon abandon abandon abandon abandon abandon\tabandon abandon abandon abandon abandon about ", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := FromSeedPhrase("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		DefaultSeedPhrasePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp1.PublicKey != kp2.PublicKey || kp1.PrivateKey != kp2.PrivateKey {
+		t.Error("normalized seed phrase derived different key pair")
+	}
+}
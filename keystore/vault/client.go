@@ -0,0 +1,147 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errNotFound is returned by Client.do for HTTP 404 responses.
+var errNotFound = errors.New("vault: not found")
+
+// Config configures the connection to a Vault server.
+type Config struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	Address string
+	// Token is used for authentication if set.
+	Token string
+	// RoleID and SecretID are used for AppRole authentication if Token is
+	// empty.
+	RoleID   string
+	SecretID string
+	// AppRoleMount is the mount path of the AppRole auth method (default
+	// "approle").
+	AppRoleMount string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+	// HTTPClient is used for all requests. If nil, a client with a 30 second
+	// timeout is used. Set a custom transport to configure TLS.
+	HTTPClient *http.Client
+}
+
+// Client is a minimal Vault HTTP API client.
+type Client struct {
+	cfg   Config
+	c     *http.Client
+	mtx   sync.Mutex
+	token string
+}
+
+// NewClient returns a new Vault client for cfg.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("vault: address not configured")
+	}
+	if cfg.Token == "" && (cfg.RoleID == "" || cfg.SecretID == "") {
+		return nil, errors.New("vault: neither token nor AppRole credentials configured")
+	}
+	if cfg.AppRoleMount == "" {
+		cfg.AppRoleMount = "approle"
+	}
+	c := &Client{cfg: cfg, c: cfg.HTTPClient, token: cfg.Token}
+	if c.c == nil {
+		c.c = &http.Client{Timeout: 30 * time.Second}
+	}
+	return c, nil
+}
+
+// authToken returns the Vault token, logging in via AppRole if necessary.
+func (c *Client) authToken(ctx context.Context) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.token != "" {
+		return c.token, nil
+	}
+	var out struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	err := c.request(ctx, "", http.MethodPost, "auth/"+c.cfg.AppRoleMount+"/login", map[string]string{
+		"role_id":   c.cfg.RoleID,
+		"secret_id": c.cfg.SecretID,
+	}, &out)
+	if err != nil {
+		return "", err
+	}
+	if out.Auth.ClientToken == "" {
+		return "", errors.New("vault: AppRole login returned no token")
+	}
+	c.token = out.Auth.ClientToken
+	return c.token, nil
+}
+
+// do performs an authenticated request for the API path.
+func (c *Client) do(ctx context.Context, method, path string, input, output interface{}) error {
+	token, err := c.authToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.request(ctx, token, method, path, input, output)
+}
+
+func (c *Client) request(ctx context.Context, token, method, path string, input, output interface{}) error {
+	var body io.Reader
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	url := strings.TrimSuffix(c.cfg.Address, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if c.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.cfg.Namespace)
+	}
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return fmt.Errorf("vault: %s %s failed with status %d: %s",
+			method, path, resp.StatusCode, strings.Join(e.Errors, "; "))
+	}
+	if output == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, output)
+}
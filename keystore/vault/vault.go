@@ -0,0 +1,217 @@
+// Package vault implements a key store backed by the HashiCorp Vault KV
+// version 2 secrets engine and a signer backed by the Vault Transit secrets
+// engine.
+package vault
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultTimeout is the timeout of the Vault requests made by KeyStore and
+// TransitSigner methods.
+const DefaultTimeout = 10 * time.Second
+
+// KeyStore is a keystore.KeyStore which stores every key pair as secret
+// <prefix>/<networkID>/<accountID> in a KV version 2 secrets engine.
+type KeyStore struct {
+	c      *Client
+	mount  string
+	prefix string
+}
+
+// NewKeyStore returns a new key store using the KV version 2 secrets engine
+// mounted at mount (e.g. "secret"). All secrets are stored below prefix.
+func NewKeyStore(c *Client, mount, prefix string) *KeyStore {
+	return &KeyStore{c: c, mount: strings.Trim(mount, "/"), prefix: strings.Trim(prefix, "/")}
+}
+
+func (ks *KeyStore) path(kind string, elems ...string) string {
+	return path.Join(append([]string{ks.mount, kind, ks.prefix}, elems...)...)
+}
+
+// Get implements keystore.KeyStore.
+func (ks *KeyStore) Get(networkID, accountID string) (*keystore.Ed25519KeyPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	var out struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	err := ks.c.do(ctx, http.MethodGet, ks.path("data", networkID, accountID), nil, &out)
+	if errors.Is(err, errNotFound) {
+		return nil, fmt.Errorf("%w: %s on %s", keystore.ErrKeyNotFound, accountID, networkID)
+	} else if err != nil {
+		return nil, err
+	}
+	return keystore.ParseKeyPair(out.Data.Data, accountID)
+}
+
+// Put implements keystore.KeyStore.
+func (ks *KeyStore) Put(networkID string, kp *keystore.Ed25519KeyPair) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	return ks.c.do(ctx, http.MethodPost, ks.path("data", networkID, kp.AccountID),
+		map[string]interface{}{"data": kp}, nil)
+}
+
+// Delete implements keystore.KeyStore. All versions of the secret are
+// destroyed.
+func (ks *KeyStore) Delete(networkID, accountID string) error {
+	if _, err := ks.Get(networkID, accountID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	return ks.c.do(ctx, http.MethodDelete, ks.path("metadata", networkID, accountID), nil, nil)
+}
+
+func (ks *KeyStore) list(elems ...string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	var out struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+	err := ks.c.do(ctx, "LIST", ks.path("metadata", elems...), nil, &out)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	sort.Strings(out.Data.Keys)
+	return out.Data.Keys, nil
+}
+
+// ListAccounts implements keystore.KeyStore.
+func (ks *KeyStore) ListAccounts(networkID string) ([]string, error) {
+	keys, err := ks.list(networkID)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []string
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "/") {
+			accounts = append(accounts, key)
+		}
+	}
+	return accounts, nil
+}
+
+// ListNetworks implements keystore.KeyStore.
+func (ks *KeyStore) ListNetworks() ([]string, error) {
+	keys, err := ks.list()
+	if err != nil {
+		return nil, err
+	}
+	var networks []string
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") {
+			networks = append(networks, strings.TrimSuffix(key, "/"))
+		}
+	}
+	return networks, nil
+}
+
+// TransitSigner is a signer.Signer which delegates Ed25519 signing to an
+// ed25519 key of the Vault Transit secrets engine. The private key never
+// leaves Vault.
+type TransitSigner struct {
+	c         *Client
+	mount     string
+	keyName   string
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewTransitSigner returns a new signer for accountID using the latest
+// version of the Transit key keyName of the engine mounted at mount (e.g.
+// "transit"). The public key is retrieved from Vault.
+func NewTransitSigner(ctx context.Context, c *Client, mount, keyName, accountID string) (*TransitSigner, error) {
+	mount = strings.Trim(mount, "/")
+	var out struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := c.do(ctx, http.MethodGet, path.Join(mount, "keys", keyName), nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Data.Type != "ed25519" {
+		return nil, fmt.Errorf("vault: transit key %s has type %s, not ed25519", keyName, out.Data.Type)
+	}
+	key, ok := out.Data.Keys[strconv.Itoa(out.Data.LatestVersion)]
+	if !ok {
+		return nil, fmt.Errorf("vault: transit key %s has no version %d", keyName, out.Data.LatestVersion)
+	}
+	pub, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("vault: invalid public key of transit key %s", keyName)
+	}
+	return &TransitSigner{
+		c:         c,
+		mount:     mount,
+		keyName:   keyName,
+		accountID: accountID,
+		pubKey:    utils.PublicKeyFromEd25519(pub),
+	}, nil
+}
+
+// SignBytes implements signer.Signer.
+func (s *TransitSigner) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	var out struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	err := s.c.do(ctx, http.MethodPost, path.Join(s.mount, "sign", s.keyName), map[string]string{
+		"input": base64.StdEncoding.EncodeToString(msg),
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	// the signature has the format vault:v<version>:<base64>
+	parts := strings.SplitN(out.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("vault: unexpected signature format '%s'", out.Data.Signature)
+	}
+	sig, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("vault: transit returned signature of invalid length %d", len(sig))
+	}
+	return sig, nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *TransitSigner) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *TransitSigner) AccountID() string {
+	return s.accountID
+}
@@ -0,0 +1,196 @@
+package vault
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+)
+
+// newVaultServer returns a fake Vault server supporting AppRole login, the KV
+// version 2 engine at "secret" and an ed25519 Transit key "near".
+func newVaultServer(t *testing.T, priv ed25519.PrivateKey) *httptest.Server {
+	var mtx sync.Mutex
+	secrets := make(map[string]json.RawMessage)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		p := strings.TrimPrefix(r.URL.Path, "/v1/")
+		if p == "auth/approle/login" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"auth": map[string]string{"client_token": "token"},
+			})
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case strings.HasPrefix(p, "secret/data/"):
+			name := strings.TrimPrefix(p, "secret/data/")
+			switch r.Method {
+			case http.MethodPost:
+				var in struct {
+					Data json.RawMessage `json:"data"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+					t.Error(err)
+				}
+				secrets[name] = in.Data
+			case http.MethodGet:
+				data, ok := secrets[name]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{"data": data},
+				})
+			}
+		case strings.HasPrefix(p, "secret/metadata/"):
+			prefix := strings.TrimPrefix(p, "secret/metadata/")
+			if r.Method == http.MethodDelete {
+				delete(secrets, prefix)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			seen := make(map[string]bool)
+			var keys []string
+			for name := range secrets {
+				if !strings.HasPrefix(name, prefix+"/") {
+					continue
+				}
+				rest := strings.TrimPrefix(name, prefix+"/")
+				if i := strings.Index(rest, "/"); i >= 0 {
+					rest = rest[:i+1]
+				}
+				if !seen[rest] {
+					seen[rest] = true
+					keys = append(keys, rest)
+				}
+			}
+			if len(keys) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			sort.Strings(keys)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"keys": keys},
+			})
+		case p == "transit/keys/near":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"type":           "ed25519",
+					"latest_version": 1,
+					"keys": map[string]interface{}{
+						"1": map[string]string{
+							"public_key": base64.StdEncoding.EncodeToString(priv.Public().(ed25519.PublicKey)),
+						},
+					},
+				},
+			})
+		case p == "transit/sign/near":
+			var in struct {
+				Input string `json:"input"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+			msg, _ := base64.StdEncoding.DecodeString(in.Input)
+			sig := base64.StdEncoding.EncodeToString(ed25519.Sign(priv, msg))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]string{"signature": "vault:v1:" + sig},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestKeyStore(t *testing.T) {
+	srv := newVaultServer(t, nil)
+	defer srv.Close()
+	c, err := NewClient(Config{Address: srv.URL, RoleID: "role", SecretID: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ks keystore.KeyStore = NewKeyStore(c, "secret", "near")
+	accountID := "test-account.testnet"
+	if _, err := ks.Get("testnet", accountID); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Fatalf("Get() of missing key returned %v (want ErrKeyNotFound)", err)
+	}
+	kp1, err := keystore.GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp1); err != nil {
+		t.Fatal(err)
+	}
+	kp2, err := ks.Get("testnet", accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kp1, kp2) {
+		t.Fatal("kp1 != kp2")
+	}
+	networks, err := ks.ListNetworks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(networks, []string{"testnet"}) {
+		t.Errorf("ListNetworks() returned %v", networks)
+	}
+	accounts, err := ks.ListAccounts("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{accountID}) {
+		t.Errorf("ListAccounts() returned %v", accounts)
+	}
+	if err := ks.Delete("testnet", accountID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Get("testnet", accountID); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("Get() of deleted key returned %v (want ErrKeyNotFound)", err)
+	}
+}
+
+func TestTransitSigner(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newVaultServer(t, priv)
+	defer srv.Close()
+	c, err := NewClient(Config{Address: srv.URL, Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewTransitSigner(context.Background(), c, "transit", "near", "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(s.PublicKey().Bytes()) != string(pub) {
+		t.Error("public key does not match")
+	}
+	msg := []byte("message")
+	sig, err := s.SignBytes(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		t.Error("signature does not verify")
+	}
+}
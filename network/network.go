@@ -0,0 +1,162 @@
+// Package network provides Near, a set of configured NEAR networks handing
+// out RPC clients and accounts per network, for tools working with several
+// networks at once like deploy pipelines bridging testnet and mainnet:
+//
+//	n, err := network.FromPresets("testnet", "mainnet")
+//	...
+//	staging, err := n.Account("testnet", "app.testnet")
+//	production, err := n.Account("mainnet", "app.near")
+package network
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/YuxSccc/near-api-go/account"
+	"github.com/YuxSccc/near-api-go/config"
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// Profile is the configuration of a network of a Near.
+type Profile struct {
+	// Network is the network configuration, its NetworkID identifies the
+	// profile and selects the keys in the key store.
+	Network config.Network
+	// ClientOptions configure the RPC client of the network. If
+	// ClientOptions.Archival is empty, Network.ArchivalRPCURL is used.
+	ClientOptions rpc.Options
+	// KeyStore holds the keys of the accounts of the network. If nil, the
+	// default file system key store (~/.near-credentials) is used.
+	KeyStore keystore.KeyStore
+	// AccountOptions configure the accounts of the network.
+	AccountOptions account.Options
+}
+
+type profile struct {
+	Profile
+	client *rpc.Client
+}
+
+// Near holds the configured networks. It is safe for concurrent use.
+type Near struct {
+	mtx      sync.RWMutex
+	profiles map[string]*profile
+}
+
+// New returns a Near with the given network profiles.
+func New(profiles ...Profile) (*Near, error) {
+	n := &Near{profiles: make(map[string]*profile)}
+	for _, p := range profiles {
+		if err := n.Add(p); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// FromPresets returns a Near with the presets of the networks names, see
+// config.Get, using the default file system key store.
+func FromPresets(names ...string) (*Near, error) {
+	profiles := make([]Profile, len(names))
+	for i, name := range names {
+		cfg, err := config.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles[i] = Profile{Network: cfg}
+	}
+	return New(profiles...)
+}
+
+// Add adds the network profile p. It fails if a network with the same
+// NetworkID exists already.
+func (n *Near) Add(p Profile) error {
+	id := p.Network.NetworkID
+	if id == "" || p.Network.RPCURL == "" {
+		return fmt.Errorf("network: profile %+v lacks network ID or RPC URL", p.Network)
+	}
+	if p.KeyStore == nil {
+		ks, err := keystore.NewDefaultFileSystemKeyStore()
+		if err != nil {
+			return err
+		}
+		p.KeyStore = ks
+	}
+	opts := p.ClientOptions
+	if opts.Archival == "" {
+		opts.Archival = p.Network.ArchivalRPCURL
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if _, ok := n.profiles[id]; ok {
+		return fmt.Errorf("network: network %s configured twice", id)
+	}
+	n.profiles[id] = &profile{Profile: p, client: rpc.NewClientWithOptions(p.Network.RPCURL, opts)}
+	return nil
+}
+
+func (n *Near) profile(networkID string) (*profile, error) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	p, ok := n.profiles[networkID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not configured", config.ErrUnknownNetwork, networkID)
+	}
+	return p, nil
+}
+
+// Networks returns the sorted IDs of the configured networks.
+func (n *Near) Networks() []string {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	ids := make([]string, 0, len(n.profiles))
+	for id := range n.profiles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Network returns the configuration of the network networkID.
+func (n *Near) Network(networkID string) (config.Network, error) {
+	p, err := n.profile(networkID)
+	if err != nil {
+		return config.Network{}, err
+	}
+	return p.Network, nil
+}
+
+// Client returns the RPC client of the network networkID. All calls for a
+// network return the same client.
+func (n *Near) Client(networkID string) (*rpc.Client, error) {
+	p, err := n.profile(networkID)
+	if err != nil {
+		return nil, err
+	}
+	return p.client, nil
+}
+
+// KeyStore returns the key store of the network networkID.
+func (n *Near) KeyStore(networkID string) (keystore.KeyStore, error) {
+	p, err := n.profile(networkID)
+	if err != nil {
+		return nil, err
+	}
+	return p.KeyStore, nil
+}
+
+// Account returns the account accountID on networkID, signing with its key
+// in the key store of the network.
+func (n *Near) Account(networkID, accountID string) (*account.Account, error) {
+	p, err := n.profile(networkID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := keystore.LoadSigner(p.KeyStore, networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return account.NewWithOptions(p.client, s, p.AccountOptions), nil
+}
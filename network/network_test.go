@@ -0,0 +1,84 @@
+package network
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/config"
+	"github.com/YuxSccc/near-api-go/keystore"
+)
+
+func TestNear(t *testing.T) {
+	testnetKeys, mainnetKeys := keystore.NewInMemoryKeyStore(), keystore.NewInMemoryKeyStore()
+	kp, err := keystore.GenerateEd25519KeyPair("app.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testnetKeys.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	n, err := New(
+		Profile{Network: config.Testnet, KeyStore: testnetKeys},
+		Profile{Network: config.Mainnet, KeyStore: mainnetKeys},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := n.Networks(); !reflect.DeepEqual(ids, []string{"mainnet", "testnet"}) {
+		t.Errorf("Networks() returned %v", ids)
+	}
+	if cfg, err := n.Network("mainnet"); err != nil || cfg != config.Mainnet {
+		t.Errorf("Network() returned %+v, %v", cfg, err)
+	}
+	c1, err := n.Client("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c2, _ := n.Client("testnet"); c1 != c2 {
+		t.Error("Client() returned different clients for the same network")
+	}
+	if c3, _ := n.Client("mainnet"); c1 == c3 {
+		t.Error("Client() returned the same client for different networks")
+	}
+	if ks, err := n.KeyStore("mainnet"); err != nil || ks != mainnetKeys {
+		t.Errorf("KeyStore() returned %v, %v", ks, err)
+	}
+
+	a, err := n.Account("testnet", "app.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID() != "app.testnet" || a.PublicKey().String() != kp.PublicKey || a.Client() != c1 {
+		t.Errorf("Account() returned %s with key %s", a.ID(), a.PublicKey())
+	}
+	if _, err := n.Account("mainnet", "app.testnet"); !errors.Is(err, keystore.ErrKeyNotFound) {
+		t.Errorf("Account() on mainnet returned %v (want ErrKeyNotFound)", err)
+	}
+	if _, err := n.Client("betanet"); !errors.Is(err, config.ErrUnknownNetwork) {
+		t.Errorf("Client() of unconfigured network returned %v (want ErrUnknownNetwork)", err)
+	}
+	if err := n.Add(Profile{Network: config.Testnet, KeyStore: testnetKeys}); err == nil {
+		t.Error("Add() accepted network twice")
+	}
+	if err := n.Add(Profile{Network: config.Network{NetworkID: "custom"}}); err == nil {
+		t.Error("Add() accepted network without RPC URL")
+	}
+}
+
+func TestFromPresets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	n, err := FromPresets("mainnet", "development")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := n.Networks(); !reflect.DeepEqual(ids, []string{"mainnet", "testnet"}) {
+		t.Errorf("Networks() returned %v", ids)
+	}
+	if _, err := FromPresets("testnet", "default"); err == nil {
+		t.Error("FromPresets() accepted network twice")
+	}
+	if _, err := FromPresets("moon"); !errors.Is(err, config.ErrUnknownNetwork) {
+		t.Errorf("FromPresets() returned %v (want ErrUnknownNetwork)", err)
+	}
+}
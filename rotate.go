@@ -0,0 +1,133 @@
+package near
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+)
+
+// RotateKey replaces the access key used by account a with a newly generated
+// Ed25519 key with the same permission and returns the new key pair.
+//
+// The new key is added and the old key deleted in a single transaction,
+// which is executed atomically: if it fails the account keeps its old key
+// and nothing is stored. Only after the transaction succeeded the new key
+// pair is stored in ks for networkID, replacing the old key pair, and a
+// switches to the new key. If storing the new key pair fails, the rotation
+// is rolled back on chain by restoring the old key with a transaction signed
+// by the new key. If even the rollback fails, the new key pair is returned
+// together with the error and must be saved by the caller, since it is the
+// only remaining access to the account.
+func RotateKey(a *Account, ks keystore.KeyStore, networkID string) (*keystore.Ed25519KeyPair, error) {
+	accountID := a.signer.AccountID()
+	oldKey, ak, err := a.findAccessKey()
+	if err != nil {
+		return nil, err
+	}
+	permission, err := accessKeyPermission(ak)
+	if err != nil {
+		return nil, err
+	}
+	kp, err := keystore.GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		return nil, err
+	}
+	newSigner, err := kp.Signer()
+	if err != nil {
+		return nil, err
+	}
+	newKey := newSigner.PublicKey()
+
+	res, err := a.SignAndSendTransaction(accountID, []Action{
+		{
+			Enum: 5,
+			AddKey: AddKey{
+				PublicKey: newKey,
+				AccessKey: AccessKey{Permission: permission},
+			},
+		},
+		{
+			Enum: 6,
+			DeleteKey: DeleteKey{
+				PublicKey: oldKey,
+			},
+		},
+	})
+	if err != nil {
+		// the outcome is unknown, check whether the new key was added
+		if _, viewErr := a.conn.ViewAccessKey(accountID, newKey.String()); viewErr != nil {
+			return nil, err
+		}
+	} else if _, err := GetTransactionLastResult(res); err != nil {
+		return nil, fmt.Errorf("near: key rotation of %s failed: %w", accountID, err)
+	}
+
+	// the new key is active on chain, persist it
+	if err := ks.Put(networkID, kp); err != nil {
+		rollback := NewAccountWithSigner(a.conn, newSigner)
+		res, rbErr := rollback.SignAndSendTransaction(accountID, []Action{
+			{
+				Enum: 5,
+				AddKey: AddKey{
+					PublicKey: oldKey,
+					AccessKey: AccessKey{Permission: permission},
+				},
+			},
+			{
+				Enum: 6,
+				DeleteKey: DeleteKey{
+					PublicKey: newKey,
+				},
+			},
+		})
+		if rbErr == nil {
+			_, rbErr = GetTransactionLastResult(res)
+		}
+		if rbErr != nil {
+			return kp, fmt.Errorf("near: storing rotated key of %s failed: %v; rollback failed: %w", accountID, err, rbErr)
+		}
+		return nil, fmt.Errorf("near: storing rotated key of %s failed, rotation rolled back: %w", accountID, err)
+	}
+	a.signer = newSigner
+	delete(a.accessKeyByPublicKeyCache, oldKey.String())
+	return kp, nil
+}
+
+// accessKeyPermission decodes the permission of the access key ak as
+// returned by the view_access_key query.
+func accessKeyPermission(ak map[string]interface{}) (AccessKeyPermission, error) {
+	switch p := ak["permission"].(type) {
+	case string:
+		if p == "FullAccess" {
+			return fullAccessKey().Permission, nil
+		}
+	case map[string]interface{}:
+		fc, ok := p["FunctionCall"].(map[string]interface{})
+		if !ok {
+			break
+		}
+		var perm FunctionCallPermission
+		if allowance, ok := fc["allowance"].(string); ok {
+			perm.Allowance, ok = new(big.Int).SetString(allowance, 10)
+			if !ok {
+				return AccessKeyPermission{}, fmt.Errorf("near: invalid allowance '%s'", allowance)
+			}
+		}
+		perm.ReceiverId, _ = fc["receiver_id"].(string)
+		methodNames, _ := fc["method_names"].([]interface{})
+		for _, m := range methodNames {
+			name, ok := m.(string)
+			if !ok {
+				return AccessKeyPermission{}, errors.New("near: invalid method name in access key permission")
+			}
+			perm.MethodNames = append(perm.MethodNames, name)
+		}
+		if perm.MethodNames == nil {
+			perm.MethodNames = []string{}
+		}
+		return AccessKeyPermission{Enum: 0, FunctionCall: perm}, nil
+	}
+	return AccessKeyPermission{}, fmt.Errorf("near: unknown access key permission %v", ak["permission"])
+}
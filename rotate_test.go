@@ -0,0 +1,223 @@
+package near
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/near/borsh-go"
+)
+
+// borsh-go deserializes non-struct enum variants like AccessKeyPermission's
+// FullAccess as one byte, although they are serialized without data. The fake
+// node therefore decodes transactions into these mirror types which use empty
+// structs instead.
+type testSignedTransaction struct {
+	Transaction testTransaction
+	Signature   Signature
+}
+
+type testTransaction struct {
+	SignerID   string
+	PublicKey  utils.PublicKey
+	Nonce      uint64
+	ReceiverID string
+	BlockHash  [32]byte
+	Actions    []testAction
+}
+
+type testAction struct {
+	Enum           borsh.Enum `borsh_enum:"true"`
+	CreateAccount  struct{}
+	DeployContract DeployContract
+	FunctionCall   FunctionCall
+	Transfer       Transfer
+	Stake          Stake
+	AddKey         struct {
+		PublicKey utils.PublicKey
+		AccessKey struct {
+			Nonce      uint64
+			Permission struct {
+				Enum         borsh.Enum `borsh_enum:"true"`
+				FunctionCall FunctionCallPermission
+				FullAccess   struct{}
+			}
+		}
+	}
+	DeleteKey     DeleteKey
+	DeleteAccount DeleteAccount
+}
+
+// fakeNode is a minimal JSON-RPC node which keeps the access keys of accounts
+// and executes AddKey and DeleteKey actions.
+type fakeNode struct {
+	mtx  sync.Mutex
+	keys map[string]bool // <accountID>/<publicKey>
+	fail bool
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     int             `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := n.handle(req.Method, req.Params)
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if err != nil {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (n *fakeNode) handle(method string, params json.RawMessage) (interface{}, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	switch method {
+	case "block":
+		return map[string]interface{}{
+			"header": map[string]interface{}{"hash": base58.Encode(make([]byte, 32))},
+		}, nil
+	case "query":
+		var q map[string]string
+		if err := json.Unmarshal(params, &q); err != nil {
+			return nil, err
+		}
+		if !n.keys[q["account_id"]+"/"+q["public_key"]] {
+			return nil, errors.New("access key does not exist")
+		}
+		return map[string]interface{}{"nonce": 1, "permission": "FullAccess"}, nil
+	case "broadcast_tx_commit":
+		var args []string
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		buf, err := base64.StdEncoding.DecodeString(args[0])
+		if err != nil {
+			return nil, err
+		}
+		var stx testSignedTransaction
+		if err := borsh.Deserialize(&stx, buf); err != nil {
+			return nil, err
+		}
+		tx := stx.Transaction
+		if !n.keys[tx.SignerID+"/"+tx.PublicKey.String()] {
+			return nil, errors.New("invalid access key")
+		}
+		if n.fail {
+			return map[string]interface{}{
+				"status": map[string]interface{}{"Failure": map[string]interface{}{"ActionError": "test"}},
+			}, nil
+		}
+		for _, action := range tx.Actions {
+			switch action.Enum {
+			case 5:
+				n.keys[tx.ReceiverID+"/"+action.AddKey.PublicKey.String()] = true
+			case 6:
+				delete(n.keys, tx.ReceiverID+"/"+action.DeleteKey.PublicKey.String())
+			}
+		}
+		return map[string]interface{}{"status": map[string]interface{}{"SuccessValue": ""}}, nil
+	}
+	return nil, errors.New("unknown method " + method)
+}
+
+func (n *fakeNode) hasKey(accountID, publicKey string) bool {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	return n.keys[accountID+"/"+publicKey]
+}
+
+func setupRotateKey(t *testing.T) (*fakeNode, *Account, *keystore.FileSystemKeyStore, *keystore.Ed25519KeyPair) {
+	tmpdir, err := ioutil.TempDir("", "near_test")
+	if err != nil {
+		t.Fatalf("os.TempDir() failed: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpdir) })
+	accountID := "test-account.testnet"
+	kp, err := keystore.GenerateEd25519KeyPair(accountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks := keystore.NewFileSystemKeyStore(tmpdir)
+	if err := ks.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	node := &fakeNode{keys: map[string]bool{accountID + "/" + kp.PublicKey: true}}
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+	s, err := kp.Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node, NewAccountWithSigner(NewConnection(srv.URL), s), ks, kp
+}
+
+func TestRotateKey(t *testing.T) {
+	node, a, ks, oldKP := setupRotateKey(t)
+	kp, err := RotateKey(a, ks, "testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.hasKey(kp.AccountID, oldKP.PublicKey) || !node.hasKey(kp.AccountID, kp.PublicKey) {
+		t.Error("RotateKey() did not replace access key on chain")
+	}
+	stored, err := ks.Get("testnet", kp.AccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PublicKey != kp.PublicKey {
+		t.Errorf("key store contains %s (want %s)", stored.PublicKey, kp.PublicKey)
+	}
+	if a.signer.PublicKey().String() != kp.PublicKey {
+		t.Error("RotateKey() did not switch account to new key")
+	}
+}
+
+func TestRotateKeyFailure(t *testing.T) {
+	node, a, ks, oldKP := setupRotateKey(t)
+	node.fail = true
+	if _, err := RotateKey(a, ks, "testnet"); err == nil {
+		t.Fatal("RotateKey() succeeded with failing transaction")
+	}
+	stored, err := ks.Get("testnet", oldKP.AccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PublicKey != oldKP.PublicKey {
+		t.Error("RotateKey() stored new key of failed transaction")
+	}
+}
+
+func TestRotateKeyRollback(t *testing.T) {
+	node, a, ks, oldKP := setupRotateKey(t)
+	ks.SetOverwrite(false)
+	kp, err := RotateKey(a, ks, "testnet")
+	if !errors.Is(err, keystore.ErrKeyExists) {
+		t.Fatalf("RotateKey() returned %v (want ErrKeyExists)", err)
+	}
+	if kp != nil {
+		t.Error("RotateKey() returned new key pair after rollback")
+	}
+	if !node.hasKey(oldKP.AccountID, oldKP.PublicKey) {
+		t.Error("RotateKey() did not restore old access key")
+	}
+	if a.signer.PublicKey().String() != oldKP.PublicKey {
+		t.Error("RotateKey() switched account to new key")
+	}
+}
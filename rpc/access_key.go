@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+)
+
+// AccessKeyResult is the result of ViewAccessKey.
+type AccessKeyResult struct {
+	QueryResponse
+	AccessKeyView
+}
+
+// AccessKeyInfoView is an access key with its public key.
+type AccessKeyInfoView struct {
+	PublicKey string        `json:"public_key"`
+	AccessKey AccessKeyView `json:"access_key"`
+}
+
+// AccessKeyList is the result of ViewAccessKeyList.
+type AccessKeyList struct {
+	QueryResponse
+	Keys []AccessKeyInfoView `json:"keys"`
+}
+
+// ViewAccessKey returns the access key of accountID with the public key in
+// "<key type>:<base58>" format at block. If the key does not exist an error
+// matching ErrUnknownAccessKey is returned.
+//
+// For details see https://docs.near.org/api/rpc/access-keys#view-access-key
+func (c *Client) ViewAccessKey(ctx context.Context, accountID, publicKey string, block BlockReference) (*AccessKeyResult, error) {
+	var res AccessKeyResult
+	if err := c.Query(ctx, "view_access_key", block, map[string]interface{}{
+		"account_id": accountID,
+		"public_key": publicKey,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ViewAccessKeyList returns all access keys of accountID at block.
+//
+// For details see https://docs.near.org/api/rpc/access-keys#view-access-key-list
+func (c *Client) ViewAccessKeyList(ctx context.Context, accountID string, block BlockReference) (*AccessKeyList, error) {
+	var res AccessKeyList
+	if err := c.Query(ctx, "view_access_key_list", block, map[string]interface{}{
+		"account_id": accountID,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestViewAccessKey(t *testing.T) {
+	const fullAccessKey = "ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e"
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		switch p["request_type"] {
+		case "view_access_key":
+			if p["public_key"] != fullAccessKey {
+				return nil, &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "UNKNOWN_ACCESS_KEY"}}
+			}
+			return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,"nonce":85,"permission":"FullAccess"}`), nil
+		case "view_access_key_list":
+			return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,"keys":[
+				{"public_key":"` + fullAccessKey + `","access_key":{"nonce":85,"permission":"FullAccess"}},
+				{"public_key":"ed25519:BGCCDDHfysuuVnaNVtEhhqeT4k9Muyem3Kpgq2U1m9HX","access_key":{"nonce":3,
+				 "permission":{"FunctionCall":{"allowance":"250000000000000000000000","receiver_id":"app.testnet","method_names":[]}}}}]}`), nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	ak, err := c.ViewAccessKey(ctx, "alice.testnet", fullAccessKey, Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ak.Nonce != 85 || !ak.Permission.FullAccess() || ak.BlockHeight != 42 {
+		t.Errorf("ViewAccessKey() returned %+v", ak)
+	}
+	if _, err := c.ViewAccessKey(ctx, "alice.testnet", "ed25519:BGCCDDHfysuuVnaNVtEhhqeT4k9Muyem3Kpgq2U1m9HX", Final()); !errors.Is(err, ErrUnknownAccessKey) {
+		t.Errorf("ViewAccessKey() returned %v (want ErrUnknownAccessKey)", err)
+	}
+	list, err := c.ViewAccessKeyList(ctx, "alice.testnet", Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Keys) != 2 || !list.Keys[0].AccessKey.Permission.FullAccess() {
+		t.Fatalf("ViewAccessKeyList() returned %+v", list)
+	}
+	fc := list.Keys[1].AccessKey.Permission.FunctionCall
+	if fc == nil || fc.Allowance.String() != "250000000000000000000000" || fc.ReceiverID != "app.testnet" || len(fc.MethodNames) != 0 {
+		t.Errorf("ViewAccessKeyList() returned permission %+v", fc)
+	}
+}
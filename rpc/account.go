@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// emptyCodeHash is the code hash of accounts without contract.
+const emptyCodeHash = "11111111111111111111111111111111"
+
+// AccountView is the result of ViewAccount.
+type AccountView struct {
+	QueryResponse
+	// Amount is the liquid balance.
+	Amount types.Balance `json:"amount"`
+	// Locked is the balance locked for staking.
+	Locked types.Balance `json:"locked"`
+	// CodeHash is the base58 encoded sha256 hash of the contract code.
+	CodeHash string `json:"code_hash"`
+	// StorageUsage is the storage used by the account in bytes.
+	StorageUsage  uint64 `json:"storage_usage"`
+	StoragePaidAt uint64 `json:"storage_paid_at"`
+}
+
+// HasContract reports whether a contract is deployed to the account.
+func (a *AccountView) HasContract() bool {
+	return a.CodeHash != "" && a.CodeHash != emptyCodeHash
+}
+
+// ViewAccount returns the account accountID at block. If the account does not
+// exist an error matching ErrUnknownAccount is returned.
+//
+// For details see https://docs.near.org/api/rpc/contracts#view-account
+func (c *Client) ViewAccount(ctx context.Context, accountID string, block BlockReference) (*AccountView, error) {
+	var res AccountView
+	if err := c.Query(ctx, "view_account", block, map[string]interface{}{
+		"account_id": accountID,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AccountExists reports whether the account accountID exists at block. An
+// error is only returned if existence could not be determined, for example
+// because of transport failures.
+func (c *Client) AccountExists(ctx context.Context, accountID string, block BlockReference) (bool, error) {
+	_, err := c.ViewAccount(ctx, accountID, block)
+	if errors.Is(err, ErrUnknownAccount) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestViewAccount(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		if method != "query" || p["request_type"] != "view_account" {
+			t.Errorf("unexpected request %s %v", method, p)
+		}
+		if p["account_id"] != "alice.testnet" {
+			return nil, &Error{
+				Code:    -32000,
+				Message: "Server error",
+				Data:    json.RawMessage(`"account bob.testnet does not exist while viewing"`),
+				Name:    "HANDLER_ERROR",
+				Cause: &ErrorCause{
+					Name: "UNKNOWN_ACCOUNT",
+					Info: json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,"requested_account_id":"bob.testnet"}`),
+				},
+			}
+		}
+		return json.RawMessage(`{"amount":"399992611103597728750000000","block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR",
+			"block_height":42,"code_hash":"11111111111111111111111111111111","locked":"0","storage_paid_at":0,"storage_usage":642}`), nil
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	account, err := c.ViewAccount(ctx, "alice.testnet", Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Amount.String() != "399992611103597728750000000" || account.StorageUsage != 642 || account.HasContract() || account.BlockHeight != 42 {
+		t.Errorf("ViewAccount() returned %+v", account)
+	}
+	if _, err := c.ViewAccount(ctx, "bob.testnet", Final()); !errors.Is(err, ErrUnknownAccount) {
+		t.Errorf("ViewAccount() returned %v (want ErrUnknownAccount)", err)
+	}
+	for accountID, want := range map[string]bool{"alice.testnet": true, "bob.testnet": false} {
+		exists, err := c.AccountExists(ctx, accountID, Final())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != want {
+			t.Errorf("AccountExists(%s) returned %v", accountID, exists)
+		}
+	}
+	// transport failures are returned
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if _, err := NewClient(failing.URL).AccountExists(ctx, "alice.testnet", Final()); err == nil {
+		t.Error("AccountExists() did not return transport failure")
+	}
+}
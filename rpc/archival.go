@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// archivalMiss reports whether the call with params failed with err because
+// the data was garbage collected by a regular node. Unknown blocks are
+// blocks which were not produced yet on optimistic queries, so these are not
+// repeated against the archival endpoint.
+func archivalMiss(params interface{}, err error) bool {
+	switch {
+	case errors.Is(err, ErrGarbageCollectedBlock), errors.Is(err, ErrUnknownChunk):
+		return true
+	case errors.Is(err, ErrUnknownBlock):
+		return !isOptimistic(params)
+	}
+	return false
+}
+
+// blockHeight returns the block height the call with params refers to.
+func blockHeight(params interface{}) (uint64, bool) {
+	p, ok := params.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	h, ok := p["block_id"].(uint64)
+	return h, ok
+}
+
+// collected reports whether the call with params refers to a block height
+// known to be garbage collected by the regular endpoints. Such calls are
+// sent to the archival endpoint right away.
+func (c *Client) collected(params interface{}) bool {
+	// zero means no block is known to be garbage collected
+	collected := atomic.LoadUint64(&c.collectedHeight)
+	h, ok := blockHeight(params)
+	return ok && collected != 0 && h <= collected
+}
+
+// markCollected records the block height of the call with params as garbage
+// collected if err reports so. Blocks below are garbage collected as well.
+func (c *Client) markCollected(params interface{}, err error) {
+	h, ok := blockHeight(params)
+	if !ok || !errors.Is(err, ErrGarbageCollectedBlock) {
+		return
+	}
+	for {
+		old := atomic.LoadUint64(&c.collectedHeight)
+		if h <= old || atomic.CompareAndSwapUint64(&c.collectedHeight, old, h) {
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestArchivalFallback(t *testing.T) {
+	var regularCalls, archivalCalls int32
+	regular := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		atomic.AddInt32(&regularCalls, 1)
+		var p map[string]interface{}
+		_ = json.Unmarshal(params, &p)
+		if p["finality"] != nil {
+			return nil, unknownBlock()
+		}
+		return nil, &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "GARBAGE_COLLECTED_BLOCK"}}
+	})
+	defer regular.Close()
+	archival := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		atomic.AddInt32(&archivalCalls, 1)
+		return map[string]interface{}{"author": "node0", "header": map[string]interface{}{"height": 42}}, nil
+	})
+	defer archival.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(regular.URL, Options{Archival: archival.URL})
+	b, err := c.Block(ctx, AtHeight(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Header.Height != 42 || regularCalls != 1 || archivalCalls != 1 {
+		t.Errorf("Block() returned height %d after %d regular and %d archival calls", b.Header.Height, regularCalls, archivalCalls)
+	}
+	// unknown optimistic blocks are not historical
+	if _, err := c.Block(ctx, Optimistic()); !errors.Is(err, ErrUnknownBlock) {
+		t.Errorf("Block(optimistic) returned %v (want ErrUnknownBlock)", err)
+	}
+	if archivalCalls != 1 {
+		t.Error("Block(optimistic) fell back to archival endpoint")
+	}
+	// without archival endpoint the error is returned
+	if _, err := NewClient(regular.URL).Block(ctx, AtHeight(42)); !errors.Is(err, ErrGarbageCollectedBlock) {
+		t.Errorf("Block() returned %v (want ErrGarbageCollectedBlock)", err)
+	}
+}
+
+func TestArchivalRouting(t *testing.T) {
+	var regularCalls, archivalCalls int32
+	regular := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		atomic.AddInt32(&regularCalls, 1)
+		return nil, &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "GARBAGE_COLLECTED_BLOCK"}}
+	})
+	defer regular.Close()
+	archival := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		atomic.AddInt32(&archivalCalls, 1)
+		return map[string]interface{}{"author": "node0", "header": map[string]interface{}{"height": 42}}, nil
+	})
+	defer archival.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(regular.URL, Options{Archival: archival.URL})
+	for _, height := range []uint64{42, 42, 41} {
+		if _, err := c.Block(ctx, AtHeight(height)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if regularCalls != 1 || archivalCalls != 3 {
+		t.Errorf("Block() made %d regular and %d archival calls (want 1 and 3)", regularCalls, archivalCalls)
+	}
+	// higher blocks are tried on the regular endpoints first
+	if _, err := c.Block(ctx, AtHeight(43)); err != nil {
+		t.Fatal(err)
+	}
+	if regularCalls != 2 || archivalCalls != 4 {
+		t.Errorf("Block(43) made %d regular and %d archival calls (want 2 and 4)", regularCalls, archivalCalls)
+	}
+}
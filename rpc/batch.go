@@ -0,0 +1,177 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// batchMethod is the method name used for batches in errors and retry
+// policies.
+const batchMethod = "batch"
+
+// BatchCall is a call of a Batch. Its result is decoded and Err is set once
+// the batch was sent.
+type BatchCall struct {
+	Method string
+	Params interface{}
+	Result interface{}
+	// Err is the error of the call, errors returned by the node are of type
+	// *Error.
+	Err error
+
+	id     uint64
+	decode func(raw json.RawMessage) error
+}
+
+// Batch is a builder for a JSON-RPC batch, which sends several calls in a
+// single HTTP request. It is not safe for concurrent use.
+type Batch struct {
+	c     *Client
+	calls []*BatchCall
+}
+
+// Batch returns a new empty batch of c.
+func (c *Client) Batch() *Batch {
+	return &Batch{c: c}
+}
+
+// Len returns the number of calls in b.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Calls returns the calls in b in the order they were added.
+func (b *Batch) Calls() []*BatchCall {
+	return b.calls
+}
+
+// add adds the call of method with params to b. The result is decoded with
+// decode, or into result if decode is nil.
+func (b *Batch) add(method string, params, result interface{}, decode func(json.RawMessage) error) *BatchCall {
+	if params == nil {
+		params = []interface{}{}
+	}
+	call := &BatchCall{
+		Method: method,
+		Params: params,
+		Result: result,
+		id:     atomic.AddUint64(&b.c.id, 1),
+		decode: decode,
+	}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Call adds the call of the JSON-RPC method with params to b, the result is
+// decoded into result (which can be nil to discard it).
+func (b *Batch) Call(method string, params, result interface{}) *BatchCall {
+	return b.add(method, params, result, nil)
+}
+
+// Query adds the query with the given request type and params against block
+// to b, see Client.Query.
+func (b *Batch) Query(requestType string, block BlockReference, params map[string]interface{}, result interface{}) *BatchCall {
+	return b.add("query", queryParams(requestType, block, params), result, func(raw json.RawMessage) error {
+		return decodeQueryResult(requestType, raw, result)
+	})
+}
+
+// ViewAccount adds the view of the account accountID at block to b, see
+// Client.ViewAccount.
+func (b *Batch) ViewAccount(accountID string, block BlockReference, result *AccountView) *BatchCall {
+	return b.Query("view_account", block, map[string]interface{}{
+		"account_id": accountID,
+	}, result)
+}
+
+// ViewAccessKey adds the view of the access key of accountID with the public
+// key at block to b, see Client.ViewAccessKey.
+func (b *Batch) ViewAccessKey(accountID, publicKey string, block BlockReference, result *AccessKeyResult) *BatchCall {
+	return b.Query("view_access_key", block, map[string]interface{}{
+		"account_id": accountID,
+		"public_key": publicKey,
+	}, result)
+}
+
+// ViewAccessKeyList adds the view of all access keys of accountID at block to
+// b, see Client.ViewAccessKeyList.
+func (b *Batch) ViewAccessKeyList(accountID string, block BlockReference, result *AccessKeyList) *BatchCall {
+	return b.Query("view_access_key_list", block, map[string]interface{}{
+		"account_id": accountID,
+	}, result)
+}
+
+// Block adds the block selected by block to b, see Client.Block.
+func (b *Batch) Block(block BlockReference, result *BlockView) *BatchCall {
+	return b.Call("block", block.params(make(map[string]interface{})), result)
+}
+
+// Send sends all calls of b in a single request. The returned error is only
+// set if the batch as a whole failed, the errors of the individual calls are
+// in their Err field. Failed batches are retried according to the retry
+// policy of the client. Calls are neither repeated against the archival
+// endpoint nor cached.
+func (b *Batch) Send(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+	reqs := make([]request, len(b.calls))
+	for i, call := range b.calls {
+		reqs[i] = request{JSONRPC: "2.0", ID: call.id, Method: call.Method, Params: call.Params}
+	}
+	data, err := json.Marshal(reqs)
+	if err != nil {
+		return err
+	}
+	raw, err := b.c.roundTrip(ctx, &call{method: batchMethod, data: data})
+	if err != nil {
+		return err
+	}
+	var resps []response
+	if err := json.Unmarshal(raw, &resps); err != nil {
+		return fmt.Errorf("rpc: cannot decode batch response: %v", err)
+	}
+	byID := make(map[uint64]*response, len(resps))
+	for i := range resps {
+		var id uint64
+		if err := json.Unmarshal(resps[i].ID, &id); err == nil {
+			byID[id] = &resps[i]
+		}
+	}
+	for _, call := range b.calls {
+		call.Err = call.set(byID[call.id])
+	}
+	return nil
+}
+
+// set sets the result of call from its response res.
+func (call *BatchCall) set(res *response) error {
+	switch {
+	case res == nil:
+		return fmt.Errorf("rpc: no response to %s in batch", call.Method)
+	case res.Error != nil:
+		return res.Error
+	case res.Result == nil:
+		return fmt.Errorf("rpc: %s returned invalid response", call.Method)
+	case call.decode != nil:
+		return call.decode(res.Result)
+	case call.Result == nil:
+		return nil
+	}
+	if err := json.Unmarshal(res.Result, call.Result); err != nil {
+		return fmt.Errorf("rpc: cannot decode result of %s: %v", call.Method, err)
+	}
+	return nil
+}
+
+// Err returns the first error of the calls in b after it was sent.
+func (b *Batch) Err() error {
+	for _, call := range b.calls {
+		if call.Err != nil {
+			return call.Err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var reqs []struct {
+			ID     json.RawMessage        `json:"id"`
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// answer in reverse order, clients match responses by ID
+		var resps []map[string]interface{}
+		for i := len(reqs) - 1; i >= 0; i-- {
+			req := reqs[i]
+			res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+			switch {
+			case req.Method == "block":
+				res["result"] = map[string]interface{}{"author": "node0", "header": map[string]interface{}{"height": 42}}
+			case req.Params["account_id"] == "missing.testnet":
+				res["error"] = &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "UNKNOWN_ACCOUNT"}}
+			case req.Params["request_type"] == "view_account":
+				res["result"] = map[string]interface{}{"amount": "100", "locked": "0", "block_height": 42}
+			default:
+				res["result"] = map[string]interface{}{"error": "unsupported"}
+			}
+			resps = append(resps, res)
+		}
+		_ = json.NewEncoder(w).Encode(resps)
+	}))
+	defer srv.Close()
+	b := NewClient(srv.URL).Batch()
+	var account, missing AccountView
+	var block BlockView
+	var keys AccessKeyList
+	accountCall := b.ViewAccount("test.testnet", Final(), &account)
+	missingCall := b.ViewAccount("missing.testnet", Final(), &missing)
+	blockCall := b.Block(AtHeight(42), &block)
+	keysCall := b.ViewAccessKeyList("test.testnet", Final(), &keys)
+	if b.Len() != 4 {
+		t.Errorf("Len() returned %d (want 4)", b.Len())
+	}
+	if err := b.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("Send() made %d requests (want 1)", requests)
+	}
+	if accountCall.Err != nil || account.Amount.String() != "100" {
+		t.Errorf("ViewAccount() returned %+v, %v", account, accountCall.Err)
+	}
+	if !errors.Is(missingCall.Err, ErrUnknownAccount) {
+		t.Errorf("ViewAccount(missing) returned %v (want ErrUnknownAccount)", missingCall.Err)
+	}
+	if blockCall.Err != nil || block.Header.Height != 42 {
+		t.Errorf("Block() returned %+v, %v", block.Header, blockCall.Err)
+	}
+	if keysCall.Err == nil {
+		t.Error("ViewAccessKeyList() did not return query error")
+	}
+	if err := b.Err(); err != missingCall.Err {
+		t.Errorf("Err() returned %v (want %v)", err, missingCall.Err)
+	}
+}
+
+func TestBatchHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	b := NewClient(srv.URL).Batch()
+	b.Call("status", nil, nil)
+	var httpErr *HTTPError
+	if err := b.Send(context.Background()); !errors.As(err, &httpErr) || httpErr.Method != "batch" {
+		t.Errorf("Send() returned %v (want HTTPError of batch)", err)
+	}
+	// empty batches are not sent
+	if err := NewClient(srv.URL).Batch().Send(context.Background()); err != nil {
+		t.Errorf("Send() of empty batch returned %v", err)
+	}
+}
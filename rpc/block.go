@@ -0,0 +1,98 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// ValidatorStakeView is a validator with its stake, as used in validator
+// proposals.
+type ValidatorStakeView struct {
+	AccountID string        `json:"account_id"`
+	PublicKey string        `json:"public_key"`
+	Stake     types.Balance `json:"stake"`
+	// ValidatorStakeStructVersion is the version of the view, "V1".
+	ValidatorStakeStructVersion string `json:"validator_stake_struct_version,omitempty"`
+}
+
+// BlockHeaderView is the header of a block.
+type BlockHeaderView struct {
+	Height            uint64  `json:"height"`
+	PrevHeight        *uint64 `json:"prev_height"`
+	EpochID           string  `json:"epoch_id"`
+	NextEpochID       string  `json:"next_epoch_id"`
+	Hash              string  `json:"hash"`
+	PrevHash          string  `json:"prev_hash"`
+	PrevStateRoot     string  `json:"prev_state_root"`
+	BlockBodyHash     *string `json:"block_body_hash"`
+	ChunkReceiptsRoot string  `json:"chunk_receipts_root"`
+	ChunkHeadersRoot  string  `json:"chunk_headers_root"`
+	ChunkTxRoot       string  `json:"chunk_tx_root"`
+	OutcomeRoot       string  `json:"outcome_root"`
+	ChunksIncluded    uint64  `json:"chunks_included"`
+	ChallengesRoot    string  `json:"challenges_root"`
+	// Timestamp is the block time in nanoseconds since the Unix epoch, see
+	// Time.
+	Timestamp             uint64               `json:"timestamp"`
+	TimestampNanosec      string               `json:"timestamp_nanosec"`
+	RandomValue           string               `json:"random_value"`
+	ValidatorProposals    []ValidatorStakeView `json:"validator_proposals"`
+	ChunkMask             []bool               `json:"chunk_mask"`
+	GasPrice              types.Balance        `json:"gas_price"`
+	BlockOrdinal          *uint64              `json:"block_ordinal"`
+	TotalSupply           types.Balance        `json:"total_supply"`
+	LastFinalBlock        string               `json:"last_final_block"`
+	LastDSFinalBlock      string               `json:"last_ds_final_block"`
+	NextBPHash            string               `json:"next_bp_hash"`
+	BlockMerkleRoot       string               `json:"block_merkle_root"`
+	EpochSyncDataHash     *string              `json:"epoch_sync_data_hash"`
+	Approvals             []*string            `json:"approvals"`
+	Signature             string               `json:"signature"`
+	LatestProtocolVersion uint32               `json:"latest_protocol_version"`
+}
+
+// Time returns the block time.
+func (h *BlockHeaderView) Time() time.Time {
+	return time.Unix(0, int64(h.Timestamp))
+}
+
+// ChunkHeaderView is the header of a chunk.
+type ChunkHeaderView struct {
+	ChunkHash            string               `json:"chunk_hash"`
+	PrevBlockHash        string               `json:"prev_block_hash"`
+	OutcomeRoot          string               `json:"outcome_root"`
+	PrevStateRoot        string               `json:"prev_state_root"`
+	EncodedMerkleRoot    string               `json:"encoded_merkle_root"`
+	EncodedLength        uint64               `json:"encoded_length"`
+	HeightCreated        uint64               `json:"height_created"`
+	HeightIncluded       uint64               `json:"height_included"`
+	ShardID              uint64               `json:"shard_id"`
+	GasUsed              uint64               `json:"gas_used"`
+	GasLimit             uint64               `json:"gas_limit"`
+	BalanceBurnt         types.Balance        `json:"balance_burnt"`
+	OutgoingReceiptsRoot string               `json:"outgoing_receipts_root"`
+	TxRoot               string               `json:"tx_root"`
+	ValidatorProposals   []ValidatorStakeView `json:"validator_proposals"`
+	Signature            string               `json:"signature"`
+}
+
+// BlockView is a block as returned by Block.
+type BlockView struct {
+	// Author is the account ID of the block producer.
+	Author string            `json:"author"`
+	Header BlockHeaderView   `json:"header"`
+	Chunks []ChunkHeaderView `json:"chunks"`
+}
+
+// Block returns the block selected by block.
+//
+// For details see https://docs.near.org/api/rpc/block-chunk#block-details
+func (c *Client) Block(ctx context.Context, block BlockReference) (*BlockView, error) {
+	var res BlockView
+	if err := c.Call(ctx, "block", block.params(make(map[string]interface{})), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"encoding/json"
+)
+
+// Finality of a block, see BlockReference.
+type Finality string
+
+// All finality levels.
+const (
+	// FinalityOptimistic is the latest block, which might still be skipped.
+	FinalityOptimistic Finality = "optimistic"
+	// FinalityNearFinal is the latest block with doomslug finality, it can
+	// only be reverted by slashing validators.
+	FinalityNearFinal Finality = "near-final"
+	// FinalityFinal is the latest final block.
+	FinalityFinal Finality = "final"
+)
+
+// BlockReference selects the block a query is made against: either the
+// latest block of the given finality, or a block by height or hash.
+type BlockReference struct {
+	Finality Finality
+	// BlockHeight is used if BlockHash is empty and Finality is not set.
+	// Height 0, the genesis block, must be selected with AtHeight.
+	BlockHeight uint64
+	// BlockHash is the base58 encoded block hash.
+	BlockHash string
+	// height is set by AtHeight, which selects the height even if it is 0.
+	height bool
+}
+
+// Final returns a reference to the latest final block.
+func Final() BlockReference {
+	return BlockReference{Finality: FinalityFinal}
+}
+
+// Optimistic returns a reference to the latest block.
+func Optimistic() BlockReference {
+	return BlockReference{Finality: FinalityOptimistic}
+}
+
+// AtHeight returns a reference to the block at height.
+func AtHeight(height uint64) BlockReference {
+	return BlockReference{BlockHeight: height, height: true}
+}
+
+// hasHeight reports whether the reference selects a block by height, unless
+// a hash is set as well.
+func (r BlockReference) hasHeight() bool {
+	return r.BlockHeight != 0 || r.height
+}
+
+// AtHash returns a reference to the block with the base58 encoded hash.
+func AtHash(hash string) BlockReference {
+	return BlockReference{BlockHash: hash}
+}
+
+// params adds the reference to the request parameters p, the zero reference
+// selects the latest final block.
+func (r BlockReference) params(p map[string]interface{}) map[string]interface{} {
+	switch {
+	case r.Finality != "":
+		p["finality"] = r.Finality
+	case r.BlockHash != "":
+		p["block_id"] = r.BlockHash
+	case r.hasHeight():
+		p["block_id"] = r.BlockHeight
+	default:
+		p["finality"] = FinalityFinal
+	}
+	return p
+}
+
+// MarshalJSON implements json.Marshaler, the reference is encoded as the
+// request parameters of block queries.
+func (r BlockReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.params(make(map[string]interface{})))
+}
@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newFixtureServer returns a JSON-RPC server which answers calls of method
+// with the result in testdata/<filename> and checks the params with check
+// (nil if they are not an object).
+func newFixtureServer(t *testing.T, method, filename string, check func(p map[string]interface{})) *Client {
+	data, err := os.ReadFile(filepath.Join("testdata", filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestServer(t, func(m string, params json.RawMessage) (interface{}, *Error) {
+		if m != method {
+			return nil, &Error{Code: -32601, Message: "Method not found"}
+		}
+		var p map[string]interface{}
+		_ = json.Unmarshal(params, &p)
+		if check != nil {
+			check(p)
+		}
+		return json.RawMessage(data), nil
+	})
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL)
+}
+
+func TestBlock(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "block", "block.json", func(p map[string]interface{}) { params = p })
+	ctx := context.Background()
+	block, err := c.Block(ctx, AtHeight(121406726))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["block_id"] != 121406726.0 {
+		t.Errorf("Block() sent params %v", params)
+	}
+	if block.Author != "node0" || block.Header.Height != 121406726 || *block.Header.PrevHeight != 121406725 {
+		t.Errorf("Block() returned %+v", block)
+	}
+	if block.Header.Time().UnixNano() != 1680000000123456789 {
+		t.Errorf("Time() returned %s", block.Header.Time())
+	}
+	if block.Header.GasPrice.Int64() != 100000000 || block.Header.TotalSupply.String() != "1159548288380011709000564585468957354" {
+		t.Errorf("Block() returned gas price %s and total supply %s", &block.Header.GasPrice, &block.Header.TotalSupply)
+	}
+	if len(block.Header.ValidatorProposals) != 1 || block.Header.ValidatorProposals[0].Stake.String() != "50000000000000000000000000000" {
+		t.Errorf("Block() returned validator proposals %+v", block.Header.ValidatorProposals)
+	}
+	if len(block.Header.Approvals) != 2 || block.Header.Approvals[1] != nil {
+		t.Errorf("Block() returned approvals %v", block.Header.Approvals)
+	}
+	if len(block.Chunks) != 1 || block.Chunks[0].ShardID != 3 || block.Chunks[0].GasLimit != 1000000000000000 {
+		t.Errorf("Block() returned chunks %+v", block.Chunks)
+	}
+	if _, err := c.Block(ctx, AtHash(block.Header.Hash)); err != nil {
+		t.Fatal(err)
+	}
+	if params["block_id"] != block.Header.Hash {
+		t.Errorf("Block() sent params %v", params)
+	}
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// BroadcastTxAsync sends the Borsh encoded signed transaction and returns its
+// base58 encoded hash immediately, without waiting for its inclusion.
+//
+// For details see https://docs.near.org/api/rpc/transactions#send-transaction-async
+func (c *Client) BroadcastTxAsync(ctx context.Context, signedTx []byte) (string, error) {
+	var res string
+	if err := c.Call(ctx, "broadcast_tx_async", []interface{}{
+		base64.StdEncoding.EncodeToString(signedTx),
+	}, &res); err != nil {
+		return "", err
+	}
+	return res, nil
+}
+
+// BroadcastTxCommit sends the Borsh encoded signed transaction and waits until
+// it and all its receipts are executed or the node timed out (then the
+// outcome can be queried with TxStatus).
+//
+// For details see https://docs.near.org/api/rpc/transactions#send-transaction-await
+func (c *Client) BroadcastTxCommit(ctx context.Context, signedTx []byte) (*FinalExecutionOutcome, error) {
+	var res FinalExecutionOutcome
+	if err := c.Call(ctx, "broadcast_tx_commit", []interface{}{
+		base64.StdEncoding.EncodeToString(signedTx),
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SendTx sends the Borsh encoded signed transaction and waits until it
+// reached waitUntil (the node default, EXECUTED_OPTIMISTIC, if empty) or the
+// node timed out. For NONE and INCLUDED only FinalExecutionStatus and the
+// Transaction.Hash computed from signedTx are set, the outcome can be
+// queried with TxStatus later.
+//
+// For details see https://docs.near.org/api/rpc/transactions#send-tx
+func (c *Client) SendTx(ctx context.Context, signedTx []byte, waitUntil TxExecutionStatus) (*FinalExecutionOutcome, error) {
+	p := map[string]interface{}{
+		"signed_tx_base64": base64.StdEncoding.EncodeToString(signedTx),
+	}
+	if waitUntil != "" {
+		p["wait_until"] = waitUntil
+	}
+	var res FinalExecutionOutcome
+	if err := c.Call(ctx, "send_tx", p, &res); err != nil {
+		return nil, err
+	}
+	if res.Transaction.Hash == "" {
+		res.Transaction.Hash = signedTxHash(signedTx)
+	}
+	return &res, nil
+}
+
+// signedTxHash returns the base58 encoded hash of the Borsh encoded signed
+// transaction, the sha256 hash of the transaction without its signature, or
+// "" if signedTx is malformed. The signature has the key type of the public
+// key following the signer ID: a type byte and 64 bytes for ed25519, 65 for
+// secp256k1.
+func signedTxHash(signedTx []byte) string {
+	if len(signedTx) < 4 {
+		return ""
+	}
+	keyType := 4 + int(binary.LittleEndian.Uint32(signedTx))
+	if keyType < 4 || keyType >= len(signedTx) {
+		return ""
+	}
+	var sigLen int
+	switch signedTx[keyType] {
+	case 0:
+		sigLen = 1 + 64
+	case 1:
+		sigLen = 1 + 65
+	default:
+		return ""
+	}
+	if len(signedTx) < keyType+sigLen {
+		return ""
+	}
+	hash := sha256.Sum256(signedTx[:len(signedTx)-sigLen])
+	return base58.Encode(hash[:])
+}
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestBroadcast(t *testing.T) {
+	outcome, err := os.ReadFile(filepath.Join("testdata", "tx_status.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "broadcast_tx_async", "broadcast_tx_commit":
+			var p []string
+			if err := json.Unmarshal(params, &p); err != nil || len(p) != 1 || p[0] != "AQID" {
+				t.Errorf("%s has params %s", method, params)
+			}
+			if method == "broadcast_tx_async" {
+				return "TX", nil
+			}
+			return json.RawMessage(outcome), nil
+		case "send_tx":
+			var p map[string]string
+			if err := json.Unmarshal(params, &p); err != nil || p["signed_tx_base64"] != "AQID" {
+				t.Errorf("send_tx has params %s", params)
+			}
+			if p["wait_until"] == "NONE" {
+				return json.RawMessage(`{"final_execution_status":"NONE"}`), nil
+			}
+			return json.RawMessage(outcome), nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	tx := []byte{1, 2, 3}
+	hash, err := c.BroadcastTxAsync(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "TX" {
+		t.Errorf("BroadcastTxAsync() returned %s", hash)
+	}
+	o, err := c.BroadcastTxCommit(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.Status.IsSuccess() || o.Transaction.Hash != "TX" {
+		t.Errorf("BroadcastTxCommit() returned %+v", o)
+	}
+	o, err = c.SendTx(ctx, tx, TxExecutionStatusNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.FinalExecutionStatus != TxExecutionStatusNone || o.Status.Kind != "" {
+		t.Errorf("SendTx(NONE) returned %+v", o)
+	}
+	o, err = c.SendTx(ctx, tx, TxExecutionStatusFinal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.FinalExecutionStatus != TxExecutionStatusFinal || len(o.ReceiptsOutcome) != 4 {
+		t.Errorf("SendTx(FINAL) returned %+v", o)
+	}
+}
+
+func TestSignedTxHash(t *testing.T) {
+	// signer "a" with a secp256k1 key, the rest of the transaction is
+	// opaque to the hash
+	tx := append([]byte{1, 0, 0, 0, 'a', 1}, make([]byte, 64+40)...)
+	sig := append([]byte{1}, make([]byte, 65)...)
+	hash := sha256.Sum256(tx)
+	if got := signedTxHash(append(tx, sig...)); got != base58.Encode(hash[:]) {
+		t.Errorf("signedTxHash() returned %q", got)
+	}
+	if got := signedTxHash([]byte{1, 2, 3}); got != "" {
+		t.Errorf("signedTxHash() of malformed transaction returned %q", got)
+	}
+}
@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+)
+
+// Cache stores the results of calls for immutable chain data, see
+// Options.Cache. Implementations must be safe for concurrent use, they can
+// be backed by external stores shared by several processes.
+type Cache interface {
+	// Get returns the result stored for key.
+	Get(key string) (json.RawMessage, bool)
+	// Add stores the result for key.
+	Add(key string, result json.RawMessage)
+}
+
+// cacheable reports whether the result of the call of method with params is
+// immutable. These are calls selecting a block by height or hash (blocks,
+// chunks, queries, changes, gas prices and protocol configs at the block),
+// receipts and the genesis config. Blocks selected by height are only
+// immutable once they are final, for these calls the height is returned as
+// well, see Client.finalized.
+func cacheable(method string, params interface{}) (bool, uint64) {
+	switch p := params.(type) {
+	case map[string]interface{}:
+		height, _ := p["block_id"].(uint64)
+		switch method {
+		case "block", "query", "EXPERIMENTAL_changes", "EXPERIMENTAL_changes_in_block", "EXPERIMENTAL_protocol_config":
+			return p["block_id"] != nil, height
+		case "chunk":
+			if p["chunk_id"] != nil {
+				return true, 0
+			}
+			return p["block_id"] != nil, height
+		case "EXPERIMENTAL_receipt":
+			return true, 0
+		}
+	case []interface{}:
+		switch method {
+		case "gas_price":
+			if len(p) != 1 || p[0] == nil {
+				return false, 0
+			}
+			height, _ := p[0].(uint64)
+			return true, height
+		case "EXPERIMENTAL_genesis_config":
+			return true, 0
+		}
+	}
+	return false, 0
+}
+
+// finalized reports whether the block at height is known to be final.
+func (c *Client) finalized(height uint64) bool {
+	return height <= atomic.LoadUint64(&c.finalHeight)
+}
+
+// observeFinal records the height of the result res of the call of method
+// with params if it is the latest final block, blocks up to this height are
+// cached.
+func (c *Client) observeFinal(method string, params interface{}, res json.RawMessage) {
+	p, ok := params.(map[string]interface{})
+	if !ok || p["finality"] != FinalityFinal {
+		return
+	}
+	var height uint64
+	switch method {
+	case "block":
+		var b struct {
+			Header struct {
+				Height uint64 `json:"height"`
+			} `json:"header"`
+		}
+		if json.Unmarshal(res, &b) != nil {
+			return
+		}
+		height = b.Header.Height
+	case "query":
+		var q QueryResponse
+		if json.Unmarshal(res, &q) != nil {
+			return
+		}
+		height = q.BlockHeight
+	default:
+		return
+	}
+	for {
+		old := atomic.LoadUint64(&c.finalHeight)
+		if height <= old || atomic.CompareAndSwapUint64(&c.finalHeight, old, height) {
+			return
+		}
+	}
+}
+
+// cacheKey returns the cache key of the call of method with params.
+func cacheKey(method string, params interface{}) (string, error) {
+	// maps are encoded with sorted keys
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return method + ":" + string(data), nil
+}
+
+// cacheableResult reports whether the result res of method can be cached.
+// Failed queries of some node versions are results with an error message
+// (see Client.Query), which can be temporary.
+func cacheableResult(method string, res json.RawMessage) bool {
+	if method != "query" {
+		return true
+	}
+	var e struct {
+		Error string `json:"error"`
+	}
+	return json.Unmarshal(res, &e) != nil || e.Error == ""
+}
+
+// LRUCache is an in-memory Cache evicting the least recently used results.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List // most recently used first
+}
+
+type lruEntry struct {
+	key    string
+	result json.RawMessage
+}
+
+// NewLRUCache returns a new in-memory cache of at most size results.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).result, true
+}
+
+// Add implements Cache.
+func (c *LRUCache) Add(key string, result json.RawMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).result = result
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, result: result})
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of results in c.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Add("a", json.RawMessage(`1`))
+	c.Add("b", json.RawMessage(`2`))
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) did not find result")
+	}
+	// b is the least recently used result
+	c.Add("c", json.RawMessage(`3`))
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found evicted result")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%s) did not find result", key)
+		}
+	}
+	c.Add("c", json.RawMessage(`4`))
+	if res, _ := c.Get("c"); string(res) != "4" || c.Len() != 2 {
+		t.Errorf("Get(c) returned %s with %d results", res, c.Len())
+	}
+}
+
+func TestCacheable(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		params interface{}
+		want   bool
+	}{
+		{"block", AtHeight(42).params(make(map[string]interface{})), true},
+		{"block", AtHash("hash").params(make(map[string]interface{})), true},
+		{"block", Final().params(make(map[string]interface{})), false},
+		{"query", queryParams("view_account", AtHeight(42), nil), true},
+		{"query", queryParams("view_account", Optimistic(), nil), false},
+		{"chunk", map[string]interface{}{"chunk_id": "hash"}, true},
+		{"gas_price", []interface{}{uint64(42)}, true},
+		{"gas_price", []interface{}{nil}, false},
+		{"EXPERIMENTAL_genesis_config", []interface{}{}, true},
+		{"EXPERIMENTAL_receipt", map[string]interface{}{"receipt_id": "id"}, true},
+		{"tx", map[string]interface{}{"tx_hash": "hash"}, false},
+		{"status", []interface{}{}, false},
+	} {
+		if got, _ := cacheable(tc.method, tc.params); got != tc.want {
+			t.Errorf("cacheable(%s, %v) returned %t (want %t)", tc.method, tc.params, got, tc.want)
+		}
+	}
+}
+
+func TestCacheableHeight(t *testing.T) {
+	if ok, height := cacheable("block", AtHeight(42).params(make(map[string]interface{}))); !ok || height != 42 {
+		t.Errorf("cacheable(block at 42) returned %t, %d", ok, height)
+	}
+	if ok, height := cacheable("gas_price", []interface{}{uint64(42)}); !ok || height != 42 {
+		t.Errorf("cacheable(gas_price at 42) returned %t, %d", ok, height)
+	}
+	if ok, height := cacheable("block", AtHash("hash").params(make(map[string]interface{}))); !ok || height != 0 {
+		t.Errorf("cacheable(block by hash) returned %t, %d", ok, height)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var calls int32
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		n := atomic.AddInt32(&calls, 1)
+		var p map[string]interface{}
+		_ = json.Unmarshal(params, &p)
+		if p["request_type"] == "call_function" {
+			return map[string]interface{}{"error": "wasm execution failed", "logs": []string{}}, nil
+		}
+		return map[string]interface{}{"author": "node" + strconv.Itoa(int(n)), "header": map[string]interface{}{"height": 42}}, nil
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	cache := NewLRUCache(10)
+	c := NewClientWithOptions(srv.URL, Options{Cache: cache})
+	// blocks by height are not cached before they are known to be final
+	if _, err := c.Block(ctx, AtHeight(42)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 || cache.Len() != 0 {
+		t.Fatalf("Block() made %d calls with %d cached results (want 1 call and no result)", calls, cache.Len())
+	}
+	if _, err := c.Block(ctx, Final()); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		b, err := c.Block(ctx, AtHeight(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Author != "node3" {
+			t.Errorf("Block() returned author %s (want cached node3)", b.Author)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("Block() made %d calls (want 1)", calls-2)
+	}
+	// latest blocks are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := c.Block(ctx, Final()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 5 {
+		t.Errorf("Block(final) made %d calls (want 2)", calls-3)
+	}
+	// failed queries are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := c.CallFunction(ctx, "contract.testnet", "get", nil, nil, AtHeight(42)); err == nil {
+			t.Fatal("CallFunction() succeeded")
+		}
+	}
+	if calls != 7 || cache.Len() != 1 {
+		t.Errorf("CallFunction() made %d calls with %d cached results (want 2 calls and 1 result)", calls-5, cache.Len())
+	}
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+)
+
+// byteArray is a byte slice encoded as array of numbers in JSON.
+type byteArray []byte
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *byteArray) UnmarshalJSON(data []byte) error {
+	// a []byte would be decoded from base64, decode the numbers individually
+	var numbers []json.Number
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return fmt.Errorf("rpc: invalid byte array: %v", err)
+	}
+	v := make([]byte, len(numbers))
+	for i, n := range numbers {
+		x, err := strconv.ParseUint(n.String(), 10, 8)
+		if err != nil {
+			return fmt.Errorf("rpc: invalid byte array: %v", err)
+		}
+		v[i] = byte(x)
+	}
+	*b = v
+	return nil
+}
+
+// CallResult is the result of CallFunction.
+type CallResult struct {
+	QueryResponse
+	// Result is the raw return value of the function.
+	Result []byte
+	// Logs are the logs emitted by the function.
+	Logs []string
+}
+
+// BorshValue is the args or result of a contract function encoded with
+// Borsh instead of JSON, see Borsh.
+type BorshValue struct {
+	Value interface{}
+}
+
+// Borsh selects the Borsh encoding for the args or result v of a contract
+// function, e.g.
+//
+//	client.CallFunction(ctx, contractID, method, rpc.Borsh(args), rpc.Borsh(&result), rpc.Final())
+//
+// For results v must be a non-nil pointer.
+func Borsh(v interface{}) BorshValue {
+	return BorshValue{Value: v}
+}
+
+// EncodeArgs returns the encoding of the args of a contract function: args
+// are passed as is if they are a []byte and Borsh encoded if they are a
+// BorshValue, otherwise they are JSON encoded (nil means no args).
+func EncodeArgs(args interface{}) ([]byte, error) {
+	switch a := args.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return a, nil
+	case BorshValue:
+		return borsh.Serialize(a.Value)
+	}
+	return json.Marshal(args)
+}
+
+// DecodeResult decodes the return value data of a contract function into
+// result: a *[]byte receives the raw value, a BorshValue is Borsh decoded and
+// any other value JSON decoded. A nil result is ignored.
+func DecodeResult(data []byte, result interface{}) error {
+	switch r := result.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*r = data
+		return nil
+	case BorshValue:
+		return borsh.Deserialize(data, r.Value)
+	}
+	return json.Unmarshal(data, result)
+}
+
+// CallFunction calls the view function method of the contract contractID at
+// block. Args are encoded with EncodeArgs, passed as is if they are a []byte,
+// Borsh encoded if wrapped by Borsh, otherwise JSON encoded (nil means no
+// args). If result is not nil the return value is decoded into it with
+// DecodeResult, a *[]byte receives the raw return value. The returned
+// CallResult contains the raw return value and the logs of the call.
+//
+// If the function fails an error matching ErrContractExecution is returned.
+//
+// For details see https://docs.near.org/api/rpc/contracts#call-a-contract-function
+func (c *Client) CallFunction(ctx context.Context, contractID, method string, args, result interface{}, block BlockReference) (*CallResult, error) {
+	argsData, err := EncodeArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("rpc: cannot encode args of %s: %v", method, err)
+	}
+	var res struct {
+		QueryResponse
+		Result byteArray `json:"result"`
+		Logs   []string  `json:"logs"`
+	}
+	if err := c.Query(ctx, "call_function", block, map[string]interface{}{
+		"account_id":  contractID,
+		"method_name": method,
+		"args_base64": base64.StdEncoding.EncodeToString(argsData),
+	}, &res); err != nil {
+		return nil, err
+	}
+	cr := &CallResult{
+		QueryResponse: res.QueryResponse,
+		Result:        res.Result,
+		Logs:          res.Logs,
+	}
+	if err := DecodeResult(cr.Result, result); err != nil {
+		return cr, fmt.Errorf("rpc: cannot decode result of %s: %v", method, err)
+	}
+	return cr, nil
+}
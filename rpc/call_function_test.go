@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeArgs(t *testing.T) {
+	for _, test := range []struct {
+		args interface{}
+		want []byte
+	}{
+		{nil, nil},
+		{[]byte{1, 2}, []byte{1, 2}},
+		{map[string]int{"a": 1}, []byte(`{"a":1}`)},
+		{Borsh("ab"), []byte{2, 0, 0, 0, 'a', 'b'}},
+		{Borsh([]byte{1, 2}), []byte{2, 0, 0, 0, 1, 2}},
+	} {
+		if got, err := EncodeArgs(test.args); err != nil || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("EncodeArgs(%v) returned %v, %v (want %v)", test.args, got, err, test.want)
+		}
+	}
+	if _, err := EncodeArgs(Borsh(func() {})); err == nil {
+		t.Error("EncodeArgs() accepted invalid Borsh args")
+	}
+}
+
+func TestCallFunction(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		args, err := base64.StdEncoding.DecodeString(p["args_base64"].(string))
+		if err != nil {
+			t.Error(err)
+		}
+		switch p["method_name"] {
+		case "get_greeting":
+			if string(args) != `{"account_id":"alice.testnet"}` {
+				t.Errorf("CallFunction() sent args %s", args)
+			}
+			// "hello"
+			return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,
+				"logs":["greeting alice.testnet"],"result":[34,104,101,108,108,111,34]}`), nil
+		case "get_point":
+			if !reflect.DeepEqual(args, []byte{7, 0, 0, 0}) {
+				t.Errorf("CallFunction() sent args %v", args)
+			}
+			return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,"logs":[],"result":[1,0,2,0]}`), nil
+		case "get_raw":
+			if len(args) != 0 {
+				t.Errorf("CallFunction() sent args %s", args)
+			}
+			return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","block_height":42,"logs":[],"result":[0,255]}`), nil
+		default:
+			return nil, &Error{
+				Code:    -32000,
+				Message: "Server error",
+				Data:    json.RawMessage(`"Function call returned an error: MethodResolveError(MethodNotFound)"`),
+				Name:    "HANDLER_ERROR",
+				Cause:   &ErrorCause{Name: "CONTRACT_EXECUTION_ERROR", Info: json.RawMessage(`{"vm_error":"MethodResolveError(MethodNotFound)"}`)},
+			}
+		}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	var greeting string
+	res, err := c.CallFunction(ctx, "app.testnet", "get_greeting", map[string]string{"account_id": "alice.testnet"}, &greeting, Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if greeting != "hello" || !reflect.DeepEqual(res.Logs, []string{"greeting alice.testnet"}) || res.BlockHeight != 42 {
+		t.Errorf("CallFunction() returned %q, %+v", greeting, res)
+	}
+	var raw []byte
+	if _, err := c.CallFunction(ctx, "app.testnet", "get_raw", nil, &raw, Final()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(raw, []byte{0, 255}) {
+		t.Errorf("CallFunction() returned raw result %v", raw)
+	}
+	var point struct{ X, Y uint16 }
+	if _, err := c.CallFunction(ctx, "app.testnet", "get_point", Borsh(uint32(7)), Borsh(&point), Final()); err != nil {
+		t.Fatal(err)
+	}
+	if point.X != 1 || point.Y != 2 {
+		t.Errorf("CallFunction() returned point %+v (want {1 2})", point)
+	}
+	var short struct{ X, Y uint32 }
+	if _, err := c.CallFunction(ctx, "app.testnet", "get_point", Borsh(uint32(7)), Borsh(&short), Final()); err == nil {
+		t.Error("CallFunction() decoded 4 bytes into 8")
+	}
+	var n int
+	if _, err := c.CallFunction(ctx, "app.testnet", "get_greeting", map[string]string{"account_id": "alice.testnet"}, &n, Final()); err == nil {
+		t.Error("CallFunction() decoded string into int")
+	}
+	if _, err := c.CallFunction(ctx, "app.testnet", "missing", []byte{}, nil, Final()); !errors.Is(err, ErrContractExecution) {
+		t.Errorf("CallFunction() returned %v (want ErrContractExecution)", err)
+	}
+}
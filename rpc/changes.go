@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// AccountPublicKey identifies an access key.
+type AccountPublicKey struct {
+	AccountID string `json:"account_id"`
+	PublicKey string `json:"public_key"`
+}
+
+// ChangesFilter selects the state changes returned by Changes, use one of the
+// constructors AccountChanges, AllAccessKeyChanges, SingleAccessKeyChanges,
+// ContractCodeChanges or DataChanges.
+type ChangesFilter struct {
+	// ChangesType is the changes_type parameter like "account_changes".
+	ChangesType string
+	AccountIDs  []string
+	// Keys are only used for single_access_key_changes.
+	Keys []AccountPublicKey
+	// KeyPrefix is only used for data_changes.
+	KeyPrefix []byte
+}
+
+// AccountChanges selects changes of the accounts accountIDs.
+func AccountChanges(accountIDs ...string) ChangesFilter {
+	return ChangesFilter{ChangesType: "account_changes", AccountIDs: accountIDs}
+}
+
+// AllAccessKeyChanges selects changes of all access keys of accountIDs.
+func AllAccessKeyChanges(accountIDs ...string) ChangesFilter {
+	return ChangesFilter{ChangesType: "all_access_key_changes", AccountIDs: accountIDs}
+}
+
+// SingleAccessKeyChanges selects changes of the access keys keys.
+func SingleAccessKeyChanges(keys ...AccountPublicKey) ChangesFilter {
+	return ChangesFilter{ChangesType: "single_access_key_changes", Keys: keys}
+}
+
+// ContractCodeChanges selects contract deployments to accountIDs.
+func ContractCodeChanges(accountIDs ...string) ChangesFilter {
+	return ChangesFilter{ChangesType: "contract_code_changes", AccountIDs: accountIDs}
+}
+
+// DataChanges selects changes of contract state of accountIDs with keys
+// starting with keyPrefix (all keys if empty).
+func DataChanges(accountIDs []string, keyPrefix []byte) ChangesFilter {
+	return ChangesFilter{ChangesType: "data_changes", AccountIDs: accountIDs, KeyPrefix: keyPrefix}
+}
+
+func (f ChangesFilter) params(block BlockReference) map[string]interface{} {
+	p := block.params(map[string]interface{}{"changes_type": f.ChangesType})
+	if f.ChangesType == "single_access_key_changes" {
+		p["keys"] = f.Keys
+	} else {
+		p["account_ids"] = f.AccountIDs
+	}
+	if f.ChangesType == "data_changes" {
+		p["key_prefix_base64"] = base64.StdEncoding.EncodeToString(f.KeyPrefix)
+	}
+	return p
+}
+
+// StateChangeCause is the cause of a state change. Type is one of
+// not_writable_to_disk, initial_state, transaction_processing,
+// action_receipt_processing_started, action_receipt_gas_reward,
+// receipt_processing, postponed_receipt, updated_delayed_receipts,
+// validator_accounts_update, migration, resharding or bandwidth_scheduler_state_update.
+type StateChangeCause struct {
+	Type string `json:"type"`
+	// TxHash is set for transaction_processing.
+	TxHash string `json:"tx_hash,omitempty"`
+	// ReceiptHash is set for the receipt causes.
+	ReceiptHash string `json:"receipt_hash,omitempty"`
+}
+
+// StateChange is the changed value. Only the fields of the change type are
+// set.
+type StateChange struct {
+	AccountID string `json:"account_id"`
+	// Amount, Locked, CodeHash, StorageUsage and StoragePaidAt are set for
+	// account_update.
+	Amount        types.Balance `json:"amount"`
+	Locked        types.Balance `json:"locked"`
+	CodeHash      string        `json:"code_hash"`
+	StorageUsage  uint64        `json:"storage_usage"`
+	StoragePaidAt uint64        `json:"storage_paid_at"`
+	// PublicKey is set for access_key_update and access_key_deletion,
+	// AccessKey for access_key_update.
+	PublicKey string         `json:"public_key"`
+	AccessKey *AccessKeyView `json:"access_key"`
+	// Key is set for data_update and data_deletion, Value for data_update.
+	Key   []byte `json:"key_base64"`
+	Value []byte `json:"value_base64"`
+	// Code is set for contract_code_update.
+	Code []byte `json:"code_base64"`
+}
+
+// StateChangeView is a state change. Type is one of account_update,
+// account_deletion, access_key_update, access_key_deletion, data_update,
+// data_deletion, contract_code_update or contract_code_deletion.
+type StateChangeView struct {
+	Cause  StateChangeCause `json:"cause"`
+	Type   string           `json:"type"`
+	Change StateChange      `json:"change"`
+}
+
+// StateChanges is the result of Changes.
+type StateChanges struct {
+	BlockHash string            `json:"block_hash"`
+	Changes   []StateChangeView `json:"changes"`
+}
+
+// Changes returns the state changes selected by filter which happened in
+// block.
+//
+// For details see https://docs.near.org/api/rpc/setup#experimental_changes
+func (c *Client) Changes(ctx context.Context, filter ChangesFilter, block BlockReference) (*StateChanges, error) {
+	var res StateChanges
+	if err := c.Call(ctx, "EXPERIMENTAL_changes", filter.params(block), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// StateChangeKind is an account with changes of a kind. Type is one of
+// account_touched, access_key_touched, data_touched or contract_code_touched.
+type StateChangeKind struct {
+	Type      string `json:"type"`
+	AccountID string `json:"account_id"`
+}
+
+// StateChangesInBlock is the result of ChangesInBlock.
+type StateChangesInBlock struct {
+	BlockHash string            `json:"block_hash"`
+	Changes   []StateChangeKind `json:"changes"`
+}
+
+// ChangesInBlock returns the accounts changed in block and the kind of the
+// changes, see Changes for the changes themselves.
+//
+// For details see https://docs.near.org/api/rpc/setup#experimental_changes_in_block
+func (c *Client) ChangesInBlock(ctx context.Context, block BlockReference) (*StateChangesInBlock, error) {
+	var res StateChangesInBlock
+	if err := c.Call(ctx, "EXPERIMENTAL_changes_in_block", block.params(make(map[string]interface{})), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "EXPERIMENTAL_changes", "changes.json", func(p map[string]interface{}) { params = p })
+	ctx := context.Background()
+	res, err := c.Changes(ctx, DataChanges([]string{"app.testnet"}, []byte("STATE")), AtHeight(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"changes_type":      "data_changes",
+		"account_ids":       []interface{}{"app.testnet"},
+		"key_prefix_base64": "U1RBVEU=",
+		"block_id":          42.0,
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("Changes() sent params %v (want %v)", params, want)
+	}
+	if len(res.Changes) != 4 {
+		t.Fatalf("Changes() returned %d changes", len(res.Changes))
+	}
+	if ch := res.Changes[0]; ch.Type != "data_update" || string(ch.Change.Key) != "STATE" || string(ch.Change.Value) != "\x01" ||
+		ch.Cause.ReceiptHash != "9a66BCncuAhLRW1HgMSPahjA4yF4yiWWTm5ChAYiaRrc" {
+		t.Errorf("Changes() returned %+v", ch)
+	}
+	if ch := res.Changes[1]; ch.Type != "data_deletion" || string(ch.Change.Key) != "STATE2" || ch.Change.Value != nil {
+		t.Errorf("Changes() returned %+v", ch)
+	}
+	if ch := res.Changes[2]; ch.Change.Amount.Int64() != 1000 || ch.Change.StorageUsage != 182 || ch.Cause.TxHash == "" {
+		t.Errorf("Changes() returned %+v", ch)
+	}
+	if ch := res.Changes[3]; ch.Change.AccessKey == nil || ch.Change.AccessKey.Nonce != 9 {
+		t.Errorf("Changes() returned %+v", ch)
+	}
+	key := AccountPublicKey{AccountID: "alice.testnet", PublicKey: "ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e"}
+	if _, err := c.Changes(ctx, SingleAccessKeyChanges(key), Final()); err != nil {
+		t.Fatal(err)
+	}
+	if params["changes_type"] != "single_access_key_changes" || params["account_ids"] != nil {
+		t.Errorf("Changes() sent params %v", params)
+	}
+	data, _ := json.Marshal(params["keys"])
+	if string(data) != `[{"account_id":"alice.testnet","public_key":"ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e"}]` {
+		t.Errorf("Changes() sent keys %s", data)
+	}
+}
+
+func TestChangesInBlock(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		return json.RawMessage(`{"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR","changes":[
+			{"type":"account_touched","account_id":"alice.testnet"},{"type":"data_touched","account_id":"app.testnet"}]}`), nil
+	})
+	defer srv.Close()
+	res, err := NewClient(srv.URL).ChangesInBlock(context.Background(), Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StateChangeKind{{Type: "account_touched", AccountID: "alice.testnet"}, {Type: "data_touched", AccountID: "app.testnet"}}
+	if !reflect.DeepEqual(res.Changes, want) {
+		t.Errorf("ChangesInBlock() returned %+v", res.Changes)
+	}
+}
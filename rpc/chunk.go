@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+)
+
+// ChunkReference selects a chunk either by its hash or by the block (height
+// or hash) and shard it is included in.
+type ChunkReference struct {
+	ChunkHash string
+	Block     BlockReference
+	ShardID   uint64
+}
+
+// ChunkByHash returns a reference to the chunk with the base58 encoded hash.
+func ChunkByHash(hash string) ChunkReference {
+	return ChunkReference{ChunkHash: hash}
+}
+
+// ChunkInBlock returns a reference to the chunk of shardID included in block,
+// which must be selected by height or hash.
+func ChunkInBlock(block BlockReference, shardID uint64) ChunkReference {
+	return ChunkReference{Block: block, ShardID: shardID}
+}
+
+func (r ChunkReference) params() (map[string]interface{}, error) {
+	if r.ChunkHash != "" {
+		return map[string]interface{}{"chunk_id": r.ChunkHash}, nil
+	}
+	if r.Block.Finality != "" || (r.Block.BlockHash == "" && !r.Block.hasHeight()) {
+		return nil, errors.New("rpc: chunk must be selected by block height or hash")
+	}
+	return r.Block.params(map[string]interface{}{"shard_id": r.ShardID}), nil
+}
+
+// ChunkView is a chunk as returned by Chunk.
+type ChunkView struct {
+	// Author is the account ID of the chunk producer.
+	Author       string                  `json:"author"`
+	Header       ChunkHeaderView         `json:"header"`
+	Transactions []SignedTransactionView `json:"transactions"`
+	Receipts     []ReceiptView           `json:"receipts"`
+}
+
+// Chunk returns the chunk selected by chunk.
+//
+// For details see https://docs.near.org/api/rpc/block-chunk#chunk-details
+func (c *Client) Chunk(ctx context.Context, chunk ChunkReference) (*ChunkView, error) {
+	p, err := chunk.params()
+	if err != nil {
+		return nil, err
+	}
+	var res ChunkView
+	if err := c.Call(ctx, "chunk", p, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "chunk", "chunk.json", func(p map[string]interface{}) { params = p })
+	ctx := context.Background()
+	chunk, err := c.Chunk(ctx, ChunkInBlock(AtHeight(121406726), 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["block_id"] != 121406726.0 || params["shard_id"] != 0.0 {
+		t.Errorf("Chunk() sent params %v", params)
+	}
+	if chunk.Author != "node2" || chunk.Header.ChunkHash != "CzPafxtJmM1FnRoasKWAVhceJzZzkz9RKUBQQ4kY9V1v" {
+		t.Errorf("Chunk() returned %+v", chunk)
+	}
+	if len(chunk.Transactions) != 1 {
+		t.Fatalf("Chunk() returned %d transactions", len(chunk.Transactions))
+	}
+	actions := chunk.Transactions[0].Actions
+	var kinds []string
+	for _, a := range actions {
+		kinds = append(kinds, a.Kind)
+	}
+	if !reflect.DeepEqual(kinds, []string{"CreateAccount", "Transfer", "AddKey", "FunctionCall", "FutureAction"}) {
+		t.Errorf("Chunk() returned actions %v", kinds)
+	}
+	if actions[0].CreateAccount == nil || actions[1].Transfer.Deposit.String() != "1000000000000000000000000" {
+		t.Errorf("Chunk() returned actions %+v", actions)
+	}
+	perm := actions[2].AddKey.AccessKey.Permission
+	if perm.FullAccess() || perm.FunctionCall.Allowance != nil || perm.FunctionCall.ReceiverID != "app.testnet" {
+		t.Errorf("Chunk() returned permission %+v", perm.FunctionCall)
+	}
+	if string(actions[3].FunctionCall.Args) != "{}" || actions[3].FunctionCall.Gas != 30000000000000 {
+		t.Errorf("Chunk() returned function call %+v", actions[3].FunctionCall)
+	}
+	if actions[4].Raw == nil {
+		t.Error("Chunk() did not preserve unknown action")
+	}
+	// actions round trip
+	data, err := json.Marshal(actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []ActionView
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, actions) {
+		t.Errorf("actions do not round trip: %s", data)
+	}
+	if len(chunk.Receipts) != 2 {
+		t.Fatalf("Chunk() returned %d receipts", len(chunk.Receipts))
+	}
+	action := chunk.Receipts[0].Receipt.Action
+	if action == nil || action.Actions[0].DeleteKey == nil || action.OutputDataReceivers[0].ReceiverID != "alice.testnet" {
+		t.Errorf("Chunk() returned action receipt %+v", action)
+	}
+	data0 := chunk.Receipts[1].Receipt.Data
+	if data0 == nil || string(data0.Data) != "\x01" {
+		t.Errorf("Chunk() returned data receipt %+v", data0)
+	}
+	if _, err := c.Chunk(ctx, ChunkByHash(chunk.Header.ChunkHash)); err != nil {
+		t.Fatal(err)
+	}
+	if params["chunk_id"] != chunk.Header.ChunkHash {
+		t.Errorf("Chunk() sent params %v", params)
+	}
+	if _, err := c.Chunk(ctx, ChunkInBlock(Final(), 0)); err == nil {
+		t.Error("Chunk() accepted block selected by finality")
+	}
+}
+
+func TestGlobalContractActionViews(t *testing.T) {
+	data := []byte(`[{"DeployGlobalContractByAccountId":{"code":"AGFzbQ=="}},{"UseGlobalContract":{"code_hash":"11111111111111111111111111111111"}},{"UseGlobalContractByAccountId":{"account_id":"factory.testnet"}}]`)
+	var actions []ActionView
+	if err := json.Unmarshal(data, &actions); err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 3 || actions[0].DeployGlobalContract == nil || string(actions[0].DeployGlobalContract.Code) != "\x00asm" ||
+		actions[1].UseGlobalContract == nil || actions[1].UseGlobalContract.CodeHash == "" ||
+		actions[2].UseGlobalContract == nil || actions[2].UseGlobalContract.AccountID != "factory.testnet" {
+		t.Errorf("Unmarshal() returned %+v", actions)
+	}
+	encoded, err := json.Marshal(actions)
+	if err != nil || string(encoded) != string(data) {
+		t.Errorf("Marshal() returned %s, %v (want %s)", encoded, err, data)
+	}
+}
@@ -0,0 +1,349 @@
+// Package rpc implements a client for the NEAR JSON-RPC API.
+//
+// All methods accept a context.Context which cancels the HTTP request. Requests
+// and responses are typed structs mirroring the nearcore views, see
+// https://docs.near.org/api/rpc/introduction for details.
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Options configure a Client.
+type Options struct {
+	// Timeout of a single HTTP request, zero means no timeout besides the
+	// deadline of the context. It is ignored if HTTPClient is set.
+	Timeout time.Duration
+	// HTTPClient is the HTTP client used for requests. If nil, a new client
+	// using Transport is created.
+	HTTPClient *http.Client
+	// Transport is the round tripper of the HTTP client created if
+	// HTTPClient is nil. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+	// Headers are sent with every request, e.g. the API key of an RPC
+	// provider. See WithHeaders for headers of single requests.
+	Headers http.Header
+	// Retry is the policy for retrying failed calls, nil disables retries.
+	Retry *RetryPolicy
+	// Fallbacks are the URLs of further endpoints. If an endpoint is not
+	// available (connection failures, HTTP 429 and 5xx status codes or
+	// nodes which are not synced), the call fails over to the next endpoint
+	// and the failed endpoint is skipped for the cooldown.
+	Fallbacks []string
+	// RoundRobin distributes calls over all healthy endpoints instead of
+	// preferring them in order.
+	RoundRobin bool
+	// Cooldown is the time failed endpoints are skipped. If zero,
+	// DefaultCooldown is used.
+	Cooldown time.Duration
+	// Archival is the URL of an archival endpoint. Calls for blocks and
+	// chunks which are unknown to or garbage collected by the regular
+	// endpoints are repeated against it. Once a block height was garbage
+	// collected, calls for this and lower heights are sent to the archival
+	// endpoint right away.
+	Archival string
+	// RateLimit limits the requests sent to each endpoint, nil means no
+	// limit.
+	RateLimit *RateLimit
+	// Hooks are called around every request in order.
+	Hooks []Hooks
+	// TracerProvider enables OpenTelemetry tracing of calls if set. Spans
+	// have the method, block reference and account of the call and the
+	// number of retries as attributes.
+	TracerProvider trace.TracerProvider
+	// Metrics are updated with the requests of the client if set.
+	Metrics *Metrics
+	// Cache stores the results of calls for immutable chain data if set, see
+	// NewLRUCache. Results for blocks selected by height are only stored
+	// once the height is at or below the latest final block seen in block
+	// or query results, blocks selected by hash are stored right away.
+	Cache Cache
+}
+
+// Client is a NEAR JSON-RPC client. It is safe for concurrent use.
+type Client struct {
+	endpoints  []*backend
+	c          *http.Client
+	headers    http.Header
+	hooks      []Hooks
+	tracer     trace.Tracer
+	metrics    *Metrics
+	cache      Cache
+	retry      *RetryPolicy
+	roundRobin bool
+	cooldown   time.Duration
+	archival   *backend
+	// collectedHeight is the highest block height known to be garbage
+	// collected by the regular endpoints.
+	collectedHeight uint64
+	// finalHeight is the height of the latest final block seen, results
+	// for blocks selected by height are cached up to it.
+	finalHeight uint64
+	next        uint32
+	id          uint64
+}
+
+// NewClient returns a new client for the JSON-RPC endpoint with the given URL.
+func NewClient(endpoint string) *Client {
+	return NewClientWithOptions(endpoint, Options{})
+}
+
+// NewClientWithOptions returns a new client for the JSON-RPC endpoint with the
+// given URL configured with opts.
+func NewClientWithOptions(endpoint string, opts Options) *Client {
+	c := &Client{
+		endpoints:  []*backend{newBackend(endpoint)},
+		c:          opts.HTTPClient,
+		headers:    opts.Headers.Clone(),
+		hooks:      append([]Hooks(nil), opts.Hooks...),
+		retry:      opts.Retry,
+		roundRobin: opts.RoundRobin,
+		cooldown:   opts.Cooldown,
+		metrics:    opts.Metrics,
+		cache:      opts.Cache,
+	}
+	for _, url := range opts.Fallbacks {
+		c.endpoints = append(c.endpoints, newBackend(url))
+	}
+	if opts.Archival != "" {
+		c.archival = newBackend(opts.Archival)
+	}
+	if opts.RateLimit != nil && opts.RateLimit.QPS > 0 {
+		for _, e := range c.endpoints {
+			e.limiter = newTokenBucket(*opts.RateLimit)
+		}
+		if c.archival != nil {
+			c.archival.limiter = newTokenBucket(*opts.RateLimit)
+		}
+	}
+	if opts.TracerProvider != nil {
+		c.tracer = opts.TracerProvider.Tracer(tracerName)
+	}
+	if c.c == nil {
+		c.c = &http.Client{Transport: opts.Transport, Timeout: opts.Timeout}
+	}
+	if c.cooldown == 0 {
+		c.cooldown = DefaultCooldown
+	}
+	return c
+}
+
+// Endpoint returns the URL of the primary JSON-RPC endpoint of c.
+func (c *Client) Endpoint() string {
+	return c.endpoints[0].url
+}
+
+// maxErrorBody is the maximum length of the body included in HTTPError.
+const maxErrorBody = 512
+
+type request struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      uint64      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *Error          `json:"error"`
+}
+
+// call is an encoded call passed to the endpoints.
+type call struct {
+	method string
+	params interface{}
+	data   []byte
+	// attempt is the number of the attempt, starting at 1.
+	attempt int
+}
+
+// Call calls the JSON-RPC method with params and decodes the result into
+// result (which can be nil to discard it, or a *json.RawMessage). Errors
+// returned by the node are of type *Error. Failed calls are retried according
+// to the retry policy of the client, calls for historical data fall back to
+// the archival endpoint and results of calls for immutable data are cached.
+func (c *Client) Call(ctx context.Context, method string, params, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	var key string
+	var height uint64
+	if c.cache != nil {
+		var ok bool
+		if ok, height = cacheable(method, params); ok {
+			var err error
+			if key, err = cacheKey(method, params); err != nil {
+				return err
+			}
+			if res, ok := c.cache.Get(key); ok {
+				return decodeResult(method, res, result)
+			}
+		}
+	}
+	data, err := json.Marshal(request{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.id, 1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := c.roundTrip(ctx, &call{method: method, params: params, data: data})
+	if err != nil {
+		return err
+	}
+	if c.cache != nil {
+		c.observeFinal(method, params, res)
+	}
+	if key != "" && c.finalized(height) && cacheableResult(method, res) {
+		c.cache.Add(key, res)
+	}
+	return decodeResult(method, res, result)
+}
+
+// decodeResult decodes the result res of method into result.
+func decodeResult(method string, res json.RawMessage, result interface{}) error {
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(res, result); err != nil {
+		return fmt.Errorf("rpc: cannot decode result of %s: %v", method, err)
+	}
+	return nil
+}
+
+// roundTrip sends the call cl and returns the result, retrying and falling
+// back to the archival endpoint as configured.
+func (c *Client) roundTrip(ctx context.Context, cl *call) (res json.RawMessage, err error) {
+	ctx, span := c.startSpan(ctx, cl)
+	defer func() {
+		c.metrics.retried(cl.method, cl.attempt-1)
+		endSpan(span, cl, err)
+	}()
+	cl.attempt = 1
+	if c.archival != nil && c.collected(cl.params) {
+		return c.do(ctx, c.archival, cl)
+	}
+	res, err = c.send(ctx, cl)
+	for err != nil && c.retry != nil && cl.attempt < c.retry.MaxAttempts {
+		if !c.retry.retryable(cl.method, cl.params, err) {
+			break
+		}
+		if err := sleep(ctx, c.retry.backoff(cl.attempt)); err != nil {
+			break
+		}
+		cl.attempt++
+		res, err = c.send(ctx, cl)
+	}
+	if err != nil && c.archival != nil && archivalMiss(cl.params, err) {
+		c.markCollected(cl.params, err)
+		res, err = c.do(ctx, c.archival, cl)
+	}
+	return res, err
+}
+
+// do sends the call cl once to the endpoint e and returns the result. The
+// result of batches (method batchMethod) is the array of responses.
+func (c *Client) do(ctx context.Context, e *backend, cl *call) (json.RawMessage, error) {
+	if e.limiter != nil {
+		if err := e.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(cl.data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req.Header, c.headers)
+	setHeaders(req.Header, contextHeaders(ctx))
+	r := &Request{
+		Method:      cl.method,
+		Params:      cl.params,
+		Endpoint:    e.url,
+		Attempt:     cl.attempt,
+		HTTPRequest: req,
+	}
+	for _, h := range c.hooks {
+		if h.BeforeRequest == nil {
+			continue
+		}
+		res, err := h.BeforeRequest(ctx, r)
+		if err != nil {
+			c.onError(ctx, r, err)
+			return nil, err
+		}
+		if res != nil {
+			return res, nil
+		}
+	}
+	start := time.Now()
+	res, statusCode, err := c.exchange(req, cl.method)
+	c.metrics.observe(cl.method, e, time.Since(start), err)
+	if err != nil {
+		c.onError(ctx, r, err)
+		return nil, err
+	}
+	resp := &Response{StatusCode: statusCode, Result: res, Duration: time.Since(start)}
+	for _, h := range c.hooks {
+		if h.AfterResponse != nil {
+			h.AfterResponse(ctx, r, resp)
+		}
+	}
+	return resp.Result, nil
+}
+
+// exchange sends the HTTP request req of method and returns the result and
+// the HTTP status code.
+func (c *Client) exchange(req *http.Request, method string) (json.RawMessage, int, error) {
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+	if method == batchMethod {
+		var res []json.RawMessage
+		if err := json.Unmarshal(body, &res); err != nil {
+			return nil, resp.StatusCode, invalidResponse(method, resp.StatusCode, body)
+		}
+		return body, resp.StatusCode, nil
+	}
+	// nodes return JSON-RPC errors with HTTP error status codes as well
+	var res response
+	if err := json.Unmarshal(body, &res); err != nil || (res.Error == nil && res.Result == nil) {
+		return nil, resp.StatusCode, invalidResponse(method, resp.StatusCode, body)
+	}
+	if res.Error != nil {
+		return nil, resp.StatusCode, res.Error
+	}
+	return res.Result, resp.StatusCode, nil
+}
+
+// invalidResponse returns the error for the response body of method which is
+// not a JSON-RPC response.
+func invalidResponse(method string, statusCode int, body []byte) error {
+	if statusCode != http.StatusOK {
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody]
+		}
+		return &HTTPError{Method: method, StatusCode: statusCode, Body: strings.TrimSpace(string(body))}
+	}
+	return fmt.Errorf("rpc: %s returned invalid response", method)
+}
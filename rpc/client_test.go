@@ -0,0 +1,170 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type handlerFunc func(method string, params json.RawMessage) (interface{}, *Error)
+
+// newTestServer returns a JSON-RPC server answering requests with h.
+func newTestServer(t *testing.T, h handlerFunc) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Method  string          `json:"method"`
+			Params  json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.JSONRPC != "2.0" {
+			t.Errorf("request has jsonrpc %s", req.JSONRPC)
+		}
+		result, rpcErr := h(req.Method, req.Params)
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != nil {
+			res["error"] = rpcErr
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			res["result"] = result
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+}
+
+func TestCall(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "echo":
+			return params, nil
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			return nil, nil
+		default:
+			return nil, &Error{Code: -32601, Message: "Method not found", Data: json.RawMessage(`"` + method + `"`)}
+		}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	var res map[string]string
+	if err := c.Call(ctx, "echo", map[string]string{"a": "b"}, &res); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, map[string]string{"a": "b"}) {
+		t.Errorf("Call() returned %v", res)
+	}
+	err := c.Call(ctx, "unknown", nil, nil)
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("Call() returned %v (want method not found)", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := c.Call(ctx, "slow", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Call() returned %v (want context.DeadlineExceeded)", err)
+	}
+}
+
+func TestBlockReference(t *testing.T) {
+	for _, test := range []struct {
+		ref  BlockReference
+		want string
+	}{
+		{BlockReference{}, `{"finality":"final"}`},
+		{Optimistic(), `{"finality":"optimistic"}`},
+		{AtHeight(42), `{"block_id":42}`},
+		{AtHeight(0), `{"block_id":0}`},
+		{BlockReference{BlockHeight: 42}, `{"block_id":42}`},
+		{AtHash("9cW4"), `{"block_id":"9cW4"}`},
+	} {
+		data, err := json.Marshal(test.ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.want {
+			t.Errorf("json.Marshal(%+v) returned %s (want %s)", test.ref, data, test.want)
+		}
+	}
+	// the genesis block is selected by height like any other block
+	if p, err := ChunkInBlock(AtHeight(0), 1).params(); err != nil || p["block_id"] != uint64(0) {
+		t.Errorf("ChunkInBlock(AtHeight(0)) has params %v, %v", p, err)
+	}
+	if p := EpochOfBlock(AtHeight(0)).params(); !reflect.DeepEqual(p, map[string]interface{}{"block_id": uint64(0)}) {
+		t.Errorf("EpochOfBlock(AtHeight(0)) has params %v", p)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		if method != "query" || p["finality"] != "final" {
+			t.Errorf("unexpected request %s %v", method, p)
+		}
+		switch p["request_type"] {
+		case "view_code":
+			return map[string]interface{}{
+				"block_hash": "9cW4", "block_height": 42,
+				"code_base64": "AGFzbQ==", "hash": "11111111111111111111111111111111",
+			}, nil
+		case "view_state":
+			if p["prefix_base64"] != "U1RBVEU=" {
+				t.Errorf("view_state has prefix %v", p["prefix_base64"])
+			}
+			return map[string]interface{}{
+				"block_hash": "9cW4", "block_height": 42,
+				"values": []map[string]string{{"key": "U1RBVEU=", "value": "AQ=="}},
+			}, nil
+		default:
+			return map[string]interface{}{"error": "account does not exist", "logs": []string{}}, nil
+		}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	code, err := c.ViewCode(ctx, "contract.testnet", Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(code.Code) != "\x00asm" || code.BlockHeight != 42 {
+		t.Errorf("ViewCode() returned %+v", code)
+	}
+	state, err := c.ViewState(ctx, "contract.testnet", []byte("STATE"), Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []StateItem{{Key: []byte("STATE"), Value: []byte{1}}}
+	if !reflect.DeepEqual(state.Values, want) {
+		t.Errorf("ViewState() returned %+v (want %+v)", state.Values, want)
+	}
+	var res QueryResponse
+	if err := c.Query(ctx, "view_account", Final(), nil, &res); err == nil {
+		t.Error("Query() did not return error in result")
+	}
+}
+
+func TestNewClientForNetwork(t *testing.T) {
+	c, err := NewClientForNetwork("mainnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Endpoint() != "https://rpc.mainnet.near.org" {
+		t.Errorf("NewClientForNetwork(mainnet) has endpoint %s", c.Endpoint())
+	}
+	if _, err := NewClientForNetwork("devnet"); err == nil {
+		t.Error("NewClientForNetwork() accepted unknown network")
+	}
+}
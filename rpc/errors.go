@@ -0,0 +1,197 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors for the error classes, errors returned by the node match
+// the sentinel of their class with errors.Is.
+var (
+	// ErrRequestValidation is matched by errors for invalid requests.
+	ErrRequestValidation = errors.New("rpc: request validation error")
+	// ErrHandler is matched by errors returned by the handler of a valid
+	// request.
+	ErrHandler = errors.New("rpc: handler error")
+	// ErrInternal is matched by internal errors of the node.
+	ErrInternal = errors.New("rpc: internal error")
+)
+
+// Sentinel errors for the error causes, errors returned by the node match the
+// sentinel of their cause with errors.Is.
+var (
+	// ErrUnknownAccount is matched by errors returned for accounts which do
+	// not exist.
+	ErrUnknownAccount = errors.New("rpc: account does not exist")
+	// ErrUnknownAccessKey is matched by errors returned for access keys which
+	// do not exist.
+	ErrUnknownAccessKey = errors.New("rpc: access key does not exist")
+	// ErrContractExecution is matched by errors returned if a view function
+	// failed. The error message of the contract runtime is part of the error
+	// data.
+	ErrContractExecution = errors.New("rpc: contract execution failed")
+	// ErrNoContractCode is matched by errors returned for accounts without
+	// contract.
+	ErrNoContractCode = errors.New("rpc: no contract code")
+	// ErrTooLargeContractState is matched by errors returned if the state to
+	// view exceeds the limit of the node.
+	ErrTooLargeContractState = errors.New("rpc: contract state too large")
+	// ErrUnknownReceipt is matched by errors returned for receipts the node
+	// does not know.
+	ErrUnknownReceipt = errors.New("rpc: unknown receipt")
+	// ErrUnknownBlock is matched by errors returned for blocks the node does
+	// not know, which includes blocks garbage collected by non-archival
+	// nodes.
+	ErrUnknownBlock = errors.New("rpc: unknown block")
+	// ErrGarbageCollectedBlock is matched by errors returned for blocks
+	// garbage collected by non-archival nodes.
+	ErrGarbageCollectedBlock = errors.New("rpc: block garbage collected")
+	// ErrUnknownChunk is matched by errors returned for chunks the node does
+	// not know.
+	ErrUnknownChunk = errors.New("rpc: unknown chunk")
+	// ErrUnknownEpoch is matched by errors returned for epochs the node does
+	// not know.
+	ErrUnknownEpoch = errors.New("rpc: unknown epoch")
+	// ErrInvalidShardID is matched by errors returned for shards which do not
+	// exist.
+	ErrInvalidShardID = errors.New("rpc: invalid shard ID")
+	// ErrUnavailableShard is matched by errors returned if the node does not
+	// track the shard of the request.
+	ErrUnavailableShard = errors.New("rpc: shard unavailable")
+	// ErrNotSynced is matched by errors returned while the node is syncing.
+	ErrNotSynced = errors.New("rpc: node not synced")
+	// ErrUnknownTransaction is matched by errors returned for transactions
+	// the node does not know (yet).
+	ErrUnknownTransaction = errors.New("rpc: unknown transaction")
+	// ErrInvalidTransaction is matched by errors returned for transactions
+	// which were rejected, the reason is the cause info.
+	ErrInvalidTransaction = errors.New("rpc: invalid transaction")
+	// ErrTimeout is matched by errors returned if the node timed out waiting
+	// for a transaction, it might still be executed.
+	ErrTimeout = errors.New("rpc: timeout")
+	// ErrParse is matched by errors returned for requests with invalid
+	// parameters.
+	ErrParse = errors.New("rpc: parse error")
+	// ErrMethodNotFound is matched by errors returned for unknown methods.
+	ErrMethodNotFound = errors.New("rpc: method not found")
+)
+
+// classes maps error names to the sentinel errors matched by Error.Is.
+var classes = map[string]error{
+	"REQUEST_VALIDATION_ERROR": ErrRequestValidation,
+	"HANDLER_ERROR":            ErrHandler,
+	"INTERNAL_ERROR":           ErrInternal,
+}
+
+// causes maps error cause names to the sentinel errors matched by Error.Is.
+var causes = map[string][]error{
+	"UNKNOWN_ACCOUNT":          {ErrUnknownAccount},
+	"UNKNOWN_ACCESS_KEY":       {ErrUnknownAccessKey},
+	"CONTRACT_EXECUTION_ERROR": {ErrContractExecution},
+	"NO_CONTRACT_CODE":         {ErrNoContractCode},
+	"TOO_LARGE_CONTRACT_STATE": {ErrTooLargeContractState},
+	"UNKNOWN_RECEIPT":          {ErrUnknownReceipt},
+	"UNKNOWN_BLOCK":            {ErrUnknownBlock},
+	"GARBAGE_COLLECTED_BLOCK":  {ErrGarbageCollectedBlock, ErrUnknownBlock},
+	"UNKNOWN_CHUNK":            {ErrUnknownChunk},
+	"UNKNOWN_EPOCH":            {ErrUnknownEpoch},
+	"INVALID_SHARD_ID":         {ErrInvalidShardID},
+	"UNAVAILABLE_SHARD":        {ErrUnavailableShard},
+	"NO_SYNCED_BLOCKS":         {ErrNotSynced},
+	"NOT_SYNCED_YET":           {ErrNotSynced},
+	"UNKNOWN_TRANSACTION":      {ErrUnknownTransaction},
+	"INVALID_TRANSACTION":      {ErrInvalidTransaction},
+	"TIMEOUT_ERROR":            {ErrTimeout},
+	"PARSE_ERROR":              {ErrParse},
+	"METHOD_NOT_FOUND":         {ErrMethodNotFound},
+}
+
+// ErrorCause is the structured cause of an error.
+type ErrorCause struct {
+	Name string          `json:"name"`
+	Info json.RawMessage `json:"info,omitempty"`
+}
+
+// Error is a JSON-RPC error returned by the node. Use errors.Is with the
+// sentinel errors of the class (like ErrHandler) and cause (like
+// ErrUnknownBlock) to check for specific errors and DecodeCauseInfo for their
+// details.
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	// Name is the error class like HANDLER_ERROR, empty for old nodes.
+	Name  string      `json:"name,omitempty"`
+	Cause *ErrorCause `json:"cause,omitempty"`
+}
+
+// CauseName returns the name of the cause like UNKNOWN_BLOCK, empty if the
+// node did not return a cause.
+func (e *Error) CauseName() string {
+	if e.Cause == nil {
+		return ""
+	}
+	return e.Cause.Name
+}
+
+// DecodeCauseInfo decodes the info of the cause into v. The info is specific
+// to the cause, for example UNKNOWN_ACCOUNT has the requested_account_id,
+// block_height and block_hash.
+func (e *Error) DecodeCauseInfo(v interface{}) error {
+	if e.Cause == nil || len(e.Cause.Info) == 0 {
+		return errors.New("rpc: error has no cause info")
+	}
+	return json.Unmarshal(e.Cause.Info, v)
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	detail := e.Message
+	if len(e.Data) > 0 {
+		var s string
+		if err := json.Unmarshal(e.Data, &s); err == nil {
+			detail = s
+		} else {
+			detail = string(e.Data)
+		}
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("rpc: %s: %s", e.Cause.Name, detail)
+	}
+	return fmt.Sprintf("rpc: %d: %s", e.Code, detail)
+}
+
+// Is reports whether the class or cause of e corresponds to the sentinel
+// error target.
+func (e *Error) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	if classes[e.Name] == target {
+		return true
+	}
+	for _, err := range causes[e.CauseName()] {
+		if err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPError is returned if the node answered with an HTTP error status and
+// without a JSON-RPC response, for example when rate limited by a provider.
+type HTTPError struct {
+	Method     string
+	StatusCode int
+	// Body is the beginning of the response body.
+	Body string
+}
+
+// Error implements error.
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("rpc: %s returned HTTP status %d", e.Method, e.StatusCode)
+	}
+	return fmt.Sprintf("rpc: %s returned HTTP status %d: %s", e.Method, e.StatusCode, e.Body)
+}
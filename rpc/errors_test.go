@@ -0,0 +1,63 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	data := `{"jsonrpc":"2.0","id":1,"error":{"name":"HANDLER_ERROR","cause":{"name":"GARBAGE_COLLECTED_BLOCK",
+		"info":{"block_height":42,"block_hash":"CbaJJ5RjK7GuCpW8kpdepznYWbmpVdJvnSvNuWDjrtmR"}},
+		"code":-32000,"message":"Server error","data":"Block either has never been observed on the node or has been garbage collected: BlockId(Height(42))"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte("rate limit exceeded\n"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(data))
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	err := NewClient(srv.URL).Call(ctx, "block", AtHeight(42), nil)
+	for _, target := range []error{ErrHandler, ErrGarbageCollectedBlock, ErrUnknownBlock} {
+		if !errors.Is(err, target) {
+			t.Errorf("Call() returned %v (want %v)", err, target)
+		}
+	}
+	for _, target := range []error{ErrInternal, ErrUnknownChunk} {
+		if errors.Is(err, target) {
+			t.Errorf("Call() returned error matching %v", target)
+		}
+	}
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("Call() returned %T", err)
+	}
+	var info struct {
+		BlockHeight uint64 `json:"block_height"`
+	}
+	if err := rpcErr.DecodeCauseInfo(&info); err != nil || info.BlockHeight != 42 {
+		t.Errorf("DecodeCauseInfo() returned %+v, %v", info, err)
+	}
+	want := "rpc: GARBAGE_COLLECTED_BLOCK: Block either has never been observed on the node or has been garbage collected: BlockId(Height(42))"
+	if err.Error() != want {
+		t.Errorf("Error() returned %q (want %q)", err.Error(), want)
+	}
+	err = NewClient(srv.URL+"/limited").Call(ctx, "block", Final(), nil)
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests || httpErr.Body != "rate limit exceeded" {
+		t.Errorf("Call() returned %v (want HTTP error 429)", err)
+	}
+	// old nodes without cause
+	old := &Error{Code: -32000, Message: "Server error", Data: json.RawMessage(`{"foo":1}`)}
+	if errors.Is(old, ErrHandler) || old.Error() != `rpc: -32000: {"foo":1}` || old.CauseName() != "" {
+		t.Errorf("error without cause is %v", old)
+	}
+}
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+)
+
+// EventLogPrefix is the prefix of logs with NEP-297 events.
+const EventLogPrefix = "EVENT_JSON:"
+
+// ErrNotEvent is returned by ParseEvent for logs which are no events.
+var ErrNotEvent = errors.New("rpc: log is no event")
+
+// Event is a NEP-297 event, which contracts emit as log with the
+// EventLogPrefix followed by the JSON encoded event.
+type Event struct {
+	// Standard is the standard of the event like nep171.
+	Standard string `json:"standard"`
+	Version  string `json:"version"`
+	// Event is the name of the event like nft_mint.
+	Event string `json:"event"`
+	// Data is the JSON encoded data of the event, if any.
+	Data json.RawMessage `json:"data,omitempty"`
+	// ExecutorID is the account which emitted the event and ReceiptID the
+	// receipt (or transaction hash) of the execution, set by Events.
+	ExecutorID string `json:"-"`
+	ReceiptID  string `json:"-"`
+}
+
+// ParseEvent parses the event of log. It returns ErrNotEvent if the log is
+// not an event log.
+func ParseEvent(log string) (*Event, error) {
+	if !strings.HasPrefix(log, EventLogPrefix) {
+		return nil, ErrNotEvent
+	}
+	var e Event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(log, EventLogPrefix)), &e); err != nil {
+		return nil, fmt.Errorf("rpc: invalid event: %w", err)
+	}
+	if e.Standard == "" || e.Event == "" {
+		return nil, fmt.Errorf("rpc: invalid event without standard or name")
+	}
+	return &e, nil
+}
+
+// DecodeData decodes the JSON encoded data of the event into v.
+func (e *Event) DecodeData(v interface{}) error {
+	if len(e.Data) == 0 {
+		return errors.New("rpc: event has no data")
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// Events returns the valid events logged by the execution, invalid event
+// logs are skipped as required by NEP-297.
+func (o *ExecutionOutcomeWithIDView) Events() []Event {
+	var events []Event
+	for _, log := range o.Outcome.Logs {
+		e, err := ParseEvent(log)
+		if err != nil {
+			continue
+		}
+		e.ExecutorID, e.ReceiptID = o.Outcome.ExecutorID, o.ID
+		events = append(events, *e)
+	}
+	return events
+}
+
+// Events returns the valid events of all outcomes in execution order, see
+// Flatten.
+func (o *FinalExecutionOutcome) Events() []Event {
+	var events []Event
+	for _, outcome := range o.Flatten() {
+		events = append(events, outcome.Events()...)
+	}
+	return events
+}
+
+// ReturnValue returns the return value of the last function call of the
+// transaction, or the decoded failure if it failed.
+func (o *FinalExecutionOutcome) ReturnValue() ([]byte, error) {
+	if err := o.Err(); err != nil {
+		return nil, err
+	}
+	if o.Status.Kind != "SuccessValue" {
+		return nil, fmt.Errorf("rpc: transaction status %s has no return value", o.Status.Kind)
+	}
+	return o.Status.SuccessValue, nil
+}
+
+// DecodeReturnJSON decodes the JSON encoded return value into v.
+func (o *FinalExecutionOutcome) DecodeReturnJSON(v interface{}) error {
+	data, err := o.ReturnValue()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DecodeReturnBorsh decodes the Borsh encoded return value into v.
+func (o *FinalExecutionOutcome) DecodeReturnBorsh(v interface{}) error {
+	data, err := o.ReturnValue()
+	if err != nil {
+		return err
+	}
+	return borsh.Deserialize(data, v)
+}
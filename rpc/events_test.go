@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestEvents(t *testing.T) {
+	o := &FinalExecutionOutcome{
+		Status: ExecutionStatus{Kind: "SuccessValue", SuccessValue: []byte(`{"minted":2}`)},
+		TransactionOutcome: ExecutionOutcomeWithIDView{
+			ID:      "TX",
+			Outcome: ExecutionOutcomeView{ReceiptIDs: []string{"R1"}, ExecutorID: "alice.testnet"},
+		},
+		ReceiptsOutcome: []ExecutionOutcomeWithIDView{{
+			ID: "R1",
+			Outcome: ExecutionOutcomeView{ExecutorID: "nft.testnet", Logs: []string{
+				`minting`,
+				`EVENT_JSON:{"standard":"nep171","version":"1.0.0","event":"nft_mint","data":[{"owner_id":"alice.testnet","token_ids":["1","2"]}]}`,
+				`EVENT_JSON:{"standard":"nep171"`,
+				`EVENT_JSON:{"standard":"nep141","version":"1.0.0","event":"ft_burn"}`,
+			}},
+		}},
+	}
+	events := o.Events()
+	if len(events) != 2 {
+		t.Fatalf("Events() returned %d events (want 2)", len(events))
+	}
+	e := events[0]
+	if e.Standard != "nep171" || e.Version != "1.0.0" || e.Event != "nft_mint" || e.ExecutorID != "nft.testnet" || e.ReceiptID != "R1" {
+		t.Errorf("Events() returned %+v", e)
+	}
+	var data []struct {
+		OwnerID  string   `json:"owner_id"`
+		TokenIDs []string `json:"token_ids"`
+	}
+	if err := e.DecodeData(&data); err != nil || len(data) != 1 || len(data[0].TokenIDs) != 2 {
+		t.Errorf("DecodeData() returned %+v, %v", data, err)
+	}
+	if err := events[1].DecodeData(&data); err == nil {
+		t.Error("DecodeData() of event without data succeeded")
+	}
+
+	if _, err := ParseEvent("minting"); !errors.Is(err, ErrNotEvent) {
+		t.Errorf("ParseEvent() of plain log returned %v (want ErrNotEvent)", err)
+	}
+	if _, err := ParseEvent(`EVENT_JSON:{"version":"1.0.0"}`); err == nil || errors.Is(err, ErrNotEvent) {
+		t.Errorf("ParseEvent() of invalid event returned %v", err)
+	}
+
+	var res struct {
+		Minted int `json:"minted"`
+	}
+	if err := o.DecodeReturnJSON(&res); err != nil || res.Minted != 2 {
+		t.Errorf("DecodeReturnJSON() returned %+v, %v", res, err)
+	}
+}
+
+func TestReturnValue(t *testing.T) {
+	o := &FinalExecutionOutcome{Status: ExecutionStatus{Kind: "SuccessValue", SuccessValue: []byte{2, 0, 0, 0, 'o', 'k'}}}
+	var s string
+	if err := o.DecodeReturnBorsh(&s); err != nil || s != "ok" {
+		t.Errorf("DecodeReturnBorsh() returned %q, %v", s, err)
+	}
+
+	o.Status = ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(
+		`{"ActionError":{"index":0,"kind":{"FunctionCallError":{"ExecutionError":"Smart contract panicked: no"}}}}`)}
+	var fcErr *FunctionCallError
+	if _, err := o.ReturnValue(); !errors.As(err, &fcErr) {
+		t.Errorf("ReturnValue() of failure returned %v (want *FunctionCallError)", err)
+	}
+
+	o.Status = ExecutionStatus{Kind: "Started"}
+	if _, err := o.ReturnValue(); err == nil {
+		t.Error("ReturnValue() of started transaction succeeded")
+	}
+}
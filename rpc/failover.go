@@ -0,0 +1,146 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCooldown is the time an endpoint is skipped after it failed, if
+// Options.Cooldown is not set.
+const DefaultCooldown = 30 * time.Second
+
+// backend is a JSON-RPC endpoint with its rate limiter and passive health
+// state.
+type backend struct {
+	url       string
+	host      string
+	limiter   *tokenBucket
+	downUntil int64 // unix nanoseconds, accessed atomically
+}
+
+func newBackend(url string) *backend {
+	return &backend{url: url, host: hostOf(url)}
+}
+
+func (e *backend) healthy(now time.Time) bool {
+	return atomic.LoadInt64(&e.downUntil) <= now.UnixNano()
+}
+
+func (e *backend) markDown(cooldown time.Duration) {
+	atomic.StoreInt64(&e.downUntil, time.Now().Add(cooldown).UnixNano())
+}
+
+func (e *backend) markUp() {
+	atomic.StoreInt64(&e.downUntil, 0)
+}
+
+// EndpointStatus is the health state of an endpoint of a client.
+type EndpointStatus struct {
+	URL     string
+	Healthy bool
+}
+
+// Endpoints returns the health state of all endpoints of c in order of
+// preference.
+func (c *Client) Endpoints() []EndpointStatus {
+	now := time.Now()
+	status := make([]EndpointStatus, len(c.endpoints))
+	for i, e := range c.endpoints {
+		status[i] = EndpointStatus{URL: e.url, Healthy: e.healthy(now)}
+	}
+	return status
+}
+
+// CheckHealth calls the health method on all endpoints of c and updates their
+// health state. It returns an error if no endpoint is healthy.
+func (c *Client) CheckHealth(ctx context.Context) error {
+	params := []interface{}{}
+	data, err := json.Marshal(request{JSONRPC: "2.0", ID: atomic.AddUint64(&c.id, 1), Method: "health", Params: params})
+	if err != nil {
+		return err
+	}
+	cl := &call{method: "health", params: params, data: data, attempt: 1}
+	var lastErr error
+	for _, e := range c.endpoints {
+		if _, err := c.do(ctx, e, cl); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			e.markDown(c.cooldown)
+			lastErr = err
+		} else {
+			e.markUp()
+		}
+	}
+	for _, e := range c.endpoints {
+		if e.healthy(time.Now()) {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+// order returns the endpoints in the order they are tried: healthy endpoints
+// first, starting at the next endpoint in round-robin mode, then the endpoints
+// which recently failed as last resort.
+func (c *Client) order() []*backend {
+	if len(c.endpoints) == 1 {
+		return c.endpoints
+	}
+	start := 0
+	if c.roundRobin {
+		start = int(atomic.AddUint32(&c.next, 1)-1) % len(c.endpoints)
+	}
+	now := time.Now()
+	healthy := make([]*backend, 0, len(c.endpoints))
+	var down []*backend
+	for i := range c.endpoints {
+		e := c.endpoints[(start+i)%len(c.endpoints)]
+		if e.healthy(now) {
+			healthy = append(healthy, e)
+		} else {
+			down = append(down, e)
+		}
+	}
+	return append(healthy, down...)
+}
+
+// send sends the call cl to the endpoints of c until one of them is
+// available.
+func (c *Client) send(ctx context.Context, cl *call) (json.RawMessage, error) {
+	var err error
+	for _, e := range c.order() {
+		var res json.RawMessage
+		res, err = c.do(ctx, e, cl)
+		if !endpointFailed(ctx, err) {
+			if err == nil && len(c.endpoints) > 1 {
+				e.markUp()
+			}
+			return res, err
+		}
+		if len(c.endpoints) > 1 {
+			e.markDown(c.cooldown)
+		}
+	}
+	return nil, err
+}
+
+// endpointFailed reports whether err is caused by the endpoint instead of the
+// request, i.e. whether another endpoint might succeed.
+func endpointFailed(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return errors.Is(err, ErrNotSynced)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+	return true
+}
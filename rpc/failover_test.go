@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer returns a server answering with status (if not 200) or the
+// result ok and counts the received requests.
+func countingServer(status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"ok"}`))
+	}))
+}
+
+func TestFailover(t *testing.T) {
+	var downCalls, upCalls int32
+	down := countingServer(http.StatusBadGateway, &downCalls)
+	defer down.Close()
+	up := countingServer(http.StatusOK, &upCalls)
+	defer up.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(down.URL, Options{Fallbacks: []string{up.URL}})
+	for i := 0; i < 3; i++ {
+		var res string
+		if err := c.Call(ctx, "status", nil, &res); err != nil || res != "ok" {
+			t.Fatalf("Call() returned %q, %v", res, err)
+		}
+	}
+	// the failed endpoint is skipped during the cooldown
+	if downCalls != 1 || upCalls != 3 {
+		t.Errorf("Call() made %d calls to failed and %d calls to healthy endpoint (want 1 and 3)", downCalls, upCalls)
+	}
+	status := c.Endpoints()
+	if len(status) != 2 || status[0].Healthy || !status[1].Healthy {
+		t.Errorf("Endpoints() returned %+v", status)
+	}
+	// node errors do not fail over
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		return nil, &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "UNKNOWN_ACCOUNT"}}
+	})
+	defer srv.Close()
+	atomic.StoreInt32(&upCalls, 0)
+	c = NewClientWithOptions(srv.URL, Options{Fallbacks: []string{up.URL}})
+	if _, err := c.ViewAccount(ctx, "missing.testnet", Final()); err == nil {
+		t.Error("ViewAccount() succeeded")
+	}
+	if upCalls != 0 {
+		t.Errorf("ViewAccount() failed over on node error")
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	var calls [3]int32
+	var urls []string
+	for i := range calls {
+		srv := countingServer(http.StatusOK, &calls[i])
+		defer srv.Close()
+		urls = append(urls, srv.URL)
+	}
+	c := NewClientWithOptions(urls[0], Options{Fallbacks: urls[1:], RoundRobin: true})
+	for i := 0; i < 6; i++ {
+		if err := c.Call(context.Background(), "status", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, n := range calls {
+		if n != 2 {
+			t.Errorf("endpoint %d received %d calls (want 2)", i, n)
+		}
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	var downCalls, upCalls int32
+	down := countingServer(http.StatusServiceUnavailable, &downCalls)
+	defer down.Close()
+	up := countingServer(http.StatusOK, &upCalls)
+	defer up.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(down.URL, Options{Fallbacks: []string{up.URL}})
+	if err := c.CheckHealth(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status := c.Endpoints(); status[0].Healthy || !status[1].Healthy {
+		t.Errorf("CheckHealth() marked endpoints %+v", status)
+	}
+	c = NewClient(down.URL)
+	if err := c.CheckHealth(ctx); err == nil {
+		t.Error("CheckHealth() succeeded without healthy endpoint")
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// GasPrice returns the gas price in yoctoNEAR of the block selected by height
+// or hash. References by finality select the latest block.
+//
+// For details see https://docs.near.org/api/rpc/gas#gas-price
+func (c *Client) GasPrice(ctx context.Context, block BlockReference) (types.Balance, error) {
+	var blockID interface{}
+	switch {
+	case block.Finality != "":
+	case block.BlockHash != "":
+		blockID = block.BlockHash
+	case block.hasHeight():
+		blockID = block.BlockHeight
+	}
+	var res struct {
+		GasPrice types.Balance `json:"gas_price"`
+	}
+	if err := c.Call(ctx, "gas_price", []interface{}{blockID}, &res); err != nil {
+		return types.Balance{}, err
+	}
+	return res.GasPrice, nil
+}
+
+// GasCost returns the cost in yoctoNEAR of gas at gasPrice.
+func GasCost(gas uint64, gasPrice types.Balance) types.Balance {
+	var cost types.Balance
+	cost.Mul(new(big.Int).SetUint64(gas), &gasPrice.Int)
+	return cost
+}
+
+// EstimateGasCost returns the cost in yoctoNEAR of gas at the gas price of the
+// latest block. This is the amount charged for gas attached to a function
+// call up front, unused gas is refunded after execution. The gas price can
+// change until receipts scheduled in later blocks are executed, so the actual
+// cost can deviate slightly.
+func (c *Client) EstimateGasCost(ctx context.Context, gas uint64) (types.Balance, error) {
+	gasPrice, err := c.GasPrice(ctx, BlockReference{Finality: FinalityFinal})
+	if err != nil {
+		return types.Balance{}, err
+	}
+	return GasCost(gas, gasPrice), nil
+}
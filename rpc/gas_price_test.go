@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestGasPrice(t *testing.T) {
+	var params []interface{}
+	srv := newTestServer(t, func(method string, p json.RawMessage) (interface{}, *Error) {
+		if err := json.Unmarshal(p, &params); err != nil {
+			t.Error(err)
+		}
+		return map[string]string{"gas_price": "100000000"}, nil
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	gasPrice, err := c.GasPrice(ctx, AtHeight(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gasPrice.Int64() != 100000000 || len(params) != 1 || params[0] != 42.0 {
+		t.Errorf("GasPrice() returned %s for params %v", &gasPrice, params)
+	}
+	// 300 Tgas
+	cost, err := c.EstimateGasCost(ctx, 300000000000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 1 || params[0] != nil {
+		t.Errorf("EstimateGasCost() sent params %v", params)
+	}
+	if cost.String() != "30000000000000000000000" {
+		t.Errorf("EstimateGasCost() returned %s (want 0.03 NEAR)", &cost)
+	}
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+)
+
+type headersKey struct{}
+
+// WithHeaders returns a copy of ctx carrying the headers h, which are sent
+// with the requests of calls using the context. They replace the static
+// headers of the client with the same name. Headers of parent contexts are
+// kept unless replaced.
+func WithHeaders(ctx context.Context, h http.Header) context.Context {
+	merged := contextHeaders(ctx).Clone()
+	if merged == nil {
+		merged = make(http.Header, len(h))
+	}
+	setHeaders(merged, h)
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// contextHeaders returns the headers of ctx set with WithHeaders.
+func contextHeaders(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headersKey{}).(http.Header)
+	return h
+}
+
+// setHeaders sets the headers src in dst, replacing values with the same
+// name.
+func setHeaders(dst, src http.Header) {
+	for k, v := range src {
+		dst[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+}
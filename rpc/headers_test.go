@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// roundTripperFunc implements http.RoundTripper with a function.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer srv.Close()
+	c := NewClientWithOptions(srv.URL, Options{Headers: http.Header{
+		"x-api-key":     {"static"},
+		"Authorization": {"Bearer static"},
+	}})
+	ctx := context.Background()
+	if err := c.Call(ctx, "status", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("X-Api-Key") != "static" || got.Get("Authorization") != "Bearer static" {
+		t.Errorf("request has headers %v", got)
+	}
+	// per-request headers replace static headers
+	ctx = WithHeaders(ctx, http.Header{"Authorization": {"Bearer request"}})
+	ctx = WithHeaders(ctx, http.Header{"X-Request-Id": {"42"}})
+	if err := c.Call(ctx, "status", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got.Get("X-Api-Key") != "static" || got.Get("Authorization") != "Bearer request" || got.Get("X-Request-Id") != "42" {
+		t.Errorf("request has headers %v", got)
+	}
+	if got.Get("Content-Type") != "application/json" {
+		t.Errorf("request has content type %s", got.Get("Content-Type"))
+	}
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`))
+	}))
+	defer srv.Close()
+	var calls int
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	for _, opts := range []Options{
+		{Transport: transport},
+		{HTTPClient: &http.Client{Transport: transport}},
+	} {
+		calls = 0
+		if err := NewClientWithOptions(srv.URL, opts).Call(context.Background(), "status", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if calls != 1 {
+			t.Errorf("Call() with options %+v made %d calls to transport (want 1)", opts, calls)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Request is a request to an endpoint as passed to hooks.
+type Request struct {
+	// Method is the JSON-RPC method, "batch" for batches.
+	Method string
+	// Params are the params of the call, nil for batches.
+	Params interface{}
+	// Endpoint is the URL of the endpoint the request is sent to.
+	Endpoint string
+	// Attempt is the number of the attempt of the call, starting at 1.
+	Attempt int
+	// HTTPRequest is the HTTP request with the encoded JSON-RPC request as
+	// body. Hooks can modify its headers, e.g. to sign the request.
+	HTTPRequest *http.Request
+}
+
+// Response is the successful response to a Request as passed to hooks.
+type Response struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int
+	// Result is the JSON-RPC result, the array of responses for batches.
+	// Hooks can replace it.
+	Result json.RawMessage
+	// Duration is the time from sending the request until the response was
+	// read.
+	Duration time.Duration
+}
+
+// Hooks intercept the requests of a client, see Options.Hooks. Hooks are
+// called for every request sent to an endpoint, so retries and failovers are
+// seen as separate requests. All hooks are optional.
+type Hooks struct {
+	// BeforeRequest is called before req is sent. If it returns an error the
+	// request fails with the error, which is handled like a transport error.
+	// If it returns a result, the request is not sent and the result is used
+	// instead; the remaining hooks are skipped.
+	BeforeRequest func(ctx context.Context, req *Request) (json.RawMessage, error)
+	// AfterResponse is called after a successful response to req was read.
+	AfterResponse func(ctx context.Context, req *Request, res *Response)
+	// OnError is called if req failed with err, including errors returned by
+	// the node and by BeforeRequest hooks.
+	OnError func(ctx context.Context, req *Request, err error)
+}
+
+// onError calls the OnError hooks of c.
+func (c *Client) onError(ctx context.Context, req *Request, err error) {
+	for _, h := range c.hooks {
+		if h.OnError != nil {
+			h.OnError(ctx, req, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var calls int32
+	srv := countingServer(http.StatusOK, &calls)
+	defer srv.Close()
+	var events []string
+	var auth string
+	c := NewClientWithOptions(srv.URL, Options{Hooks: []Hooks{
+		{
+			BeforeRequest: func(ctx context.Context, req *Request) (json.RawMessage, error) {
+				events = append(events, "before:"+req.Method)
+				req.HTTPRequest.Header.Set("Authorization", "signed")
+				return nil, nil
+			},
+			AfterResponse: func(ctx context.Context, req *Request, res *Response) {
+				auth = req.HTTPRequest.Header.Get("Authorization")
+				events = append(events, "after:"+string(res.Result))
+				res.Result = json.RawMessage(`"replaced"`)
+			},
+			OnError: func(ctx context.Context, req *Request, err error) {
+				events = append(events, "error:"+err.Error())
+			},
+		},
+		{
+			BeforeRequest: func(ctx context.Context, req *Request) (json.RawMessage, error) {
+				switch req.Method {
+				case "cached":
+					return json.RawMessage(`"cached"`), nil
+				case "chaos":
+					return nil, errors.New("chaos")
+				}
+				return nil, nil
+			},
+		},
+	}})
+	ctx := context.Background()
+	var res string
+	if err := c.Call(ctx, "status", nil, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res != "replaced" || auth != "signed" {
+		t.Errorf("Call() returned %s with authorization %s", res, auth)
+	}
+	if err := c.Call(ctx, "cached", nil, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res != "cached" {
+		t.Errorf("Call() returned %s (want cached)", res)
+	}
+	if err := c.Call(ctx, "chaos", nil, &res); err == nil || err.Error() != "chaos" {
+		t.Errorf("Call() returned %v (want chaos)", err)
+	}
+	want := []string{"before:status", `after:"ok"`, "before:cached", "before:chaos", "error:chaos"}
+	if len(events) != len(want) {
+		t.Fatalf("hooks were called with %q (want %q)", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("hook %d was called with %q (want %q)", i, events[i], want[i])
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server received %d calls (want 1)", calls)
+	}
+}
+
+func TestHooksRetry(t *testing.T) {
+	var calls int32
+	srv := countingServer(http.StatusServiceUnavailable, &calls)
+	defer srv.Close()
+	var attempts []int
+	c := NewClientWithOptions(srv.URL, Options{
+		Retry: &testRetryPolicy,
+		Hooks: []Hooks{{
+			OnError: func(ctx context.Context, req *Request, err error) {
+				attempts = append(attempts, req.Attempt)
+			},
+		}},
+	})
+	if err := c.Call(context.Background(), "status", nil, nil); err == nil {
+		t.Fatal("Call() succeeded")
+	}
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Errorf("OnError() was called for attempts %v (want [1 2 3])", attempts)
+	}
+}
@@ -0,0 +1,198 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// BlockHeaderInnerLiteView is the part of a block header signed by the block
+// producers which light clients need.
+type BlockHeaderInnerLiteView struct {
+	Height           uint64 `json:"height"`
+	EpochID          string `json:"epoch_id"`
+	NextEpochID      string `json:"next_epoch_id"`
+	PrevStateRoot    string `json:"prev_state_root"`
+	OutcomeRoot      string `json:"outcome_root"`
+	Timestamp        uint64 `json:"timestamp"`
+	TimestampNanosec string `json:"timestamp_nanosec"`
+	NextBPHash       string `json:"next_bp_hash"`
+	BlockMerkleRoot  string `json:"block_merkle_root"`
+}
+
+// LightClientBlockLiteView is a block header reduced to the data needed to
+// compute the block hash.
+type LightClientBlockLiteView struct {
+	PrevBlockHash string                   `json:"prev_block_hash"`
+	InnerRestHash string                   `json:"inner_rest_hash"`
+	InnerLite     BlockHeaderInnerLiteView `json:"inner_lite"`
+}
+
+// decodeHash decodes a base58 encoded 32 byte hash.
+func decodeHash(s string) ([]byte, error) {
+	h := base58.Decode(s)
+	if len(h) != sha256.Size {
+		return nil, fmt.Errorf("rpc: invalid hash '%s'", s)
+	}
+	return h, nil
+}
+
+// combineHash returns sha256(a || b).
+func combineHash(a, b []byte) []byte {
+	h := sha256.New()
+	h.Write(a)
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// BlockHash computes the hash of the block from the header data, which is
+// sha256(sha256(sha256(borsh(inner_lite)) || inner_rest_hash) ||
+// prev_block_hash). The result is base58 encoded.
+func (b *LightClientBlockLiteView) BlockHash() (string, error) {
+	return blockHash(b.PrevBlockHash, b.InnerRestHash, &b.InnerLite)
+}
+
+func blockHash(prevBlockHash, innerRestHash string, inner *BlockHeaderInnerLiteView) (string, error) {
+	var lite []byte
+	lite = binary.LittleEndian.AppendUint64(lite, inner.Height)
+	for _, s := range []string{inner.EpochID, inner.NextEpochID, inner.PrevStateRoot, inner.OutcomeRoot} {
+		h, err := decodeHash(s)
+		if err != nil {
+			return "", err
+		}
+		lite = append(lite, h...)
+	}
+	lite = binary.LittleEndian.AppendUint64(lite, inner.Timestamp)
+	for _, s := range []string{inner.NextBPHash, inner.BlockMerkleRoot} {
+		h, err := decodeHash(s)
+		if err != nil {
+			return "", err
+		}
+		lite = append(lite, h...)
+	}
+	restHash, err := decodeHash(innerRestHash)
+	if err != nil {
+		return "", err
+	}
+	prevHash, err := decodeHash(prevBlockHash)
+	if err != nil {
+		return "", err
+	}
+	liteHash := sha256.Sum256(lite)
+	return base58.Encode(combineHash(combineHash(liteHash[:], restHash), prevHash)), nil
+}
+
+// MerkleRoot computes the root of the merkle tree with the leaf hash (raw) and
+// path and returns it base58 encoded. It is used to check the proofs returned
+// by LightClientProof against the outcome root and block merkle root.
+func MerkleRoot(leaf []byte, path []MerklePathItem) (string, error) {
+	h := leaf
+	for _, item := range path {
+		sibling, err := decodeHash(item.Hash)
+		if err != nil {
+			return "", err
+		}
+		switch item.Direction {
+		case "Left":
+			h = combineHash(sibling, h)
+		case "Right":
+			h = combineHash(h, sibling)
+		default:
+			return "", fmt.Errorf("rpc: invalid merkle path direction '%s'", item.Direction)
+		}
+	}
+	return base58.Encode(h), nil
+}
+
+// LightClientProofRequest selects the outcome proven by LightClientProof, use
+// TransactionProof or ReceiptProof.
+type LightClientProofRequest struct {
+	Type            string `json:"type"`
+	TransactionHash string `json:"transaction_hash,omitempty"`
+	SenderID        string `json:"sender_id,omitempty"`
+	ReceiptID       string `json:"receipt_id,omitempty"`
+	ReceiverID      string `json:"receiver_id,omitempty"`
+	// LightClientHead is the hash of the latest block known to the light
+	// client, the proof is relative to it.
+	LightClientHead string `json:"light_client_head"`
+}
+
+// TransactionProof requests the proof of the outcome of the transaction
+// txHash signed by senderID relative to lightClientHead.
+func TransactionProof(txHash, senderID, lightClientHead string) LightClientProofRequest {
+	return LightClientProofRequest{Type: "transaction", TransactionHash: txHash, SenderID: senderID, LightClientHead: lightClientHead}
+}
+
+// ReceiptProof requests the proof of the outcome of the receipt receiptID
+// executed by receiverID relative to lightClientHead.
+func ReceiptProof(receiptID, receiverID, lightClientHead string) LightClientProofRequest {
+	return LightClientProofRequest{Type: "receipt", ReceiptID: receiptID, ReceiverID: receiverID, LightClientHead: lightClientHead}
+}
+
+// LightClientProof is the result of LightClientProof.
+type LightClientProof struct {
+	// OutcomeProof is the outcome with the proof of its inclusion in the
+	// outcome root of its shard.
+	OutcomeProof ExecutionOutcomeWithIDView `json:"outcome_proof"`
+	// OutcomeRootProof proves the inclusion of the shard outcome root in the
+	// outcome root of the block.
+	OutcomeRootProof []MerklePathItem `json:"outcome_root_proof"`
+	// BlockHeaderLite is the header of the block after the block including
+	// the outcome, whose outcome root covers the outcome.
+	BlockHeaderLite LightClientBlockLiteView `json:"block_header_lite"`
+	// BlockProof proves the inclusion of the block in the block merkle root
+	// of the light client head.
+	BlockProof []MerklePathItem `json:"block_proof"`
+}
+
+// LightClientProof returns the proof of an outcome for light clients.
+//
+// For details see https://docs.near.org/api/rpc/setup#light-client-proof
+func (c *Client) LightClientProof(ctx context.Context, req LightClientProofRequest) (*LightClientProof, error) {
+	var res LightClientProof
+	if err := c.Call(ctx, "light_client_proof", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// LightClientBlockView is a block light clients advance to, signed by the
+// block producers of its epoch.
+type LightClientBlockView struct {
+	PrevBlockHash      string                   `json:"prev_block_hash"`
+	NextBlockInnerHash string                   `json:"next_block_inner_hash"`
+	InnerLite          BlockHeaderInnerLiteView `json:"inner_lite"`
+	InnerRestHash      string                   `json:"inner_rest_hash"`
+	// NextBPs are the block producers of the next epoch, only set for the
+	// first block of an epoch.
+	NextBPs            []ValidatorStakeView `json:"next_bps"`
+	ApprovalsAfterNext []*string            `json:"approvals_after_next"`
+}
+
+// BlockHash computes the hash of the block, see
+// LightClientBlockLiteView.BlockHash.
+func (b *LightClientBlockView) BlockHash() (string, error) {
+	return blockHash(b.PrevBlockHash, b.InnerRestHash, &b.InnerLite)
+}
+
+// NextLightClientBlock returns the light client block following
+// lastBlockHash, the last block known to the light client. This is the next
+// block in the next epoch or the last final block of the current epoch. It
+// returns nil if there is no newer block.
+//
+// For details see https://docs.near.org/api/rpc/setup#light-client-block
+func (c *Client) NextLightClientBlock(ctx context.Context, lastBlockHash string) (*LightClientBlockView, error) {
+	var res *LightClientBlockView
+	if err := c.Call(ctx, "next_light_client_block", map[string]interface{}{
+		"last_block_hash": lastBlockHash,
+	}, &res); err != nil {
+		return nil, err
+	}
+	if res == nil || res.PrevBlockHash == "" {
+		return nil, nil
+	}
+	return res, nil
+}
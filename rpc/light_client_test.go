@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/near/borsh-go"
+)
+
+func TestLightClientBlockHash(t *testing.T) {
+	h := func(s string) [32]byte { return sha256.Sum256([]byte(s)) }
+	enc := func(b [32]byte) string { return base58.Encode(b[:]) }
+	inner := struct {
+		Height          uint64
+		EpochID         [32]byte
+		NextEpochID     [32]byte
+		PrevStateRoot   [32]byte
+		OutcomeRoot     [32]byte
+		Timestamp       uint64
+		NextBPHash      [32]byte
+		BlockMerkleRoot [32]byte
+	}{42, h("epoch"), h("next epoch"), h("state"), h("outcome"), 1680000000123456789, h("bp"), h("merkle")}
+	block := LightClientBlockLiteView{
+		PrevBlockHash: enc(h("prev")),
+		InnerRestHash: enc(h("rest")),
+		InnerLite: BlockHeaderInnerLiteView{
+			Height:          inner.Height,
+			EpochID:         enc(inner.EpochID),
+			NextEpochID:     enc(inner.NextEpochID),
+			PrevStateRoot:   enc(inner.PrevStateRoot),
+			OutcomeRoot:     enc(inner.OutcomeRoot),
+			Timestamp:       inner.Timestamp,
+			NextBPHash:      enc(inner.NextBPHash),
+			BlockMerkleRoot: enc(inner.BlockMerkleRoot),
+		},
+	}
+	data, err := borsh.Serialize(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	liteHash := sha256.Sum256(data)
+	rest, prev := h("rest"), h("prev")
+	want := base58.Encode(combineHash(combineHash(liteHash[:], rest[:]), prev[:]))
+	got, err := block.BlockHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("BlockHash() returned %s (want %s)", got, want)
+	}
+	block.InnerLite.EpochID = "invalid"
+	if _, err := block.BlockHash(); err == nil {
+		t.Error("BlockHash() accepted invalid hash")
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := make([][]byte, 3)
+	for i := range leaves {
+		h := sha256.Sum256([]byte{byte(i)})
+		leaves[i] = h[:]
+	}
+	// tree ((0, 1), 2)
+	root := base58.Encode(combineHash(combineHash(leaves[0], leaves[1]), leaves[2]))
+	path := []MerklePathItem{
+		{Hash: base58.Encode(leaves[0]), Direction: "Left"},
+		{Hash: base58.Encode(leaves[2]), Direction: "Right"},
+	}
+	got, err := MerkleRoot(leaves[1], path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != root {
+		t.Errorf("MerkleRoot() returned %s (want %s)", got, root)
+	}
+	path[0].Direction = "Up"
+	if _, err := MerkleRoot(leaves[1], path); err == nil {
+		t.Error("MerkleRoot() accepted invalid direction")
+	}
+}
+
+func TestLightClientQueries(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		switch method {
+		case "light_client_proof":
+			if p["type"] != "transaction" || p["transaction_hash"] != "TX" || p["sender_id"] != "alice.testnet" || p["light_client_head"] != "HEAD" {
+				t.Errorf("light_client_proof has params %v", p)
+			}
+			return json.RawMessage(`{"outcome_proof":{"proof":[],"block_hash":"B","id":"TX","outcome":{"logs":[],"receipt_ids":["R1"],
+				"gas_burnt":1,"tokens_burnt":"1","executor_id":"alice.testnet","status":{"SuccessReceiptId":"R1"},"metadata":{"version":1,"gas_profile":null}}},
+				"outcome_root_proof":[{"hash":"11111111111111111111111111111111","direction":"Left"}],
+				"block_header_lite":{"prev_block_hash":"P","inner_rest_hash":"R","inner_lite":{"height":42}},"block_proof":[]}`), nil
+		case "next_light_client_block":
+			if p["last_block_hash"] == "HEAD" {
+				return json.RawMessage(`{}`), nil
+			}
+			return json.RawMessage(`{"prev_block_hash":"P","next_block_inner_hash":"N","inner_lite":{"height":43},"inner_rest_hash":"R",
+				"next_bps":[{"account_id":"node0","public_key":"ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e","stake":"1","validator_stake_struct_version":"V1"}],
+				"approvals_after_next":[null]}`), nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	proof, err := c.LightClientProof(ctx, TransactionProof("TX", "alice.testnet", "HEAD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.OutcomeProof.ID != "TX" || len(proof.OutcomeRootProof) != 1 || proof.BlockHeaderLite.InnerLite.Height != 42 {
+		t.Errorf("LightClientProof() returned %+v", proof)
+	}
+	block, err := c.NextLightClientBlock(ctx, "OLD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block == nil || block.InnerLite.Height != 43 || len(block.NextBPs) != 1 || block.ApprovalsAfterNext[0] != nil {
+		t.Errorf("NextLightClientBlock() returned %+v", block)
+	}
+	if block, err := c.NextLightClientBlock(ctx, "HEAD"); err != nil || block != nil {
+		t.Errorf("NextLightClientBlock() returned %+v, %v for latest block", block, err)
+	}
+}
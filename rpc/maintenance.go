@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+)
+
+// MaintenanceWindow is a range of block heights [Start, End).
+type MaintenanceWindow struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+// Contains reports whether height lies within the window.
+func (w MaintenanceWindow) Contains(height uint64) bool {
+	return w.Start <= height && height < w.End
+}
+
+// MaintenanceWindows returns the ranges of block heights of the current epoch
+// in which the validator accountID neither produces blocks nor chunks, so its
+// node can be restarted without missing any.
+//
+// For details see https://docs.near.org/api/rpc/maintenance-windows
+func (c *Client) MaintenanceWindows(ctx context.Context, accountID string) ([]MaintenanceWindow, error) {
+	var res []MaintenanceWindow
+	if err := c.Call(ctx, "EXPERIMENTAL_maintenance_windows", map[string]interface{}{
+		"account_id": accountID,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SplitStorageInfo is the result of SplitStorageInfo. The heights are nil if
+// the node does not have the respective head.
+type SplitStorageInfo struct {
+	HeadHeight      *uint64 `json:"head_height"`
+	FinalHeadHeight *uint64 `json:"final_head_height"`
+	// ColdHeadHeight is the height of the latest block copied to cold
+	// storage, nil for nodes without split storage.
+	ColdHeadHeight *uint64 `json:"cold_head_height"`
+	// HotDBKind is the kind of the hot database like RPC or Archive.
+	HotDBKind *string `json:"hot_db_kind"`
+}
+
+// SplitStorage reports whether the node uses split storage.
+func (s *SplitStorageInfo) SplitStorage() bool {
+	return s.ColdHeadHeight != nil
+}
+
+// InColdStorage reports whether the block at height was already copied to
+// cold storage. Queries for such blocks are served from cold storage (and are
+// slower) once they were garbage collected from hot storage.
+func (s *SplitStorageInfo) InColdStorage(height uint64) bool {
+	return s.ColdHeadHeight != nil && height <= *s.ColdHeadHeight
+}
+
+// SplitStorageInfo returns the state of the hot and cold storage of archival
+// nodes with split storage. It is informational only, e.g. for monitoring:
+// the client does not route calls by it, calls for garbage collected blocks
+// fall back to Options.Archival regardless of its storage layout.
+//
+// For details see https://docs.near.org/api/rpc/setup#split-storage
+func (c *Client) SplitStorageInfo(ctx context.Context) (*SplitStorageInfo, error) {
+	var res SplitStorageInfo
+	if err := c.Call(ctx, "EXPERIMENTAL_split_storage_info", map[string]interface{}{}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "EXPERIMENTAL_maintenance_windows":
+			var p map[string]string
+			if err := json.Unmarshal(params, &p); err != nil || p["account_id"] != "node0" {
+				t.Errorf("maintenance_windows has params %s", params)
+			}
+			return json.RawMessage(`[{"start":1028,"end":1031},{"start":1034,"end":1038}]`), nil
+		case "EXPERIMENTAL_split_storage_info":
+			return json.RawMessage(`{"head_height":2000,"final_head_height":1998,"cold_head_height":1990,"hot_db_kind":"Hot"}`), nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	windows, err := c.MaintenanceWindows(ctx, "node0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(windows, []MaintenanceWindow{{1028, 1031}, {1034, 1038}}) {
+		t.Errorf("MaintenanceWindows() returned %v", windows)
+	}
+	if !windows[0].Contains(1028) || windows[0].Contains(1031) {
+		t.Error("Contains() does not treat the window as half-open")
+	}
+	info, err := c.SplitStorageInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.SplitStorage() || !info.InColdStorage(1990) || info.InColdStorage(1991) || *info.HotDBKind != "Hot" {
+		t.Errorf("SplitStorageInfo() returned %+v", info)
+	}
+}
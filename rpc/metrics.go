@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are the Prometheus metrics of clients, see Options.Metrics. They
+// can be shared by several clients.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+// NewMetrics returns new client metrics registered on reg:
+//
+//   - near_rpc_requests_total: requests sent by method and endpoint host
+//   - near_rpc_errors_total: failed requests by method and error cause
+//   - near_rpc_request_duration_seconds: latency of requests by method
+//   - near_rpc_retries_total: retried calls by method
+//
+// The error cause is the cause name of errors returned by the node (e.g.
+// UNKNOWN_BLOCK), HTTP_<status code> for HTTP errors, TIMEOUT for network
+// timeouts and TRANSPORT for other failures.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "near_rpc_requests_total",
+			Help: "Number of NEAR JSON-RPC requests sent.",
+		}, []string{"method", "endpoint"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "near_rpc_errors_total",
+			Help: "Number of failed NEAR JSON-RPC requests.",
+		}, []string{"method", "cause"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "near_rpc_request_duration_seconds",
+			Help:    "Latency of NEAR JSON-RPC requests.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "near_rpc_retries_total",
+			Help: "Number of retried NEAR JSON-RPC calls.",
+		}, []string{"method"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.errors, m.duration, m.retries} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// observe records the request of method to endpoint e which took d and
+// failed with err (if not nil).
+func (m *Metrics) observe(method string, e *backend, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.requests.WithLabelValues(method, e.host).Inc()
+	m.duration.WithLabelValues(method).Observe(d.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(method, errorCause(err)).Inc()
+	}
+}
+
+// retried records the retries of a call of method.
+func (m *Metrics) retried(method string, retries int) {
+	if m == nil || retries == 0 {
+		return
+	}
+	m.retries.WithLabelValues(method).Add(float64(retries))
+}
+
+// errorCause returns the cause label of err.
+func errorCause(err error) string {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		if cause := rpcErr.CauseName(); cause != "" {
+			return cause
+		}
+		if rpcErr.Name != "" {
+			return rpcErr.Name
+		}
+		return "RPC_" + strconv.Itoa(rpcErr.Code)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return "HTTP_" + strconv.Itoa(httpErr.StatusCode)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "TIMEOUT"
+	}
+	return "TRANSPORT"
+}
+
+// hostOf returns the host of the endpoint URL u, which is used as label
+// instead of the URL as the path can contain API keys.
+func hostOf(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Host == "" {
+		return "invalid"
+	}
+	return parsed.Host
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		if method == "block" {
+			return nil, unknownBlock()
+		}
+		return "ok", nil
+	})
+	defer srv.Close()
+	reg := prometheus.NewRegistry()
+	m, err := NewMetrics(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewClientWithOptions(srv.URL, Options{Metrics: m, Retry: &testRetryPolicy})
+	ctx := context.Background()
+	if err := c.Call(ctx, "status", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Block(ctx, Optimistic()); err == nil {
+		t.Fatal("Block() succeeded")
+	}
+	host := c.endpoints[0].host
+	for _, tc := range []struct {
+		name string
+		c    prometheus.Collector
+		want float64
+	}{
+		{"requests{status}", m.requests.WithLabelValues("status", host), 1},
+		{"requests{block}", m.requests.WithLabelValues("block", host), 3},
+		{"errors{block,UNKNOWN_BLOCK}", m.errors.WithLabelValues("block", "UNKNOWN_BLOCK"), 3},
+		{"retries{block}", m.retries.WithLabelValues("block"), 2},
+	} {
+		if got := testutil.ToFloat64(tc.c); got != tc.want {
+			t.Errorf("%s = %v (want %v)", tc.name, got, tc.want)
+		}
+	}
+	if n := testutil.CollectAndCount(m.duration); n != 2 {
+		t.Errorf("duration has %d series (want 2)", n)
+	}
+	// metrics cannot be registered twice on the same registry
+	if _, err := NewMetrics(reg); err == nil {
+		t.Error("NewMetrics() registered metrics twice")
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{unknownBlock(), "UNKNOWN_BLOCK"},
+		{&Error{Code: -32700, Name: "REQUEST_VALIDATION_ERROR"}, "REQUEST_VALIDATION_ERROR"},
+		{&Error{Code: -32601}, "RPC_-32601"},
+		{&HTTPError{StatusCode: http.StatusTooManyRequests}, "HTTP_429"},
+		{errors.New("connection refused"), "TRANSPORT"},
+	} {
+		if got := errorCause(tc.err); got != tc.want {
+			t.Errorf("errorCause(%v) returned %s (want %s)", tc.err, got, tc.want)
+		}
+	}
+	if got := hostOf("https://example.quiknode.pro/secret-token/"); got != "example.quiknode.pro" {
+		t.Errorf("hostOf() returned %s", got)
+	}
+}
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"github.com/YuxSccc/near-api-go/config"
+)
+
+// NewClientForNetwork returns a new client for the RPC endpoint of the network
+// preset name, see config.Get. Historical queries fall back to the archival
+// endpoint of the network if it has one.
+func NewClientForNetwork(name string) (*Client, error) {
+	n, err := config.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithOptions(n.RPCURL, Options{Archival: n.ArchivalRPCURL}), nil
+}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// Rational is a fraction encoded as [numerator, denominator] in JSON.
+type Rational [2]int64
+
+// Float returns the value of the fraction.
+func (r Rational) Float() float64 {
+	if r[1] == 0 {
+		return 0
+	}
+	return float64(r[0]) / float64(r[1])
+}
+
+// Fee is the gas cost of an action or receipt.
+type Fee struct {
+	// SendSir is burned when the receipt is created if the sender is the
+	// receiver.
+	SendSir uint64 `json:"send_sir"`
+	// SendNotSir is burned when the receipt is created otherwise.
+	SendNotSir uint64 `json:"send_not_sir"`
+	// Execution is burned when the receipt is executed.
+	Execution uint64 `json:"execution"`
+}
+
+// Send returns the send fee for a receipt between sender and receiver.
+func (f Fee) Send(senderIsReceiver bool) uint64 {
+	if senderIsReceiver {
+		return f.SendSir
+	}
+	return f.SendNotSir
+}
+
+// AccessKeyCreationConfigView are the fees of AddKey actions.
+type AccessKeyCreationConfigView struct {
+	FullAccessCost          Fee `json:"full_access_cost"`
+	FunctionCallCost        Fee `json:"function_call_cost"`
+	FunctionCallCostPerByte Fee `json:"function_call_cost_per_byte"`
+}
+
+// ActionCreationConfigView are the fees of all actions.
+type ActionCreationConfigView struct {
+	CreateAccountCost         Fee                         `json:"create_account_cost"`
+	DeployContractCost        Fee                         `json:"deploy_contract_cost"`
+	DeployContractCostPerByte Fee                         `json:"deploy_contract_cost_per_byte"`
+	FunctionCallCost          Fee                         `json:"function_call_cost"`
+	FunctionCallCostPerByte   Fee                         `json:"function_call_cost_per_byte"`
+	TransferCost              Fee                         `json:"transfer_cost"`
+	StakeCost                 Fee                         `json:"stake_cost"`
+	AddKeyCost                AccessKeyCreationConfigView `json:"add_key_cost"`
+	DeleteKeyCost             Fee                         `json:"delete_key_cost"`
+	DeleteAccountCost         Fee                         `json:"delete_account_cost"`
+	DelegateCost              Fee                         `json:"delegate_cost"`
+}
+
+// DataReceiptCreationConfigView are the fees of data receipts.
+type DataReceiptCreationConfigView struct {
+	BaseCost    Fee `json:"base_cost"`
+	CostPerByte Fee `json:"cost_per_byte"`
+}
+
+// StorageUsageConfigView describes the storage accounted for accounts and
+// records.
+type StorageUsageConfigView struct {
+	NumBytesAccount     uint64 `json:"num_bytes_account"`
+	NumExtraBytesRecord uint64 `json:"num_extra_bytes_record"`
+}
+
+// RuntimeFeesConfigView is the fee schedule.
+type RuntimeFeesConfigView struct {
+	ActionReceiptCreationConfig       Fee                           `json:"action_receipt_creation_config"`
+	DataReceiptCreationConfig         DataReceiptCreationConfigView `json:"data_receipt_creation_config"`
+	ActionCreationConfig              ActionCreationConfigView      `json:"action_creation_config"`
+	StorageUsageConfig                StorageUsageConfigView        `json:"storage_usage_config"`
+	BurntGasReward                    Rational                      `json:"burnt_gas_reward"`
+	PessimisticGasPriceInflationRatio Rational                      `json:"pessimistic_gas_price_inflation_ratio"`
+}
+
+// AccountCreationConfigView restricts the creation of top-level accounts.
+type AccountCreationConfigView struct {
+	MinAllowedTopLevelAccountLength uint8  `json:"min_allowed_top_level_account_length"`
+	RegistrarAccountID              string `json:"registrar_account_id"`
+}
+
+// RuntimeConfigView is the runtime configuration.
+type RuntimeConfigView struct {
+	// StorageAmountPerByte is the amount of yoctoNEAR locked per byte of
+	// storage used by an account.
+	StorageAmountPerByte  types.Balance             `json:"storage_amount_per_byte"`
+	TransactionCosts      RuntimeFeesConfigView     `json:"transaction_costs"`
+	AccountCreationConfig AccountCreationConfigView `json:"account_creation_config"`
+	// WasmConfig is the configuration of the contract runtime, including the
+	// gas costs of host functions and limits.
+	WasmConfig json.RawMessage `json:"wasm_config"`
+}
+
+// ChainConfigView are the fields common to the protocol config and the
+// genesis config.
+type ChainConfigView struct {
+	ProtocolVersion                 uint32          `json:"protocol_version"`
+	GenesisTime                     time.Time       `json:"genesis_time"`
+	ChainID                         string          `json:"chain_id"`
+	GenesisHeight                   uint64          `json:"genesis_height"`
+	NumBlockProducerSeats           uint64          `json:"num_block_producer_seats"`
+	NumBlockProducerSeatsPerShard   []uint64        `json:"num_block_producer_seats_per_shard"`
+	AvgHiddenValidatorSeatsPerShard []uint64        `json:"avg_hidden_validator_seats_per_shard"`
+	DynamicResharding               bool            `json:"dynamic_resharding"`
+	ProtocolUpgradeStakeThreshold   Rational        `json:"protocol_upgrade_stake_threshold"`
+	EpochLength                     uint64          `json:"epoch_length"`
+	GasLimit                        uint64          `json:"gas_limit"`
+	MinGasPrice                     types.Balance   `json:"min_gas_price"`
+	MaxGasPrice                     types.Balance   `json:"max_gas_price"`
+	BlockProducerKickoutThreshold   uint8           `json:"block_producer_kickout_threshold"`
+	ChunkProducerKickoutThreshold   uint8           `json:"chunk_producer_kickout_threshold"`
+	OnlineMinThreshold              Rational        `json:"online_min_threshold"`
+	OnlineMaxThreshold              Rational        `json:"online_max_threshold"`
+	GasPriceAdjustmentRate          Rational        `json:"gas_price_adjustment_rate"`
+	TransactionValidityPeriod       uint64          `json:"transaction_validity_period"`
+	ProtocolRewardRate              Rational        `json:"protocol_reward_rate"`
+	MaxInflationRate                Rational        `json:"max_inflation_rate"`
+	NumBlocksPerYear                uint64          `json:"num_blocks_per_year"`
+	ProtocolTreasuryAccount         string          `json:"protocol_treasury_account"`
+	FishermenThreshold              types.Balance   `json:"fishermen_threshold"`
+	MinimumStakeDivisor             uint64          `json:"minimum_stake_divisor"`
+	ShardLayout                     json.RawMessage `json:"shard_layout"`
+}
+
+// ProtocolConfigView is the result of ProtocolConfig.
+type ProtocolConfigView struct {
+	ChainConfigView
+	RuntimeConfig RuntimeConfigView `json:"runtime_config"`
+}
+
+// AccountInfo is a genesis validator.
+type AccountInfo struct {
+	AccountID string        `json:"account_id"`
+	PublicKey string        `json:"public_key"`
+	Amount    types.Balance `json:"amount"`
+}
+
+// GenesisConfigView is the result of GenesisConfig.
+type GenesisConfigView struct {
+	ChainConfigView
+	Validators  []AccountInfo `json:"validators"`
+	TotalSupply types.Balance `json:"total_supply"`
+}
+
+// ProtocolConfig returns the protocol config valid at block.
+//
+// For details see https://docs.near.org/api/rpc/protocol#protocol-config
+func (c *Client) ProtocolConfig(ctx context.Context, block BlockReference) (*ProtocolConfigView, error) {
+	var res ProtocolConfigView
+	if err := c.Call(ctx, "EXPERIMENTAL_protocol_config", block.params(make(map[string]interface{})), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GenesisConfig returns the genesis config of the network. Later protocol
+// upgrades are not reflected, see ProtocolConfig.
+//
+// For details see https://docs.near.org/api/rpc/protocol#genesis-config
+func (c *Client) GenesisConfig(ctx context.Context) (*GenesisConfigView, error) {
+	var res GenesisConfigView
+	if err := c.Call(ctx, "EXPERIMENTAL_genesis_config", nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
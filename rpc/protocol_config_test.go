@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProtocolConfig(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "EXPERIMENTAL_protocol_config", "protocol_config.json", func(p map[string]interface{}) { params = p })
+	cfg, err := c.ProtocolConfig(context.Background(), AtHeight(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["block_id"] != 42.0 {
+		t.Errorf("ProtocolConfig() sent params %v", params)
+	}
+	if cfg.ChainID != "mainnet" || cfg.ProtocolVersion != 63 || cfg.GenesisTime.Year() != 2020 || cfg.EpochLength != 43200 {
+		t.Errorf("ProtocolConfig() returned %+v", cfg.ChainConfigView)
+	}
+	if cfg.GasPriceAdjustmentRate.Float() != 0.01 || cfg.MinGasPrice.Int64() != 100000000 {
+		t.Errorf("ProtocolConfig() returned gas price config %v %s", cfg.GasPriceAdjustmentRate, &cfg.MinGasPrice)
+	}
+	rc := cfg.RuntimeConfig
+	if rc.StorageAmountPerByte.String() != "10000000000000000000" {
+		t.Errorf("ProtocolConfig() returned storage amount per byte %s", &rc.StorageAmountPerByte)
+	}
+	fees := rc.TransactionCosts
+	if fees.ActionCreationConfig.TransferCost.Send(false) != 115123062500 || fees.ActionCreationConfig.FunctionCallCost.Execution != 780000000000 {
+		t.Errorf("ProtocolConfig() returned action fees %+v", fees.ActionCreationConfig)
+	}
+	if fees.ActionCreationConfig.AddKeyCost.FullAccessCost.SendSir != 101765125000 || fees.StorageUsageConfig.NumBytesAccount != 100 {
+		t.Errorf("ProtocolConfig() returned fees %+v", fees)
+	}
+	if rc.AccountCreationConfig.RegistrarAccountID != "registrar" || len(rc.WasmConfig) == 0 || len(cfg.ShardLayout) == 0 {
+		t.Errorf("ProtocolConfig() returned runtime config %+v", rc)
+	}
+}
+
+func TestGenesisConfig(t *testing.T) {
+	c := newFixtureServer(t, "EXPERIMENTAL_genesis_config", "protocol_config.json", nil)
+	cfg, err := c.GenesisConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ChainID != "mainnet" || cfg.GenesisHeight != 9820210 {
+		t.Errorf("GenesisConfig() returned %+v", cfg.ChainConfigView)
+	}
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// QueryResponse are the fields common to all query results.
+type QueryResponse struct {
+	BlockHash   string `json:"block_hash"`
+	BlockHeight uint64 `json:"block_height"`
+}
+
+// Query calls the query method with the given request type and params
+// against block and decodes the result into result. Some node versions report
+// query errors inside the result, they are returned as errors as well.
+func (c *Client) Query(ctx context.Context, requestType string, block BlockReference, params map[string]interface{}, result interface{}) error {
+	p := queryParams(requestType, block, params)
+	var raw json.RawMessage
+	if err := c.Call(ctx, "query", p, &raw); err != nil {
+		return err
+	}
+	return decodeQueryResult(requestType, raw, result)
+}
+
+// queryParams returns the params of the query with the given request type and
+// params against block.
+func queryParams(requestType string, block BlockReference, params map[string]interface{}) map[string]interface{} {
+	p := block.params(map[string]interface{}{"request_type": requestType})
+	for k, v := range params {
+		p[k] = v
+	}
+	return p
+}
+
+// decodeQueryResult decodes the raw result of the query with the given
+// request type into result.
+func decodeQueryResult(requestType string, raw json.RawMessage, result interface{}) error {
+	var e struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &e); err == nil && e.Error != "" {
+		return fmt.Errorf("rpc: query %s failed: %s", requestType, e.Error)
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("rpc: cannot decode result of query %s: %v", requestType, err)
+	}
+	return nil
+}
+
+// ContractCode is the result of ViewCode.
+type ContractCode struct {
+	QueryResponse
+	// Code is the Wasm binary of the contract.
+	Code []byte `json:"code_base64"`
+	// Hash is the base58 encoded sha256 hash of the code.
+	Hash string `json:"hash"`
+}
+
+// ViewCode returns the contract code deployed to accountID.
+func (c *Client) ViewCode(ctx context.Context, accountID string, block BlockReference) (*ContractCode, error) {
+	var res ContractCode
+	if err := c.Query(ctx, "view_code", block, map[string]interface{}{
+		"account_id": accountID,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// StateItem is a key-value pair of contract state.
+type StateItem struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// ContractState is the result of ViewState.
+type ContractState struct {
+	QueryResponse
+	Values []StateItem `json:"values"`
+}
+
+// ViewState returns the contract state of accountID with keys starting with
+// prefix (all state if empty). Nodes limit the size of state which can be
+// viewed, see the trie_viewer_state_size_limit node config.
+func (c *Client) ViewState(ctx context.Context, accountID string, prefix []byte, block BlockReference) (*ContractState, error) {
+	var res ContractState
+	if err := c.Query(ctx, "view_state", block, map[string]interface{}{
+		"account_id":    accountID,
+		"prefix_base64": base64.StdEncoding.EncodeToString(prefix),
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
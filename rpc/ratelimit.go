@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit configures the client-side rate limit of requests to each
+// endpoint, see Options.RateLimit. Requests exceeding the limit wait until
+// they are allowed or the context is done.
+type RateLimit struct {
+	// QPS is the sustained number of requests per second.
+	QPS float64
+	// Burst is the number of requests which can be sent at once after the
+	// endpoint was idle. Values below 1 are treated as 1.
+	Burst int
+}
+
+// tokenBucket is a token bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(l RateLimit) *tokenBucket {
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: l.QPS, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token from b, waiting until one is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	// reserve the token, waiting requests are served in order
+	b.tokens--
+	if b.tokens >= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	d := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if err := sleep(ctx, d); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
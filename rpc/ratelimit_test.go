@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(RateLimit{QPS: 100, Burst: 3})
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := b.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d > 5*time.Millisecond {
+		t.Errorf("wait() within burst took %v", d)
+	}
+	// the next tokens are available every 10ms
+	for i := 0; i < 5; i++ {
+		if err := b.wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("wait() beyond burst took %v (want about 50ms)", d)
+	}
+	// waiting is canceled with the context
+	b = newTokenBucket(RateLimit{QPS: 0.001})
+	if err := b.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx); err == nil {
+		t.Error("wait() ignored context deadline")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	var calls int32
+	srv := countingServer(http.StatusOK, &calls)
+	defer srv.Close()
+	c := NewClientWithOptions(srv.URL, Options{RateLimit: &RateLimit{QPS: 50, Burst: 1}})
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := c.Call(context.Background(), "status", nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("4 calls at 50 QPS took %v (want about 60ms)", d)
+	}
+}
@@ -0,0 +1,19 @@
+package rpc
+
+import (
+	"context"
+)
+
+// Receipt returns the receipt with the base58 encoded receiptID. If the node
+// does not know the receipt an error matching ErrUnknownReceipt is returned.
+//
+// For details see https://docs.near.org/api/rpc/transactions#receipt-by-id
+func (c *Client) Receipt(ctx context.Context, receiptID string) (*ReceiptView, error) {
+	var res ReceiptView
+	if err := c.Call(ctx, "EXPERIMENTAL_receipt", map[string]interface{}{
+		"receipt_id": receiptID,
+	}, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestReceipt(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]string
+		if err := json.Unmarshal(params, &p); err != nil {
+			t.Error(err)
+		}
+		if method != "EXPERIMENTAL_receipt" || p["receipt_id"] != "R1" {
+			return nil, &Error{Code: -32000, Message: "Server error", Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "UNKNOWN_RECEIPT"}}
+		}
+		return json.RawMessage(`{"predecessor_id":"alice.testnet","receiver_id":"app.testnet","receipt_id":"R1",
+			"receipt":{"Action":{"signer_id":"alice.testnet","signer_public_key":"ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e",
+			"gas_price":"103000000","output_data_receivers":[],"input_data_ids":[],
+			"actions":[{"FunctionCall":{"method_name":"ping","args":"e30=","gas":30000000000000,"deposit":"1"}}]}}}`), nil
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	r, err := c.Receipt(ctx, "R1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ReceiptID != "R1" || r.Receipt.Action == nil || r.Receipt.Action.GasPrice.Int64() != 103000000 {
+		t.Fatalf("Receipt() returned %+v", r)
+	}
+	if fc := r.Receipt.Action.Actions[0].FunctionCall; fc == nil || fc.MethodName != "ping" || fc.Deposit.Int64() != 1 {
+		t.Errorf("Receipt() returned actions %+v", r.Receipt.Action.Actions)
+	}
+	if _, err := c.Receipt(ctx, "R2"); !errors.Is(err, ErrUnknownReceipt) {
+		t.Errorf("Receipt() returned %v (want ErrUnknownReceipt)", err)
+	}
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures the automatic retry of failed calls, see
+// Options.Retry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call including the
+	// first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts, zero means no cap.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each retry. Values
+	// below 1 keep the delay constant.
+	Multiplier float64
+	// Jitter is the fraction of the delay which is randomized, e.g. 0.2
+	// waits between 80% and 120% of the delay.
+	Jitter float64
+	// Retryable reports whether the call of method with params which failed
+	// with err is retried. If nil, DefaultRetryable is used.
+	Retryable func(method string, params interface{}, err error) bool
+}
+
+// DefaultRetryPolicy is a retry policy suitable for public RPC providers.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// DefaultRetryable reports whether err is transient: network timeouts and
+// connection failures, HTTP 408, 429 and 5xx status codes, node timeouts and
+// nodes which are not synced yet. Unknown blocks are only retried on queries
+// with optimistic finality, where the block may not have reached the node
+// yet.
+func DefaultRetryable(method string, params interface{}, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests:
+			return true
+		}
+		return httpErr.StatusCode >= 500
+	}
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		switch {
+		case errors.Is(err, ErrTimeout), errors.Is(err, ErrNotSynced):
+			return true
+		case errors.Is(err, ErrGarbageCollectedBlock):
+			return false
+		case errors.Is(err, ErrUnknownBlock):
+			return isOptimistic(params)
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// isOptimistic reports whether params select a block by optimistic finality.
+func isOptimistic(params interface{}) bool {
+	switch p := params.(type) {
+	case map[string]interface{}:
+		return p["finality"] == FinalityOptimistic
+	case BlockReference:
+		return p.Finality == FinalityOptimistic
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry (starting at 1).
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < retry && p.Multiplier > 1; i++ {
+		d *= p.Multiplier
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// retryable reports whether the failed call is retried by p.
+func (p *RetryPolicy) retryable(method string, params interface{}, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(method, params, err)
+	}
+	return DefaultRetryable(method, params, err)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
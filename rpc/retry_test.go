@@ -0,0 +1,144 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	Multiplier:     2,
+}
+
+func unknownBlock() *Error {
+	return &Error{
+		Code:    -32000,
+		Message: "Server error",
+		Name:    "HANDLER_ERROR",
+		Cause:   &ErrorCause{Name: "UNKNOWN_BLOCK"},
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"ok"}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(srv.URL, Options{Retry: &testRetryPolicy})
+	var res string
+	if err := c.Call(ctx, "status", nil, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res != "ok" || calls != 3 {
+		t.Errorf("Call() returned %s after %d calls (want ok after 3)", res, calls)
+	}
+	// attempts are limited
+	atomic.StoreInt32(&calls, -10)
+	var httpErr *HTTPError
+	if err := c.Call(ctx, "status", nil, &res); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Call() returned %v (want HTTP 429)", err)
+	}
+	if calls != -7 {
+		t.Errorf("Call() made %d attempts (want 3)", calls+10)
+	}
+	// no retries without policy
+	atomic.StoreInt32(&calls, 0)
+	if err := NewClient(srv.URL).Call(ctx, "status", nil, &res); err == nil || calls != 1 {
+		t.Errorf("Call() without retry policy returned %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryUnknownBlock(t *testing.T) {
+	var calls int32
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, unknownBlock()
+	})
+	defer srv.Close()
+	ctx := context.Background()
+	c := NewClientWithOptions(srv.URL, Options{Retry: &testRetryPolicy})
+	for _, tc := range []struct {
+		block BlockReference
+		calls int32
+	}{
+		{Optimistic(), 3},
+		{Final(), 1},
+		{AtHeight(42), 1},
+	} {
+		atomic.StoreInt32(&calls, 0)
+		if _, err := c.Block(ctx, tc.block); !errors.Is(err, ErrUnknownBlock) {
+			t.Errorf("Block(%+v) returned %v (want ErrUnknownBlock)", tc.block, err)
+		}
+		if calls != tc.calls {
+			t.Errorf("Block(%+v) made %d attempts (want %d)", tc.block, calls, tc.calls)
+		}
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	policy := testRetryPolicy
+	policy.MaxAttempts = 100
+	policy.InitialBackoff = time.Hour
+	c := NewClientWithOptions(srv.URL, Options{Retry: &policy})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := c.Call(ctx, "status", nil, nil); err == nil {
+		t.Error("Call() succeeded")
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("Call() returned after %v despite context deadline", d)
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{&HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{&HTTPError{StatusCode: http.StatusBadGateway}, true},
+		{&HTTPError{StatusCode: http.StatusNotFound}, false},
+		{&Error{Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "TIMEOUT_ERROR"}}, true},
+		{&Error{Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "UNKNOWN_ACCOUNT"}}, false},
+		{&Error{Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: "GARBAGE_COLLECTED_BLOCK"}}, false},
+		{context.Canceled, false},
+		{errors.New("other"), false},
+	} {
+		if got := DefaultRetryable("query", nil, tc.err); got != tc.want {
+			t.Errorf("DefaultRetryable(%v) returned %t (want %t)", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	for retry, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if got := p.backoff(retry + 1); got != want {
+			t.Errorf("backoff(%d) returned %v (want %v)", retry+1, got, want)
+		}
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < time.Second/2 || got > 3*time.Second/2 {
+			t.Fatalf("backoff(1) with jitter returned %v", got)
+		}
+	}
+}
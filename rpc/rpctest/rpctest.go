@@ -0,0 +1,274 @@
+// Package rpctest provides an in-process NEAR JSON-RPC server for tests.
+//
+// The server answers calls with handlers registered per method (or per
+// request type for queries) and records all received requests:
+//
+//	srv := rpctest.NewServer()
+//	defer srv.Close()
+//	srv.Query("view_account", rpctest.Result(map[string]interface{}{
+//		"amount": "1000000000000000000000000",
+//	}))
+//	acc, err := srv.Client().ViewAccount(ctx, "alice.testnet", rpc.Final())
+package rpctest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// Handler answers a call with the JSON encoded params. Errors of type
+// *rpc.Error are returned as JSON-RPC errors, other errors as internal
+// errors of the node.
+type Handler func(params json.RawMessage) (interface{}, error)
+
+// Result returns a handler answering with the canned result res.
+func Result(res interface{}) Handler {
+	return func(json.RawMessage) (interface{}, error) {
+		return res, nil
+	}
+}
+
+// Fail returns a handler answering with the error err.
+func Fail(err error) Handler {
+	return func(json.RawMessage) (interface{}, error) {
+		return nil, err
+	}
+}
+
+// Sequence returns a handler which answers the calls with the handlers in
+// order, the last handler answers all further calls.
+func Sequence(handlers ...Handler) Handler {
+	var mu sync.Mutex
+	var i int
+	return func(params json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		h := handlers[i]
+		if i < len(handlers)-1 {
+			i++
+		}
+		mu.Unlock()
+		return h(params)
+	}
+}
+
+// HandlerError returns the error nodes return for the handler error cause,
+// e.g. UNKNOWN_ACCOUNT or UNKNOWN_BLOCK.
+func HandlerError(cause string) *rpc.Error {
+	return &rpc.Error{
+		Code:    -32000,
+		Message: "Server error",
+		Name:    "HANDLER_ERROR",
+		Cause:   &rpc.ErrorCause{Name: cause},
+	}
+}
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	Params json.RawMessage
+	Header http.Header
+}
+
+// Server is a JSON-RPC server answering calls with registered handlers.
+// Calls of methods without handler fail with METHOD_NOT_FOUND. It is safe for
+// concurrent use.
+type Server struct {
+	// URL is the URL of the server.
+	URL string
+
+	srv      *httptest.Server
+	mu       sync.Mutex
+	handlers map[string]Handler
+	queries  map[string]Handler
+	requests []Request
+}
+
+// NewServer returns a new started server. It must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		handlers: make(map[string]Handler),
+		queries:  make(map[string]Handler),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a new client for s. Clients with options are created with
+// rpc.NewClientWithOptions and the URL of s.
+func (s *Server) Client() *rpc.Client {
+	return rpc.NewClient(s.URL)
+}
+
+// Handle registers the handler h for method, replacing the previous handler.
+func (s *Server) Handle(method string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = h
+}
+
+// Query registers the handler h for queries with the request type, which
+// takes precedence over a handler of the query method.
+func (s *Server) Query(requestType string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries[requestType] = h
+}
+
+// Requests returns the requests received by s in order. The calls of batches
+// are recorded as separate requests.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the received requests of method in order.
+func (s *Server) RequestsFor(method string) []Request {
+	var reqs []Request
+	for _, req := range s.Requests() {
+		if req.Method == method {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
+// Reset removes all handlers and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers = make(map[string]Handler)
+	s.queries = make(map[string]Handler)
+	s.requests = nil
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpc.Error      `json:"error,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, response{JSONRPC: "2.0", Error: parseError(err)})
+		return
+	}
+	if len(body) > 0 && body[0] == '[' {
+		var reqs []request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJSON(w, http.StatusBadRequest, response{JSONRPC: "2.0", Error: parseError(err)})
+			return
+		}
+		resps := make([]response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = s.serve(req, r.Header)
+		}
+		writeJSON(w, http.StatusOK, resps)
+		return
+	}
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, response{JSONRPC: "2.0", Error: parseError(err)})
+		return
+	}
+	res := s.serve(req, r.Header)
+	status := http.StatusOK
+	if res.Error != nil {
+		// nodes return errors with HTTP error status codes
+		status = http.StatusBadRequest
+		if res.Error.Name == "INTERNAL_ERROR" {
+			status = http.StatusInternalServerError
+		}
+	}
+	writeJSON(w, status, res)
+}
+
+// serve answers the request req with the registered handler.
+func (s *Server) serve(req request, header http.Header) response {
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: req.Method, Params: req.Params, Header: header.Clone()})
+	h := s.handlers[req.Method]
+	if req.Method == "query" {
+		var p struct {
+			RequestType string `json:"request_type"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err == nil {
+			if qh, ok := s.queries[p.RequestType]; ok {
+				h = qh
+			}
+		}
+	}
+	s.mu.Unlock()
+	res := response{JSONRPC: "2.0", ID: req.ID}
+	if h == nil {
+		res.Error = &rpc.Error{
+			Code:    -32601,
+			Message: "Method not found",
+			Data:    mustMarshal(req.Method),
+			Name:    "REQUEST_VALIDATION_ERROR",
+			Cause:   &rpc.ErrorCause{Name: "METHOD_NOT_FOUND"},
+		}
+		return res
+	}
+	result, err := h(req.Params)
+	var rpcErr *rpc.Error
+	switch {
+	case errors.As(err, &rpcErr):
+		res.Error = rpcErr
+	case err != nil:
+		res.Error = &rpc.Error{
+			Code:    -32000,
+			Message: "Server error",
+			Name:    "INTERNAL_ERROR",
+			Cause:   &rpc.ErrorCause{Name: "INTERNAL_ERROR", Info: mustMarshal(map[string]string{"error_message": err.Error()})},
+		}
+	case result == nil:
+		res.Result = json.RawMessage("null")
+	default:
+		res.Result = result
+	}
+	return res
+}
+
+func parseError(err error) *rpc.Error {
+	return &rpc.Error{
+		Code:    -32700,
+		Message: "Parse error",
+		Name:    "REQUEST_VALIDATION_ERROR",
+		Cause:   &rpc.ErrorCause{Name: "PARSE_ERROR", Info: mustMarshal(map[string]string{"error_message": err.Error()})},
+	}
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
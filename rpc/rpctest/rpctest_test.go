@@ -0,0 +1,113 @@
+package rpctest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Query("view_account", func(params json.RawMessage) (interface{}, error) {
+		var p map[string]interface{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if p["account_id"] != "alice.testnet" {
+			return nil, HandlerError("UNKNOWN_ACCOUNT")
+		}
+		return map[string]interface{}{"amount": "100", "locked": "0", "block_height": 42}, nil
+	})
+	srv.Handle("status", Fail(errors.New("storage error")))
+	ctx := context.Background()
+	c := srv.Client()
+	acc, err := c.ViewAccount(ctx, "alice.testnet", rpc.Final())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.Amount.String() != "100" || acc.BlockHeight != 42 {
+		t.Errorf("ViewAccount() returned %+v", acc)
+	}
+	if _, err := c.ViewAccount(ctx, "bob.testnet", rpc.Final()); !errors.Is(err, rpc.ErrUnknownAccount) {
+		t.Errorf("ViewAccount(bob) returned %v (want ErrUnknownAccount)", err)
+	}
+	if _, err := c.Status(ctx); !errors.Is(err, rpc.ErrInternal) {
+		t.Errorf("Status() returned %v (want ErrInternal)", err)
+	}
+	if _, err := c.Block(ctx, rpc.Final()); !errors.Is(err, rpc.ErrMethodNotFound) {
+		t.Errorf("Block() returned %v (want ErrMethodNotFound)", err)
+	}
+	reqs := srv.Requests()
+	if len(reqs) != 4 || reqs[0].Method != "query" || reqs[3].Method != "block" {
+		t.Fatalf("Requests() returned %+v", reqs)
+	}
+	var p map[string]interface{}
+	if err := json.Unmarshal(reqs[1].Params, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p["account_id"] != "bob.testnet" || p["finality"] != "final" {
+		t.Errorf("request has params %v", p)
+	}
+	if n := len(srv.RequestsFor("query")); n != 2 {
+		t.Errorf("RequestsFor(query) returned %d requests (want 2)", n)
+	}
+	srv.Reset()
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("Requests() returned %d requests after Reset()", n)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Handle("block", Sequence(
+		Fail(HandlerError("UNKNOWN_BLOCK")),
+		Result(map[string]interface{}{"author": "node0"}),
+	))
+	c := srv.Client()
+	ctx := context.Background()
+	if _, err := c.Block(ctx, rpc.AtHeight(42)); !errors.Is(err, rpc.ErrUnknownBlock) {
+		t.Errorf("Block() returned %v (want ErrUnknownBlock)", err)
+	}
+	for i := 0; i < 2; i++ {
+		b, err := c.Block(ctx, rpc.AtHeight(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Author != "node0" {
+			t.Errorf("Block() returned author %s (want node0)", b.Author)
+		}
+	}
+}
+
+func TestBatchAndHeaders(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Handle("health", Result(nil))
+	srv.Handle("gas_price", Result(map[string]interface{}{"gas_price": "100000000"}))
+	c := rpc.NewClientWithOptions(srv.URL, rpc.Options{Headers: http.Header{"X-Api-Key": {"secret"}}})
+	b := c.Batch()
+	health := b.Call("health", nil, nil)
+	var price struct {
+		GasPrice string `json:"gas_price"`
+	}
+	gasPrice := b.Call("gas_price", []interface{}{nil}, &price)
+	if err := b.Send(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if health.Err != nil || gasPrice.Err != nil || price.GasPrice != "100000000" {
+		t.Errorf("Send() returned %v, %v, %+v", health.Err, gasPrice.Err, price)
+	}
+	reqs := srv.Requests()
+	if len(reqs) != 2 {
+		t.Fatalf("Requests() returned %d requests (want 2)", len(reqs))
+	}
+	if reqs[0].Header.Get("X-Api-Key") != "secret" {
+		t.Errorf("request has headers %v", reqs[0].Header)
+	}
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"time"
+)
+
+// Version is the version of a node.
+type Version struct {
+	Version      string `json:"version"`
+	Build        string `json:"build"`
+	RustcVersion string `json:"rustc_version"`
+}
+
+// SyncInfo is the sync state of a node.
+type SyncInfo struct {
+	LatestBlockHash     string    `json:"latest_block_hash"`
+	LatestBlockHeight   uint64    `json:"latest_block_height"`
+	LatestStateRoot     string    `json:"latest_state_root"`
+	LatestBlockTime     time.Time `json:"latest_block_time"`
+	Syncing             bool      `json:"syncing"`
+	EarliestBlockHash   string    `json:"earliest_block_hash"`
+	EarliestBlockHeight uint64    `json:"earliest_block_height"`
+	EarliestBlockTime   time.Time `json:"earliest_block_time"`
+	EpochID             string    `json:"epoch_id"`
+	EpochStartHeight    uint64    `json:"epoch_start_height"`
+}
+
+// StatusValidator is a validator of the current epoch as reported by Status.
+type StatusValidator struct {
+	AccountID string `json:"account_id"`
+	IsSlashed bool   `json:"is_slashed"`
+}
+
+// StatusResult is the result of Status.
+type StatusResult struct {
+	Version               Version           `json:"version"`
+	ChainID               string            `json:"chain_id"`
+	ProtocolVersion       uint32            `json:"protocol_version"`
+	LatestProtocolVersion uint32            `json:"latest_protocol_version"`
+	RPCAddr               string            `json:"rpc_addr"`
+	Validators            []StatusValidator `json:"validators"`
+	SyncInfo              SyncInfo          `json:"sync_info"`
+	// ValidatorAccountID and ValidatorPublicKey are only set for validator
+	// nodes.
+	ValidatorAccountID string `json:"validator_account_id"`
+	ValidatorPublicKey string `json:"validator_public_key"`
+	NodePublicKey      string `json:"node_public_key"`
+	UptimeSec          int64  `json:"uptime_sec"`
+	GenesisHash        string `json:"genesis_hash"`
+}
+
+// Status returns the general status of the node.
+//
+// For details see https://docs.near.org/api/rpc/network#node-status
+func (c *Client) Status(ctx context.Context) (*StatusResult, error) {
+	var res StatusResult
+	if err := c.Call(ctx, "status", nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// PeerInfo is a peer of a node.
+type PeerInfo struct {
+	ID string `json:"id"`
+	// Addr and AccountID are nil if unknown.
+	Addr      *string `json:"addr"`
+	AccountID *string `json:"account_id"`
+}
+
+// KnownProducer is a block producer known to a node.
+type KnownProducer struct {
+	AccountID string   `json:"account_id"`
+	Addr      *string  `json:"addr"`
+	PeerID    string   `json:"peer_id"`
+	NextHops  []string `json:"next_hops"`
+}
+
+// NetworkInfo is the result of NetworkInfo.
+type NetworkInfo struct {
+	ActivePeers         []PeerInfo      `json:"active_peers"`
+	NumActivePeers      uint64          `json:"num_active_peers"`
+	PeerMaxCount        uint64          `json:"peer_max_count"`
+	SentBytesPerSec     uint64          `json:"sent_bytes_per_sec"`
+	ReceivedBytesPerSec uint64          `json:"received_bytes_per_sec"`
+	KnownProducers      []KnownProducer `json:"known_producers"`
+}
+
+// NetworkInfo returns the network connections of the node.
+//
+// For details see https://docs.near.org/api/rpc/network#network-info
+func (c *Client) NetworkInfo(ctx context.Context) (*NetworkInfo, error) {
+	var res NetworkInfo
+	if err := c.Call(ctx, "network_info", nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Health returns nil if the node is healthy, i.e. it is not stuck and has
+// received blocks recently, and an error otherwise.
+//
+// For details see https://docs.near.org/api/rpc/network#node-health
+func (c *Client) Health(ctx context.Context) error {
+	return c.Call(ctx, "health", nil, nil)
+}
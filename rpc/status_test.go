@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	c := newFixtureServer(t, "status", "status.json", nil)
+	status, err := c.Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.ChainID != "testnet" || status.Version.Version != "2.3.0" || status.LatestProtocolVersion != 72 {
+		t.Errorf("Status() returned %+v", status)
+	}
+	if status.SyncInfo.Syncing || status.SyncInfo.LatestBlockHeight != 121406726 || status.SyncInfo.LatestBlockTime.Day() != 14 {
+		t.Errorf("Status() returned sync info %+v", status.SyncInfo)
+	}
+}
+
+func TestNetworkInfoHealth(t *testing.T) {
+	healthy := true
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		switch method {
+		case "network_info":
+			return json.RawMessage(`{"active_peers":[{"id":"ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e","addr":"1.2.3.4:24567","account_id":null}],
+				"num_active_peers":1,"peer_max_count":40,"sent_bytes_per_sec":100,"received_bytes_per_sec":200,
+				"known_producers":[{"account_id":"node0","addr":null,"peer_id":"ed25519:6DSjZ8mvsRZDvFqFxo8tCKePG96omXW7eVYVSySmDk8e","next_hops":null}]}`), nil
+		case "health":
+			if !healthy {
+				return nil, &Error{Code: -32000, Message: "Server error", Data: json.RawMessage(`"No blocks for 120s"`)}
+			}
+			return nil, nil
+		}
+		return nil, &Error{Code: -32601, Message: "Method not found"}
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	ctx := context.Background()
+	info, err := c.NetworkInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.NumActivePeers != 1 || *info.ActivePeers[0].Addr != "1.2.3.4:24567" || info.ActivePeers[0].AccountID != nil {
+		t.Errorf("NetworkInfo() returned %+v", info)
+	}
+	if len(info.KnownProducers) != 1 || info.KnownProducers[0].AccountID != "node0" {
+		t.Errorf("NetworkInfo() returned known producers %+v", info.KnownProducers)
+	}
+	if err := c.Health(ctx); err != nil {
+		t.Errorf("Health() returned %v", err)
+	}
+	healthy = false
+	if err := c.Health(ctx); err == nil {
+		t.Error("Health() returned nil for unhealthy node")
+	}
+}
@@ -0,0 +1,108 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the OpenTelemetry tracer of clients.
+const tracerName = "github.com/YuxSccc/near-api-go/rpc"
+
+// startSpan starts the span of the call cl if tracing is enabled.
+func (c *Client) startSpan(ctx context.Context, cl *call) (context.Context, trace.Span) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "jsonrpc"),
+		attribute.String("rpc.method", cl.method),
+	}
+	attrs = append(attrs, paramAttributes(cl.params)...)
+	return c.tracer.Start(ctx, "near.rpc "+cl.method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endSpan ends the span of the call cl which failed with err (if not nil).
+func endSpan(span trace.Span, cl *call, err error) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("near.rpc.retries", cl.attempt-1))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		var rpcErr *Error
+		if errors.As(err, &rpcErr) && rpcErr.CauseName() != "" {
+			span.SetAttributes(attribute.String("near.rpc.error_cause", rpcErr.CauseName()))
+		}
+	}
+	span.End()
+}
+
+// paramAttributes returns the span attributes of the block reference, the
+// accounts and the query request type in params.
+func paramAttributes(params interface{}) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if p, ok := params.([]interface{}); ok && len(p) == 1 {
+		// broadcast_tx_async and broadcast_tx_commit
+		if tx, ok := p[0].(string); ok {
+			if signer := signerID(tx); signer != "" {
+				attrs = append(attrs, attribute.String("near.account_id", signer))
+			}
+		}
+	}
+	p, ok := params.(map[string]interface{})
+	if !ok {
+		return attrs
+	}
+	if tx, ok := p["signed_tx_base64"].(string); ok {
+		if signer := signerID(tx); signer != "" {
+			attrs = append(attrs, attribute.String("near.account_id", signer))
+		}
+	}
+	for _, k := range []struct {
+		param, attr string
+	}{
+		{"finality", "near.block.finality"},
+		{"block_id", "near.block.id"},
+		{"request_type", "near.query.request_type"},
+		{"account_id", "near.account_id"},
+		{"sender_account_id", "near.account_id"},
+		{"tx_hash", "near.tx_hash"},
+	} {
+		if v, ok := p[k.param]; ok && v != nil {
+			attrs = append(attrs, attribute.String(k.attr, fmt.Sprint(v)))
+		}
+	}
+	return attrs
+}
+
+// signerID returns the signer of the base64 encoded signed transaction tx
+// without decoding the whole transaction, or "" if tx is invalid. The signer
+// is the first field of the Borsh encoding, a string with 32 bit length
+// prefix.
+func signerID(tx string) string {
+	// 4 bytes length and account IDs of at most 64 bytes
+	prefix := tx
+	if max := base64.StdEncoding.EncodedLen(4 + 64); len(prefix) > max {
+		prefix = prefix[:max]
+	}
+	data, err := base64.StdEncoding.DecodeString(prefix)
+	if err != nil || len(data) < 4 {
+		return ""
+	}
+	n := binary.LittleEndian.Uint32(data)
+	if n == 0 || int(n) > len(data)-4 {
+		return ""
+	}
+	return string(data[4 : 4+n])
+}
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// spanAttributes returns the attributes of span as map.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		if method == "block" {
+			return nil, unknownBlock()
+		}
+		return map[string]interface{}{"amount": "1", "locked": "0"}, nil
+	})
+	defer srv.Close()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	c := NewClientWithOptions(srv.URL, Options{TracerProvider: tp, Retry: &testRetryPolicy})
+	ctx := context.Background()
+	if _, err := c.ViewAccount(ctx, "test.testnet", AtHeight(42)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Block(ctx, Optimistic()); err == nil {
+		t.Fatal("Block() succeeded")
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans (want 2)", len(spans))
+	}
+	if spans[0].Name() != "near.rpc query" {
+		t.Errorf("span has name %s", spans[0].Name())
+	}
+	attrs := spanAttributes(spans[0])
+	for k, want := range map[attribute.Key]string{
+		"rpc.method":              "query",
+		"near.account_id":         "test.testnet",
+		"near.block.id":           "42",
+		"near.query.request_type": "view_account",
+	} {
+		if got := attrs[k].AsString(); got != want {
+			t.Errorf("span has attribute %s=%q (want %q)", k, got, want)
+		}
+	}
+	if retries := attrs["near.rpc.retries"].AsInt64(); retries != 0 {
+		t.Errorf("span has %d retries (want 0)", retries)
+	}
+	// failed calls
+	attrs = spanAttributes(spans[1])
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("span of failed call has status %v", spans[1].Status())
+	}
+	if attrs["near.block.finality"].AsString() != "optimistic" || attrs["near.rpc.error_cause"].AsString() != "UNKNOWN_BLOCK" {
+		t.Errorf("span of failed call has attributes %v", attrs)
+	}
+	if retries := attrs["near.rpc.retries"].AsInt64(); retries != 2 {
+		t.Errorf("span has %d retries (want 2)", retries)
+	}
+}
+
+func TestSignerID(t *testing.T) {
+	tx := append([]byte{12, 0, 0, 0}, "test.testnet"...)
+	tx = append(tx, make([]byte, 100)...)
+	if got := signerID(base64.StdEncoding.EncodeToString(tx)); got != "test.testnet" {
+		t.Errorf("signerID() returned %q (want test.testnet)", got)
+	}
+	if got := signerID("invalid"); got != "" {
+		t.Errorf("signerID(invalid) returned %q", got)
+	}
+	attrs := paramAttributes([]interface{}{base64.StdEncoding.EncodeToString(tx)})
+	if len(attrs) != 1 || attrs[0].Value.AsString() != "test.testnet" {
+		t.Errorf("paramAttributes() returned %v", attrs)
+	}
+}
@@ -0,0 +1,252 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// TxExecutionStatus is the execution level of a transaction, it selects how
+// long transaction queries wait before returning.
+type TxExecutionStatus string
+
+// All transaction execution levels, each implies the previous ones.
+const (
+	// TxExecutionStatusNone: the transaction is not included yet.
+	TxExecutionStatusNone TxExecutionStatus = "NONE"
+	// TxExecutionStatusIncluded: the transaction is included in a block.
+	TxExecutionStatusIncluded TxExecutionStatus = "INCLUDED"
+	// TxExecutionStatusExecutedOptimistic: the transaction and all its
+	// receipts are executed, the blocks are not necessarily final.
+	TxExecutionStatusExecutedOptimistic TxExecutionStatus = "EXECUTED_OPTIMISTIC"
+	// TxExecutionStatusIncludedFinal: the block including the transaction
+	// is final.
+	TxExecutionStatusIncludedFinal TxExecutionStatus = "INCLUDED_FINAL"
+	// TxExecutionStatusExecuted: the transaction and all its receipts are
+	// executed and the block including the transaction is final.
+	TxExecutionStatusExecuted TxExecutionStatus = "EXECUTED"
+	// TxExecutionStatusFinal: all blocks with receipts of the transaction
+	// are final.
+	TxExecutionStatusFinal TxExecutionStatus = "FINAL"
+)
+
+// ExecutionStatus is the status of a transaction or of the execution of a
+// single receipt. Kind is one of NotStarted or Started (transactions only),
+// Unknown (receipts only), Failure, SuccessValue or SuccessReceiptId
+// (receipts only).
+type ExecutionStatus struct {
+	Kind string
+	// SuccessValue is the return value of the last function call, set for
+	// SuccessValue.
+	SuccessValue []byte
+	// SuccessReceiptID is set for SuccessReceiptId, the result is the outcome
+	// of that receipt.
+	SuccessReceiptID string
+	// Failure is the JSON encoded error, set for Failure.
+	Failure json.RawMessage
+}
+
+// IsSuccess reports whether the status is SuccessValue or SuccessReceiptId.
+func (s ExecutionStatus) IsSuccess() bool {
+	return s.Kind == "SuccessValue" || s.Kind == "SuccessReceiptId"
+}
+
+// IsFailure reports whether the status is Failure.
+func (s ExecutionStatus) IsFailure() bool {
+	return s.Kind == "Failure"
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s ExecutionStatus) MarshalJSON() ([]byte, error) {
+	switch s.Kind {
+	case "SuccessValue":
+		return json.Marshal(map[string][]byte{s.Kind: s.SuccessValue})
+	case "SuccessReceiptId":
+		return json.Marshal(map[string]string{s.Kind: s.SuccessReceiptID})
+	case "Failure":
+		return json.Marshal(map[string]json.RawMessage{s.Kind: s.Failure})
+	default:
+		return json.Marshal(s.Kind)
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ExecutionStatus) UnmarshalJSON(data []byte) error {
+	*s = ExecutionStatus{}
+	if err := json.Unmarshal(data, &s.Kind); err == nil {
+		return nil
+	}
+	var v struct {
+		SuccessValue     *[]byte
+		SuccessReceiptId *string
+		Failure          json.RawMessage
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("rpc: invalid execution status %s", data)
+	}
+	switch {
+	case v.SuccessValue != nil:
+		s.Kind, s.SuccessValue = "SuccessValue", *v.SuccessValue
+	case v.SuccessReceiptId != nil:
+		s.Kind, s.SuccessReceiptID = "SuccessReceiptId", *v.SuccessReceiptId
+	case v.Failure != nil:
+		s.Kind, s.Failure = "Failure", v.Failure
+	default:
+		return fmt.Errorf("rpc: unknown execution status %s", data)
+	}
+	return nil
+}
+
+// MerklePathItem is an element of a merkle proof.
+type MerklePathItem struct {
+	Hash string `json:"hash"`
+	// Direction is Left or Right.
+	Direction string `json:"direction"`
+}
+
+// ExecutionMetadataView are details of an execution.
+type ExecutionMetadataView struct {
+	Version    uint32          `json:"version"`
+	GasProfile json.RawMessage `json:"gas_profile"`
+}
+
+// ExecutionOutcomeView is the outcome of the conversion of a transaction to
+// a receipt or of the execution of a receipt.
+type ExecutionOutcomeView struct {
+	Logs []string `json:"logs"`
+	// ReceiptIDs are the IDs of the receipts created by the execution.
+	ReceiptIDs  []string              `json:"receipt_ids"`
+	GasBurnt    uint64                `json:"gas_burnt"`
+	TokensBurnt types.Balance         `json:"tokens_burnt"`
+	ExecutorID  string                `json:"executor_id"`
+	Status      ExecutionStatus       `json:"status"`
+	Metadata    ExecutionMetadataView `json:"metadata"`
+}
+
+// ExecutionOutcomeWithIDView is an execution outcome with its ID (the
+// transaction hash or receipt ID) and inclusion proof.
+type ExecutionOutcomeWithIDView struct {
+	Proof     []MerklePathItem     `json:"proof"`
+	BlockHash string               `json:"block_hash"`
+	ID        string               `json:"id"`
+	Outcome   ExecutionOutcomeView `json:"outcome"`
+}
+
+// FinalExecutionOutcome is the outcome of a transaction and all receipts
+// created by it.
+type FinalExecutionOutcome struct {
+	FinalExecutionStatus TxExecutionStatus            `json:"final_execution_status"`
+	Status               ExecutionStatus              `json:"status"`
+	Transaction          SignedTransactionView        `json:"transaction"`
+	TransactionOutcome   ExecutionOutcomeWithIDView   `json:"transaction_outcome"`
+	ReceiptsOutcome      []ExecutionOutcomeWithIDView `json:"receipts_outcome"`
+	// Receipts are only returned by TxStatusWithReceipts.
+	Receipts []ReceiptView `json:"receipts,omitempty"`
+}
+
+// Outcome returns the outcome with id, which is the transaction hash or a
+// receipt ID, or nil.
+func (o *FinalExecutionOutcome) Outcome(id string) *ExecutionOutcomeWithIDView {
+	if o.TransactionOutcome.ID == id {
+		return &o.TransactionOutcome
+	}
+	for i := range o.ReceiptsOutcome {
+		if o.ReceiptsOutcome[i].ID == id {
+			return &o.ReceiptsOutcome[i]
+		}
+	}
+	return nil
+}
+
+// Flatten returns all outcomes of the receipt tree in execution order: the
+// transaction outcome and then the receipt outcomes depth first in the order
+// the receipts were created. Outcomes not reachable from the transaction are
+// appended in the order returned by the node.
+func (o *FinalExecutionOutcome) Flatten() []*ExecutionOutcomeWithIDView {
+	outcomes := make([]*ExecutionOutcomeWithIDView, 0, len(o.ReceiptsOutcome)+1)
+	seen := make(map[string]bool)
+	var walk func(outcome *ExecutionOutcomeWithIDView)
+	walk = func(outcome *ExecutionOutcomeWithIDView) {
+		if seen[outcome.ID] {
+			return
+		}
+		seen[outcome.ID] = true
+		outcomes = append(outcomes, outcome)
+		for _, id := range outcome.Outcome.ReceiptIDs {
+			if child := o.Outcome(id); child != nil {
+				walk(child)
+			}
+		}
+	}
+	walk(&o.TransactionOutcome)
+	for i := range o.ReceiptsOutcome {
+		walk(&o.ReceiptsOutcome[i])
+	}
+	return outcomes
+}
+
+// Logs returns the logs of all outcomes in execution order, see Flatten.
+func (o *FinalExecutionOutcome) Logs() []string {
+	var logs []string
+	for _, outcome := range o.Flatten() {
+		logs = append(logs, outcome.Outcome.Logs...)
+	}
+	return logs
+}
+
+// GasBurnt returns the gas burnt by the transaction and all its receipts.
+func (o *FinalExecutionOutcome) GasBurnt() uint64 {
+	gas := o.TransactionOutcome.Outcome.GasBurnt
+	for _, outcome := range o.ReceiptsOutcome {
+		gas += outcome.Outcome.GasBurnt
+	}
+	return gas
+}
+
+// TokensBurnt returns the yoctoNEAR burnt by the transaction and all its
+// receipts.
+func (o *FinalExecutionOutcome) TokensBurnt() types.Balance {
+	var tokens types.Balance
+	tokens.Set(&o.TransactionOutcome.Outcome.TokensBurnt.Int)
+	for _, outcome := range o.ReceiptsOutcome {
+		tokens.Add(&tokens.Int, &outcome.Outcome.TokensBurnt.Int)
+	}
+	return tokens
+}
+
+func txStatusParams(txHash, senderID string, waitUntil TxExecutionStatus) map[string]interface{} {
+	p := map[string]interface{}{
+		"tx_hash":           txHash,
+		"sender_account_id": senderID,
+	}
+	if waitUntil != "" {
+		p["wait_until"] = waitUntil
+	}
+	return p
+}
+
+// TxStatus returns the outcome of the transaction with the base58 encoded
+// txHash signed by senderID, waiting until the transaction reached waitUntil
+// (the node default, EXECUTED_OPTIMISTIC, if empty) or the node timed out.
+//
+// For details see https://docs.near.org/api/rpc/transactions#transaction-status
+func (c *Client) TxStatus(ctx context.Context, txHash, senderID string, waitUntil TxExecutionStatus) (*FinalExecutionOutcome, error) {
+	var res FinalExecutionOutcome
+	if err := c.Call(ctx, "tx", txStatusParams(txHash, senderID, waitUntil), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// TxStatusWithReceipts is like TxStatus, but returns the receipts as well.
+//
+// For details see https://docs.near.org/api/rpc/transactions#transaction-status-with-receipts
+func (c *Client) TxStatusWithReceipts(ctx context.Context, txHash, senderID string, waitUntil TxExecutionStatus) (*FinalExecutionOutcome, error) {
+	var res FinalExecutionOutcome
+	if err := c.Call(ctx, "EXPERIMENTAL_tx_status", txStatusParams(txHash, senderID, waitUntil), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,269 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Kinds of action errors, see ActionError.
+const (
+	ActionAccountAlreadyExists                       = "AccountAlreadyExists"
+	ActionAccountDoesNotExist                        = "AccountDoesNotExist"
+	ActionCreateAccountOnlyByRegistrar               = "CreateAccountOnlyByRegistrar"
+	ActionCreateAccountNotAllowed                    = "CreateAccountNotAllowed"
+	ActionActorNoPermission                          = "ActorNoPermission"
+	ActionDeleteKeyDoesNotExist                      = "DeleteKeyDoesNotExist"
+	ActionAddKeyAlreadyExists                        = "AddKeyAlreadyExists"
+	ActionDeleteAccountStaking                       = "DeleteAccountStaking"
+	ActionLackBalanceForState                        = "LackBalanceForState"
+	ActionTriesToUnstake                             = "TriesToUnstake"
+	ActionTriesToStake                               = "TriesToStake"
+	ActionInsufficientStake                          = "InsufficientStake"
+	ActionFunctionCallError                          = "FunctionCallError"
+	ActionNewReceiptValidationError                  = "NewReceiptValidationError"
+	ActionOnlyImplicitAccountCreationAllowed         = "OnlyImplicitAccountCreationAllowed"
+	ActionDeleteAccountWithLargeState                = "DeleteAccountWithLargeState"
+	ActionDelegateActionInvalidSignature             = "DelegateActionInvalidSignature"
+	ActionDelegateActionSenderDoesNotMatchTxReceiver = "DelegateActionSenderDoesNotMatchTxReceiver"
+	ActionDelegateActionExpired                      = "DelegateActionExpired"
+	ActionDelegateActionAccessKeyError               = "DelegateActionAccessKeyError"
+	ActionDelegateActionInvalidNonce                 = "DelegateActionInvalidNonce"
+	ActionDelegateActionNonceTooLarge                = "DelegateActionNonceTooLarge"
+	ActionGlobalContractDoesNotExist                 = "GlobalContractDoesNotExist"
+)
+
+// Kinds of function call errors, see FunctionCallError.
+const (
+	FunctionCallCompilationError   = "CompilationError"
+	FunctionCallLinkError          = "LinkError"
+	FunctionCallMethodResolveError = "MethodResolveError"
+	FunctionCallWasmTrap           = "WasmTrap"
+	FunctionCallWasmUnknownError   = "WasmUnknownError"
+	FunctionCallHostError          = "HostError"
+	FunctionCallExecutionError     = "ExecutionError"
+)
+
+// Kinds of invalid transaction errors, see InvalidTxError.
+const (
+	InvalidTxInvalidAccessKeyError   = "InvalidAccessKeyError"
+	InvalidTxInvalidSignerID         = "InvalidSignerId"
+	InvalidTxSignerDoesNotExist      = "SignerDoesNotExist"
+	InvalidTxInvalidNonce            = "InvalidNonce"
+	InvalidTxNonceTooLarge           = "NonceTooLarge"
+	InvalidTxInvalidReceiverID       = "InvalidReceiverId"
+	InvalidTxInvalidSignature        = "InvalidSignature"
+	InvalidTxNotEnoughBalance        = "NotEnoughBalance"
+	InvalidTxLackBalanceForState     = "LackBalanceForState"
+	InvalidTxCostOverflow            = "CostOverflow"
+	InvalidTxInvalidChain            = "InvalidChain"
+	InvalidTxExpired                 = "Expired"
+	InvalidTxActionsValidation       = "ActionsValidation"
+	InvalidTxTransactionSizeExceeded = "TransactionSizeExceeded"
+	InvalidTxShardCongested          = "ShardCongested"
+	InvalidTxShardStuck              = "ShardStuck"
+)
+
+// ActionError is the failure of an action of a transaction or receipt.
+type ActionError struct {
+	// Index is the index of the failed action, nil if the error is not
+	// specific to an action.
+	Index *int
+	// Kind is the kind of the error like AccountAlreadyExists, see the
+	// Action constants.
+	Kind string
+	// Info are the JSON encoded details of the kind, if any.
+	Info json.RawMessage
+	// FunctionCall is the error of the called contract for
+	// FunctionCallError.
+	FunctionCall *FunctionCallError
+}
+
+// Error implements error.
+func (e *ActionError) Error() string {
+	msg := e.Kind
+	if e.FunctionCall != nil {
+		msg = e.FunctionCall.Error()
+	} else if len(e.Info) > 0 {
+		msg += ": " + string(e.Info)
+	}
+	if e.Index == nil {
+		return "rpc: action failed: " + msg
+	}
+	return fmt.Sprintf("rpc: action %d failed: %s", *e.Index, msg)
+}
+
+// Unwrap returns the function call error of FunctionCallError, or nil.
+func (e *ActionError) Unwrap() error {
+	if e.FunctionCall == nil {
+		return nil
+	}
+	return e.FunctionCall
+}
+
+// DecodeInfo decodes the details of the kind into v.
+func (e *ActionError) DecodeInfo(v interface{}) error {
+	return decodeInfo(e.Info, v)
+}
+
+// FunctionCallError is the failure of a function call of a contract.
+type FunctionCallError struct {
+	// Kind is the kind of the error, like ExecutionError for panics, see
+	// the FunctionCall constants.
+	Kind string
+	// Message is the panic message for ExecutionError and the name of the
+	// error for kinds with a unit error like MethodResolveError.
+	Message string
+	// Info are the JSON encoded details for other kinds like HostError.
+	Info json.RawMessage
+}
+
+// Error implements error.
+func (e *FunctionCallError) Error() string {
+	switch {
+	case e.Kind == FunctionCallExecutionError:
+		return e.Message
+	case e.Message != "":
+		return e.Kind + ": " + e.Message
+	case len(e.Info) > 0:
+		return e.Kind + ": " + string(e.Info)
+	}
+	return e.Kind
+}
+
+// DecodeInfo decodes the details of the kind into v.
+func (e *FunctionCallError) DecodeInfo(v interface{}) error {
+	return decodeInfo(e.Info, v)
+}
+
+// InvalidTxError is the error of a transaction which was rejected before
+// execution.
+type InvalidTxError struct {
+	// Kind is the kind of the error like InvalidNonce, see the InvalidTx
+	// constants.
+	Kind string
+	// Info are the JSON encoded details of the kind, if any. For
+	// InvalidAccessKeyError and ActionsValidation it is the nested error,
+	// like {"AccessKeyNotFound": {...}}.
+	Info json.RawMessage
+}
+
+// Error implements error.
+func (e *InvalidTxError) Error() string {
+	if len(e.Info) == 0 {
+		return "rpc: invalid transaction: " + e.Kind
+	}
+	return "rpc: invalid transaction: " + e.Kind + ": " + string(e.Info)
+}
+
+// Is reports whether target is ErrInvalidTransaction.
+func (e *InvalidTxError) Is(target error) bool {
+	return target == ErrInvalidTransaction
+}
+
+// DecodeInfo decodes the details of the kind into v.
+func (e *InvalidTxError) DecodeInfo(v interface{}) error {
+	return decodeInfo(e.Info, v)
+}
+
+func decodeInfo(info json.RawMessage, v interface{}) error {
+	if len(info) == 0 {
+		return errors.New("rpc: error has no info")
+	}
+	return json.Unmarshal(info, v)
+}
+
+// variant decodes the JSON encoding of a Rust enum, which is the name of
+// unit variants or an object with the name of the variant as only key.
+func variant(data []byte) (string, json.RawMessage, error) {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return name, nil, nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil || len(m) != 1 {
+		return "", nil, fmt.Errorf("rpc: invalid enum %s", data)
+	}
+	var info json.RawMessage
+	for name, info = range m {
+	}
+	return name, info, nil
+}
+
+// parseTxExecutionError decodes the JSON encoded TxExecutionError into an
+// *ActionError or *InvalidTxError.
+func parseTxExecutionError(data []byte) error {
+	name, info, err := variant(data)
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "ActionError":
+		var v struct {
+			Index *int            `json:"index"`
+			Kind  json.RawMessage `json:"kind"`
+		}
+		if err := json.Unmarshal(info, &v); err != nil {
+			return fmt.Errorf("rpc: invalid action error %s", info)
+		}
+		e := &ActionError{Index: v.Index}
+		if e.Kind, e.Info, err = variant(v.Kind); err != nil {
+			return err
+		}
+		if e.Kind == ActionFunctionCallError && e.Info != nil {
+			f := new(FunctionCallError)
+			var fInfo json.RawMessage
+			if f.Kind, fInfo, err = variant(e.Info); err != nil {
+				return err
+			}
+			// panic messages and unit errors are strings
+			if err := json.Unmarshal(fInfo, &f.Message); err != nil {
+				f.Info = fInfo
+			}
+			e.FunctionCall = f
+		}
+		return e
+	case "InvalidTxError":
+		e := new(InvalidTxError)
+		if e.Kind, e.Info, err = variant(info); err != nil {
+			return err
+		}
+		return e
+	}
+	return fmt.Errorf("rpc: unknown execution error %s", data)
+}
+
+// Err returns the decoded failure as *ActionError or *InvalidTxError, nil if
+// the status is not Failure.
+func (s ExecutionStatus) Err() error {
+	if !s.IsFailure() {
+		return nil
+	}
+	return parseTxExecutionError(s.Failure)
+}
+
+// Err returns the decoded failure of the transaction as *ActionError or
+// *InvalidTxError, nil if it did not fail.
+func (o *FinalExecutionOutcome) Err() error {
+	return o.Status.Err()
+}
+
+// AsInvalidTxError returns the InvalidTxError of err if it is the error of a
+// transaction rejected by the node on submission.
+func AsInvalidTxError(err error) (*InvalidTxError, bool) {
+	var rpcErr *Error
+	if !errors.Is(err, ErrInvalidTransaction) || !errors.As(err, &rpcErr) {
+		return nil, false
+	}
+	var info struct {
+		TxExecutionError json.RawMessage
+	}
+	if rpcErr.DecodeCauseInfo(&info) != nil || info.TxExecutionError == nil {
+		return nil, false
+	}
+	var e *InvalidTxError
+	if !errors.As(parseTxExecutionError(info.TxExecutionError), &e) {
+		return nil, false
+	}
+	return e, true
+}
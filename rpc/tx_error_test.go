@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestExecutionStatusErr(t *testing.T) {
+	var s ExecutionStatus
+	if err := json.Unmarshal([]byte(`{"SuccessValue":""}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() of success returned %v", err)
+	}
+
+	for _, tt := range []struct {
+		failure string
+		index   int
+		kind    string
+		msg     string
+	}{
+		{`{"ActionError":{"index":1,"kind":{"AccountAlreadyExists":{"account_id":"bob.testnet"}}}}`,
+			1, ActionAccountAlreadyExists, `rpc: action 1 failed: AccountAlreadyExists: {"account_id":"bob.testnet"}`},
+		{`{"ActionError":{"index":0,"kind":"DelegateActionExpired"}}`,
+			0, ActionDelegateActionExpired, "rpc: action 0 failed: DelegateActionExpired"},
+		{`{"ActionError":{"index":0,"kind":{"LackBalanceForState":{"account_id":"bob.testnet","amount":"1000"}}}}`,
+			0, ActionLackBalanceForState, `rpc: action 0 failed: LackBalanceForState: {"account_id":"bob.testnet","amount":"1000"}`},
+	} {
+		s := ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(tt.failure)}
+		var e *ActionError
+		if err := s.Err(); !errors.As(err, &e) {
+			t.Errorf("Err() of %s returned %v (want *ActionError)", tt.failure, err)
+			continue
+		}
+		if e.Index == nil || *e.Index != tt.index || e.Kind != tt.kind || e.Error() != tt.msg {
+			t.Errorf("Err() of %s returned %+v with message %q", tt.failure, e, e.Error())
+		}
+	}
+}
+
+func TestFunctionCallError(t *testing.T) {
+	for _, tt := range []struct {
+		failure string
+		kind    string
+		msg     string
+	}{
+		{`{"ActionError":{"index":0,"kind":{"FunctionCallError":{"ExecutionError":"Smart contract panicked: not owner"}}}}`,
+			FunctionCallExecutionError, "Smart contract panicked: not owner"},
+		{`{"ActionError":{"index":0,"kind":{"FunctionCallError":{"MethodResolveError":"MethodNotFound"}}}}`,
+			FunctionCallMethodResolveError, "MethodNotFound"},
+		{`{"ActionError":{"index":0,"kind":{"FunctionCallError":{"HostError":{"GasLimitExceeded":null}}}}}`,
+			FunctionCallHostError, ""},
+	} {
+		err := (&FinalExecutionOutcome{Status: ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(tt.failure)}}).Err()
+		var e *FunctionCallError
+		if !errors.As(err, &e) {
+			t.Errorf("Err() of %s returned %v (want *FunctionCallError)", tt.failure, err)
+			continue
+		}
+		if e.Kind != tt.kind || e.Message != tt.msg {
+			t.Errorf("Err() of %s returned %+v", tt.failure, e)
+		}
+	}
+	err := ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(
+		`{"ActionError":{"index":0,"kind":{"FunctionCallError":{"ExecutionError":"Smart contract panicked: not owner"}}}}`)}.Err()
+	if want := "rpc: action 0 failed: Smart contract panicked: not owner"; err.Error() != want {
+		t.Errorf("Error() returned %q (want %q)", err, want)
+	}
+}
+
+func TestInvalidTxError(t *testing.T) {
+	err := ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(
+		`{"InvalidTxError":{"InvalidAccessKeyError":{"AccessKeyNotFound":{"account_id":"alice.testnet","public_key":"ed25519:abc"}}}}`)}.Err()
+	var e *InvalidTxError
+	if !errors.As(err, &e) || e.Kind != InvalidTxInvalidAccessKeyError || !errors.Is(err, ErrInvalidTransaction) {
+		t.Fatalf("Err() returned %v (want InvalidAccessKeyError)", err)
+	}
+	var info map[string]json.RawMessage
+	if err := e.DecodeInfo(&info); err != nil || info["AccessKeyNotFound"] == nil {
+		t.Errorf("DecodeInfo() returned %v, %v", info, err)
+	}
+
+	rpcErr := &Error{Code: -32000, Name: "HANDLER_ERROR", Cause: &ErrorCause{
+		Name: "INVALID_TRANSACTION",
+		Info: json.RawMessage(`{"TxExecutionError":{"InvalidTxError":"Expired"}}`),
+	}}
+	if e, ok := AsInvalidTxError(rpcErr); !ok || e.Kind != InvalidTxExpired {
+		t.Errorf("AsInvalidTxError() returned %+v, %v (want Expired)", e, ok)
+	}
+	if _, ok := AsInvalidTxError(errors.New("other")); ok {
+		t.Error("AsInvalidTxError() accepted other error")
+	}
+
+	for _, failure := range []string{`"Failure"`, `{"A":1,"B":2}`, `{"Unknown":{}}`} {
+		if err := (ExecutionStatus{Kind: "Failure", Failure: json.RawMessage(failure)}).Err(); err == nil {
+			t.Errorf("Err() of %s returned nil", failure)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTxStatus(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "tx", "tx_status.json", func(p map[string]interface{}) { params = p })
+	o, err := c.TxStatus(context.Background(), "TX", "alice.testnet", TxExecutionStatusFinal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"tx_hash": "TX", "sender_account_id": "alice.testnet", "wait_until": "FINAL"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("TxStatus() sent params %v (want %v)", params, want)
+	}
+	if o.FinalExecutionStatus != TxExecutionStatusFinal || !o.Status.IsSuccess() || string(o.Status.SuccessValue) != `"ok"` {
+		t.Errorf("TxStatus() returned status %v %+v", o.FinalExecutionStatus, o.Status)
+	}
+	if o.TransactionOutcome.Outcome.Status.SuccessReceiptID != "R1" || o.TransactionOutcome.Proof[0].Direction != "Right" {
+		t.Errorf("TxStatus() returned transaction outcome %+v", o.TransactionOutcome)
+	}
+	var ids []string
+	for _, outcome := range o.Flatten() {
+		ids = append(ids, outcome.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"TX", "R1", "R2", "R4", "R3"}) {
+		t.Errorf("Flatten() returned %v", ids)
+	}
+	if logs := o.Logs(); !reflect.DeepEqual(logs, []string{"ping", "pong", "refund"}) {
+		t.Errorf("Logs() returned %v", logs)
+	}
+	if gas := o.GasBurnt(); gas != 7428000000000 {
+		t.Errorf("GasBurnt() returned %d", gas)
+	}
+	if tokens := o.TokensBurnt(); tokens.String() != "742800000000000000000" {
+		t.Errorf("TokensBurnt() returned %s", &tokens)
+	}
+	if o.Outcome("R3") == nil || o.Outcome("R5") != nil {
+		t.Error("Outcome() returned wrong outcomes")
+	}
+}
+
+func TestExecutionStatusJSON(t *testing.T) {
+	for _, s := range []string{
+		`"Unknown"`,
+		`"Started"`,
+		`{"SuccessValue":""}`,
+		`{"SuccessReceiptId":"R1"}`,
+		`{"Failure":{"ActionError":{"index":0,"kind":{"AccountDoesNotExist":{"account_id":"bob.testnet"}}}}}`,
+	} {
+		var status ExecutionStatus
+		if err := json.Unmarshal([]byte(s), &status); err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != s {
+			t.Errorf("json.Marshal() returned %s (want %s)", data, s)
+		}
+	}
+	var status ExecutionStatus
+	if err := json.Unmarshal([]byte(`{"Failure":{}}`), &status); err != nil || !status.IsFailure() {
+		t.Errorf("json.Unmarshal() returned %+v, %v", status, err)
+	}
+}
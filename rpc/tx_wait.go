@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPending is returned (wrapped) by WaitForOutcome if the context is done
+// before a known transaction reached the requested execution level.
+var ErrPending = errors.New("rpc: transaction pending")
+
+// Backoff of WaitForOutcome between polls, variables for tests.
+var (
+	waitInitialBackoff = 500 * time.Millisecond
+	waitMaxBackoff     = 5 * time.Second
+)
+
+var txExecutionLevels = []TxExecutionStatus{
+	TxExecutionStatusNone,
+	TxExecutionStatusIncluded,
+	TxExecutionStatusExecutedOptimistic,
+	TxExecutionStatusIncludedFinal,
+	TxExecutionStatusExecuted,
+	TxExecutionStatusFinal,
+}
+
+func (s TxExecutionStatus) level() int {
+	if s == "" {
+		s = TxExecutionStatusExecutedOptimistic
+	}
+	for i, l := range txExecutionLevels {
+		if l == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reached reports whether the execution level s implies level, the node
+// default EXECUTED_OPTIMISTIC if empty. Unknown levels are never reached.
+func (s TxExecutionStatus) Reached(level TxExecutionStatus) bool {
+	l := level.level()
+	return l >= 0 && s != "" && s.level() >= l
+}
+
+// WaitForOutcome polls the status of the transaction with the base58 encoded
+// txHash signed by senderID until it reached until (the node default,
+// EXECUTED_OPTIMISTIC, if empty) and returns its outcome. Transactions which
+// are unknown to the node, e.g. because they are not propagated yet, and
+// node timeouts are polled again with growing backoff until ctx is done.
+//
+// If ctx is done first, the returned error wraps ErrPending if the node
+// knows the transaction, along with its last outcome if any, and
+// ErrUnknownTransaction if it does not.
+func (c *Client) WaitForOutcome(ctx context.Context, txHash, senderID string, until TxExecutionStatus) (*FinalExecutionOutcome, error) {
+	if until.level() < 0 {
+		return nil, fmt.Errorf("rpc: unknown execution level '%s'", until)
+	}
+	var last *FinalExecutionOutcome
+	known := false
+	backoff := waitInitialBackoff
+	for {
+		outcome, err := c.TxStatus(ctx, txHash, senderID, until)
+		switch {
+		case err == nil:
+			// nodes without execution levels answer after execution
+			if outcome.FinalExecutionStatus == "" || outcome.FinalExecutionStatus.Reached(until) {
+				return outcome, nil
+			}
+			last, known = outcome, true
+		case errors.Is(err, ErrTimeout):
+			known = true
+		case errors.Is(err, ErrUnknownTransaction):
+		case ctx.Err() != nil:
+		default:
+			return nil, err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			if known {
+				return last, fmt.Errorf("%w: %s did not reach %s: %v", ErrPending, txHash, until, ctx.Err())
+			}
+			return nil, fmt.Errorf("%w: %s: %v", ErrUnknownTransaction, txHash, ctx.Err())
+		}
+		if backoff *= 2; backoff > waitMaxBackoff {
+			backoff = waitMaxBackoff
+		}
+	}
+}
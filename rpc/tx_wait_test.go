@@ -0,0 +1,99 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReached(t *testing.T) {
+	for _, tt := range []struct {
+		status, level TxExecutionStatus
+		want          bool
+	}{
+		{TxExecutionStatusFinal, TxExecutionStatusExecuted, true},
+		{TxExecutionStatusExecuted, TxExecutionStatusExecuted, true},
+		{TxExecutionStatusIncluded, TxExecutionStatusExecuted, false},
+		{TxExecutionStatusExecutedOptimistic, "", true},
+		{TxExecutionStatusIncluded, "", false},
+		{"", TxExecutionStatusNone, false},
+		{TxExecutionStatusFinal, "SOMETIME", false},
+	} {
+		if got := tt.status.Reached(tt.level); got != tt.want {
+			t.Errorf("%q.Reached(%q) returned %v (want %v)", tt.status, tt.level, got, tt.want)
+		}
+	}
+}
+
+func handlerError(cause string) *Error {
+	return &Error{Code: -32000, Name: "HANDLER_ERROR", Cause: &ErrorCause{Name: cause}}
+}
+
+func TestWaitForOutcome(t *testing.T) {
+	waitInitialBackoff, waitMaxBackoff = time.Millisecond, 2*time.Millisecond
+	defer func() { waitInitialBackoff, waitMaxBackoff = 500*time.Millisecond, 5*time.Second }()
+
+	// the transaction is unknown at first, then pending and finally executed
+	var calls int
+	srv := newTestServer(t, func(method string, params json.RawMessage) (interface{}, *Error) {
+		var p map[string]string
+		if err := json.Unmarshal(params, &p); err != nil || method != "tx" || p["tx_hash"] != "TX" ||
+			p["sender_account_id"] != "alice.testnet" || p["wait_until"] != "FINAL" {
+			t.Errorf("%s has params %s", method, params)
+		}
+		calls++
+		switch calls {
+		case 1:
+			return nil, handlerError("UNKNOWN_TRANSACTION")
+		case 2:
+			return nil, handlerError("TIMEOUT_ERROR")
+		case 3:
+			return map[string]string{"final_execution_status": "EXECUTED"}, nil
+		}
+		return map[string]string{"final_execution_status": "FINAL"}, nil
+	})
+	defer srv.Close()
+	c := NewClient(srv.URL)
+	outcome, err := c.WaitForOutcome(context.Background(), "TX", "alice.testnet", TxExecutionStatusFinal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.FinalExecutionStatus != TxExecutionStatusFinal || calls != 4 {
+		t.Errorf("WaitForOutcome() returned %s after %d calls (want FINAL after 4)", outcome.FinalExecutionStatus, calls)
+	}
+
+	if _, err := c.WaitForOutcome(context.Background(), "TX", "alice.testnet", "SOMETIME"); err == nil {
+		t.Error("WaitForOutcome() accepted unknown level")
+	}
+}
+
+func TestWaitForOutcomeDeadline(t *testing.T) {
+	waitInitialBackoff, waitMaxBackoff = time.Millisecond, 2*time.Millisecond
+	defer func() { waitInitialBackoff, waitMaxBackoff = 500*time.Millisecond, 5*time.Second }()
+
+	for _, tt := range []struct {
+		name    string
+		res     interface{}
+		err     *Error
+		want    error
+		outcome bool
+	}{
+		{"unknown", nil, handlerError("UNKNOWN_TRANSACTION"), ErrUnknownTransaction, false},
+		{"timeout", nil, handlerError("TIMEOUT_ERROR"), ErrPending, false},
+		{"included", map[string]string{"final_execution_status": "INCLUDED"}, nil, ErrPending, true},
+		{"failed", nil, handlerError("INVALID_TRANSACTION"), ErrInvalidTransaction, false},
+	} {
+		srv := newTestServer(t, func(string, json.RawMessage) (interface{}, *Error) {
+			return tt.res, tt.err
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		outcome, err := NewClient(srv.URL).WaitForOutcome(ctx, "TX", "alice.testnet", TxExecutionStatusExecuted)
+		if !errors.Is(err, tt.want) || (outcome != nil) != tt.outcome {
+			t.Errorf("WaitForOutcome() of %s transaction returned %v, %v (want %v)", tt.name, outcome, err, tt.want)
+		}
+		cancel()
+		srv.Close()
+	}
+}
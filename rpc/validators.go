@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// EpochReference selects an epoch for Validators: the current epoch (zero
+// value), an epoch by ID or the epoch of a block selected by height or hash.
+type EpochReference struct {
+	EpochID string
+	Block   BlockReference
+}
+
+// CurrentEpoch returns a reference to the current epoch.
+func CurrentEpoch() EpochReference {
+	return EpochReference{}
+}
+
+// EpochByID returns a reference to the epoch with the base58 encoded ID.
+func EpochByID(epochID string) EpochReference {
+	return EpochReference{EpochID: epochID}
+}
+
+// EpochOfBlock returns a reference to the epoch the block selected by height
+// or hash belongs to. Nodes only answer for the last block of an epoch.
+func EpochOfBlock(block BlockReference) EpochReference {
+	return EpochReference{Block: block}
+}
+
+func (r EpochReference) params() interface{} {
+	switch {
+	case r.EpochID != "":
+		return map[string]interface{}{"epoch_id": r.EpochID}
+	case r.Block.BlockHash != "":
+		return map[string]interface{}{"block_id": r.Block.BlockHash}
+	case r.Block.hasHeight():
+		return map[string]interface{}{"block_id": r.Block.BlockHeight}
+	default:
+		return []interface{}{nil}
+	}
+}
+
+// CurrentEpochValidatorInfo is a validator of the current epoch with its
+// production statistics.
+type CurrentEpochValidatorInfo struct {
+	AccountID                       string        `json:"account_id"`
+	PublicKey                       string        `json:"public_key"`
+	IsSlashed                       bool          `json:"is_slashed"`
+	Stake                           types.Balance `json:"stake"`
+	Shards                          []uint64      `json:"shards"`
+	NumProducedBlocks               uint64        `json:"num_produced_blocks"`
+	NumExpectedBlocks               uint64        `json:"num_expected_blocks"`
+	NumProducedChunks               uint64        `json:"num_produced_chunks"`
+	NumExpectedChunks               uint64        `json:"num_expected_chunks"`
+	NumProducedChunksPerShard       []uint64      `json:"num_produced_chunks_per_shard"`
+	NumExpectedChunksPerShard       []uint64      `json:"num_expected_chunks_per_shard"`
+	NumProducedEndorsements         uint64        `json:"num_produced_endorsements"`
+	NumExpectedEndorsements         uint64        `json:"num_expected_endorsements"`
+	NumProducedEndorsementsPerShard []uint64      `json:"num_produced_endorsements_per_shard"`
+	NumExpectedEndorsementsPerShard []uint64      `json:"num_expected_endorsements_per_shard"`
+	ShardsEndorsed                  []uint64      `json:"shards_endorsed"`
+}
+
+// ProductionRate returns the share of expected blocks, chunks and chunk
+// endorsements the validator produced so far in the epoch, 1 if nothing was
+// expected yet. Validators below the kickout thresholds of the protocol
+// config lose their seat in the next epochs.
+func (v *CurrentEpochValidatorInfo) ProductionRate() float64 {
+	expected := v.NumExpectedBlocks + v.NumExpectedChunks + v.NumExpectedEndorsements
+	if expected == 0 {
+		return 1
+	}
+	produced := v.NumProducedBlocks + v.NumProducedChunks + v.NumProducedEndorsements
+	return float64(produced) / float64(expected)
+}
+
+// NextEpochValidatorInfo is a validator of the next epoch.
+type NextEpochValidatorInfo struct {
+	AccountID string        `json:"account_id"`
+	PublicKey string        `json:"public_key"`
+	Stake     types.Balance `json:"stake"`
+	Shards    []uint64      `json:"shards"`
+}
+
+// ValidatorKickoutReason is the reason a validator was kicked out. Kind is
+// the name of the reason, the other fields are only set for the reasons
+// carrying them.
+type ValidatorKickoutReason struct {
+	// Kind is one of Slashed, NotEnoughBlocks, NotEnoughChunks,
+	// NotEnoughChunkEndorsements, Unstaked, NotEnoughStake, DidNotGetASeat or
+	// ProtocolVersionTooOld.
+	Kind string
+	// Produced and Expected are set for NotEnoughBlocks, NotEnoughChunks and
+	// NotEnoughChunkEndorsements.
+	Produced uint64 `json:"produced"`
+	Expected uint64 `json:"expected"`
+	// Stake and Threshold are set for NotEnoughStake.
+	Stake     *types.Balance `json:"stake_u128"`
+	Threshold *types.Balance `json:"threshold_u128"`
+	// Version and NetworkVersion are set for ProtocolVersionTooOld.
+	Version        uint32 `json:"version"`
+	NetworkVersion uint32 `json:"network_version"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *ValidatorKickoutReason) UnmarshalJSON(data []byte) error {
+	*r = ValidatorKickoutReason{}
+	if err := json.Unmarshal(data, &r.Kind); err == nil {
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if len(m) != 1 {
+		return fmt.Errorf("rpc: invalid validator kickout reason %s", data)
+	}
+	for kind, v := range m {
+		// alias type to decode the fields without recursion
+		type fields ValidatorKickoutReason
+		if err := json.Unmarshal(v, (*fields)(r)); err != nil {
+			return fmt.Errorf("rpc: invalid validator kickout reason %s: %v", kind, err)
+		}
+		r.Kind = kind
+	}
+	return nil
+}
+
+// ValidatorKickoutView is a validator kicked out in the previous epoch.
+type ValidatorKickoutView struct {
+	AccountID string                 `json:"account_id"`
+	Reason    ValidatorKickoutReason `json:"reason"`
+}
+
+// EpochValidatorInfo is the result of Validators.
+type EpochValidatorInfo struct {
+	CurrentValidators []CurrentEpochValidatorInfo `json:"current_validators"`
+	NextValidators    []NextEpochValidatorInfo    `json:"next_validators"`
+	CurrentFishermen  []ValidatorStakeView        `json:"current_fishermen"`
+	NextFishermen     []ValidatorStakeView        `json:"next_fishermen"`
+	CurrentProposals  []ValidatorStakeView        `json:"current_proposals"`
+	PrevEpochKickout  []ValidatorKickoutView      `json:"prev_epoch_kickout"`
+	EpochStartHeight  uint64                      `json:"epoch_start_height"`
+	EpochHeight       uint64                      `json:"epoch_height"`
+}
+
+// Validators returns the validators of the epoch selected by epoch.
+//
+// For details see https://docs.near.org/api/rpc/network#validation-status
+func (c *Client) Validators(ctx context.Context, epoch EpochReference) (*EpochValidatorInfo, error) {
+	var res EpochValidatorInfo
+	if err := c.Call(ctx, "validators", epoch.params(), &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
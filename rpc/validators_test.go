@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	var params map[string]interface{}
+	c := newFixtureServer(t, "validators", "validators.json", func(p map[string]interface{}) { params = p })
+	ctx := context.Background()
+	info, err := c.Validators(ctx, EpochByID("9S2Ex9i7qGZYyXjs1uYQGbsgjchQoHQBNsnnXyqP8sDW"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["epoch_id"] != "9S2Ex9i7qGZYyXjs1uYQGbsgjchQoHQBNsnnXyqP8sDW" {
+		t.Errorf("Validators() sent params %v", params)
+	}
+	if info.EpochHeight != 2612 || len(info.CurrentValidators) != 1 || len(info.NextValidators) != 1 {
+		t.Fatalf("Validators() returned %+v", info)
+	}
+	if _, err := c.Validators(ctx, CurrentEpoch()); err != nil {
+		t.Fatal(err)
+	}
+	if params != nil {
+		t.Errorf("Validators() sent params %v for current epoch (want [null])", params)
+	}
+	v := info.CurrentValidators[0]
+	if v.Stake.String() != "50000000000000000000000000000" || v.NumExpectedChunksPerShard[1] != 100 {
+		t.Errorf("Validators() returned current validator %+v", v)
+	}
+	if rate := v.ProductionRate(); rate != 0.9 {
+		t.Errorf("ProductionRate() returned %v (want 0.9)", rate)
+	}
+	kickouts := info.PrevEpochKickout
+	if len(kickouts) != 3 {
+		t.Fatalf("Validators() returned %d kickouts", len(kickouts))
+	}
+	if r := kickouts[0].Reason; r.Kind != "NotEnoughBlocks" || r.Produced != 10 || r.Expected != 100 {
+		t.Errorf("Validators() returned kickout reason %+v", r)
+	}
+	if r := kickouts[1].Reason; r.Kind != "NotEnoughStake" || r.Stake.Int64() != 100 || r.Threshold.Int64() != 1000 {
+		t.Errorf("Validators() returned kickout reason %+v", r)
+	}
+	if r := kickouts[2].Reason; r.Kind != "Unstaked" {
+		t.Errorf("Validators() returned kickout reason %+v", r)
+	}
+}
@@ -0,0 +1,324 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// FunctionCallPermissionView restricts a function call access key.
+type FunctionCallPermissionView struct {
+	// Allowance is the remaining allowance, nil if it is unlimited.
+	Allowance   *types.Balance `json:"allowance"`
+	ReceiverID  string         `json:"receiver_id"`
+	MethodNames []string       `json:"method_names"`
+}
+
+// AccessKeyPermissionView is the permission of an access key, FunctionCall is
+// nil for full access keys.
+type AccessKeyPermissionView struct {
+	FunctionCall *FunctionCallPermissionView
+}
+
+// FullAccess reports whether the permission grants full access.
+func (p AccessKeyPermissionView) FullAccess() bool {
+	return p.FunctionCall == nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p AccessKeyPermissionView) MarshalJSON() ([]byte, error) {
+	if p.FunctionCall == nil {
+		return json.Marshal("FullAccess")
+	}
+	return json.Marshal(map[string]interface{}{"FunctionCall": p.FunctionCall})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *AccessKeyPermissionView) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		if s != "FullAccess" {
+			return fmt.Errorf("rpc: unknown access key permission '%s'", s)
+		}
+		p.FunctionCall = nil
+		return nil
+	}
+	var v struct {
+		FunctionCall *FunctionCallPermissionView
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.FunctionCall == nil {
+		return fmt.Errorf("rpc: unknown access key permission %s", data)
+	}
+	p.FunctionCall = v.FunctionCall
+	return nil
+}
+
+// AccessKeyView is an access key.
+type AccessKeyView struct {
+	Nonce      uint64                  `json:"nonce"`
+	Permission AccessKeyPermissionView `json:"permission"`
+}
+
+// CreateAccountActionView creates the receiver account.
+type CreateAccountActionView struct{}
+
+// DeployContractActionView deploys a contract to the receiver account.
+type DeployContractActionView struct {
+	Code []byte `json:"code"`
+}
+
+// FunctionCallActionView calls a function of the receiver contract.
+type FunctionCallActionView struct {
+	MethodName string        `json:"method_name"`
+	Args       []byte        `json:"args"`
+	Gas        uint64        `json:"gas"`
+	Deposit    types.Balance `json:"deposit"`
+}
+
+// TransferActionView transfers NEAR to the receiver account.
+type TransferActionView struct {
+	Deposit types.Balance `json:"deposit"`
+}
+
+// StakeActionView stakes NEAR of the receiver account.
+type StakeActionView struct {
+	Stake     types.Balance `json:"stake"`
+	PublicKey string        `json:"public_key"`
+}
+
+// AddKeyActionView adds an access key to the receiver account.
+type AddKeyActionView struct {
+	PublicKey string        `json:"public_key"`
+	AccessKey AccessKeyView `json:"access_key"`
+}
+
+// DeleteKeyActionView deletes an access key of the receiver account.
+type DeleteKeyActionView struct {
+	PublicKey string `json:"public_key"`
+}
+
+// DeleteAccountActionView deletes the receiver account.
+type DeleteAccountActionView struct {
+	BeneficiaryID string `json:"beneficiary_id"`
+}
+
+// DelegateActionView is a meta transaction (NEP-366) executed on behalf of
+// its sender.
+type DelegateActionView struct {
+	DelegateAction struct {
+		SenderID       string       `json:"sender_id"`
+		ReceiverID     string       `json:"receiver_id"`
+		Actions        []ActionView `json:"actions"`
+		Nonce          uint64       `json:"nonce"`
+		MaxBlockHeight uint64       `json:"max_block_height"`
+		PublicKey      string       `json:"public_key"`
+	} `json:"delegate_action"`
+	Signature string `json:"signature"`
+}
+
+// DeployGlobalContractActionView deploys a global contract (NEP-591), by
+// code hash for DeployGlobalContract and by account ID for
+// DeployGlobalContractByAccountId.
+type DeployGlobalContractActionView struct {
+	Code []byte `json:"code"`
+}
+
+// UseGlobalContractActionView makes a global contract the contract of the
+// receiver account, identified by CodeHash for UseGlobalContract and by
+// AccountID for UseGlobalContractByAccountId.
+type UseGlobalContractActionView struct {
+	CodeHash  string `json:"code_hash,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+}
+
+// ActionView is an action of a transaction or receipt. Kind is the name of
+// the action and exactly the field of that name is set. Actions unknown to
+// this package only have Kind and Raw set.
+type ActionView struct {
+	Kind           string
+	CreateAccount  *CreateAccountActionView
+	DeployContract *DeployContractActionView
+	FunctionCall   *FunctionCallActionView
+	Transfer       *TransferActionView
+	Stake          *StakeActionView
+	AddKey         *AddKeyActionView
+	DeleteKey      *DeleteKeyActionView
+	DeleteAccount  *DeleteAccountActionView
+	Delegate       *DelegateActionView
+	// DeployGlobalContract is set for both DeployGlobalContract and
+	// DeployGlobalContractByAccountId, UseGlobalContract for both
+	// UseGlobalContract and UseGlobalContractByAccountId.
+	DeployGlobalContract *DeployGlobalContractActionView
+	UseGlobalContract    *UseGlobalContractActionView
+	// Raw is the JSON encoding of the action, only set for unknown actions.
+	Raw json.RawMessage
+}
+
+// variant returns a pointer to the field of the action kind, nil for unknown
+// kinds.
+func (a *ActionView) variant(alloc bool) interface{} {
+	switch a.Kind {
+	case "CreateAccount":
+		if alloc {
+			a.CreateAccount = new(CreateAccountActionView)
+		}
+		return a.CreateAccount
+	case "DeployContract":
+		if alloc {
+			a.DeployContract = new(DeployContractActionView)
+		}
+		return a.DeployContract
+	case "FunctionCall":
+		if alloc {
+			a.FunctionCall = new(FunctionCallActionView)
+		}
+		return a.FunctionCall
+	case "Transfer":
+		if alloc {
+			a.Transfer = new(TransferActionView)
+		}
+		return a.Transfer
+	case "Stake":
+		if alloc {
+			a.Stake = new(StakeActionView)
+		}
+		return a.Stake
+	case "AddKey":
+		if alloc {
+			a.AddKey = new(AddKeyActionView)
+		}
+		return a.AddKey
+	case "DeleteKey":
+		if alloc {
+			a.DeleteKey = new(DeleteKeyActionView)
+		}
+		return a.DeleteKey
+	case "DeleteAccount":
+		if alloc {
+			a.DeleteAccount = new(DeleteAccountActionView)
+		}
+		return a.DeleteAccount
+	case "Delegate":
+		if alloc {
+			a.Delegate = new(DelegateActionView)
+		}
+		return a.Delegate
+	case "DeployGlobalContract", "DeployGlobalContractByAccountId":
+		if alloc {
+			a.DeployGlobalContract = new(DeployGlobalContractActionView)
+		}
+		return a.DeployGlobalContract
+	case "UseGlobalContract", "UseGlobalContractByAccountId":
+		if alloc {
+			a.UseGlobalContract = new(UseGlobalContractActionView)
+		}
+		return a.UseGlobalContract
+	default:
+		return nil
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a ActionView) MarshalJSON() ([]byte, error) {
+	if a.Raw != nil {
+		return a.Raw, nil
+	}
+	if a.Kind == "CreateAccount" {
+		return json.Marshal(a.Kind)
+	}
+	v := a.variant(false)
+	if v == nil {
+		return nil, fmt.Errorf("rpc: unknown action '%s'", a.Kind)
+	}
+	return json.Marshal(map[string]interface{}{a.Kind: v})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Actions are encoded as
+// {"<kind>": {...}}, actions without fields as "<kind>".
+func (a *ActionView) UnmarshalJSON(data []byte) error {
+	*a = ActionView{}
+	var kind string
+	if err := json.Unmarshal(data, &kind); err == nil {
+		a.Kind = kind
+		if a.variant(true) == nil {
+			a.Raw = append(json.RawMessage{}, data...)
+		}
+		return nil
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if len(m) != 1 {
+		return fmt.Errorf("rpc: invalid action %s", data)
+	}
+	for k, v := range m {
+		a.Kind = k
+		variant := a.variant(true)
+		if variant == nil {
+			a.Raw = append(json.RawMessage{}, data...)
+			return nil
+		}
+		if err := json.Unmarshal(v, variant); err != nil {
+			return fmt.Errorf("rpc: invalid action %s: %v", k, err)
+		}
+	}
+	return nil
+}
+
+// SignedTransactionView is a signed transaction.
+type SignedTransactionView struct {
+	SignerID    string       `json:"signer_id"`
+	PublicKey   string       `json:"public_key"`
+	Nonce       uint64       `json:"nonce"`
+	ReceiverID  string       `json:"receiver_id"`
+	Actions     []ActionView `json:"actions"`
+	PriorityFee uint64       `json:"priority_fee,omitempty"`
+	Signature   string       `json:"signature"`
+	Hash        string       `json:"hash"`
+}
+
+// DataReceiverView is a receiver of the output data of an action receipt.
+type DataReceiverView struct {
+	DataID     string `json:"data_id"`
+	ReceiverID string `json:"receiver_id"`
+}
+
+// ActionReceiptView is the content of an action receipt.
+type ActionReceiptView struct {
+	SignerID            string             `json:"signer_id"`
+	SignerPublicKey     string             `json:"signer_public_key"`
+	GasPrice            types.Balance      `json:"gas_price"`
+	OutputDataReceivers []DataReceiverView `json:"output_data_receivers"`
+	InputDataIDs        []string           `json:"input_data_ids"`
+	Actions             []ActionView       `json:"actions"`
+	IsPromiseYield      bool               `json:"is_promise_yield,omitempty"`
+}
+
+// DataReceiptView is the content of a data receipt.
+type DataReceiptView struct {
+	DataID string `json:"data_id"`
+	// Data is nil if the promise producing it failed.
+	Data            []byte `json:"data"`
+	IsPromiseResume bool   `json:"is_promise_resume,omitempty"`
+}
+
+// ReceiptContentView is the content of a receipt, exactly one of the fields is
+// set.
+type ReceiptContentView struct {
+	Action *ActionReceiptView `json:"Action,omitempty"`
+	Data   *DataReceiptView   `json:"Data,omitempty"`
+}
+
+// ReceiptView is a receipt.
+type ReceiptView struct {
+	PredecessorID string             `json:"predecessor_id"`
+	ReceiverID    string             `json:"receiver_id"`
+	ReceiptID     string             `json:"receipt_id"`
+	Receipt       ReceiptContentView `json:"receipt"`
+	Priority      uint64             `json:"priority,omitempty"`
+}
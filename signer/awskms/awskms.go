@@ -0,0 +1,145 @@
+// Package awskms implements signers which keep NEAR keys in AWS KMS.
+package awskms
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultTimeout is the timeout of a single KMS request made by a signer.
+const DefaultTimeout = 10 * time.Second
+
+// Signer is a signer.Signer which delegates Ed25519 signing to an asymmetric
+// ECC_NIST_EDWARDS25519 KMS key. The private key never leaves KMS.
+type Signer struct {
+	client    Client
+	keyID     string
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewSigner returns a new signer for accountID using the KMS key keyID. The
+// public key is retrieved from KMS.
+func NewSigner(ctx context.Context, client Client, keyID, accountID string) (*Signer, error) {
+	der, err := client.GetPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("awskms: cannot parse public key of %s: %v", keyID, err)
+	}
+	edPub, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("awskms: key %s is not an Ed25519 key", keyID)
+	}
+	return &Signer{
+		client:    client,
+		keyID:     keyID,
+		accountID: accountID,
+		pubKey:    utils.PublicKeyFromEd25519(edPub),
+	}, nil
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	sig, err := s.client.Sign(ctx, s.keyID, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("awskms: KMS returned signature of invalid length %d", len(sig))
+	}
+	return sig, nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
+
+// DataKeySigner is a signer.Signer which signs locally with an Ed25519 key
+// that is stored encrypted by KMS (for example a data key). The key is
+// decrypted for every signature and wiped from memory afterwards, it is
+// never written to disk.
+type DataKeySigner struct {
+	client     Client
+	ciphertext []byte
+	accountID  string
+	pubKey     utils.PublicKey
+}
+
+// NewDataKeySigner returns a new signer for accountID using the KMS encrypted
+// Ed25519 key in ciphertext. The plaintext must be a 32 byte seed or a 64 byte
+// Ed25519 private key.
+func NewDataKeySigner(ctx context.Context, client Client, ciphertext []byte, accountID string) (*DataKeySigner, error) {
+	s := &DataKeySigner{
+		client:     client,
+		ciphertext: ciphertext,
+		accountID:  accountID,
+	}
+	privKey, err := s.decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(privKey)
+	s.pubKey = utils.PublicKeyFromEd25519(privKey.Public().(ed25519.PublicKey))
+	return s, nil
+}
+
+func (s *DataKeySigner) decrypt(ctx context.Context) (ed25519.PrivateKey, error) {
+	plaintext, err := s.client.Decrypt(ctx, s.ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	switch len(plaintext) {
+	case ed25519.SeedSize:
+		defer wipe(plaintext)
+		return ed25519.NewKeyFromSeed(plaintext), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(plaintext), nil
+	default:
+		wipe(plaintext)
+		return nil, fmt.Errorf("awskms: decrypted data key has invalid length %d", len(plaintext))
+	}
+}
+
+// SignBytes implements signer.Signer.
+func (s *DataKeySigner) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	privKey, err := s.decrypt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe(privKey)
+	return ed25519.Sign(privKey, msg), nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *DataKeySigner) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *DataKeySigner) AccountID() string {
+	return s.accountID
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
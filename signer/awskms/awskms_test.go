@@ -0,0 +1,101 @@
+package awskms
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/signer"
+)
+
+func TestSignV4(t *testing.T) {
+	// example from the AWS Signature Version 4 documentation
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	now, _ := time.Parse("20060102T150405Z", "20150830T123600Z")
+	signV4(req, nil, Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "iam", now)
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization header is\n%s\nwant\n%s", got, want)
+	}
+}
+
+func newKMSServer(t *testing.T, priv ed25519.PrivateKey) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			t.Error("request is not signed")
+		}
+		var in struct {
+			KeyId          string
+			Message        []byte
+			CiphertextBlob []byte
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Error(err)
+		}
+		var out interface{}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			der, err := x509.MarshalPKIXPublicKey(priv.Public())
+			if err != nil {
+				t.Error(err)
+			}
+			out = map[string]interface{}{"PublicKey": der}
+		case "TrentService.Sign":
+			out = map[string]interface{}{"Signature": ed25519.Sign(priv, in.Message)}
+		case "TrentService.Decrypt":
+			out = map[string]interface{}{"Plaintext": priv.Seed()}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestSigners(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newKMSServer(t, priv)
+	defer srv.Close()
+	client := NewHTTPClient("us-east-1", srv.URL, Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	ctx := context.Background()
+	kmsSigner, err := NewSigner(ctx, client, "alias/near", "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataKeySigner, err := NewDataKeySigner(ctx, client, []byte("ciphertext"), "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []signer.Signer{kmsSigner, dataKeySigner} {
+		if string(s.PublicKey().Bytes()) != string(pub) {
+			t.Error("public key does not match")
+		}
+		msg := []byte("message")
+		sig, err := s.SignBytes(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ed25519.Verify(pub, msg, sig) {
+			t.Error("signature does not verify")
+		}
+	}
+}
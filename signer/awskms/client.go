@@ -0,0 +1,200 @@
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Client is the subset of the AWS KMS API used by the signers in this
+// package. It is implemented by HTTPClient, but can also be implemented by an
+// adapter around the official AWS SDK.
+type Client interface {
+	// Sign signs the raw message with the asymmetric KMS key keyID using the
+	// ED25519_SHA_512 signing algorithm and returns the signature.
+	Sign(ctx context.Context, keyID string, message []byte) ([]byte, error)
+	// GetPublicKey returns the DER encoded public key of the KMS key keyID.
+	GetPublicKey(ctx context.Context, keyID string) ([]byte, error)
+	// Decrypt decrypts the ciphertext blob created by KMS and returns the
+	// plaintext.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Credentials are the AWS credentials used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// CredentialsFromEnv returns the credentials configured in the standard
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment
+// variables.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// HTTPClient implements Client by calling the KMS JSON API directly, signing
+// requests with AWS Signature Version 4.
+type HTTPClient struct {
+	endpoint string
+	region   string
+	creds    Credentials
+	c        *http.Client
+}
+
+// NewHTTPClient returns a new KMS client for region using creds. If endpoint
+// is empty the public endpoint https://kms.<region>.amazonaws.com is used.
+func NewHTTPClient(region, endpoint string, creds Credentials) *HTTPClient {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", region)
+	}
+	return &HTTPClient{
+		endpoint: endpoint,
+		region:   region,
+		creds:    creds,
+		c:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// call invokes the KMS operation with input and decodes the response into
+// output.
+func (c *HTTPClient) call(ctx context.Context, operation string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+operation)
+	signV4(req, body, c.creds, c.region, "kms", time.Now())
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return fmt.Errorf("awskms: %s failed with status %d: %s: %s",
+			operation, resp.StatusCode, e.Type, e.Message)
+	}
+	return json.Unmarshal(data, output)
+}
+
+// Sign implements Client.
+func (c *HTTPClient) Sign(ctx context.Context, keyID string, message []byte) ([]byte, error) {
+	var out struct {
+		Signature []byte
+	}
+	err := c.call(ctx, "Sign", map[string]interface{}{
+		"KeyId":            keyID,
+		"Message":          message,
+		"MessageType":      "RAW",
+		"SigningAlgorithm": "ED25519_SHA_512",
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
+
+// GetPublicKey implements Client.
+func (c *HTTPClient) GetPublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	var out struct {
+		PublicKey []byte
+	}
+	if err := c.call(ctx, "GetPublicKey", map[string]interface{}{"KeyId": keyID}, &out); err != nil {
+		return nil, err
+	}
+	return out.PublicKey, nil
+}
+
+// Decrypt implements Client.
+func (c *HTTPClient) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	var out struct {
+		Plaintext []byte
+	}
+	if err := c.call(ctx, "Decrypt", map[string]interface{}{"CiphertextBlob": ciphertext}, &out); err != nil {
+		return nil, err
+	}
+	return out.Plaintext, nil
+}
+
+// signV4 adds an AWS Signature Version 4 Authorization header to req. All
+// headers already set on req are signed.
+func signV4(req *http.Request, body []byte, creds Credentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
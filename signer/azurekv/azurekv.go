@@ -0,0 +1,110 @@
+// Package azurekv implements a signer which keeps NEAR keys in Azure Key
+// Vault or Managed HSM.
+//
+// Key Vault does not support Ed25519, so the NEAR key is a secp256k1 key
+// (key type EC or EC-HSM with curve P-256K) and the account must use the
+// corresponding "secp256k1:" access key.
+package azurekv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// DefaultTimeout is the timeout of a single Key Vault request made by a
+// signer.
+const DefaultTimeout = 10 * time.Second
+
+// Signer is a signer.Signer which delegates secp256k1 signing to a P-256K Key
+// Vault key. The private key never leaves Key Vault.
+type Signer struct {
+	client    Client
+	keyID     string
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewSigner returns a new signer for accountID using the Key Vault key keyID
+// ("<name>" for the current version or "<name>/<version>"). The public key is
+// retrieved from Key Vault.
+func NewSigner(ctx context.Context, client Client, keyID, accountID string) (*Signer, error) {
+	key, err := client.GetKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if (key.KTY != "EC" && key.KTY != "EC-HSM") || key.CRV != "P-256K" {
+		return nil, fmt.Errorf("azurekv: key %s is not a P-256K key (%s %s)", keyID, key.KTY, key.CRV)
+	}
+	if len(key.X) != 32 || len(key.Y) != 32 {
+		return nil, fmt.Errorf("azurekv: key %s has invalid coordinates", keyID)
+	}
+	uncompressed := append(append([]byte{0x04}, key.X...), key.Y...)
+	if _, err := btcec.ParsePubKey(uncompressed, btcec.S256()); err != nil {
+		return nil, fmt.Errorf("azurekv: cannot parse public key of %s: %v", keyID, err)
+	}
+	return &Signer{
+		client:    client,
+		keyID:     keyID,
+		accountID: accountID,
+		pubKey:    utils.PublicKeyFromSecp256k1(uncompressed[1:]),
+	}, nil
+}
+
+// SignBytes implements signer.Signer. Like signer.Secp256k1Signer msg must be
+// a 32 byte hash and the signature has the format r || s || v used by NEAR.
+// Key Vault does not return the recovery ID v, it is determined by recovering
+// the public key.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	if len(msg) != 32 {
+		return nil, fmt.Errorf("azurekv: secp256k1 message must be a 32 byte hash, got %d bytes", len(msg))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	rs, err := s.client.Sign(ctx, s.keyID, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("azurekv: Key Vault returned signature of invalid length %d", len(rs))
+	}
+	// normalize to low s, which yields the same recovery ID as a signature
+	// created locally by btcec
+	n := btcec.S256().N
+	sv := new(big.Int).SetBytes(rs[32:])
+	if sv.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		sv.Sub(n, sv)
+		sv.FillBytes(rs[32:])
+	}
+	compact := make([]byte, 65)
+	copy(compact[1:], rs)
+	for v := byte(0); v < 2; v++ {
+		compact[0] = 27 + v
+		pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, msg)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(pub.SerializeUncompressed()[1:], s.pubKey.Bytes()) {
+			sig := make([]byte, 65)
+			copy(sig, rs)
+			sig[64] = v
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("azurekv: signature of %s does not match its public key", s.keyID)
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
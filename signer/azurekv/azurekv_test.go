@@ -0,0 +1,162 @@
+package azurekv
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/crypto"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+func newVaultServer(t *testing.T, priv *btcec.PrivateKey) *httptest.Server {
+	b64 := base64.RawURLEncoding.EncodeToString
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("api-version") != apiVersion {
+			t.Errorf("request has api-version %s", r.URL.Query().Get("api-version"))
+		}
+		var out interface{}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/keys/near/v1":
+			pub := priv.PubKey().SerializeUncompressed()
+			out = map[string]interface{}{"key": map[string]string{
+				"kid": "https://vault/keys/near/v1", "kty": "EC-HSM", "crv": "P-256K",
+				"x": b64(pub[1:33]), "y": b64(pub[33:]),
+			}}
+		case r.Method == http.MethodPost && r.URL.Path == "/keys/near/v1/sign":
+			var in struct {
+				Alg   string `json:"alg"`
+				Value string `json:"value"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+			if in.Alg != "ES256K" {
+				t.Errorf("sign request has algorithm %s", in.Alg)
+			}
+			digest, err := base64.RawURLEncoding.DecodeString(in.Value)
+			if err != nil {
+				t.Error(err)
+			}
+			sig, err := priv.Sign(digest)
+			if err != nil {
+				t.Error(err)
+			}
+			// return high s to exercise normalization
+			s := sig.S
+			if s.Cmp(new(big.Int).Rsh(btcec.S256().N, 1)) <= 0 {
+				s.Sub(btcec.S256().N, s)
+			}
+			rs := make([]byte, 64)
+			sig.R.FillBytes(rs[:32])
+			s.FillBytes(rs[32:])
+			out = map[string]string{"kid": "https://vault/keys/near/v1", "value": b64(rs)}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"KeyNotFound","message":"no such key"}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestSigner(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newVaultServer(t, priv)
+	defer srv.Close()
+	client := NewHTTPClient(srv.URL+"/", StaticToken("token"))
+	var s signer.Signer
+	s, err = NewSigner(context.Background(), client, "near/v1", "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := signer.NewSecp256k1Signer("test-account.testnet", priv).PublicKey()
+	if !s.PublicKey().Equal(want) {
+		t.Errorf("PublicKey() returned %s (want %s)", s.PublicKey(), want)
+	}
+	for i := 0; i < 8; i++ {
+		msg := sha256.Sum256([]byte{byte(i)})
+		sig, err := s.SignBytes(msg[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !crypto.Verify(s.PublicKey(), msg[:], sig) {
+			t.Fatal("signature does not verify")
+		}
+	}
+	if _, err := s.SignBytes([]byte("message")); err == nil {
+		t.Error("SignBytes() accepted message which is not a hash")
+	}
+	_, err = NewSigner(context.Background(), client, "other", "test-account.testnet")
+	if err == nil || !strings.Contains(err.Error(), "KeyNotFound") {
+		t.Errorf("NewSigner() returned %v for missing key", err)
+	}
+}
+
+func TestClientSecretTokenSource(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" {
+			t.Errorf("token request to %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != "https://vault.azure.net/.default" {
+			t.Errorf("token request has form %v", r.Form)
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600}`))
+	}))
+	defer srv.Close()
+	defer func(host string) { authorityHost = host }(authorityHost)
+	authorityHost = srv.URL
+	ts := ClientSecretTokenSource("tenant", "client", "secret")
+	for i := 0; i < 2; i++ {
+		token, err := ts(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "token" {
+			t.Errorf("token source returned %s", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("token source made %d requests (want 1)", requests)
+	}
+}
+
+func TestManagedIdentityTokenSource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-IDENTITY-HEADER") != "header" || r.URL.Query().Get("client_id") != "client" ||
+			r.URL.Query().Get("resource") != "https://vault.azure.net" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_on":"4102444800"}`))
+	}))
+	defer srv.Close()
+	t.Setenv("IDENTITY_ENDPOINT", srv.URL)
+	t.Setenv("IDENTITY_HEADER", "header")
+	token, err := ManagedIdentityTokenSource("client")(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "token" {
+		t.Errorf("token source returned %s", token)
+	}
+}
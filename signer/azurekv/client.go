@@ -0,0 +1,286 @@
+package azurekv
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiVersion is the Key Vault REST API version used by HTTPClient.
+const apiVersion = "7.4"
+
+// vaultResource is the resource (or scope without "/.default") of access
+// tokens for Key Vault.
+const vaultResource = "https://vault.azure.net"
+
+// imdsTokenURL is the Azure Instance Metadata Service endpoint for managed
+// identity access tokens.
+const imdsTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// authorityHost is the Microsoft identity platform endpoint used by
+// ClientSecretTokenSource.
+var authorityHost = "https://login.microsoftonline.com"
+
+// JSONWebKey is the public part of a Key Vault key.
+type JSONWebKey struct {
+	KID string `json:"kid"`
+	KTY string `json:"kty"`
+	CRV string `json:"crv"`
+	X   []byte `json:"-"`
+	Y   []byte `json:"-"`
+}
+
+// Client is the subset of the Key Vault keys API used by Signer. It is
+// implemented by HTTPClient, but can also be implemented by an adapter around
+// the official Azure SDK.
+type Client interface {
+	// Sign signs the digest with the key keyID ("<name>" or
+	// "<name>/<version>") using the ES256K algorithm and returns the
+	// signature r || s.
+	Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+	// GetKey returns the public key of the key keyID.
+	GetKey(ctx context.Context, keyID string) (*JSONWebKey, error)
+}
+
+// TokenSource returns an Azure AD access token for Key Vault.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource which always returns token.
+func StaticToken(token string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+type accessToken struct {
+	token   string
+	expires time.Time
+}
+
+// cachedTokenSource returns a TokenSource which calls fetch only if the last
+// token is about to expire.
+func cachedTokenSource(fetch func(ctx context.Context) (accessToken, error)) TokenSource {
+	var (
+		mtx sync.Mutex
+		tok accessToken
+	)
+	return func(ctx context.Context) (string, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		if tok.token != "" && time.Until(tok.expires) > time.Minute {
+			return tok.token, nil
+		}
+		t, err := fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+		tok = t
+		return tok.token, nil
+	}
+}
+
+// tokenResponse is the access token response of the identity endpoints. The
+// managed identity endpoints encode numbers as strings.
+type tokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   json.Number `json:"expires_in"`
+	ExpiresOn   json.Number `json:"expires_on"`
+}
+
+func fetchToken(c *http.Client, req *http.Request) (accessToken, error) {
+	resp, err := c.Do(req)
+	if err != nil {
+		return accessToken{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return accessToken{}, fmt.Errorf("azurekv: token endpoint returned status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	var tr tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return accessToken{}, err
+	}
+	if tr.AccessToken == "" {
+		return accessToken{}, fmt.Errorf("azurekv: token endpoint returned no access token")
+	}
+	tok := accessToken{token: tr.AccessToken}
+	if on, err := tr.ExpiresOn.Int64(); err == nil && on > 0 {
+		tok.expires = time.Unix(on, 0)
+	} else if in, err := tr.ExpiresIn.Int64(); err == nil {
+		tok.expires = time.Now().Add(time.Duration(in) * time.Second)
+	}
+	return tok, nil
+}
+
+// ManagedIdentityTokenSource returns a TokenSource which obtains access tokens
+// of a managed identity. On App Service and Functions the IDENTITY_ENDPOINT
+// and IDENTITY_HEADER environment variables are used, otherwise the Instance
+// Metadata Service (VMs, AKS, Container Instances). If clientID is not empty
+// it selects a user-assigned identity, otherwise the system-assigned identity
+// is used. Tokens are cached until shortly before they expire.
+func ManagedIdentityTokenSource(clientID string) TokenSource {
+	c := &http.Client{Timeout: 10 * time.Second}
+	return cachedTokenSource(func(ctx context.Context) (accessToken, error) {
+		q := url.Values{"resource": {vaultResource}}
+		if clientID != "" {
+			q.Set("client_id", clientID)
+		}
+		endpoint, header := os.Getenv("IDENTITY_ENDPOINT"), os.Getenv("IDENTITY_HEADER")
+		if endpoint != "" && header != "" {
+			q.Set("api-version", "2019-08-01")
+		} else {
+			endpoint = imdsTokenURL
+			q.Set("api-version", "2018-02-01")
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+q.Encode(), nil)
+		if err != nil {
+			return accessToken{}, err
+		}
+		if header != "" {
+			req.Header.Set("X-IDENTITY-HEADER", header)
+		} else {
+			req.Header.Set("Metadata", "true")
+		}
+		return fetchToken(c, req)
+	})
+}
+
+// ClientSecretTokenSource returns a TokenSource which obtains access tokens
+// for the app registration clientID in tenantID with the client credentials
+// flow. Tokens are cached until shortly before they expire.
+func ClientSecretTokenSource(tenantID, clientID, clientSecret string) TokenSource {
+	c := &http.Client{Timeout: 10 * time.Second}
+	return cachedTokenSource(func(ctx context.Context) (accessToken, error) {
+		form := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"scope":         {vaultResource + "/.default"},
+		}
+		u := authorityHost + "/" + url.PathEscape(tenantID) + "/oauth2/v2.0/token"
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, strings.NewReader(form.Encode()))
+		if err != nil {
+			return accessToken{}, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return fetchToken(c, req)
+	})
+}
+
+// HTTPClient implements Client by calling the Key Vault REST API.
+type HTTPClient struct {
+	vaultURL string
+	token    TokenSource
+	c        *http.Client
+}
+
+// NewHTTPClient returns a new Key Vault client for vaultURL (like
+// "https://myvault.vault.azure.net") authenticating with token.
+func NewHTTPClient(vaultURL string, token TokenSource) *HTTPClient {
+	return &HTTPClient{
+		vaultURL: strings.TrimSuffix(vaultURL, "/"),
+		token:    token,
+		c:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *HTTPClient) do(ctx context.Context, method, path string, input, output interface{}) error {
+	var body io.Reader
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	u := c.vaultURL + "/keys/" + path + "?api-version=" + apiVersion
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return fmt.Errorf("azurekv: %s %s failed with status %d: %s: %s",
+			method, path, resp.StatusCode, e.Error.Code, e.Error.Message)
+	}
+	return json.Unmarshal(data, output)
+}
+
+// keyPath escapes the key name and optional version of keyID.
+func keyPath(keyID string) string {
+	parts := strings.SplitN(keyID, "/", 2)
+	for i := range parts {
+		parts[i] = url.PathEscape(parts[i])
+	}
+	return strings.Join(parts, "/")
+}
+
+// Sign implements Client.
+func (c *HTTPClient) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	in := map[string]string{
+		"alg":   "ES256K",
+		"value": base64.RawURLEncoding.EncodeToString(digest),
+	}
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := c.do(ctx, http.MethodPost, keyPath(keyID)+"/sign", in, &out); err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(out.Value, "="))
+}
+
+// GetKey implements Client.
+func (c *HTTPClient) GetKey(ctx context.Context, keyID string) (*JSONWebKey, error) {
+	var out struct {
+		Key struct {
+			JSONWebKey
+			X string `json:"x"`
+			Y string `json:"y"`
+		} `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodGet, keyPath(keyID), nil, &out); err != nil {
+		return nil, err
+	}
+	key := out.Key.JSONWebKey
+	var err error
+	if key.X, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(out.Key.X, "=")); err != nil {
+		return nil, fmt.Errorf("azurekv: invalid x coordinate of %s: %v", keyID, err)
+	}
+	if key.Y, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(out.Key.Y, "=")); err != nil {
+		return nil, fmt.Errorf("azurekv: invalid y coordinate of %s: %v", keyID, err)
+	}
+	return &key, nil
+}
@@ -0,0 +1,157 @@
+package gcpkms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultEndpoint is the public Cloud KMS API endpoint.
+const DefaultEndpoint = "https://cloudkms.googleapis.com"
+
+// metadataTokenURL is the GCE metadata server URL for the access token of the
+// default service account.
+const metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// Client is the subset of the Cloud KMS API used by Signer. It is implemented
+// by HTTPClient, but can also be implemented by an adapter around the official
+// Google Cloud client library.
+type Client interface {
+	// AsymmetricSign signs data with the crypto key version name and returns
+	// the signature.
+	AsymmetricSign(ctx context.Context, name string, data []byte) ([]byte, error)
+	// GetPublicKey returns the PEM encoded public key of the crypto key
+	// version name.
+	GetPublicKey(ctx context.Context, name string) (string, error)
+}
+
+// TokenSource returns an OAuth2 access token for the Cloud KMS API.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource which always returns token.
+func StaticToken(token string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// MetadataTokenSource returns a TokenSource which obtains access tokens of the
+// default service account from the GCE metadata server. It works on Compute
+// Engine, GKE, Cloud Run and Cloud Functions.
+func MetadataTokenSource() TokenSource {
+	c := &http.Client{Timeout: 10 * time.Second}
+	return func(ctx context.Context) (string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataTokenURL, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("gcpkms: metadata server returned status %d", resp.StatusCode)
+		}
+		var token struct {
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}
+}
+
+// HTTPClient implements Client by calling the Cloud KMS REST API.
+type HTTPClient struct {
+	endpoint string
+	token    TokenSource
+	c        *http.Client
+}
+
+// NewHTTPClient returns a new Cloud KMS client authenticating with token. If
+// endpoint is empty DefaultEndpoint is used.
+func NewHTTPClient(endpoint string, token TokenSource) *HTTPClient {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	return &HTTPClient{
+		endpoint: endpoint,
+		token:    token,
+		c:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *HTTPClient) do(ctx context.Context, method, path string, input, output interface{}) error {
+	var body io.Reader
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+"/v1/"+path, body)
+	if err != nil {
+		return err
+	}
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return fmt.Errorf("gcpkms: %s %s failed with status %d: %s: %s",
+			method, path, resp.StatusCode, e.Error.Status, e.Error.Message)
+	}
+	return json.Unmarshal(data, output)
+}
+
+// AsymmetricSign implements Client.
+func (c *HTTPClient) AsymmetricSign(ctx context.Context, name string, data []byte) ([]byte, error) {
+	var out struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := c.do(ctx, http.MethodPost, name+":asymmetricSign", map[string]interface{}{"data": data}, &out); err != nil {
+		return nil, err
+	}
+	return out.Signature, nil
+}
+
+// GetPublicKey implements Client.
+func (c *HTTPClient) GetPublicKey(ctx context.Context, name string) (string, error) {
+	var out struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := c.do(ctx, http.MethodGet, name+"/publicKey", nil, &out); err != nil {
+		return "", err
+	}
+	if out.Algorithm != "" && out.Algorithm != "EC_SIGN_ED25519" {
+		return "", fmt.Errorf("gcpkms: key %s has algorithm %s, not EC_SIGN_ED25519", name, out.Algorithm)
+	}
+	return out.PEM, nil
+}
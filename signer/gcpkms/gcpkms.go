@@ -0,0 +1,86 @@
+// Package gcpkms implements a signer which keeps NEAR keys in Google Cloud
+// KMS.
+package gcpkms
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultTimeout is the timeout of a single Cloud KMS request made by a
+// signer.
+const DefaultTimeout = 10 * time.Second
+
+// KeyVersionName returns the resource name of a crypto key version.
+func KeyVersionName(project, location, keyRing, key, version string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s/cryptoKeyVersions/%s",
+		project, location, keyRing, key, version)
+}
+
+// Signer is a signer.Signer which delegates Ed25519 signing to an
+// EC_SIGN_ED25519 Cloud KMS crypto key version. The private key never leaves
+// Cloud KMS.
+type Signer struct {
+	client    Client
+	name      string
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewSigner returns a new signer for accountID using the crypto key version
+// with resource name (see KeyVersionName). The public key is retrieved from
+// Cloud KMS.
+func NewSigner(ctx context.Context, client Client, name, accountID string) (*Signer, error) {
+	pemKey, err := client.GetPublicKey(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, fmt.Errorf("gcpkms: cannot decode PEM public key of %s", name)
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("gcpkms: cannot parse public key of %s: %v", name, err)
+	}
+	edPub, ok := pub.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("gcpkms: key %s is not an Ed25519 key", name)
+	}
+	return &Signer{
+		client:    client,
+		name:      name,
+		accountID: accountID,
+		pubKey:    utils.PublicKeyFromEd25519(edPub),
+	}, nil
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	sig, err := s.client.AsymmetricSign(ctx, s.name, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("gcpkms: Cloud KMS returned signature of invalid length %d", len(sig))
+	}
+	return sig, nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
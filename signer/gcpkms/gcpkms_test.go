@@ -0,0 +1,66 @@
+package gcpkms
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := KeyVersionName("project", "global", "ring", "near", "1")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/"+name+"/publicKey":
+			der, err := x509.MarshalPKIXPublicKey(pub)
+			if err != nil {
+				t.Error(err)
+			}
+			pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"pem":       string(pemKey),
+				"algorithm": "EC_SIGN_ED25519",
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":asymmetricSign"):
+			var in struct {
+				Data []byte `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Error(err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"signature": ed25519.Sign(priv, in.Data)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	s, err := NewSigner(context.Background(), NewHTTPClient(srv.URL, StaticToken("token")), name, "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(s.PublicKey().Bytes()) != string(pub) {
+		t.Error("public key does not match")
+	}
+	msg := []byte("message")
+	sig, err := s.SignBytes(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		t.Error("signature does not verify")
+	}
+}
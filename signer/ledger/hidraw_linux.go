@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ledgerVendorID is the USB vendor ID of Ledger devices.
+const ledgerVendorID = "00002C97"
+
+// FindHIDRaw returns the paths of all hidraw devices belonging to Ledger
+// devices.
+func FindHIDRaw() ([]string, error) {
+	entries, err := filepath.Glob("/sys/class/hidraw/hidraw*")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		uevent, err := os.ReadFile(filepath.Join(entry, "device", "uevent"))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(uevent), "\n") {
+			// HID_ID=<bus>:<vendor>:<product>
+			if strings.HasPrefix(line, "HID_ID=") {
+				parts := strings.Split(strings.TrimPrefix(line, "HID_ID="), ":")
+				if len(parts) == 3 && strings.EqualFold(parts[1], ledgerVendorID) {
+					paths = append(paths, filepath.Join("/dev", filepath.Base(entry)))
+				}
+			}
+		}
+	}
+	return paths, nil
+}
+
+// OpenHIDRaw opens the hidraw device at path and returns a transport for it.
+// The returned file must be closed by the caller.
+func OpenHIDRaw(path string) (*HIDTransport, *os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewHIDTransport(f, true), f, nil
+}
@@ -0,0 +1,145 @@
+// Package ledger implements a signer for the NEAR app on Ledger hardware
+// wallets.
+package ledger
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultPath is the BIP32 path used by NEAR wallets for Ledger keys.
+const DefaultPath = "44'/397'/0'/0'/1'"
+
+// APDU constants of the NEAR Ledger app.
+const (
+	cla             = 0x80
+	insSign         = 0x02
+	insGetPublicKey = 0x04
+	insGetVersion   = 0x06
+	p1More          = 0x00
+	p1Last          = 0x80
+	networkMainnet  = 'W'
+	chunkSize       = 128
+)
+
+// ErrRawSigning is returned by Signer.SignBytes, the NEAR app only signs
+// complete transactions (see Signer.SignTransaction).
+var ErrRawSigning = errors.New("ledger: the NEAR app does not sign raw messages")
+
+// Signer is a signer.TransactionSigner which signs transactions with the NEAR
+// app on a Ledger device. Every transaction has to be confirmed on the device.
+type Signer struct {
+	t         Transport
+	path      []byte
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewSigner returns a new signer for accountID using the key at the BIP32
+// path (DefaultPath if empty) of the device connected via t. The public key
+// is retrieved from the device.
+func NewSigner(t Transport, path, accountID string) (*Signer, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	p, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.Exchange(apdu(insGetPublicKey, p1Last, p))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("ledger: device returned public key of invalid length %d", len(resp))
+	}
+	return &Signer{
+		t:         t,
+		path:      p,
+		accountID: accountID,
+		pubKey:    utils.PublicKeyFromEd25519(resp),
+	}, nil
+}
+
+// Version returns the version of the NEAR app running on the device.
+func (s *Signer) Version() (string, error) {
+	resp, err := s.t.Exchange(apdu(insGetVersion, 0, nil))
+	if err != nil {
+		return "", err
+	}
+	if len(resp) < 3 {
+		return "", errors.New("ledger: invalid version response")
+	}
+	return fmt.Sprintf("%d.%d.%d", resp[0], resp[1], resp[2]), nil
+}
+
+// SignBytes implements signer.Signer. It always fails with ErrRawSigning.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	return nil, ErrRawSigning
+}
+
+// SignTransaction implements signer.TransactionSigner. The BIP32 path and the
+// transaction are sent to the device in chunks.
+func (s *Signer) SignTransaction(tx []byte) ([]byte, error) {
+	data := append(append([]byte{}, s.path...), tx...)
+	for i := 0; i < len(data); i += chunkSize {
+		end := i + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		p1 := byte(p1More)
+		if end == len(data) {
+			p1 = p1Last
+		}
+		resp, err := s.t.Exchange(apdu(insSign, p1, data[i:end]))
+		if err != nil {
+			return nil, err
+		}
+		if p1 == p1Last {
+			if len(resp) != ed25519.SignatureSize {
+				return nil, fmt.Errorf("ledger: device returned signature of invalid length %d", len(resp))
+			}
+			return resp, nil
+		}
+	}
+	return nil, errors.New("ledger: empty transaction")
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
+
+// apdu returns a command APDU for the NEAR app.
+func apdu(ins, p1 byte, data []byte) []byte {
+	return append([]byte{cla, ins, p1, networkMainnet, byte(len(data))}, data...)
+}
+
+// encodePath encodes a BIP32 path like "44'/397'/0'/0'/1'" as a list of big
+// endian uint32 values.
+func encodePath(path string) ([]byte, error) {
+	var buf []byte
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "m/"), "/") {
+		hardened := strings.HasSuffix(segment, "'")
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("ledger: invalid BIP32 path '%s'", path)
+		}
+		if hardened {
+			index |= 0x80000000
+		}
+		buf = binary.BigEndian.AppendUint32(buf, uint32(index))
+	}
+	return buf, nil
+}
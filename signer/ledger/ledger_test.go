@@ -0,0 +1,132 @@
+package ledger
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/signer"
+)
+
+// fakeDevice emulates the NEAR app behind the HID framing protocol.
+type fakeDevice struct {
+	t       *testing.T
+	priv    ed25519.PrivateKey
+	in      []byte // reassembled command
+	out     bytes.Buffer
+	signBuf []byte
+	reject  bool
+}
+
+func (d *fakeDevice) Write(packet []byte) (int, error) {
+	if packet[0] != 0 {
+		d.t.Fatal("missing report ID")
+	}
+	payload := packet[6:]
+	if binary.BigEndian.Uint16(packet[4:]) == 0 {
+		d.in = nil
+	}
+	d.in = append(d.in, payload...)
+	length := int(binary.BigEndian.Uint16(d.in))
+	if len(d.in)-2 < length {
+		return len(packet), nil
+	}
+	resp := d.handle(d.in[2 : 2+length])
+	if err := NewHIDTransport(&d.out, false).write(resp); err != nil {
+		d.t.Fatal(err)
+	}
+	return len(packet), nil
+}
+
+func (d *fakeDevice) Read(p []byte) (int, error) {
+	return d.out.Read(p)
+}
+
+func (d *fakeDevice) handle(apdu []byte) []byte {
+	ok := []byte{0x90, 0x00}
+	if apdu[0] != cla || apdu[3] != networkMainnet || int(apdu[4]) != len(apdu)-5 {
+		return []byte{0x6e, 0x00}
+	}
+	data := apdu[5:]
+	switch apdu[1] {
+	case insGetPublicKey:
+		return append(append([]byte{}, d.priv.Public().(ed25519.PublicKey)...), ok...)
+	case insGetVersion:
+		return append([]byte{1, 2, 3}, ok...)
+	case insSign:
+		d.signBuf = append(d.signBuf, data...)
+		if apdu[2] != p1Last {
+			return ok
+		}
+		if d.reject {
+			return []byte{0x69, 0x85}
+		}
+		// skip the 5 element BIP32 path
+		hash := sha256.Sum256(d.signBuf[20:])
+		d.signBuf = nil
+		return append(ed25519.Sign(d.priv, hash[:]), ok...)
+	}
+	return []byte{0x6d, 0x00}
+}
+
+func TestSigner(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := &fakeDevice{t: t, priv: priv}
+	var s signer.TransactionSigner
+	ls, err := NewSigner(NewHIDTransport(dev, true), "", "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = ls
+	if string(s.PublicKey().Bytes()) != string(pub) {
+		t.Error("public key does not match")
+	}
+	version, err := ls.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "1.2.3" {
+		t.Errorf("Version() returned %s", version)
+	}
+	// large enough to require multiple chunks and HID packets
+	tx := bytes.Repeat([]byte{0x42}, 300)
+	sig, err := s.SignTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(tx)
+	if !ed25519.Verify(pub, hash[:], sig) {
+		t.Error("signature does not verify")
+	}
+	if _, err := s.SignBytes(hash[:]); !errors.Is(err, ErrRawSigning) {
+		t.Errorf("SignBytes() returned %v (want ErrRawSigning)", err)
+	}
+	dev.reject = true
+	var statusErr *StatusError
+	if _, err := s.SignTransaction(tx); !errors.As(err, &statusErr) || statusErr.Status != 0x6985 {
+		t.Errorf("rejected SignTransaction() returned %v", err)
+	}
+}
+
+func TestEncodePath(t *testing.T) {
+	p, err := encodePath("44'/397'/0'/0'/1'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{
+		0x80, 0, 0, 44, 0x80, 0, 0x01, 0x8d, 0x80, 0, 0, 0, 0x80, 0, 0, 0, 0x80, 0, 0, 1,
+	}
+	if !bytes.Equal(p, want) {
+		t.Errorf("encodePath() returned %x (want %x)", p, want)
+	}
+	if _, err := encodePath("44'/abc"); err == nil {
+		t.Error("encodePath() accepted invalid path")
+	}
+}
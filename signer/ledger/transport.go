@@ -0,0 +1,126 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	hidPacketSize = 64
+	hidChannel    = 0x0101
+	hidTagAPDU    = 0x05
+)
+
+// Transport exchanges APDUs with a Ledger device.
+type Transport interface {
+	// Exchange sends the command APDU and returns the response data without
+	// the status word. Status words other than 0x9000 are returned as
+	// *StatusError.
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// StatusError is returned if the Ledger device answers with a status word
+// indicating an error.
+type StatusError struct {
+	Status uint16
+}
+
+func (e *StatusError) Error() string {
+	switch e.Status {
+	case 0x6985:
+		return "ledger: request rejected by user"
+	case 0x6e00, 0x6d00:
+		return "ledger: NEAR app not open"
+	case 0x5515:
+		return "ledger: device is locked"
+	default:
+		return fmt.Sprintf("ledger: device returned status 0x%04x", e.Status)
+	}
+}
+
+// HIDTransport implements Transport with the Ledger HID framing protocol on
+// top of a raw HID device. On Linux the device can be opened with OpenHIDRaw,
+// on other platforms any HID library providing an io.ReadWriter for the
+// device can be used.
+type HIDTransport struct {
+	dev io.ReadWriter
+	// reportID is prepended to every written packet, hidraw devices require
+	// a leading report ID byte.
+	reportID bool
+}
+
+// NewHIDTransport returns a new transport for the HID device dev. If reportID
+// is true a zero report ID byte is prepended to every written packet.
+func NewHIDTransport(dev io.ReadWriter, reportID bool) *HIDTransport {
+	return &HIDTransport{dev: dev, reportID: reportID}
+}
+
+// Exchange implements Transport.
+func (t *HIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	if err := t.write(apdu); err != nil {
+		return nil, err
+	}
+	resp, err := t.read()
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) < 2 {
+		return nil, errors.New("ledger: response too short")
+	}
+	status := binary.BigEndian.Uint16(resp[len(resp)-2:])
+	if status != 0x9000 {
+		return nil, &StatusError{Status: status}
+	}
+	return resp[:len(resp)-2], nil
+}
+
+// write sends apdu framed into HID packets.
+func (t *HIDTransport) write(apdu []byte) error {
+	data := make([]byte, 2+len(apdu))
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	copy(data[2:], apdu)
+	for seq := 0; len(data) > 0; seq++ {
+		packet := make([]byte, hidPacketSize)
+		binary.BigEndian.PutUint16(packet, hidChannel)
+		packet[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(packet[3:], uint16(seq))
+		n := copy(packet[5:], data)
+		data = data[n:]
+		if t.reportID {
+			packet = append([]byte{0}, packet...)
+		}
+		if _, err := t.dev.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read receives a framed response.
+func (t *HIDTransport) read() ([]byte, error) {
+	var (
+		resp   []byte
+		length = -1
+	)
+	for seq := 0; length < 0 || len(resp) < length; seq++ {
+		packet := make([]byte, hidPacketSize)
+		if _, err := io.ReadFull(t.dev, packet); err != nil {
+			return nil, err
+		}
+		if binary.BigEndian.Uint16(packet) != hidChannel || packet[2] != hidTagAPDU {
+			return nil, errors.New("ledger: invalid HID packet header")
+		}
+		if int(binary.BigEndian.Uint16(packet[3:])) != seq {
+			return nil, errors.New("ledger: unexpected HID packet sequence")
+		}
+		payload := packet[5:]
+		if seq == 0 {
+			length = int(binary.BigEndian.Uint16(payload))
+			payload = payload[2:]
+		}
+		resp = append(resp, payload...)
+	}
+	return resp[:length], nil
+}
@@ -0,0 +1,66 @@
+// Package pkcs11 implements a signer which signs with Ed25519 keys kept in a
+// hardware security module accessed through a PKCS#11 module, such as
+// SoftHSM, YubiHSM or Luna.
+//
+// The implementation requires cgo, without cgo Open always fails.
+package pkcs11
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// PKCS#11 v3.0 constants for Ed25519 which are missing in older headers.
+const (
+	ckkECEdwards = 0x00000040
+	ckmEdDSA     = 0x00001057
+)
+
+// ErrNoCgo is returned by Open if the package was built without cgo.
+var ErrNoCgo = errors.New("pkcs11: PKCS#11 support requires cgo")
+
+// Config selects the PKCS#11 module, token and key used by a Signer.
+type Config struct {
+	// Module is the path of the PKCS#11 shared library, e.g.
+	// /usr/lib/softhsm/libsofthsm2.so.
+	Module string
+	// TokenLabel selects the slot by the label of its token. If empty, Slot
+	// is used.
+	TokenLabel string
+	// Slot is the ID of the slot to use if TokenLabel is empty.
+	Slot uint
+	// PIN is the user PIN of the token.
+	PIN string
+	// KeyLabel and KeyID select the Ed25519 key pair by its CKA_LABEL and/or
+	// CKA_ID attributes. At least one of them must be set.
+	KeyLabel string
+	KeyID    []byte
+}
+
+func (cfg *Config) validate() error {
+	if cfg.Module == "" {
+		return errors.New("pkcs11: module not configured")
+	}
+	if cfg.KeyLabel == "" && len(cfg.KeyID) == 0 {
+		return errors.New("pkcs11: neither key label nor key ID configured")
+	}
+	return nil
+}
+
+// decodeECPoint decodes the CKA_EC_POINT attribute of an Ed25519 public key.
+// Depending on the module it is either a DER encoded OCTET STRING or the raw
+// 32 byte key.
+func decodeECPoint(point []byte) ([]byte, error) {
+	if len(point) == 32 {
+		return point, nil
+	}
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("pkcs11: cannot decode EC point: %v", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("pkcs11: EC point has invalid length %d", len(raw))
+	}
+	return raw, nil
+}
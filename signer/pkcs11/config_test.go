@@ -0,0 +1,36 @@
+package pkcs11
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"testing"
+)
+
+func TestDecodeECPoint(t *testing.T) {
+	raw := bytes.Repeat([]byte{0x42}, 32)
+	der, err := asn1.Marshal(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, point := range [][]byte{raw, der} {
+		pub, err := decodeECPoint(point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pub, raw) {
+			t.Errorf("decodeECPoint(%x) returned %x", point, pub)
+		}
+	}
+	if _, err := decodeECPoint([]byte{0x04, 0x01, 0x00}); err == nil {
+		t.Error("decodeECPoint() accepted short point")
+	}
+}
+
+func TestOpenValidatesConfig(t *testing.T) {
+	if _, err := Open(Config{}, "test-account.testnet"); err == nil {
+		t.Error("Open() accepted empty config")
+	}
+	if _, err := Open(Config{Module: "/nonexistent/libpkcs11.so", KeyLabel: "near"}, "test-account.testnet"); err == nil {
+		t.Error("Open() succeeded with nonexistent module")
+	}
+}
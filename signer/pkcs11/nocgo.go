@@ -0,0 +1,39 @@
+//go:build !cgo
+
+package pkcs11
+
+import (
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// Signer is a signer.Signer which signs with an Ed25519 private key stored in
+// a PKCS#11 token. Without cgo it cannot be opened.
+type Signer struct{}
+
+// Open always fails with ErrNoCgo, because the package was built without cgo.
+func Open(cfg Config, accountID string) (*Signer, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNoCgo
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	return nil, ErrNoCgo
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return utils.PublicKey{}
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return ""
+}
+
+// Close implements io.Closer.
+func (s *Signer) Close() error {
+	return nil
+}
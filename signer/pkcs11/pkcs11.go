@@ -0,0 +1,179 @@
+//go:build cgo
+
+package pkcs11
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/miekg/pkcs11"
+)
+
+// Signer is a signer.Signer which signs with an Ed25519 private key stored in
+// a PKCS#11 token. It is safe for concurrent use.
+type Signer struct {
+	mtx       sync.Mutex
+	ctx       *pkcs11.Ctx
+	session   pkcs11.SessionHandle
+	privKey   pkcs11.ObjectHandle
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// Open loads the PKCS#11 module configured in cfg, logs into the token and
+// returns a signer for accountID using the configured key. The signer must
+// be closed after use.
+func Open(cfg Config, accountID string) (*Signer, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	ctx := pkcs11.New(cfg.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("pkcs11: cannot load module %s", cfg.Module)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, err
+	}
+	s := &Signer{ctx: ctx, accountID: accountID}
+	if err := s.open(cfg); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Signer) open(cfg Config) error {
+	slot, err := findSlot(s.ctx, cfg)
+	if err != nil {
+		return err
+	}
+	s.session, err = s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return err
+	}
+	if err := s.ctx.Login(s.session, pkcs11.CKU_USER, cfg.PIN); err != nil &&
+		!errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return err
+	}
+	s.privKey, err = s.findKey(cfg, pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		return err
+	}
+	pubObj, err := s.findKey(cfg, pkcs11.CKO_PUBLIC_KEY)
+	if err != nil {
+		return err
+	}
+	attrs, err := s.ctx.GetAttributeValue(s.session, pubObj, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return err
+	}
+	pub, err := decodeECPoint(attrs[0].Value)
+	if err != nil {
+		return err
+	}
+	s.pubKey = utils.PublicKeyFromEd25519(pub)
+	return nil
+}
+
+func findSlot(ctx *pkcs11.Ctx, cfg Config) (uint, error) {
+	if cfg.TokenLabel == "" {
+		return cfg.Slot, nil
+	}
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, err
+		}
+		if strings.TrimSpace(info.Label) == cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("pkcs11: no token with label '%s'", cfg.TokenLabel)
+}
+
+func (s *Signer) findKey(cfg Config, class uint) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, ckkECEdwards),
+	}
+	if cfg.KeyLabel != "" {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel))
+	}
+	if len(cfg.KeyID) > 0 {
+		template = append(template, pkcs11.NewAttribute(pkcs11.CKA_ID, cfg.KeyID))
+	}
+	if err := s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return 0, err
+	}
+	objs, _, err := s.ctx.FindObjects(s.session, 2)
+	if ferr := s.ctx.FindObjectsFinal(s.session); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return 0, err
+	}
+	switch len(objs) {
+	case 0:
+		return 0, fmt.Errorf("pkcs11: no Ed25519 key with label '%s' and ID %x", cfg.KeyLabel, cfg.KeyID)
+	case 1:
+		return objs[0], nil
+	default:
+		return 0, fmt.Errorf("pkcs11: multiple Ed25519 keys with label '%s' and ID %x", cfg.KeyLabel, cfg.KeyID)
+	}
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	mech := []*pkcs11.Mechanism{pkcs11.NewMechanism(ckmEdDSA, nil)}
+	if err := s.ctx.SignInit(s.session, mech, s.privKey); err != nil {
+		return nil, err
+	}
+	sig, err := s.ctx.Sign(s.session, msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("pkcs11: token returned signature of invalid length %d", len(sig))
+	}
+	return sig, nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
+
+// Close logs out, closes the session and unloads the module.
+func (s *Signer) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.ctx == nil {
+		return nil
+	}
+	if s.session != 0 {
+		_ = s.ctx.Logout(s.session)
+		_ = s.ctx.CloseSession(s.session)
+	}
+	err := s.ctx.Finalize()
+	s.ctx.Destroy()
+	s.ctx = nil
+	return err
+}
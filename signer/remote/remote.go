@@ -0,0 +1,161 @@
+// Package remote implements a signer which forwards signing requests to an
+// external signing service over HTTP, and a handler implementing such a
+// service, so key custody can be centralized.
+//
+// The protocol consists of two JSON endpoints:
+//
+//	GET  <endpoint>/v1/public_key?account_id=<accountID>
+//	     -> {"account_id": "...", "public_key": "ed25519:..."}
+//	POST <endpoint>/v1/sign {"account_id": "...", "public_key": "...", "message": "<base64>"}
+//	     -> {"signature": "<base64>"}
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultTimeout is the timeout of a single request to the signing service.
+const DefaultTimeout = 10 * time.Second
+
+// Config configures the connection to the signing service.
+type Config struct {
+	// Endpoint is the base URL of the signing service.
+	Endpoint string
+	// AuthHeader and AuthValue are sent with every request if AuthHeader is
+	// set, e.g. "Authorization" and "Bearer <token>".
+	AuthHeader string
+	AuthValue  string
+	// TLSConfig configures TLS, e.g. client certificates or custom root CAs.
+	// It is ignored if HTTPClient is set.
+	TLSConfig *tls.Config
+	// HTTPClient is used for all requests if set.
+	HTTPClient *http.Client
+}
+
+type publicKeyResponse struct {
+	AccountID string `json:"account_id"`
+	PublicKey string `json:"public_key"`
+}
+
+type signRequest struct {
+	AccountID string `json:"account_id"`
+	PublicKey string `json:"public_key"`
+	Message   []byte `json:"message"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Signer is a signer.Signer which forwards all signing requests to a remote
+// signing service.
+type Signer struct {
+	cfg       Config
+	c         *http.Client
+	accountID string
+	pubKey    utils.PublicKey
+}
+
+// NewSigner returns a new signer for accountID using the signing service
+// configured by cfg. The public key is retrieved from the service.
+func NewSigner(ctx context.Context, cfg Config, accountID string) (*Signer, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("remote: endpoint not configured")
+	}
+	c := cfg.HTTPClient
+	if c == nil {
+		c = &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: cfg.TLSConfig},
+		}
+	}
+	s := &Signer{cfg: cfg, c: c, accountID: accountID}
+	var resp publicKeyResponse
+	err := s.do(ctx, http.MethodGet, "/v1/public_key?account_id="+url.QueryEscape(accountID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	s.pubKey, err = utils.ParsePublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Signer) do(ctx context.Context, method, path string, input, output interface{}) error {
+	var body io.Reader
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(s.cfg.Endpoint, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	if input != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.cfg.AuthHeader != "" {
+		req.Header.Set(s.cfg.AuthHeader, s.cfg.AuthValue)
+	}
+	resp, err := s.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		_ = json.Unmarshal(data, &e)
+		return fmt.Errorf("remote: signing service returned status %d: %s", resp.StatusCode, e.Error)
+	}
+	return json.Unmarshal(data, output)
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	var resp signResponse
+	err := s.do(ctx, http.MethodPost, "/v1/sign", &signRequest{
+		AccountID: s.accountID,
+		PublicKey: s.pubKey.String(),
+		Message:   msg,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	kp, err := keystore.GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := kp.Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHandler("Authorization", "Bearer secret")
+	h.Add(local)
+	srv := httptest.NewTLSServer(h)
+	defer srv.Close()
+	ctx := context.Background()
+	cfg := Config{
+		Endpoint:   srv.URL,
+		AuthHeader: "Authorization",
+		AuthValue:  "Bearer secret",
+		HTTPClient: srv.Client(),
+	}
+	s, err := NewSigner(ctx, cfg, "test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.PublicKey() != local.PublicKey() {
+		t.Error("public key does not match")
+	}
+	msg := []byte("message")
+	sig, err := s.SignBytes(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(kp.Ed25519PubKey, msg, sig) {
+		t.Error("signature does not verify")
+	}
+	// unknown account
+	if _, err := NewSigner(ctx, cfg, "unknown.testnet"); err == nil {
+		t.Error("NewSigner() succeeded for unknown account")
+	}
+	// wrong credentials
+	cfg.AuthValue = "Bearer wrong"
+	if _, err := NewSigner(ctx, cfg, "test-account.testnet"); err == nil {
+		t.Error("NewSigner() succeeded with wrong credentials")
+	}
+}
@@ -0,0 +1,109 @@
+package remote
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/YuxSccc/near-api-go/signer"
+)
+
+// Handler is an http.Handler implementing the signing service protocol with
+// a set of local signers.
+type Handler struct {
+	mtx        sync.RWMutex
+	signers    map[string]signer.Signer
+	authHeader string
+	authValue  string
+	mux        *http.ServeMux
+}
+
+// NewHandler returns a new signing service handler. If authHeader is set
+// every request must carry that header with value authValue.
+func NewHandler(authHeader, authValue string) *Handler {
+	h := &Handler{
+		signers:    make(map[string]signer.Signer),
+		authHeader: authHeader,
+		authValue:  authValue,
+		mux:        http.NewServeMux(),
+	}
+	h.mux.HandleFunc("/v1/public_key", h.publicKey)
+	h.mux.HandleFunc("/v1/sign", h.sign)
+	return h
+}
+
+// Add makes the signer s available via the service.
+func (h *Handler) Add(s signer.Signer) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.signers[s.AccountID()] = s
+}
+
+func (h *Handler) signer(accountID string) signer.Signer {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+	return h.signers[accountID]
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.authHeader != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get(h.authHeader)), []byte(h.authValue)) != 1 {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) publicKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	accountID := r.URL.Query().Get("account_id")
+	s := h.signer(accountID)
+	if s == nil {
+		writeError(w, http.StatusNotFound, "unknown account "+accountID)
+		return
+	}
+	writeJSON(w, &publicKeyResponse{AccountID: accountID, PublicKey: s.PublicKey().String()})
+}
+
+func (h *Handler) sign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req signRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s := h.signer(req.AccountID)
+	if s == nil {
+		writeError(w, http.StatusNotFound, "unknown account "+req.AccountID)
+		return
+	}
+	if req.PublicKey != s.PublicKey().String() {
+		writeError(w, http.StatusNotFound, "unknown public key "+req.PublicKey)
+		return
+	}
+	sig, err := s.SignBytes(req.Message)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, &signResponse{Signature: sig})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: msg})
+}
@@ -0,0 +1,53 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Secp256k1Signer is a Signer holding a secp256k1 private key in memory.
+type Secp256k1Signer struct {
+	accountID string
+	privKey   *btcec.PrivateKey
+	pubKey    utils.PublicKey
+}
+
+// NewSecp256k1Signer returns a new signer for accountID using privKey.
+func NewSecp256k1Signer(accountID string, privKey *btcec.PrivateKey) *Secp256k1Signer {
+	return &Secp256k1Signer{
+		accountID: accountID,
+		privKey:   privKey,
+		pubKey:    utils.PublicKeyFromSecp256k1(privKey.PubKey().SerializeUncompressed()[1:]),
+	}
+}
+
+// SignBytes implements Signer. The secp256k1 signature scheme signs a digest,
+// therefore msg must be a 32 byte hash (the transaction hash). The returned
+// signature has the format r || s || v used by NEAR, where v is the recovery
+// ID.
+func (s *Secp256k1Signer) SignBytes(msg []byte) ([]byte, error) {
+	if len(msg) != 32 {
+		return nil, fmt.Errorf("signer: secp256k1 message must be a 32 byte hash, got %d bytes", len(msg))
+	}
+	compact, err := btcec.SignCompact(btcec.S256(), s.privKey, msg, false)
+	if err != nil {
+		return nil, err
+	}
+	// compact has the format (27 + v) || r || s
+	sig := make([]byte, 65)
+	copy(sig, compact[1:])
+	sig[64] = compact[0] - 27
+	return sig, nil
+}
+
+// PublicKey implements Signer.
+func (s *Secp256k1Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements Signer.
+func (s *Secp256k1Signer) AccountID() string {
+	return s.accountID
+}
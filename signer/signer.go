@@ -0,0 +1,68 @@
+// Package signer defines the Signer interface used to sign NEAR transactions
+// without direct access to the underlying key material.
+package signer
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// Signer signs messages on behalf of a NEAR account. Implementations may keep
+// the key material in memory, in a remote service, or in hardware.
+type Signer interface {
+	// SignBytes signs msg and returns the raw signature.
+	SignBytes(msg []byte) ([]byte, error)
+	// PublicKey returns the public key corresponding to the signing key.
+	PublicKey() utils.PublicKey
+	// AccountID returns the ID of the account the signing key belongs to.
+	AccountID() string
+}
+
+// Ed25519Signer is a Signer holding an Ed25519 private key in memory.
+type Ed25519Signer struct {
+	accountID string
+	privKey   ed25519.PrivateKey
+	pubKey    utils.PublicKey
+}
+
+// NewEd25519Signer returns a new signer for accountID using privKey.
+func NewEd25519Signer(accountID string, privKey ed25519.PrivateKey) (*Ed25519Signer, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("signer: invalid Ed25519 private key length %d", len(privKey))
+	}
+	return &Ed25519Signer{
+		accountID: accountID,
+		privKey:   privKey,
+		pubKey:    utils.PublicKeyFromEd25519(privKey.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// SignBytes implements Signer.
+func (s *Ed25519Signer) SignBytes(msg []byte) ([]byte, error) {
+	return s.privKey.Sign(rand.Reader, msg, crypto.Hash(0))
+}
+
+// PublicKey implements Signer.
+func (s *Ed25519Signer) PublicKey() utils.PublicKey {
+	return s.pubKey
+}
+
+// AccountID implements Signer.
+func (s *Ed25519Signer) AccountID() string {
+	return s.accountID
+}
+
+// TransactionSigner is implemented by signers which have to sign the complete
+// Borsh serialized transaction instead of its hash, for example hardware
+// wallets which display the transaction for confirmation. If a Signer
+// implements TransactionSigner, SignTransaction is used to sign transactions.
+type TransactionSigner interface {
+	Signer
+	// SignTransaction signs the Borsh serialized transaction tx and returns
+	// the raw signature of its sha256 hash.
+	SignTransaction(tx []byte) ([]byte, error)
+}
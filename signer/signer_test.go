@@ -0,0 +1,37 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEd25519Signer(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s Signer
+	s, err = NewEd25519Signer("test-account.testnet", priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.AccountID() != "test-account.testnet" {
+		t.Errorf("AccountID() returned %s", s.AccountID())
+	}
+	pk := s.PublicKey()
+	if string(pk.Bytes()) != string(pub) {
+		t.Error("PublicKey() does not match generated public key")
+	}
+	msg := []byte("message")
+	sig, err := s.SignBytes(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(pub, msg, sig) {
+		t.Error("signature does not verify")
+	}
+	if _, err := NewEd25519Signer("test-account.testnet", priv[:32]); err == nil {
+		t.Error("NewEd25519Signer() accepted short private key")
+	}
+}
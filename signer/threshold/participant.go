@@ -0,0 +1,169 @@
+package threshold
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"filippo.io/edwards25519"
+)
+
+// Commitment is the commitment of a participant to the nonces it will use
+// for a single signature (round one of FROST).
+type Commitment struct {
+	Identifier uint16 `json:"identifier"`
+	Hiding     []byte `json:"hiding"`
+	Binding    []byte `json:"binding"`
+}
+
+// SignRequest asks a participant for its signature share (round two of
+// FROST).
+type SignRequest struct {
+	// Message is the message to sign. For transactions it is the SHA-256
+	// hash of the Borsh serialized transaction.
+	Message []byte `json:"message"`
+	// Payload is the Borsh serialized transaction whose hash is Message, if
+	// a transaction is signed. Participants use it to review the
+	// transaction before they approve it.
+	Payload []byte `json:"payload,omitempty"`
+	// Commitments contains the commitments of all signing participants
+	// sorted by identifier.
+	Commitments []Commitment `json:"commitments"`
+}
+
+// Participant is a holder of a key share. Participants may be local, see
+// NewParticipant, or remote services which implement the interface by
+// forwarding both rounds to the share holder.
+type Participant interface {
+	// Identifier returns the identifier of the participant's key share.
+	Identifier() uint16
+	// Commit generates fresh nonces and returns the commitment to them.
+	Commit(ctx context.Context) (Commitment, error)
+	// Sign returns the signature share for req. The nonces committed to in
+	// req are used once and discarded, even if the request is rejected.
+	Sign(ctx context.Context, req *SignRequest) ([]byte, error)
+}
+
+// ApproveFunc decides whether a participant contributes a signature share to
+// req. It rejects the request by returning an error.
+type ApproveFunc func(ctx context.Context, req *SignRequest) error
+
+type nonces struct {
+	hiding, binding *edwards25519.Scalar
+}
+
+// LocalParticipant is a Participant holding its key share in memory.
+type LocalParticipant struct {
+	id      uint16
+	secret  *edwards25519.Scalar
+	pubKey  []byte
+	approve ApproveFunc
+
+	mtx     sync.Mutex
+	pending map[string]nonces
+}
+
+// NewParticipant returns a participant for share. If approve is not nil it
+// is called for every signing request before the signature share is created.
+func NewParticipant(share *KeyShare, approve ApproveFunc) (*LocalParticipant, error) {
+	secret, err := edwards25519.NewScalar().SetCanonicalBytes(share.SecretShare)
+	if err != nil {
+		return nil, fmt.Errorf("threshold: invalid secret share: %v", err)
+	}
+	if share.Identifier == 0 {
+		return nil, fmt.Errorf("threshold: invalid identifier 0")
+	}
+	return &LocalParticipant{
+		id:      share.Identifier,
+		secret:  secret,
+		pubKey:  share.PublicKey,
+		approve: approve,
+		pending: make(map[string]nonces),
+	}, nil
+}
+
+// Identifier implements Participant.
+func (p *LocalParticipant) Identifier() uint16 {
+	return p.id
+}
+
+// generateNonce implements nonce_generate of RFC 9591.
+func (p *LocalParticipant) generateNonce() (*edwards25519.Scalar, error) {
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, err
+	}
+	return hashToScalar([]byte(contextString+"nonce"), buf[:], p.secret.Bytes()), nil
+}
+
+// Commit implements Participant.
+func (p *LocalParticipant) Commit(ctx context.Context) (Commitment, error) {
+	var n nonces
+	var err error
+	if n.hiding, err = p.generateNonce(); err != nil {
+		return Commitment{}, err
+	}
+	if n.binding, err = p.generateNonce(); err != nil {
+		return Commitment{}, err
+	}
+	c := Commitment{
+		Identifier: p.id,
+		Hiding:     new(edwards25519.Point).ScalarBaseMult(n.hiding).Bytes(),
+		Binding:    new(edwards25519.Point).ScalarBaseMult(n.binding).Bytes(),
+	}
+	p.mtx.Lock()
+	p.pending[string(c.Hiding)] = n
+	p.mtx.Unlock()
+	return c, nil
+}
+
+// Sign implements Participant.
+func (p *LocalParticipant) Sign(ctx context.Context, req *SignRequest) ([]byte, error) {
+	var own *Commitment
+	for i := range req.Commitments {
+		if req.Commitments[i].Identifier == p.id {
+			own = &req.Commitments[i]
+			break
+		}
+	}
+	if own == nil {
+		return nil, fmt.Errorf("threshold: no commitment of participant %d", p.id)
+	}
+	p.mtx.Lock()
+	n, ok := p.pending[string(own.Hiding)]
+	delete(p.pending, string(own.Hiding))
+	p.mtx.Unlock()
+	if !ok || !bytes.Equal(own.Binding, new(edwards25519.Point).ScalarBaseMult(n.binding).Bytes()) {
+		return nil, fmt.Errorf("threshold: unknown commitment of participant %d", p.id)
+	}
+	if req.Payload != nil {
+		h := sha256.Sum256(req.Payload)
+		if !bytes.Equal(h[:], req.Message) {
+			return nil, fmt.Errorf("%w: message is not the hash of the payload", ErrRejected)
+		}
+	}
+	if p.approve != nil {
+		if err := p.approve(ctx, req); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrRejected, err)
+		}
+	}
+	factors, err := bindingFactors(p.pubKey, req.Message, req.Commitments)
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := groupCommitment(req.Commitments, factors)
+	if err != nil {
+		return nil, err
+	}
+	c := challenge(r, p.pubKey, req.Message)
+	lambda := lagrangeCoefficient(p.id, req.Commitments)
+	// z = hiding + binding * rho + lambda * secret * c
+	z := edwards25519.NewScalar().Multiply(lambda, p.secret)
+	z.Multiply(z, c)
+	z.MultiplyAdd(n.binding, factors[p.id], z)
+	z.Add(z, n.hiding)
+	return z.Bytes(), nil
+}
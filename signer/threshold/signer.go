@@ -0,0 +1,191 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"filippo.io/edwards25519"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DefaultTimeout is the timeout of a signing session started by SignBytes or
+// SignTransaction. It includes the time participants need for approval.
+const DefaultTimeout = 5 * time.Minute
+
+// Signer is a signer.TransactionSigner which coordinates the signing of
+// messages by threshold participants.
+type Signer struct {
+	accountID    string
+	group        *Group
+	participants []Participant
+}
+
+// NewSigner returns a new signer for accountID which signs with the split
+// key described by group. At least group.Threshold of participants have to
+// take part in every signature.
+func NewSigner(accountID string, group *Group, participants []Participant) (*Signer, error) {
+	if len(group.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("threshold: group public key has invalid length %d", len(group.PublicKey))
+	}
+	if len(participants) < group.Threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNotEnoughParticipants, len(participants), group.Threshold)
+	}
+	for _, p := range participants {
+		if _, ok := group.VerifyingShares[p.Identifier()]; !ok {
+			return nil, fmt.Errorf("threshold: participant %d is not part of the group", p.Identifier())
+		}
+	}
+	return &Signer{
+		accountID:    accountID,
+		group:        group,
+		participants: participants,
+	}, nil
+}
+
+// SignBytes implements signer.Signer.
+func (s *Signer) SignBytes(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	return s.Sign(ctx, msg, nil)
+}
+
+// SignTransaction implements signer.TransactionSigner. The participants
+// receive the serialized transaction for review.
+func (s *Signer) SignTransaction(tx []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	h := sha256.Sum256(tx)
+	return s.Sign(ctx, h[:], tx)
+}
+
+// PublicKey implements signer.Signer.
+func (s *Signer) PublicKey() utils.PublicKey {
+	return s.group.NearPublicKey()
+}
+
+// AccountID implements signer.Signer.
+func (s *Signer) AccountID() string {
+	return s.accountID
+}
+
+type commitResult struct {
+	p   Participant
+	c   Commitment
+	err error
+}
+
+type shareResult struct {
+	id    uint16
+	share []byte
+	err   error
+}
+
+// Sign runs a signing session for msg with the first group.Threshold
+// participants which commit to nonces. payload is passed to the
+// participants for review, see SignRequest.
+func (s *Signer) Sign(ctx context.Context, msg, payload []byte) ([]byte, error) {
+	// round one: collect commitments
+	commitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan commitResult, len(s.participants))
+	for _, p := range s.participants {
+		go func(p Participant) {
+			c, err := p.Commit(commitCtx)
+			results <- commitResult{p, c, err}
+		}(p)
+	}
+	var signers []Participant
+	var commitments []Commitment
+	var firstErr error
+	for range s.participants {
+		r := <-results
+		if r.err == nil && r.c.Identifier != r.p.Identifier() {
+			r.err = fmt.Errorf("threshold: participant %d committed as %d", r.p.Identifier(), r.c.Identifier)
+		}
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("participant %d: %w", r.p.Identifier(), r.err)
+			}
+			continue
+		}
+		signers = append(signers, r.p)
+		commitments = append(commitments, r.c)
+		if len(signers) == s.group.Threshold {
+			break
+		}
+	}
+	if len(signers) < s.group.Threshold {
+		return nil, fmt.Errorf("%w: %d of %d committed (%v)", ErrNotEnoughParticipants,
+			len(signers), s.group.Threshold, firstErr)
+	}
+	sort.Slice(commitments, func(i, j int) bool {
+		return commitments[i].Identifier < commitments[j].Identifier
+	})
+
+	// round two: collect signature shares
+	req := &SignRequest{Message: msg, Payload: payload, Commitments: commitments}
+	shares := make(chan shareResult, len(signers))
+	for _, p := range signers {
+		go func(p Participant) {
+			share, err := p.Sign(ctx, req)
+			shares <- shareResult{p.Identifier(), share, err}
+		}(p)
+	}
+	factors, err := bindingFactors(s.group.PublicKey, msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+	r, single, err := groupCommitment(commitments, factors)
+	if err != nil {
+		return nil, err
+	}
+	c := challenge(r, s.group.PublicKey, msg)
+	z := edwards25519.NewScalar()
+	firstErr = nil
+	for range signers {
+		res := <-shares
+		if res.err == nil {
+			res.err = s.verifyShare(res.id, res.share, c, commitments, single[res.id])
+		}
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("participant %d: %w", res.id, res.err)
+			}
+			continue
+		}
+		share, _ := edwards25519.NewScalar().SetCanonicalBytes(res.share)
+		z.Add(z, share)
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sig := append(r.Bytes(), z.Bytes()...)
+	if !ed25519.Verify(s.group.PublicKey, msg, sig) {
+		return nil, fmt.Errorf("%w: aggregated signature is invalid", ErrInvalidShare)
+	}
+	return sig, nil
+}
+
+// verifyShare verifies the signature share of participant id:
+// G * share == R_id + (c * lambda_id) * Y_id.
+func (s *Signer) verifyShare(id uint16, share []byte, c *edwards25519.Scalar, commitments []Commitment, r *edwards25519.Point) error {
+	z, err := edwards25519.NewScalar().SetCanonicalBytes(share)
+	if err != nil {
+		return ErrInvalidShare
+	}
+	y, err := new(edwards25519.Point).SetBytes(s.group.VerifyingShares[id])
+	if err != nil {
+		return fmt.Errorf("threshold: invalid verifying share of participant %d", id)
+	}
+	k := edwards25519.NewScalar().Multiply(c, lagrangeCoefficient(id, commitments))
+	want := new(edwards25519.Point).ScalarMult(k, y)
+	want.Add(want, r)
+	if new(edwards25519.Point).ScalarBaseMult(z).Equal(want) != 1 {
+		return ErrInvalidShare
+	}
+	return nil
+}
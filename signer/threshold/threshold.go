@@ -0,0 +1,223 @@
+// Package threshold implements m-of-n threshold signing of Ed25519 signatures
+// with FROST (RFC 9591, ciphersuite FROST(Ed25519, SHA-512)).
+//
+// The private key of an account is split into n key shares, each held by a
+// Participant. A Signer coordinates the two rounds of the protocol between at
+// least threshold participants and aggregates their signature shares into an
+// ordinary Ed25519 signature, which is indistinguishable from one created with
+// the unsplit key. Participants can inspect every transaction before they
+// contribute a signature share, so a transaction is only signed if enough
+// share holders approve it.
+package threshold
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+
+	"filippo.io/edwards25519"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// Errors returned by the threshold signer.
+var (
+	ErrNotEnoughParticipants = errors.New("threshold: not enough participants")
+	ErrInvalidShare          = errors.New("threshold: invalid signature share")
+	ErrRejected              = errors.New("threshold: signing request rejected")
+)
+
+// Group is the public information of a split key: the group public key, the
+// threshold and the verifying share of every participant, which the Signer
+// uses to detect invalid signature shares.
+type Group struct {
+	PublicKey       []byte            `json:"public_key"`
+	Threshold       int               `json:"threshold"`
+	VerifyingShares map[uint16][]byte `json:"verifying_shares"`
+}
+
+// KeyShare is the secret key share of a single participant.
+type KeyShare struct {
+	Identifier  uint16 `json:"identifier"`
+	SecretShare []byte `json:"secret_share"`
+	PublicKey   []byte `json:"public_key"`
+}
+
+// NearPublicKey returns the group public key in NEAR encoding.
+func (g *Group) NearPublicKey() utils.PublicKey {
+	return utils.PublicKeyFromEd25519(g.PublicKey)
+}
+
+// GenerateShares generates a new random Ed25519 key and splits it into n key
+// shares of which threshold are required to sign. The key itself is never
+// assembled; the returned key shares have to be distributed to the
+// participants by the caller.
+func GenerateShares(threshold, n int) (*Group, []KeyShare, error) {
+	secret, err := randomScalar(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	return split(secret, threshold, n)
+}
+
+// Split splits the existing Ed25519 private key privKey into n key shares of
+// which threshold are required to sign, e.g. to move an existing account to
+// threshold signing. The private key should be destroyed afterwards.
+func Split(privKey ed25519.PrivateKey, threshold, n int) (*Group, []KeyShare, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, nil, fmt.Errorf("threshold: private key has invalid length %d", len(privKey))
+	}
+	h := sha512.Sum512(privKey.Seed())
+	secret, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, nil, err
+	}
+	return split(secret, threshold, n)
+}
+
+// split creates the key shares with Shamir secret sharing of secret, as the
+// trusted dealer key generation in RFC 9591 appendix C.
+func split(secret *edwards25519.Scalar, threshold, n int) (*Group, []KeyShare, error) {
+	if threshold < 2 || n < threshold || n > 0xffff {
+		return nil, nil, fmt.Errorf("threshold: invalid threshold %d of %d", threshold, n)
+	}
+	coeffs := []*edwards25519.Scalar{secret}
+	for i := 1; i < threshold; i++ {
+		c, err := randomScalar(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		coeffs = append(coeffs, c)
+	}
+	group := &Group{
+		PublicKey:       new(edwards25519.Point).ScalarBaseMult(secret).Bytes(),
+		Threshold:       threshold,
+		VerifyingShares: make(map[uint16][]byte),
+	}
+	shares := make([]KeyShare, n)
+	for i := range shares {
+		id := uint16(i + 1)
+		x := identifierScalar(id)
+		// evaluate the polynomial with Horner's method
+		y := edwards25519.NewScalar()
+		for j := len(coeffs) - 1; j >= 0; j-- {
+			y.MultiplyAdd(y, x, coeffs[j])
+		}
+		shares[i] = KeyShare{
+			Identifier:  id,
+			SecretShare: y.Bytes(),
+			PublicKey:   group.PublicKey,
+		}
+		group.VerifyingShares[id] = new(edwards25519.Point).ScalarBaseMult(y).Bytes()
+	}
+	return group, shares, nil
+}
+
+func randomScalar(r io.Reader) (*edwards25519.Scalar, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	return edwards25519.NewScalar().SetUniformBytes(buf[:])
+}
+
+func identifierScalar(id uint16) *edwards25519.Scalar {
+	var buf [32]byte
+	buf[0] = byte(id)
+	buf[1] = byte(id >> 8)
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(buf[:])
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func hashToScalar(parts ...[]byte) *edwards25519.Scalar {
+	h := sha512.New()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	s, err := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func hash(parts ...[]byte) []byte {
+	h := sha512.New()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
+
+// bindingFactors computes the binding factor of every participant in
+// commitments, which must be sorted by identifier.
+func bindingFactors(groupPublicKey, msg []byte, commitments []Commitment) (map[uint16]*edwards25519.Scalar, error) {
+	var encoded []byte
+	for _, c := range commitments {
+		encoded = append(encoded, identifierScalar(c.Identifier).Bytes()...)
+		encoded = append(encoded, c.Hiding...)
+		encoded = append(encoded, c.Binding...)
+	}
+	prefix := append([]byte{}, groupPublicKey...)
+	prefix = append(prefix, hash([]byte(contextString+"msg"), msg)...)
+	prefix = append(prefix, hash([]byte(contextString+"com"), encoded)...)
+	factors := make(map[uint16]*edwards25519.Scalar)
+	for _, c := range commitments {
+		if _, ok := factors[c.Identifier]; ok {
+			return nil, fmt.Errorf("threshold: duplicate participant %d", c.Identifier)
+		}
+		factors[c.Identifier] = hashToScalar([]byte(contextString+"rho"), prefix,
+			identifierScalar(c.Identifier).Bytes())
+	}
+	return factors, nil
+}
+
+// groupCommitment computes the group commitment R and the commitment of
+// every single participant.
+func groupCommitment(commitments []Commitment, factors map[uint16]*edwards25519.Scalar) (*edwards25519.Point, map[uint16]*edwards25519.Point, error) {
+	r := edwards25519.NewIdentityPoint()
+	single := make(map[uint16]*edwards25519.Point)
+	for _, c := range commitments {
+		hiding, err := new(edwards25519.Point).SetBytes(c.Hiding)
+		if err != nil {
+			return nil, nil, fmt.Errorf("threshold: invalid commitment of participant %d", c.Identifier)
+		}
+		binding, err := new(edwards25519.Point).SetBytes(c.Binding)
+		if err != nil {
+			return nil, nil, fmt.Errorf("threshold: invalid commitment of participant %d", c.Identifier)
+		}
+		p := new(edwards25519.Point).ScalarMult(factors[c.Identifier], binding)
+		p.Add(p, hiding)
+		single[c.Identifier] = p
+		r.Add(r, p)
+	}
+	return r, single, nil
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient of id for the
+// interpolation at 0 over the participants in commitments.
+func lagrangeCoefficient(id uint16, commitments []Commitment) *edwards25519.Scalar {
+	x := identifierScalar(id)
+	num := identifierScalar(1)
+	den := identifierScalar(1)
+	for _, c := range commitments {
+		if c.Identifier == id {
+			continue
+		}
+		xj := identifierScalar(c.Identifier)
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, x))
+	}
+	return num.Multiply(num, edwards25519.NewScalar().Invert(den))
+}
+
+func challenge(r *edwards25519.Point, groupPublicKey, msg []byte) *edwards25519.Scalar {
+	return hashToScalar(r.Bytes(), groupPublicKey, msg)
+}
@@ -0,0 +1,161 @@
+package threshold
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func participants(t *testing.T, shares []KeyShare, approve ApproveFunc) []Participant {
+	var ps []Participant
+	for i := range shares {
+		p, err := NewParticipant(&shares[i], approve)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+func TestSplitSign(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group, shares, err := Split(priv, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(ed25519.PublicKey(group.PublicKey)) {
+		t.Fatal("Split() changed public key")
+	}
+	ps := participants(t, shares, nil)
+	msg := []byte("message")
+	// every 2 of 3 subset must produce a valid signature
+	for _, subset := range [][]Participant{{ps[0], ps[1]}, {ps[0], ps[2]}, {ps[1], ps[2]}, ps} {
+		s, err := NewSigner("test-account.testnet", group, subset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := s.SignBytes(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ed25519.Verify(pub, msg, sig) {
+			t.Error("SignBytes() returned invalid signature")
+		}
+	}
+}
+
+func TestGenerateSharesSignTransaction(t *testing.T) {
+	group, shares, err := GenerateShares(3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := []byte("serialized transaction")
+	var reviewed int32
+	approve := func(ctx context.Context, req *SignRequest) error {
+		if string(req.Payload) != string(tx) {
+			t.Errorf("participant received payload %q", req.Payload)
+		}
+		atomic.AddInt32(&reviewed, 1)
+		return nil
+	}
+	s, err := NewSigner("test-account.testnet", group, participants(t, shares[:3], approve))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := s.SignTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(tx)
+	if !ed25519.Verify(s.PublicKey().Bytes(), h[:], sig) {
+		t.Error("SignTransaction() returned invalid signature")
+	}
+	if reviewed != 3 {
+		t.Errorf("transaction reviewed %d times (want 3)", reviewed)
+	}
+}
+
+func TestReject(t *testing.T) {
+	group, shares, err := GenerateShares(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := participants(t, shares[:1], nil)
+	reject, err := NewParticipant(&shares[1], func(ctx context.Context, req *SignRequest) error {
+		return errors.New("not approved")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSigner("test-account.testnet", group, append(ps, reject))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SignBytes([]byte("message")); !errors.Is(err, ErrRejected) {
+		t.Errorf("SignBytes() returned %v (want ErrRejected)", err)
+	}
+	if _, err := NewSigner("test-account.testnet", group, ps); !errors.Is(err, ErrNotEnoughParticipants) {
+		t.Errorf("NewSigner() returned %v (want ErrNotEnoughParticipants)", err)
+	}
+}
+
+type badParticipant struct {
+	Participant
+}
+
+func (p badParticipant) Sign(ctx context.Context, req *SignRequest) ([]byte, error) {
+	share, err := p.Participant.Sign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	share[0] ^= 1
+	return share, nil
+}
+
+func TestInvalidShare(t *testing.T) {
+	group, shares, err := GenerateShares(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := participants(t, shares, nil)
+	ps[1] = badParticipant{ps[1]}
+	s, err := NewSigner("test-account.testnet", group, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SignBytes([]byte("message")); !errors.Is(err, ErrInvalidShare) {
+		t.Errorf("SignBytes() returned %v (want ErrInvalidShare)", err)
+	}
+}
+
+func TestNonceReuse(t *testing.T) {
+	_, shares, err := GenerateShares(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ps := participants(t, shares, nil)
+	ctx := context.Background()
+	var commitments []Commitment
+	for _, p := range ps {
+		c, err := p.Commit(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitments = append(commitments, c)
+	}
+	req := &SignRequest{Message: []byte("message"), Commitments: commitments}
+	if _, err := ps[0].Sign(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ps[0].Sign(ctx, req); err == nil {
+		t.Error("Sign() reused nonces")
+	}
+}
@@ -1,12 +1,11 @@
 package near
 
 import (
-	"crypto"
-	"crypto/ed25519"
-	"crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"math/big"
 
+	"github.com/YuxSccc/near-api-go/signer"
 	"github.com/YuxSccc/near-api-go/utils"
 	"github.com/near/borsh-go"
 )
@@ -105,10 +104,44 @@ type DeleteAccount struct {
 	BeneficiaryID string
 }
 
-// A Signature used for signing transaction.
+// A Signature used for signing transaction. It is a Borsh enum with one
+// variant per key type, the KeyType selects the variant.
 type Signature struct {
-	KeyType uint8
-	Data    [64]byte
+	KeyType   borsh.Enum `borsh_enum:"true"` // treat struct as complex enum when serializing/deserializing
+	ED25519   Ed25519Signature
+	SECP256K1 Secp256k1Signature
+}
+
+// An Ed25519Signature is the data of an Ed25519 signature.
+type Ed25519Signature struct {
+	Data [64]byte
+}
+
+// A Secp256k1Signature is the data of a recoverable secp256k1 signature
+// (r, s and the recovery ID v).
+type Secp256k1Signature struct {
+	Data [65]byte
+}
+
+// newSignature returns the signature sig of type keyType in NEAR encoding.
+func newSignature(keyType borsh.Enum, sig []byte) (Signature, error) {
+	var signature Signature
+	signature.KeyType = keyType
+	var data []byte
+	switch keyType {
+	case utils.ED25519:
+		data = signature.ED25519.Data[:]
+	case utils.SECP256K1:
+		data = signature.SECP256K1.Data[:]
+	default:
+		return signature, fmt.Errorf("near: unsupported signature key type %d", keyType)
+	}
+	if len(sig) != len(data) {
+		return signature, fmt.Errorf("near: signer returned %s signature of invalid length %d",
+			utils.KeyTypeName(keyType), len(sig))
+	}
+	copy(data, sig)
+	return signature, nil
 }
 
 // SignedTransaction encodes signed transactions for NEAR.
@@ -137,8 +170,7 @@ func createTransaction(
 
 func signTransactionObject(
 	tx *Transaction,
-	privKey ed25519.PrivateKey,
-	accountID string,
+	s signer.Signer,
 ) (txHash []byte, signedTx *SignedTransaction, err error) {
 	buf, err := borsh.Serialize(*tx)
 	if err != nil {
@@ -147,14 +179,20 @@ func signTransactionObject(
 
 	hash := sha256.Sum256(buf)
 
-	sig, err := privKey.Sign(rand.Reader, hash[:], crypto.Hash(0))
+	var sig []byte
+	if ts, ok := s.(signer.TransactionSigner); ok {
+		sig, err = ts.SignTransaction(buf)
+	} else {
+		sig, err = s.SignBytes(hash[:])
+	}
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var signature Signature
-	signature.KeyType = utils.ED25519
-	copy(signature.Data[:], sig)
+	signature, err := newSignature(s.PublicKey().KeyType, sig)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var stx SignedTransaction
 	stx.Transaction = *tx
@@ -168,16 +206,14 @@ func signTransaction(
 	nonce uint64,
 	actions []Action,
 	blockHash []byte,
-	publicKey ed25519.PublicKey,
-	privKey ed25519.PrivateKey,
-	accountID string,
+	s signer.Signer,
 ) (txHash []byte, signedTx *SignedTransaction, err error) {
 	// create transaction
-	tx := createTransaction(accountID, utils.PublicKeyFromEd25519(publicKey),
+	tx := createTransaction(s.AccountID(), s.PublicKey(),
 		receiverID, nonce, blockHash, actions)
 
 	// sign transaction object
-	txHash, signedTx, err = signTransactionObject(tx, privKey, accountID)
+	txHash, signedTx, err = signTransactionObject(tx, s)
 	if err != nil {
 		return nil, nil, err
 	}
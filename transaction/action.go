@@ -0,0 +1,272 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// All action kinds, in the order of the nearcore Action enum.
+const (
+	ActionCreateAccount borsh.Enum = iota
+	ActionDeployContract
+	ActionFunctionCall
+	ActionTransfer
+	ActionStake
+	ActionAddKey
+	ActionDeleteKey
+	ActionDeleteAccount
+	ActionDelegate
+	ActionDeployGlobalContract
+	ActionUseGlobalContract
+)
+
+// Action is an action of a transaction. It is a Borsh enum, the Kind selects
+// the field holding the action.
+type Action struct {
+	Kind           borsh.Enum `borsh_enum:"true"`
+	CreateAccount  borsh.Enum
+	DeployContract DeployContractAction
+	FunctionCall   FunctionCallAction
+	Transfer       TransferAction
+	Stake          StakeAction
+	AddKey         AddKeyAction
+	DeleteKey      DeleteKeyAction
+	DeleteAccount  DeleteAccountAction
+	Delegate       SignedDelegateAction
+	// DeployGlobalContract and UseGlobalContract are the global
+	// contract actions of NEP-591.
+	DeployGlobalContract DeployGlobalContractAction
+	UseGlobalContract    UseGlobalContractAction
+}
+
+// DeployContractAction deploys the Wasm code to the receiver account.
+type DeployContractAction struct {
+	Code []byte
+}
+
+// FunctionCallAction calls the method of the contract of the receiver
+// account.
+type FunctionCallAction struct {
+	MethodName string
+	Args       []byte
+	Gas        uint64
+	Deposit    types.Balance
+}
+
+// TransferAction transfers the deposit to the receiver account.
+type TransferAction struct {
+	Deposit types.Balance
+}
+
+// StakeAction stakes the amount with the validator key PublicKey, staking
+// zero unstakes.
+type StakeAction struct {
+	Stake     types.Balance
+	PublicKey utils.PublicKey
+}
+
+// AddKeyAction adds the access key to the receiver account.
+type AddKeyAction struct {
+	PublicKey utils.PublicKey
+	AccessKey AccessKey
+}
+
+// DeleteKeyAction deletes the access key from the receiver account.
+type DeleteKeyAction struct {
+	PublicKey utils.PublicKey
+}
+
+// DeleteAccountAction deletes the receiver account and transfers the
+// remaining balance to the beneficiary.
+type DeleteAccountAction struct {
+	BeneficiaryID string
+}
+
+// All deploy modes of global contracts, see DeployGlobalContractAction.
+const (
+	// GlobalContractByHash makes the code usable by its hash, the code of
+	// the hash can never change.
+	GlobalContractByHash borsh.Enum = iota
+	// GlobalContractByAccountID makes the code usable by the account ID of
+	// the deploying account, which can upgrade the code of all users by
+	// deploying again.
+	GlobalContractByAccountID
+)
+
+// DeployGlobalContractAction deploys the Wasm code as global contract, which
+// other accounts can use without paying for the storage of the code. The
+// deploying account pays for the storage on all shards, burning its balance.
+type DeployGlobalContractAction struct {
+	Code       []byte
+	DeployMode borsh.Enum
+}
+
+// All kinds of global contract identifiers.
+const (
+	GlobalContractCodeHash borsh.Enum = iota
+	GlobalContractAccountID
+)
+
+// GlobalContractIdentifier identifies a global contract by the hash of its
+// code or the account which deployed it. It is a Borsh enum, the Kind selects
+// the identifier.
+type GlobalContractIdentifier struct {
+	Kind      borsh.Enum `borsh_enum:"true"`
+	CodeHash  [32]byte
+	AccountID string
+}
+
+// UseGlobalContractAction makes the global contract the contract of the
+// receiver account.
+type UseGlobalContractAction struct {
+	ContractIdentifier GlobalContractIdentifier
+}
+
+// All access key permission kinds.
+const (
+	PermissionFunctionCall borsh.Enum = iota
+	PermissionFullAccess
+)
+
+// AccessKey is an access key added with AddKey.
+type AccessKey struct {
+	Nonce      uint64
+	Permission AccessKeyPermission
+}
+
+// AccessKeyPermission is the permission of an access key. It is a Borsh
+// enum, the Kind selects the permission.
+type AccessKeyPermission struct {
+	Kind         borsh.Enum `borsh_enum:"true"`
+	FunctionCall FunctionCallPermission
+	FullAccess   borsh.Enum
+}
+
+// FunctionCallPermission allows calling the methods of the receiver without
+// deposit.
+type FunctionCallPermission struct {
+	// Allowance is the amount the key can spend on gas, nil means unlimited.
+	Allowance *types.Balance
+	// ReceiverID is the contract which can be called.
+	ReceiverID string
+	// MethodNames are the methods which can be called, all methods if empty.
+	MethodNames []string
+}
+
+// FullAccessKey returns an access key with full access.
+func FullAccessKey() AccessKey {
+	return AccessKey{Permission: AccessKeyPermission{Kind: PermissionFullAccess}}
+}
+
+// FunctionCallAccessKey returns an access key which can call methodNames
+// (all methods if empty) of receiverID, paying at most allowance for gas
+// (unlimited if nil).
+func FunctionCallAccessKey(receiverID string, methodNames []string, allowance *types.Balance) AccessKey {
+	return AccessKey{Permission: AccessKeyPermission{
+		Kind: PermissionFunctionCall,
+		FunctionCall: FunctionCallPermission{
+			Allowance:   allowance,
+			ReceiverID:  receiverID,
+			MethodNames: methodNames,
+		},
+	}}
+}
+
+// CreateAccount returns an action creating the receiver account.
+func CreateAccount() Action {
+	return Action{Kind: ActionCreateAccount}
+}
+
+// DeployContract returns an action deploying the Wasm code.
+func DeployContract(code []byte) Action {
+	return Action{Kind: ActionDeployContract, DeployContract: DeployContractAction{Code: code}}
+}
+
+// FunctionCall returns an action calling methodName with the raw args,
+// attaching gas and deposit.
+func FunctionCall(methodName string, args []byte, gas uint64, deposit types.Balance) Action {
+	return Action{Kind: ActionFunctionCall, FunctionCall: FunctionCallAction{
+		MethodName: methodName,
+		Args:       args,
+		Gas:        gas,
+		Deposit:    deposit,
+	}}
+}
+
+// FunctionCallJSON returns an action calling methodName with the JSON
+// encoding of args.
+func FunctionCallJSON(methodName string, args interface{}, gas uint64, deposit types.Balance) (Action, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return Action{}, err
+	}
+	return FunctionCall(methodName, data, gas, deposit), nil
+}
+
+// FunctionCallBorsh returns an action calling methodName with the Borsh
+// encoding of args.
+func FunctionCallBorsh(methodName string, args interface{}, gas uint64, deposit types.Balance) (Action, error) {
+	data, err := borsh.Serialize(args)
+	if err != nil {
+		return Action{}, err
+	}
+	return FunctionCall(methodName, data, gas, deposit), nil
+}
+
+// Transfer returns an action transferring deposit to the receiver.
+func Transfer(deposit types.Balance) Action {
+	return Action{Kind: ActionTransfer, Transfer: TransferAction{Deposit: deposit}}
+}
+
+// Stake returns an action staking stake with the validator key publicKey.
+func Stake(stake types.Balance, publicKey utils.PublicKey) Action {
+	return Action{Kind: ActionStake, Stake: StakeAction{Stake: stake, PublicKey: publicKey}}
+}
+
+// AddKey returns an action adding the access key with publicKey.
+func AddKey(publicKey utils.PublicKey, accessKey AccessKey) Action {
+	return Action{Kind: ActionAddKey, AddKey: AddKeyAction{PublicKey: publicKey, AccessKey: accessKey}}
+}
+
+// DeleteKey returns an action deleting the access key with publicKey.
+func DeleteKey(publicKey utils.PublicKey) Action {
+	return Action{Kind: ActionDeleteKey, DeleteKey: DeleteKeyAction{PublicKey: publicKey}}
+}
+
+// DeleteAccount returns an action deleting the receiver account, the
+// remaining balance is transferred to beneficiaryID.
+func DeleteAccount(beneficiaryID string) Action {
+	return Action{Kind: ActionDeleteAccount, DeleteAccount: DeleteAccountAction{BeneficiaryID: beneficiaryID}}
+}
+
+// CodeHash returns the hash identifying code deployed as global contract by
+// hash.
+func CodeHash(code []byte) [32]byte {
+	return sha256.Sum256(code)
+}
+
+// DeployGlobalContract returns an action deploying the Wasm code as global
+// contract with the mode GlobalContractByHash or GlobalContractByAccountID.
+func DeployGlobalContract(code []byte, mode borsh.Enum) Action {
+	return Action{Kind: ActionDeployGlobalContract, DeployGlobalContract: DeployGlobalContractAction{Code: code, DeployMode: mode}}
+}
+
+// UseGlobalContractByHash returns an action using the global contract with
+// the code hash, see CodeHash.
+func UseGlobalContractByHash(codeHash [32]byte) Action {
+	return Action{Kind: ActionUseGlobalContract, UseGlobalContract: UseGlobalContractAction{
+		ContractIdentifier: GlobalContractIdentifier{Kind: GlobalContractCodeHash, CodeHash: codeHash},
+	}}
+}
+
+// UseGlobalContractByAccountID returns an action using the global contract
+// deployed by accountID, which follows its upgrades.
+func UseGlobalContractByAccountID(accountID string) Action {
+	return Action{Kind: ActionUseGlobalContract, UseGlobalContract: UseGlobalContractAction{
+		ContractIdentifier: GlobalContractIdentifier{Kind: GlobalContractAccountID, AccountID: accountID},
+	}}
+}
@@ -0,0 +1,72 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+)
+
+// DefaultBlockHashMaxAge is the default age after which a BlockHashCache
+// fetches a new block hash. Transactions are valid for about 24 hours
+// (86400 blocks on mainnet) after their block.
+const DefaultBlockHashMaxAge = 10 * time.Minute
+
+// BlockHashCache caches the hash of the latest final block for building
+// transactions, saving a block request per transaction. It is safe for
+// concurrent use.
+type BlockHashCache struct {
+	client *rpc.Client
+	maxAge time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	hash    [32]byte
+	fetched time.Time
+}
+
+// NewBlockHashCache returns a new cache of the latest final block hash
+// fetched via client, which is refreshed after maxAge (DefaultBlockHashMaxAge
+// if zero).
+func NewBlockHashCache(client *rpc.Client, maxAge time.Duration) *BlockHashCache {
+	if maxAge == 0 {
+		maxAge = DefaultBlockHashMaxAge
+	}
+	return &BlockHashCache{client: client, maxAge: maxAge, now: time.Now}
+}
+
+// Get returns the cached block hash, fetching the hash of the latest final
+// block if the cached one is older than the maximum age.
+func (c *BlockHashCache) Get(ctx context.Context) ([32]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if !c.fetched.IsZero() && now.Sub(c.fetched) < c.maxAge {
+		return c.hash, nil
+	}
+	block, err := c.client.Block(ctx, rpc.Final())
+	if err != nil {
+		return [32]byte{}, err
+	}
+	hash, err := decodeHash(block.Header.Hash)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	c.hash, c.fetched = hash, now
+	return hash, nil
+}
+
+// Invalidate makes the next call of Get fetch a new block hash.
+func (c *BlockHashCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetched = time.Time{}
+}
+
+// expired reports whether err is the error of a transaction rejected because
+// its block hash is too old.
+func expired(err error) bool {
+	e, ok := rpc.AsInvalidTxError(err)
+	return ok && e.Kind == rpc.InvalidTxExpired
+}
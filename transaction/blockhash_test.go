@@ -0,0 +1,81 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func blockResult(hash [32]byte) rpctest.Handler {
+	return rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(hash[:])},
+	})
+}
+
+func TestBlockHashCache(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("block", rpctest.Sequence(blockResult([32]byte{1}), blockResult([32]byte{2}), blockResult([32]byte{3})))
+	c := NewBlockHashCache(srv.Client(), time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if h, err := c.Get(ctx); err != nil || h != [32]byte{1} {
+			t.Errorf("Get() returned %x, %v (want first hash)", h, err)
+		}
+	}
+	now = now.Add(time.Minute)
+	if h, err := c.Get(ctx); err != nil || h != [32]byte{2} {
+		t.Errorf("Get() after max age returned %x, %v (want second hash)", h, err)
+	}
+	c.Invalidate()
+	if h, err := c.Get(ctx); err != nil || h != [32]byte{3} {
+		t.Errorf("Get() after Invalidate() returned %x, %v (want third hash)", h, err)
+	}
+	if n := len(srv.RequestsFor("block")); n != 3 {
+		t.Errorf("Get() fetched %d blocks (want 3)", n)
+	}
+}
+
+func expiredError() *rpc.Error {
+	err := rpctest.HandlerError("INVALID_TRANSACTION")
+	err.Cause.Info = json.RawMessage(`{"TxExecutionError":{"InvalidTxError":"Expired"}}`)
+	return err
+}
+
+func TestSendExpired(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("block", rpctest.Sequence(blockResult([32]byte{1}), blockResult([32]byte{2})))
+	srv.Handle("send_tx", rpctest.Sequence(
+		rpctest.Fail(expiredError()),
+		rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}),
+	))
+	s := NewSender(srv.Client(), testSigner(t))
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 2 {
+		t.Fatalf("Send() made %d send_tx calls (want 2)", len(reqs))
+	}
+	if h := sentTransaction(t, reqs[1]).Transaction.BlockHash; h != [32]byte{2} {
+		t.Errorf("Send() retried with block hash %x (want new hash)", h)
+	}
+
+	// retries are limited
+	srv.Handle("send_tx", rpctest.Fail(expiredError()))
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); !expired(err) {
+		t.Errorf("Send() returned %v (want expired error)", err)
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 2+1+DefaultRetries {
+		t.Errorf("Send() made %d send_tx calls (want %d)", n-2, 1+DefaultRetries)
+	}
+}
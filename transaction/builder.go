@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// Builder composes the actions of a transaction, which are executed in
+// order and atomically: if one action fails, all are reverted. Its methods
+// return the builder for chaining:
+//
+//	b := transaction.Batch("new.alice.testnet").
+//		CreateAccount().
+//		Transfer(amount).
+//		AddFullAccessKey(publicKey)
+//	outcome, err := sender.SendBatch(ctx, b, rpc.TxExecutionStatusFinal)
+type Builder struct {
+	receiverID string
+	actions    []Action
+	err        error
+}
+
+// Batch returns a new builder of a transaction executing actions on
+// receiverID.
+func Batch(receiverID string) *Builder {
+	return &Builder{receiverID: receiverID}
+}
+
+// ReceiverID returns the receiver of the transaction.
+func (b *Builder) ReceiverID() string {
+	return b.receiverID
+}
+
+// Actions returns the actions added so far.
+func (b *Builder) Actions() []Action {
+	return b.actions
+}
+
+// Err returns the first error of adding an action.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Add adds the actions.
+func (b *Builder) Add(actions ...Action) *Builder {
+	b.actions = append(b.actions, actions...)
+	return b
+}
+
+// CreateAccount adds a CreateAccount action.
+func (b *Builder) CreateAccount() *Builder {
+	return b.Add(CreateAccount())
+}
+
+// DeployContract adds a DeployContract action.
+func (b *Builder) DeployContract(code []byte) *Builder {
+	return b.Add(DeployContract(code))
+}
+
+// DeployGlobalContract adds a DeployGlobalContract action.
+func (b *Builder) DeployGlobalContract(code []byte, mode borsh.Enum) *Builder {
+	return b.Add(DeployGlobalContract(code, mode))
+}
+
+// UseGlobalContractByHash adds a UseGlobalContract action by code hash.
+func (b *Builder) UseGlobalContractByHash(codeHash [32]byte) *Builder {
+	return b.Add(UseGlobalContractByHash(codeHash))
+}
+
+// UseGlobalContractByAccountID adds a UseGlobalContract action by account ID.
+func (b *Builder) UseGlobalContractByAccountID(accountID string) *Builder {
+	return b.Add(UseGlobalContractByAccountID(accountID))
+}
+
+// FunctionCall adds a FunctionCall action with the raw args.
+func (b *Builder) FunctionCall(methodName string, args []byte, gas uint64, deposit types.Balance) *Builder {
+	return b.Add(FunctionCall(methodName, args, gas, deposit))
+}
+
+// FunctionCallJSON adds a FunctionCall action with the JSON encoding of
+// args. Encoding errors are returned by Err.
+func (b *Builder) FunctionCallJSON(methodName string, args interface{}, gas uint64, deposit types.Balance) *Builder {
+	a, err := FunctionCallJSON(methodName, args, gas, deposit)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.Add(a)
+}
+
+// FunctionCallBorsh adds a FunctionCall action with the Borsh encoding of
+// args. Encoding errors are returned by Err.
+func (b *Builder) FunctionCallBorsh(methodName string, args interface{}, gas uint64, deposit types.Balance) *Builder {
+	a, err := FunctionCallBorsh(methodName, args, gas, deposit)
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.Add(a)
+}
+
+// Transfer adds a Transfer action.
+func (b *Builder) Transfer(deposit types.Balance) *Builder {
+	return b.Add(Transfer(deposit))
+}
+
+// Stake adds a Stake action.
+func (b *Builder) Stake(stake types.Balance, publicKey utils.PublicKey) *Builder {
+	return b.Add(Stake(stake, publicKey))
+}
+
+// AddKey adds an AddKey action.
+func (b *Builder) AddKey(publicKey utils.PublicKey, accessKey AccessKey) *Builder {
+	return b.Add(AddKey(publicKey, accessKey))
+}
+
+// AddFullAccessKey adds an AddKey action of a full access key.
+func (b *Builder) AddFullAccessKey(publicKey utils.PublicKey) *Builder {
+	return b.AddKey(publicKey, FullAccessKey())
+}
+
+// AddFunctionCallKey adds an AddKey action of a function call access key,
+// see FunctionCallAccessKey.
+func (b *Builder) AddFunctionCallKey(publicKey utils.PublicKey, receiverID string, methodNames []string, allowance *types.Balance) *Builder {
+	return b.AddKey(publicKey, FunctionCallAccessKey(receiverID, methodNames, allowance))
+}
+
+// DeleteKey adds a DeleteKey action.
+func (b *Builder) DeleteKey(publicKey utils.PublicKey) *Builder {
+	return b.Add(DeleteKey(publicKey))
+}
+
+// DeleteAccount adds a DeleteAccount action.
+func (b *Builder) DeleteAccount(beneficiaryID string) *Builder {
+	return b.Add(DeleteAccount(beneficiaryID))
+}
+
+// Build returns the transaction of signerID with the access key publicKey.
+// It fails for invalid account IDs, see Transaction.Validate.
+func (b *Builder) Build(signerID string, publicKey utils.PublicKey, nonce uint64, blockHash [32]byte) (*Transaction, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := validateAccountIDs(signerID, b.receiverID, b.actions); err != nil {
+		return nil, err
+	}
+	return New(signerID, publicKey, nonce, b.receiverID, blockHash, b.actions...), nil
+}
+
+// SendBatch sends the transaction composed by b, see Send.
+func (s *Sender) SendBatch(ctx context.Context, b *Builder, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return s.Send(ctx, b.receiverID, waitUntil, b.actions...)
+}
@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestBuilder(t *testing.T) {
+	amount := types.NewBalance(big.NewInt(100))
+	b := Batch("new.alice.testnet").
+		CreateAccount().
+		Transfer(amount).
+		AddFullAccessKey(testKey(1)).
+		AddFunctionCallKey(testKey(2), "app.testnet", []string{"play"}, nil).
+		DeployContract([]byte{0}).
+		FunctionCallJSON("new", map[string]string{"owner_id": "alice.testnet"}, 10, types.Balance{}).
+		FunctionCallBorsh("set", uint8(3), 5, types.Balance{})
+	if err := b.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []Action{
+		CreateAccount(),
+		Transfer(amount),
+		AddKey(testKey(1), FullAccessKey()),
+		AddKey(testKey(2), FunctionCallAccessKey("app.testnet", []string{"play"}, nil)),
+		DeployContract([]byte{0}),
+		FunctionCall("new", []byte(`{"owner_id":"alice.testnet"}`), 10, types.Balance{}),
+		FunctionCall("set", []byte{3}, 5, types.Balance{}),
+	}
+	if !reflect.DeepEqual(b.Actions(), want) {
+		t.Errorf("Actions() returned %+v (want %+v)", b.Actions(), want)
+	}
+	tx, err := b.Build("alice.testnet", testKey(3), 1, [32]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.ReceiverID != "new.alice.testnet" || len(tx.Actions) != len(want) {
+		t.Errorf("Build() returned %+v", tx)
+	}
+}
+
+func TestBuilderError(t *testing.T) {
+	b := Batch("app.testnet").FunctionCallJSON("f", func() {}, 1, types.Balance{}).Transfer(types.Balance{})
+	if b.Err() == nil {
+		t.Fatal("FunctionCallJSON() accepted invalid args")
+	}
+	if _, err := b.Build("alice.testnet", testKey(1), 1, [32]byte{}); err == nil {
+		t.Error("Build() succeeded with invalid action")
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	if _, err := NewSender(srv.Client(), testSigner(t)).SendBatch(context.Background(), b, ""); err == nil {
+		t.Error("SendBatch() succeeded with invalid action")
+	}
+	if _, err := NewSender(srv.Client(), testSigner(t)).SendBatch(context.Background(), Batch("App.testnet"), ""); err == nil {
+		t.Error("SendBatch() succeeded with invalid receiver")
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Errorf("SendBatch() made %d requests (want 0)", n)
+	}
+}
+
+func TestSendBatch(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	b := Batch("app.testnet").DeployContract([]byte{0}).FunctionCall("migrate", nil, 10, types.Balance{})
+	if _, err := NewSender(srv.Client(), testSigner(t)).SendBatch(context.Background(), b, ""); err != nil {
+		t.Fatal(err)
+	}
+	tx := sentTransaction(t, srv.RequestsFor("send_tx")[0]).Transaction
+	if tx.ReceiverID != "app.testnet" || len(tx.Actions) != 2 || tx.Actions[1].FunctionCall.MethodName != "migrate" {
+		t.Errorf("SendBatch() sent %+v", tx)
+	}
+}
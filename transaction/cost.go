@@ -0,0 +1,165 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// Cost is the cost breakdown of a transaction, see EstimateCost.
+type Cost struct {
+	// SendGas is burned when the transaction is converted to a receipt
+	// and ExecutionGas when the actions of the receipt are executed, both
+	// without the gas attached to function calls.
+	SendGas      uint64
+	ExecutionGas uint64
+	// AttachedGas is the gas attached to function calls.
+	AttachedGas uint64
+	// GasPrice is the gas price the costs are computed with.
+	GasPrice types.Balance
+	// ExecutionFee is the cost of SendGas and ExecutionGas.
+	ExecutionFee types.Balance
+	// AttachedGasCost is the cost of AttachedGas, which is prepaid but
+	// refunded as far as it is not used.
+	AttachedGasCost types.Balance
+	// Deposit is the amount transferred by transfers and function calls.
+	Deposit types.Balance
+	// Stake is the amount staked by stake actions, which are not charged
+	// but lock the balance.
+	Stake types.Balance
+	// StorageDeposit is the balance locked for the storage of accounts,
+	// access keys and contracts created by the transaction. It is not
+	// charged, but has to be available on the receiver account.
+	StorageDeposit types.Balance
+	// Total is the amount charged from the signer on submission: the fees,
+	// the attached gas and the deposits.
+	Total types.Balance
+}
+
+// EstimateCost returns the cost breakdown of tx with the fees of the current
+// protocol config and the gas price of the latest final block, see
+// ComputeCost.
+func EstimateCost(ctx context.Context, client *rpc.Client, tx *Transaction) (*Cost, error) {
+	cfg, err := client.ProtocolConfig(ctx, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := client.GasPrice(ctx, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	return ComputeCost(tx, &cfg.RuntimeConfig, gasPrice)
+}
+
+// ComputeCost returns the cost breakdown of tx with the fees of cfg at
+// gasPrice. All gas is priced at gasPrice, while the network prices gas of
+// later receipts at the gas price of their block, so the actual execution
+// fee can deviate slightly. Costs of contract execution beyond the attached
+// gas, like the storage used by called contracts, are not included, and
+// neither are the fees of global contract actions, which the views of the
+// protocol config do not expose.
+func ComputeCost(tx *Transaction, cfg *rpc.RuntimeConfigView, gasPrice types.Balance) (*Cost, error) {
+	c := &Cost{GasPrice: gasPrice}
+	fees := &cfg.TransactionCosts
+	sir := tx.SignerID == tx.ReceiverID
+	c.SendGas = fees.ActionReceiptCreationConfig.Send(sir)
+	c.ExecutionGas = fees.ActionReceiptCreationConfig.Execution
+	storage, err := c.addActions(fees, sir, tx.Actions)
+	if err != nil {
+		return nil, err
+	}
+	c.StorageDeposit.Mul(new(big.Int).SetUint64(storage), &cfg.StorageAmountPerByte.Int)
+	c.ExecutionFee = rpc.GasCost(c.SendGas+c.ExecutionGas, gasPrice)
+	c.AttachedGasCost = rpc.GasCost(c.AttachedGas, gasPrice)
+	c.Total.Add(&c.ExecutionFee.Int, &c.AttachedGasCost.Int)
+	c.Total.Add(&c.Total.Int, &c.Deposit.Int)
+	return c, nil
+}
+
+// addActions adds the gas and deposits of actions to c and returns the
+// number of storage bytes they allocate.
+func (c *Cost) addActions(fees *rpc.RuntimeFeesConfigView, sir bool, actions []Action) (uint64, error) {
+	var storage uint64
+	for i := range actions {
+		a := &actions[i]
+		var fee rpc.Fee
+		var perByte rpc.Fee
+		var bytes uint64
+		cfg := &fees.ActionCreationConfig
+		switch a.Kind {
+		case ActionCreateAccount:
+			fee = cfg.CreateAccountCost
+			storage += fees.StorageUsageConfig.NumBytesAccount
+		case ActionDeployContract:
+			fee, perByte = cfg.DeployContractCost, cfg.DeployContractCostPerByte
+			bytes = uint64(len(a.DeployContract.Code))
+			storage += bytes
+		case ActionFunctionCall:
+			fee, perByte = cfg.FunctionCallCost, cfg.FunctionCallCostPerByte
+			bytes = uint64(len(a.FunctionCall.MethodName) + len(a.FunctionCall.Args))
+			c.AttachedGas += a.FunctionCall.Gas
+			c.Deposit.Add(&c.Deposit.Int, &a.FunctionCall.Deposit.Int)
+		case ActionTransfer:
+			fee = cfg.TransferCost
+			c.Deposit.Add(&c.Deposit.Int, &a.Transfer.Deposit.Int)
+		case ActionStake:
+			fee = cfg.StakeCost
+			c.Stake.Add(&c.Stake.Int, &a.Stake.Stake.Int)
+		case ActionAddKey:
+			fee = cfg.AddKeyCost.FullAccessCost
+			if p := a.AddKey.AccessKey.Permission; p.Kind == PermissionFunctionCall {
+				fee, perByte = cfg.AddKeyCost.FunctionCallCost, cfg.AddKeyCost.FunctionCallCostPerByte
+				// method names are accounted with a separator each
+				for _, m := range p.FunctionCall.MethodNames {
+					bytes += uint64(len(m)) + 1
+				}
+			}
+			n, err := keyStorage(&a.AddKey)
+			if err != nil {
+				return 0, err
+			}
+			storage += n + fees.StorageUsageConfig.NumExtraBytesRecord
+		case ActionDeleteKey:
+			fee = cfg.DeleteKeyCost
+		case ActionDeleteAccount:
+			fee = cfg.DeleteAccountCost
+		case ActionDelegate:
+			fee = cfg.DelegateCost
+			// the inner actions are executed in a receipt of their own
+			da := &a.Delegate.DelegateAction
+			innerSir := da.SenderID == da.ReceiverID
+			c.ExecutionGas += fees.ActionReceiptCreationConfig.Send(innerSir) + fees.ActionReceiptCreationConfig.Execution
+			inner := &Cost{}
+			n, err := inner.addActions(fees, innerSir, da.Actions)
+			if err != nil {
+				return 0, err
+			}
+			// the inner send fees are burned on execution of the delegate
+			c.ExecutionGas += inner.SendGas + inner.ExecutionGas
+			c.AttachedGas += inner.AttachedGas
+			c.Deposit.Add(&c.Deposit.Int, &inner.Deposit.Int)
+			c.Stake.Add(&c.Stake.Int, &inner.Stake.Int)
+			storage += n
+		}
+		c.SendGas += fee.Send(sir) + perByte.Send(sir)*bytes
+		c.ExecutionGas += fee.Execution + perByte.Execution*bytes
+	}
+	return storage, nil
+}
+
+// keyStorage returns the number of bytes of the Borsh encoded public key and
+// access key, which are stored for an access key.
+func keyStorage(a *AddKeyAction) (uint64, error) {
+	pk, err := borsh.Serialize(a.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	ak, err := borsh.Serialize(a.AccessKey)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(pk) + len(ak)), nil
+}
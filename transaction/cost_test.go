@@ -0,0 +1,85 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func testRuntimeConfig() *rpc.RuntimeConfigView {
+	cfg := &rpc.RuntimeConfigView{StorageAmountPerByte: types.NewBalance(big.NewInt(1))}
+	fees := &cfg.TransactionCosts
+	fees.ActionReceiptCreationConfig = rpc.Fee{SendSir: 1, SendNotSir: 2, Execution: 3}
+	fees.ActionCreationConfig.CreateAccountCost = rpc.Fee{SendSir: 10, SendNotSir: 20, Execution: 30}
+	fees.ActionCreationConfig.TransferCost = rpc.Fee{SendSir: 100, SendNotSir: 200, Execution: 300}
+	fees.ActionCreationConfig.AddKeyCost.FullAccessCost = rpc.Fee{SendSir: 1000, SendNotSir: 2000, Execution: 3000}
+	fees.ActionCreationConfig.FunctionCallCost = rpc.Fee{SendSir: 10000, SendNotSir: 20000, Execution: 30000}
+	fees.ActionCreationConfig.FunctionCallCostPerByte = rpc.Fee{SendSir: 1, SendNotSir: 2, Execution: 3}
+	fees.StorageUsageConfig = rpc.StorageUsageConfigView{NumBytesAccount: 100, NumExtraBytesRecord: 40}
+	return cfg
+}
+
+func TestComputeCost(t *testing.T) {
+	pk := testSigner(t).PublicKey()
+	tx := New("alice.testnet", pk, 1, "bob.alice.testnet", testBlockHash,
+		CreateAccount(),
+		Transfer(types.NewBalance(big.NewInt(10))),
+		AddKey(pk, FullAccessKey()),
+		FunctionCall("m", []byte("{}"), 100, types.NewBalance(big.NewInt(1))),
+	)
+	c, err := ComputeCost(tx, testRuntimeConfig(), types.NewBalance(big.NewInt(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// receipt, create account, transfer, add key, function call with 3 bytes
+	if want := uint64(2 + 20 + 200 + 2000 + 20000 + 2*3); c.SendGas != want {
+		t.Errorf("ComputeCost() returned send gas %d (want %d)", c.SendGas, want)
+	}
+	if want := uint64(3 + 30 + 300 + 3000 + 30000 + 3*3); c.ExecutionGas != want {
+		t.Errorf("ComputeCost() returned execution gas %d (want %d)", c.ExecutionGas, want)
+	}
+	for _, tt := range []struct {
+		name string
+		got  types.Balance
+		want int64
+	}{
+		{"execution fee", c.ExecutionFee, (22228 + 33342) * 2},
+		{"attached gas cost", c.AttachedGasCost, 200},
+		{"deposit", c.Deposit, 11},
+		// account, Ed25519 key with nonce and permission, extra record bytes
+		{"storage deposit", c.StorageDeposit, 100 + 33 + 9 + 40},
+		{"total", c.Total, 111140 + 200 + 11},
+	} {
+		if tt.got.Int64() != tt.want {
+			t.Errorf("ComputeCost() returned %s %s (want %d)", tt.name, tt.got.String(), tt.want)
+		}
+	}
+
+	// receipts to the signer use the cheaper send fees
+	tx = New("alice.testnet", pk, 1, "alice.testnet", testBlockHash, Transfer(types.NewBalance(big.NewInt(10))))
+	if c, err = ComputeCost(tx, testRuntimeConfig(), types.NewBalance(big.NewInt(2))); err != nil || c.SendGas != 101 {
+		t.Errorf("ComputeCost() to signer returned send gas %d, %v (want 101)", c.SendGas, err)
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+	srv.Handle("EXPERIMENTAL_protocol_config", rpctest.Result(map[string]interface{}{
+		"runtime_config": testRuntimeConfig(),
+	}))
+	srv.Handle("gas_price", rpctest.Result(map[string]interface{}{"gas_price": "2"}))
+	tx := New("alice.testnet", testSigner(t).PublicKey(), 1, "bob.testnet", testBlockHash,
+		Transfer(types.NewBalance(big.NewInt(10))))
+	c, err := EstimateCost(context.Background(), srv.Client(), tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.GasPrice.Int64() != 2 || c.Total.Int64() != (2+200+3+300)*2+10 {
+		t.Errorf("EstimateCost() returned gas price %s and total %s", c.GasPrice.String(), c.Total.String())
+	}
+}
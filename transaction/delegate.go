@@ -0,0 +1,161 @@
+package transaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// DelegateActionPrefix is the NEP-461 prefix of signed delegate actions,
+// 2^30 + 366, which keeps their signatures from being valid for
+// transactions.
+const DelegateActionPrefix uint32 = 1<<30 + 366
+
+// ErrNestedDelegate is returned for delegate actions which contain delegate
+// actions.
+var ErrNestedDelegate = errors.New("transaction: delegate action contains delegate action")
+
+// ErrInvalidSignature is returned if a signature does not verify.
+var ErrInvalidSignature = errors.New("transaction: invalid signature")
+
+// DelegateAction is a list of actions the sender signs to be executed on
+// the receiver by a relayer paying the gas (NEP-366 meta-transaction).
+type DelegateAction struct {
+	SenderID   string
+	ReceiverID string
+	// Actions must not contain delegate actions.
+	Actions []Action
+	// Nonce is the nonce of the access key of the sender, like the nonce of
+	// a transaction.
+	Nonce uint64
+	// MaxBlockHeight is the last block height the action can be included.
+	MaxBlockHeight uint64
+	PublicKey      utils.PublicKey
+}
+
+// SignedDelegateAction is a delegate action with the signature of its
+// sender. A relayer submits it with the Delegate action.
+type SignedDelegateAction struct {
+	DelegateAction DelegateAction
+	Signature      Signature
+}
+
+// NewDelegateAction returns a new delegate action of senderID with the
+// access key publicKey executing actions on receiverID, valid until
+// maxBlockHeight.
+func NewDelegateAction(senderID string, publicKey utils.PublicKey, nonce uint64, receiverID string, maxBlockHeight uint64, actions ...Action) *DelegateAction {
+	return &DelegateAction{
+		SenderID:       senderID,
+		ReceiverID:     receiverID,
+		Actions:        actions,
+		Nonce:          nonce,
+		MaxBlockHeight: maxBlockHeight,
+		PublicKey:      publicKey,
+	}
+}
+
+// Hash returns the hash signed by the sender: the sha256 hash of the
+// DelegateActionPrefix followed by the delegate action, both Borsh encoded.
+func (da *DelegateAction) Hash() ([32]byte, error) {
+	for _, a := range da.Actions {
+		if a.Kind == ActionDelegate {
+			return [32]byte{}, ErrNestedDelegate
+		}
+	}
+	data, err := borsh.Serialize(*da)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	prefix := binary.LittleEndian.AppendUint32(nil, DelegateActionPrefix)
+	return sha256.Sum256(append(prefix, data...)), nil
+}
+
+// SignDelegateAction signs da with s, which must have the public key of da.
+func SignDelegateAction(da *DelegateAction, s signer.Signer) (*SignedDelegateAction, error) {
+	if pk := s.PublicKey(); !pk.Equal(da.PublicKey) {
+		return nil, fmt.Errorf("transaction: signer key %s does not match delegate action key %s", pk, da.PublicKey)
+	}
+	hash, err := da.Hash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.SignBytes(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	signature, err := NewSignature(borsh.Enum(da.PublicKey.KeyType), sig)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedDelegateAction{DelegateAction: *da, Signature: signature}, nil
+}
+
+// Verify checks that sda is signed with its public key and contains no
+// delegate actions. It does not check whether the key belongs to the
+// sender.
+func (sda *SignedDelegateAction) Verify() error {
+	hash, err := sda.DelegateAction.Hash()
+	if err != nil {
+		return err
+	}
+	if !sda.Signature.Verify(sda.DelegateAction.PublicKey, hash[:]) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Serialize returns the Borsh encoding of sda, for transport to a relayer.
+func (sda *SignedDelegateAction) Serialize() ([]byte, error) {
+	return borsh.Serialize(*sda)
+}
+
+// DeserializeSignedDelegate decodes the Borsh encoded signed delegate action
+// data.
+func DeserializeSignedDelegate(data []byte) (*SignedDelegateAction, error) {
+	var sda SignedDelegateAction
+	if err := borsh.Deserialize(data, &sda); err != nil {
+		return nil, err
+	}
+	return &sda, nil
+}
+
+// Delegate returns an action executing the signed delegate action, the
+// receiver of its transaction must be the sender of the delegate action.
+func Delegate(sda SignedDelegateAction) Action {
+	return Action{Kind: ActionDelegate, Delegate: sda}
+}
+
+// SignDelegate returns a delegate action of the signer executing actions on
+// receiverID, which is valid for validFor blocks after the latest final
+// block. It is sent by a relayer with Relay.
+func (s *Sender) SignDelegate(ctx context.Context, receiverID string, validFor uint64, actions ...Action) (*SignedDelegateAction, error) {
+	pk := s.signer.PublicKey()
+	if err := validateAccountIDs(s.signer.AccountID(), receiverID, actions); err != nil {
+		return nil, err
+	}
+	nonce, err := s.nonces.Next(ctx, s.signer.AccountID(), pk)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.client.Block(ctx, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	da := NewDelegateAction(s.signer.AccountID(), pk, nonce, receiverID, block.Header.Height+validFor, actions...)
+	return SignDelegateAction(da, s.signer)
+}
+
+// Relay sends the signed delegate action sda of another account in a
+// transaction of the signer, which pays for the gas, and waits like Send. It
+// returns the hash of the transaction, which tracks it also if waitUntil is
+// NONE or INCLUDED and the outcome is empty.
+func (s *Sender) Relay(ctx context.Context, sda *SignedDelegateAction, waitUntil rpc.TxExecutionStatus) (string, *rpc.FinalExecutionOutcome, error) {
+	return s.send(ctx, sda.DelegateAction.SenderID, waitUntil, []Action{Delegate(*sda)})
+}
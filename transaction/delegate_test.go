@@ -0,0 +1,133 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestSignDelegateAction(t *testing.T) {
+	s := testSigner(t)
+	da := NewDelegateAction(s.AccountID(), s.PublicKey(), 3, "app.testnet", 100,
+		FunctionCall("play", []byte("{}"), 10, types.Balance{}))
+	sda, err := SignDelegateAction(da, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := borsh.Serialize(*da)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the u32 prefix 2^30 + 366 in little endian
+	msg, _ := hex.DecodeString("6e010040")
+	hash := sha256.Sum256(append(msg, data...))
+	if h, err := da.Hash(); err != nil || h != hash {
+		t.Errorf("Hash() returned %x, %v (want %x)", h, err, hash)
+	}
+	if !ed25519.Verify(s.PublicKey().Bytes(), hash[:], sda.Signature.Bytes()) {
+		t.Error("signature does not verify")
+	}
+
+	enc, err := sda.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DeserializeSignedDelegate(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reenc, err := got.Serialize(); err != nil || !bytes.Equal(reenc, enc) {
+		t.Errorf("DeserializeSignedDelegate() returned %+v (want %+v)", got, sda)
+	}
+
+	if err := got.Verify(); err != nil {
+		t.Errorf("Verify() returned %v", err)
+	}
+	got.DelegateAction.Nonce++
+	if err := got.Verify(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() of modified action returned %v (want ErrInvalidSignature)", err)
+	}
+
+	da.Actions = append(da.Actions, Delegate(*sda))
+	if _, err := SignDelegateAction(da, s); !errors.Is(err, ErrNestedDelegate) {
+		t.Errorf("SignDelegateAction() of nested delegate returned %v (want ErrNestedDelegate)", err)
+	}
+	da.PublicKey = testKey(1)
+	if _, err := SignDelegateAction(da, s); err == nil {
+		t.Error("SignDelegateAction() accepted signer with other key")
+	}
+}
+
+func TestRelay(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(testBlockHash[:]), "height": 1000},
+	}))
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	user := NewSender(srv.Client(), testSigner(t))
+	sda, err := user.SignDelegate(context.Background(), "app.testnet", 100,
+		Transfer(types.NewBalance(big.NewInt(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if da := sda.DelegateAction; da.Nonce != 6 || da.MaxBlockHeight != 1100 || da.ReceiverID != "app.testnet" {
+		t.Errorf("SignDelegate() returned %+v", da)
+	}
+
+	relayer := testSigner(t)
+	hash, _, err := NewSender(srv.Client(), relayer).Relay(context.Background(), sda, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := sentTransaction(t, srv.RequestsFor("send_tx")[0])
+	if sentHash, _ := st.Hash(); hash != sentHash {
+		t.Errorf("Relay() returned hash %s (want %s)", hash, sentHash)
+	}
+	tx := st.Transaction
+	if !tx.PublicKey.Equal(relayer.PublicKey()) || tx.ReceiverID != "alice.testnet" {
+		t.Errorf("Relay() sent transaction of %s to %s", tx.PublicKey, tx.ReceiverID)
+	}
+	if len(tx.Actions) != 1 || tx.Actions[0].Kind != ActionDelegate || tx.Actions[0].Delegate.Signature != sda.Signature {
+		t.Errorf("Relay() sent actions %+v", tx.Actions)
+	}
+}
+
+func TestVerifySecp256k1(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := signer.NewSecp256k1Signer("alice.testnet", priv)
+	sda, err := SignDelegateAction(NewDelegateAction(s.AccountID(), s.PublicKey(), 1, "app.testnet", 10, CreateAccount()), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sda.Verify(); err != nil {
+		t.Errorf("Verify() returned %v", err)
+	}
+	// recovery IDs above 3 are invalid, btcec would read them as the flag
+	// of compressed keys
+	valid := sda.Signature
+	sda.Signature.SECP256K1.Data[64] += 4
+	if err := sda.Verify(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() with recovery ID %d returned %v (want ErrInvalidSignature)", sda.Signature.SECP256K1.Data[64], err)
+	}
+	sda.Signature = valid
+	sda.DelegateAction.PublicKey = testSigner(t).PublicKey()
+	if err := sda.Verify(); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() with other key returned %v (want ErrInvalidSignature)", err)
+	}
+}
@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// NonceManager hands out the nonces of access keys. It fetches the nonce of
+// a key once and counts locally afterwards, so multiple transactions of a
+// key can be in flight at the same time. It is safe for concurrent use.
+type NonceManager struct {
+	client *rpc.Client
+	mu     sync.Mutex
+	keys   map[nonceKey]*nonceEntry
+}
+
+type nonceKey struct {
+	accountID string
+	publicKey string
+}
+
+type nonceEntry struct {
+	mu         sync.Mutex
+	synced     bool
+	nonce      uint64 // last used nonce
+	permission rpc.AccessKeyPermissionView
+}
+
+// NewNonceManager returns a new nonce manager fetching the nonces of access
+// keys via client.
+func NewNonceManager(client *rpc.Client) *NonceManager {
+	return &NonceManager{client: client, keys: make(map[nonceKey]*nonceEntry)}
+}
+
+func (m *NonceManager) entry(accountID string, publicKey utils.PublicKey) *nonceEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := nonceKey{accountID: accountID, publicKey: publicKey.String()}
+	e, ok := m.keys[k]
+	if !ok {
+		e = new(nonceEntry)
+		m.keys[k] = e
+	}
+	return e
+}
+
+// Next returns the next nonce of the access key of accountID with
+// publicKey. The nonce of the key is fetched with the first call and after
+// Reset.
+func (m *NonceManager) Next(ctx context.Context, accountID string, publicKey utils.PublicKey) (uint64, error) {
+	e := m.entry(accountID, publicKey)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := m.sync(ctx, e, accountID, publicKey); err != nil {
+		return 0, err
+	}
+	e.nonce++
+	return e.nonce, nil
+}
+
+// Permission returns the permission of the access key of accountID with
+// publicKey. It is fetched along with the nonce, see Next.
+func (m *NonceManager) Permission(ctx context.Context, accountID string, publicKey utils.PublicKey) (rpc.AccessKeyPermissionView, error) {
+	e := m.entry(accountID, publicKey)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := m.sync(ctx, e, accountID, publicKey); err != nil {
+		return rpc.AccessKeyPermissionView{}, err
+	}
+	return e.permission, nil
+}
+
+// sync fetches the access key of e if it is not synced, e must be locked.
+func (m *NonceManager) sync(ctx context.Context, e *nonceEntry, accountID string, publicKey utils.PublicKey) error {
+	if e.synced {
+		return nil
+	}
+	ak, err := m.client.ViewAccessKey(ctx, accountID, publicKey.String(), rpc.Final())
+	if err != nil {
+		return err
+	}
+	// keep nonces handed out before a reset
+	if ak.Nonce > e.nonce {
+		e.nonce = ak.Nonce
+	}
+	e.permission = ak.Permission
+	e.synced = true
+	return nil
+}
+
+// Update records that the access key of accountID with publicKey has at
+// least the nonce, for example from an InvalidNonce error.
+func (m *NonceManager) Update(accountID string, publicKey utils.PublicKey, nonce uint64) {
+	e := m.entry(accountID, publicKey)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if nonce > e.nonce {
+		e.nonce = nonce
+	}
+}
+
+// Reset makes the next call of Next or Permission fetch the access key of
+// accountID with publicKey again.
+func (m *NonceManager) Reset(accountID string, publicKey utils.PublicKey) {
+	e := m.entry(accountID, publicKey)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.synced = false
+}
+
+// invalidNonce returns the nonce of the access key if err is an
+// InvalidNonce error of a rejected transaction.
+func invalidNonce(err error) (uint64, bool) {
+	var info struct {
+		AkNonce uint64 `json:"ak_nonce"`
+	}
+	e, ok := rpc.AsInvalidTxError(err)
+	if !ok || e.Kind != rpc.InvalidTxInvalidNonce || e.DecodeInfo(&info) != nil {
+		return 0, false
+	}
+	return info.AkNonce, true
+}
@@ -0,0 +1,107 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+)
+
+func TestNonceManager(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	m := NewNonceManager(srv.Client())
+	ctx := context.Background()
+	pk := testKey(1)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[uint64]bool)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := m.Next(ctx, "alice.testnet", pk)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			seen[n] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	for n := uint64(6); n <= 15; n++ {
+		if !seen[n] {
+			t.Errorf("Next() did not return nonce %d", n)
+		}
+	}
+	if n := len(srv.RequestsFor("query")); n != 1 {
+		t.Errorf("Next() fetched the nonce %d times (want 1)", n)
+	}
+
+	// other keys are independent
+	if n, err := m.Next(ctx, "alice.testnet", testKey(2)); err != nil || n != 6 {
+		t.Errorf("Next() of other key returned %d, %v (want 6)", n, err)
+	}
+
+	m.Update("alice.testnet", pk, 20)
+	if n, err := m.Next(ctx, "alice.testnet", pk); err != nil || n != 21 {
+		t.Errorf("Next() after Update() returned %d, %v (want 21)", n, err)
+	}
+	// nonces handed out are not reused after a reset
+	m.Reset("alice.testnet", pk)
+	if n, err := m.Next(ctx, "alice.testnet", pk); err != nil || n != 22 {
+		t.Errorf("Next() after Reset() returned %d, %v (want 22)", n, err)
+	}
+	if n := len(srv.RequestsFor("query")); n != 3 {
+		t.Errorf("Next() fetched the nonce %d times (want 3)", n)
+	}
+}
+
+func invalidNonceError(txNonce, akNonce uint64) *rpc.Error {
+	err := rpctest.HandlerError("INVALID_TRANSACTION")
+	err.Cause.Info, _ = json.Marshal(map[string]interface{}{
+		"TxExecutionError": map[string]interface{}{
+			"InvalidTxError": map[string]interface{}{
+				"InvalidNonce": map[string]uint64{"tx_nonce": txNonce, "ak_nonce": akNonce},
+			},
+		},
+	})
+	return err
+}
+
+func TestSendInvalidNonce(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Sequence(
+		rpctest.Fail(invalidNonceError(6, 9)),
+		rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}),
+	))
+	srv.Handle("tx", rpctest.Fail(rpctest.HandlerError("UNKNOWN_TRANSACTION")))
+	s := NewSender(srv.Client(), testSigner(t))
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 2 {
+		t.Fatalf("Send() made %d send_tx calls (want 2)", len(reqs))
+	}
+	if n := sentTransaction(t, reqs[1]).Transaction.Nonce; n != 10 {
+		t.Errorf("Send() retried with nonce %d (want 10)", n)
+	}
+
+	// retries are limited
+	srv.Handle("send_tx", rpctest.Fail(invalidNonceError(6, 9)))
+	s = NewSenderWithOptions(srv.Client(), testSigner(t), SenderOptions{Retries: -1})
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); err == nil {
+		t.Error("Send() succeeded with invalid nonce")
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 3 {
+		t.Errorf("Send() without retries made %d send_tx calls (want 1)", n-2)
+	}
+}
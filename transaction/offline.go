@@ -0,0 +1,83 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/crypto"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcutil/base58"
+	nearborsh "github.com/near/borsh-go"
+)
+
+// Base64 returns the base64 encoding of the Borsh encoded tx, for transport
+// to an offline signer.
+func (tx *Transaction) Base64() (string, error) {
+	data, err := tx.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DeserializeBase64 decodes the base64 encoding of a Borsh encoded
+// transaction.
+func DeserializeBase64(s string) (*Transaction, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("transaction: invalid base64: %w", err)
+	}
+	return Deserialize(data)
+}
+
+// DeserializeSignedBase64 decodes the base64 encoding of a Borsh encoded
+// signed transaction as returned by SignedTransaction.Base64.
+func DeserializeSignedBase64(s string) (*SignedTransaction, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("transaction: invalid base64: %w", err)
+	}
+	return DeserializeSigned(data)
+}
+
+// NewSignedTransaction attaches the signature sig to tx after checking that
+// it is a valid signature of tx by its public key.
+func NewSignedTransaction(tx *Transaction, sig Signature) (*SignedTransaction, error) {
+	st := &SignedTransaction{Transaction: *tx, Signature: sig}
+	if err := st.Verify(); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// String returns the signature in the "<key type>:<base58>" format.
+func (s Signature) String() string {
+	return utils.KeyTypeName(nearborsh.Enum(s.KeyType)) + ":" + base58.Encode(s.Bytes())
+}
+
+// MarshalText implements encoding.TextMarshaler, which is used for JSON as
+// well.
+func (s Signature) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Signature) UnmarshalText(text []byte) error {
+	sig, err := ParseSignature(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sig
+	return nil
+}
+
+// ParseSignature parses a signature in the "<key type>:<base58>" format, see
+// crypto.ParseSignature.
+func ParseSignature(s string) (Signature, error) {
+	sig, err := crypto.ParseSignature(s)
+	if err != nil {
+		return Signature{}, err
+	}
+	return NewSignature(borsh.Enum(sig.KeyType), sig.Data)
+}
@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestOfflineSigning(t *testing.T) {
+	s := testSigner(t)
+
+	// online: build and export
+	tx := New(s.AccountID(), s.PublicKey(), 42, "treasury.testnet", testBlockHash,
+		Transfer(types.NewBalance(big.NewInt(5))))
+	exported, err := tx.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// offline: import, sign and export the signature
+	imported, err := DeserializeBase64(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := SignTransaction(imported, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigText := st.Signature.String()
+
+	// online: attach the signature and broadcast
+	sig, err := ParseSignature(sigText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := NewSignedTransaction(tx, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	if _, err := NewSender(srv.Client(), s).SendSigned(context.Background(), signed, ""); err != nil {
+		t.Fatal(err)
+	}
+	sent := sentTransaction(t, srv.RequestsFor("send_tx")[0])
+	if sent.Signature != sig || sent.Transaction.Nonce != 42 {
+		t.Errorf("SendSigned() sent %+v", sent)
+	}
+
+	// signatures of other transactions are rejected
+	tx.Nonce++
+	if _, err := NewSignedTransaction(tx, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("NewSignedTransaction() of modified transaction returned %v (want ErrInvalidSignature)", err)
+	}
+
+	b64, err := signed.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := DeserializeSignedBase64(b64); err != nil || got.Signature != sig {
+		t.Errorf("DeserializeSignedBase64() returned %+v, %v", got, err)
+	}
+}
+
+func TestSignatureText(t *testing.T) {
+	sig, err := NewSignature(0, make([]byte, 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig.ED25519.Data[0] = 1
+	data, err := json.Marshal(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Signature
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != sig {
+		t.Errorf("json.Unmarshal() returned %s (want %s)", got, sig)
+	}
+	for _, s := range []string{"", "ed25519", "rsa:abc", "ed25519:abc"} {
+		if _, err := ParseSignature(s); err == nil {
+			t.Errorf("ParseSignature(%q) succeeded", s)
+		}
+	}
+	if _, err := DeserializeBase64("!"); err == nil {
+		t.Error("DeserializeBase64() accepted invalid base64")
+	}
+}
@@ -0,0 +1,57 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+// ErrAccessKeyPermission is returned (wrapped) for transactions the access
+// key of the signer is not allowed to sign.
+var ErrAccessKeyPermission = errors.New("transaction: not permitted by access key")
+
+// CheckPermission checks whether an access key with permission may sign a
+// transaction executing actions on receiverID, like the network does on
+// submission. Function call access keys may only sign a single function
+// call without deposit of an allowed method on their receiver.
+//
+// If gasPrice is not nil, the cost of the attached gas is checked against
+// the remaining allowance. The network charges the transaction fees from
+// the allowance as well, so a transaction passing the check can still be
+// rejected for a nearly exhausted allowance.
+func CheckPermission(permission rpc.AccessKeyPermissionView, receiverID string, gasPrice *types.Balance, actions ...Action) error {
+	p := permission.FunctionCall
+	if p == nil {
+		return nil
+	}
+	if len(actions) != 1 || actions[0].Kind != ActionFunctionCall {
+		return fmt.Errorf("%w: function call access keys can only sign a single function call", ErrAccessKeyPermission)
+	}
+	fc := actions[0].FunctionCall
+	if fc.Deposit.Sign() != 0 {
+		return fmt.Errorf("%w: function call access keys cannot attach a deposit", ErrAccessKeyPermission)
+	}
+	if receiverID != p.ReceiverID {
+		return fmt.Errorf("%w: receiver %s is not %s", ErrAccessKeyPermission, receiverID, p.ReceiverID)
+	}
+	if len(p.MethodNames) > 0 && !contains(p.MethodNames, fc.MethodName) {
+		return fmt.Errorf("%w: method %s is not allowed", ErrAccessKeyPermission, fc.MethodName)
+	}
+	if p.Allowance != nil && gasPrice != nil {
+		if cost := rpc.GasCost(fc.Gas, *gasPrice); cost.Cmp(&p.Allowance.Int) > 0 {
+			return fmt.Errorf("%w: gas costs %s yoctoNEAR, but the allowance is %s", ErrAccessKeyPermission, cost.String(), p.Allowance.String())
+		}
+	}
+	return nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+)
+
+func TestCheckPermission(t *testing.T) {
+	allowance := types.NewBalance(big.NewInt(1000))
+	p := rpc.AccessKeyPermissionView{FunctionCall: &rpc.FunctionCallPermissionView{
+		Allowance:   &allowance,
+		ReceiverID:  "game.testnet",
+		MethodNames: []string{"play"},
+	}}
+	gasPrice := types.NewBalance(big.NewInt(10))
+	play := FunctionCall("play", nil, 100, types.Balance{})
+	for _, tt := range []struct {
+		receiverID string
+		actions    []Action
+		ok         bool
+	}{
+		{"game.testnet", []Action{play}, true},
+		{"game.testnet", []Action{FunctionCall("play", nil, 101, types.Balance{})}, false},
+		{"game.testnet", []Action{FunctionCall("play", nil, 1, types.NewBalance(big.NewInt(1)))}, false},
+		{"game.testnet", []Action{FunctionCall("withdraw", nil, 1, types.Balance{})}, false},
+		{"other.testnet", []Action{play}, false},
+		{"game.testnet", []Action{play, play}, false},
+		{"game.testnet", []Action{Transfer(types.NewBalance(big.NewInt(1)))}, false},
+		{"game.testnet", nil, false},
+	} {
+		err := CheckPermission(p, tt.receiverID, &gasPrice, tt.actions...)
+		if tt.ok && err != nil || !tt.ok && !errors.Is(err, ErrAccessKeyPermission) {
+			t.Errorf("CheckPermission(%s, %+v) returned %v (want ok %v)", tt.receiverID, tt.actions, err, tt.ok)
+		}
+	}
+
+	// the allowance is only checked with a gas price
+	if err := CheckPermission(p, "game.testnet", nil, FunctionCall("play", nil, 101, types.Balance{})); err != nil {
+		t.Errorf("CheckPermission() without gas price returned %v", err)
+	}
+	// any method is allowed without method names and any allowance without limit
+	p.FunctionCall.MethodNames, p.FunctionCall.Allowance = nil, nil
+	if err := CheckPermission(p, "game.testnet", &gasPrice, FunctionCall("withdraw", nil, 1e15, types.Balance{})); err != nil {
+		t.Errorf("CheckPermission() without restrictions returned %v", err)
+	}
+	if err := CheckPermission(rpc.AccessKeyPermissionView{}, "bob.testnet", nil, play, Transfer(types.NewBalance(big.NewInt(1)))); err != nil {
+		t.Errorf("CheckPermission() of full access key returned %v", err)
+	}
+}
+
+func TestSendFunctionCallKey(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce": 5,
+		"permission": map[string]interface{}{"FunctionCall": map[string]interface{}{
+			"allowance":    "1000",
+			"receiver_id":  "game.testnet",
+			"method_names": []string{},
+		}},
+	}))
+	srv.Handle("gas_price", rpctest.Result(map[string]interface{}{"gas_price": "10"}))
+	srv.Handle("block", blockResult(testBlockHash))
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	s := NewSender(srv.Client(), testSigner(t))
+
+	if _, err := s.Call(context.Background(), "game.testnet", "play", map[string]int{"move": 1}, 100, ""); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 1 {
+		t.Fatalf("Call() made %d send_tx calls (want 1)", len(reqs))
+	}
+	tx := sentTransaction(t, reqs[0]).Transaction
+	if fc := tx.Actions[0].FunctionCall; fc.MethodName != "play" || string(fc.Args) != `{"move":1}` || fc.Deposit.Sign() != 0 {
+		t.Errorf("Call() sent %+v", fc)
+	}
+
+	for _, actions := range [][]Action{
+		{Transfer(types.NewBalance(big.NewInt(1)))},
+		{FunctionCall("play", nil, 101, types.Balance{})},
+	} {
+		if _, err := s.Send(context.Background(), "game.testnet", "", actions...); !errors.Is(err, ErrAccessKeyPermission) {
+			t.Errorf("Send(%+v) returned %v (want ErrAccessKeyPermission)", actions, err)
+		}
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 1 {
+		t.Errorf("Send() sent %d rejected transactions (want 0)", n-1)
+	}
+}
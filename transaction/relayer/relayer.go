@@ -0,0 +1,277 @@
+// Package relayer implements an HTTP service relaying NEP-366 signed
+// delegate actions: it wraps them in transactions of its own funding keys,
+// so users can execute actions without paying for gas.
+//
+// The protocol consists of one JSON endpoint:
+//
+//	POST <endpoint>/v1/relay {"signed_delegate_action": "<base64 Borsh>"}
+//	     -> {"transaction_hash": "...", "outcome": {...}}
+//
+// Errors are returned as {"error": "..."} with an HTTP error status.
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ErrNotAllowed is returned for delegate actions of senders or to
+	// receivers which are not allowed.
+	ErrNotAllowed = errors.New("relayer: not allowed")
+	// ErrGasLimit is returned if a delegate action exceeds the gas limit
+	// of its sender.
+	ErrGasLimit = errors.New("relayer: gas limit exceeded")
+)
+
+// Config configures a relayer.
+type Config struct {
+	// Client sends the transactions.
+	Client *rpc.Client
+	// Signers are the funding keys paying for the gas, transactions are
+	// distributed round-robin over them. At least one is required.
+	Signers []signer.Signer
+	// AllowedSenders are the accounts whose delegate actions are relayed,
+	// all if empty.
+	AllowedSenders []string
+	// AllowedReceivers are the accounts delegate actions may be executed
+	// on, all if empty.
+	AllowedReceivers []string
+	// GasLimit is the total gas attached to function calls the relayer
+	// pays for per sender, unlimited if zero.
+	GasLimit uint64
+	// WaitUntil is the execution level the relayer waits for before
+	// responding, the node default if empty.
+	WaitUntil rpc.TxExecutionStatus
+	// Registerer registers the metrics of the relayer if not nil:
+	//
+	//   - near_relayer_requests_total: relay requests by result (relayed,
+	//     rejected or failed)
+	//   - near_relayer_gas_total: gas attached to relayed function calls
+	Registerer prometheus.Registerer
+}
+
+type relayRequest struct {
+	SignedDelegateAction []byte `json:"signed_delegate_action"`
+}
+
+type relayResponse struct {
+	TransactionHash string                     `json:"transaction_hash"`
+	Outcome         *rpc.FinalExecutionOutcome `json:"outcome"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler is an http.Handler relaying signed delegate actions. It is safe
+// for concurrent use.
+type Handler struct {
+	senders   []*transaction.Sender
+	next      uint32
+	senderIDs map[string]bool
+	receivers map[string]bool
+	gasLimit  uint64
+	waitUntil rpc.TxExecutionStatus
+	mux       *http.ServeMux
+
+	mtx     sync.Mutex
+	gasUsed map[string]uint64
+
+	requests *prometheus.CounterVec
+	gas      prometheus.Counter
+}
+
+// NewHandler returns a new relayer configured by cfg.
+func NewHandler(cfg Config) (*Handler, error) {
+	if len(cfg.Signers) == 0 {
+		return nil, errors.New("relayer: no funding keys")
+	}
+	h := &Handler{
+		senderIDs: set(cfg.AllowedSenders),
+		receivers: set(cfg.AllowedReceivers),
+		gasLimit:  cfg.GasLimit,
+		waitUntil: cfg.WaitUntil,
+		mux:       http.NewServeMux(),
+		gasUsed:   make(map[string]uint64),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "near_relayer_requests_total",
+			Help: "Number of relay requests.",
+		}, []string{"result"}),
+		gas: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "near_relayer_gas_total",
+			Help: "Gas attached to relayed function calls.",
+		}),
+	}
+	for _, s := range cfg.Signers {
+		h.senders = append(h.senders, transaction.NewSender(cfg.Client, s))
+	}
+	if cfg.Registerer != nil {
+		for _, c := range []prometheus.Collector{h.requests, h.gas} {
+			if err := cfg.Registerer.Register(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	h.mux.HandleFunc("/v1/relay", h.relay)
+	return h, nil
+}
+
+func set(ids []string) map[string]bool {
+	if len(ids) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		m[id] = true
+	}
+	return m
+}
+
+// attachedGas returns the gas attached to the function calls of da, false
+// if the sum overflows.
+func attachedGas(da *transaction.DelegateAction) (uint64, bool) {
+	var gas uint64
+	for _, a := range da.Actions {
+		if a.Kind == transaction.ActionFunctionCall {
+			if gas+a.FunctionCall.Gas < gas {
+				return 0, false
+			}
+			gas += a.FunctionCall.Gas
+		}
+	}
+	return gas, true
+}
+
+// check verifies sda and reserves its gas, which has to be released with
+// release if it is not relayed.
+func (h *Handler) check(sda *transaction.SignedDelegateAction) (uint64, error) {
+	if err := sda.Verify(); err != nil {
+		return 0, err
+	}
+	da := &sda.DelegateAction
+	if h.senderIDs != nil && !h.senderIDs[da.SenderID] {
+		return 0, fmt.Errorf("%w: sender %s", ErrNotAllowed, da.SenderID)
+	}
+	if h.receivers != nil && !h.receivers[da.ReceiverID] {
+		return 0, fmt.Errorf("%w: receiver %s", ErrNotAllowed, da.ReceiverID)
+	}
+	gas, ok := attachedGas(da)
+	if !ok {
+		return 0, fmt.Errorf("%w: sender %s attached gas overflows", ErrGasLimit, da.SenderID)
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	used := h.gasUsed[da.SenderID]
+	if used+gas < used || (h.gasLimit != 0 && used+gas > h.gasLimit) {
+		return 0, fmt.Errorf("%w: sender %s", ErrGasLimit, da.SenderID)
+	}
+	h.gasUsed[da.SenderID] += gas
+	return gas, nil
+}
+
+func (h *Handler) release(senderID string, gas uint64) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.gasUsed[senderID] -= gas
+}
+
+// GasUsed returns the gas attached to relayed function calls of senderID,
+// including those of failed relays which may have been executed.
+func (h *Handler) GasUsed(senderID string) uint64 {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.gasUsed[senderID]
+}
+
+// Relay checks and relays sda and returns the hash and outcome of the
+// transaction, see transaction.Sender.Relay. Errors of checks match
+// ErrNotAllowed, ErrGasLimit or the verification errors of
+// SignedDelegateAction.Verify. The gas of a failed relay is only released if
+// its transaction was not sent or rejected as invalid, otherwise, e.g. after
+// a timeout, it may have been executed and stays reserved.
+func (h *Handler) Relay(ctx context.Context, sda *transaction.SignedDelegateAction) (string, *rpc.FinalExecutionOutcome, error) {
+	gas, err := h.check(sda)
+	if err != nil {
+		h.requests.WithLabelValues("rejected").Inc()
+		return "", nil, err
+	}
+	s := h.senders[int(atomic.AddUint32(&h.next, 1)-1)%len(h.senders)]
+	hash, outcome, err := s.Relay(ctx, sda, h.waitUntil)
+	if err != nil {
+		// the gas stays reserved if the transaction may have been executed,
+		// e.g. after a timeout
+		if unsubmitted(hash, err) {
+			h.release(sda.DelegateAction.SenderID, gas)
+		}
+		h.requests.WithLabelValues("failed").Inc()
+		return hash, nil, err
+	}
+	h.requests.WithLabelValues("relayed").Inc()
+	h.gas.Add(float64(gas))
+	return hash, outcome, nil
+}
+
+// unsubmitted reports whether the transaction with hash which failed with
+// err was certainly not executed: it was never sent (no hash), or it was
+// rejected as invalid by the node.
+func unsubmitted(hash string, err error) bool {
+	_, invalid := rpc.AsInvalidTxError(err)
+	return hash == "" || invalid
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) relay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var req relayRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sda, err := transaction.DeserializeSignedDelegate(req.SignedDelegateAction)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	hash, outcome, err := h.Relay(r.Context(), sda)
+	switch {
+	case errors.Is(err, ErrNotAllowed), errors.Is(err, ErrGasLimit):
+		writeError(w, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, transaction.ErrInvalidSignature), errors.Is(err, transaction.ErrNestedDelegate):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	writeJSON(w, &relayResponse{TransactionHash: hash, Outcome: outcome})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: msg})
+}
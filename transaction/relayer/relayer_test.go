@@ -0,0 +1,226 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func testSigner(t *testing.T, accountID string) signer.Signer {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewEd25519Signer(accountID, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newTestServer() *rpctest.Server {
+	srv := rpctest.NewServer()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce":      1,
+		"permission": "FullAccess",
+	}))
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(make([]byte, 32)), "height": 10},
+	}))
+	// the outcome is empty like for wait_until INCLUDED
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "INCLUDED",
+	}))
+	return srv
+}
+
+func signedDelegate(t *testing.T, s signer.Signer, receiverID string, gas uint64) *transaction.SignedDelegateAction {
+	da := transaction.NewDelegateAction(s.AccountID(), s.PublicKey(), 2, receiverID, 100,
+		transaction.FunctionCall("play", []byte("{}"), gas, types.Balance{}))
+	sda, err := transaction.SignDelegateAction(da, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sda
+}
+
+func post(t *testing.T, url string, sda *transaction.SignedDelegateAction) (int, map[string]interface{}) {
+	data, err := sda.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(&relayRequest{SignedDelegateAction: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(url+"/v1/relay", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var res map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, res
+}
+
+func TestRelay(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	funders := []signer.Signer{testSigner(t, "relayer.testnet"), testSigner(t, "relayer.testnet")}
+	reg := prometheus.NewRegistry()
+	h, err := NewHandler(Config{
+		Client:           srv.Client(),
+		Signers:          funders,
+		AllowedReceivers: []string{"app.testnet"},
+		GasLimit:         100,
+		Registerer:       reg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	user := testSigner(t, "alice.testnet")
+	var hashes []interface{}
+	for i := 0; i < 2; i++ {
+		status, res := post(t, ts.URL, signedDelegate(t, user, "app.testnet", 50))
+		if status != http.StatusOK {
+			t.Errorf("relay returned %d %v", status, res)
+		}
+		hashes = append(hashes, res["transaction_hash"])
+	}
+	// the funding keys are used round-robin
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 2 {
+		t.Fatalf("relayer sent %d transactions (want 2)", len(reqs))
+	}
+	for i, req := range reqs {
+		var p struct {
+			SignedTx []byte `json:"signed_tx_base64"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			t.Fatal(err)
+		}
+		st, err := transaction.DeserializeSigned(p.SignedTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !st.Transaction.PublicKey.Equal(funders[i].PublicKey()) || st.Transaction.ReceiverID != "alice.testnet" {
+			t.Errorf("transaction %d signed with %s for %s", i, st.Transaction.PublicKey, st.Transaction.ReceiverID)
+		}
+		if hash, err := st.Hash(); err != nil || hashes[i] != hash {
+			t.Errorf("relay %d returned hash %v (want %s)", i, hashes[i], hash)
+		}
+	}
+	if gas := h.GasUsed("alice.testnet"); gas != 100 {
+		t.Errorf("GasUsed() returned %d (want 100)", gas)
+	}
+
+	if status, _ := post(t, ts.URL, signedDelegate(t, user, "app.testnet", 1)); status != http.StatusForbidden {
+		t.Errorf("relay beyond gas limit returned %d (want 403)", status)
+	}
+	if status, _ := post(t, ts.URL, signedDelegate(t, user, "other.testnet", 1)); status != http.StatusForbidden {
+		t.Errorf("relay to other receiver returned %d (want 403)", status)
+	}
+	sda := signedDelegate(t, testSigner(t, "bob.testnet"), "app.testnet", 1)
+	sda.DelegateAction.Nonce++
+	if status, _ := post(t, ts.URL, sda); status != http.StatusBadRequest {
+		t.Errorf("relay with invalid signature returned %d (want 400)", status)
+	}
+
+	if n := testutil.ToFloat64(h.requests.WithLabelValues("relayed")); n != 2 {
+		t.Errorf("near_relayer_requests_total{result=relayed} is %v (want 2)", n)
+	}
+	if n := testutil.ToFloat64(h.requests.WithLabelValues("rejected")); n != 3 {
+		t.Errorf("near_relayer_requests_total{result=rejected} is %v (want 3)", n)
+	}
+	if n := testutil.ToFloat64(h.gas); n != 100 {
+		t.Errorf("near_relayer_gas_total is %v (want 100)", n)
+	}
+}
+
+func TestRelayGasOverflow(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	h, err := NewHandler(Config{
+		Client:   srv.Client(),
+		Signers:  []signer.Signer{testSigner(t, "relayer.testnet")},
+		GasLimit: 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := testSigner(t, "alice.testnet")
+	// the attached gas wraps around to 99
+	call := transaction.FunctionCall("play", []byte("{}"), 1<<63, types.Balance{})
+	da := transaction.NewDelegateAction(user.AccountID(), user.PublicKey(), 2, "app.testnet", 100,
+		call, call, transaction.FunctionCall("play", []byte("{}"), 99, types.Balance{}))
+	sda, err := transaction.SignDelegateAction(da, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := h.Relay(context.Background(), sda); !errors.Is(err, ErrGasLimit) {
+		t.Errorf("Relay() with overflowing gas returned %v (want ErrGasLimit)", err)
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 0 || h.GasUsed("alice.testnet") != 0 {
+		t.Errorf("Relay() with overflowing gas made %d send_tx calls and reserved %d gas", n, h.GasUsed("alice.testnet"))
+	}
+}
+
+func TestRelayFailure(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Fail(errors.New("node down")))
+	h, err := NewHandler(Config{
+		Client:         srv.Client(),
+		Signers:        []signer.Signer{testSigner(t, "relayer.testnet")},
+		AllowedSenders: []string{"alice.testnet"},
+		GasLimit:       10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := testSigner(t, "alice.testnet")
+	invalidErr := rpctest.HandlerError("INVALID_TRANSACTION")
+	invalidErr.Cause.Info = json.RawMessage(`{"TxExecutionError":{"InvalidTxError":{"NotEnoughBalance":{"signer_id":"relayer.testnet","balance":"0","cost":"1"}}}}`)
+	srv.Handle("send_tx", rpctest.Fail(invalidErr))
+	if _, _, err := h.Relay(context.Background(), signedDelegate(t, user, "app.testnet", 10)); err == nil {
+		t.Fatal("Relay() succeeded with invalid transaction")
+	}
+	// the gas of rejected transactions is released
+	if gas := h.GasUsed("alice.testnet"); gas != 0 {
+		t.Errorf("GasUsed() after rejection returned %d (want 0)", gas)
+	}
+	srv.Handle("send_tx", rpctest.Fail(errors.New("node down")))
+	if _, _, err := h.Relay(context.Background(), signedDelegate(t, user, "app.testnet", 10)); err == nil {
+		t.Fatal("Relay() succeeded with failing node")
+	}
+	// the transaction may have been executed, its gas stays reserved
+	if gas := h.GasUsed("alice.testnet"); gas != 10 {
+		t.Errorf("GasUsed() after failure returned %d (want 10)", gas)
+	}
+	_, _, err = h.Relay(context.Background(), signedDelegate(t, testSigner(t, "bob.testnet"), "app.testnet", 1))
+	if !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("Relay() of other sender returned %v (want ErrNotAllowed)", err)
+	}
+
+	if _, err := NewHandler(Config{Client: srv.Client()}); err == nil {
+		t.Error("NewHandler() accepted config without funding keys")
+	}
+}
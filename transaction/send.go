@@ -0,0 +1,279 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// DefaultRetries is the default number of times a transaction is rebuilt
+// after it was rejected as expired, with an invalid nonce or because its
+// shard is congested.
+const DefaultRetries = 3
+
+// DefaultRetryBackoff is the default delay before a transaction rejected
+// because its shard is congested is sent again.
+const DefaultRetryBackoff = time.Second
+
+// SenderOptions configure a Sender.
+type SenderOptions struct {
+	// Nonces hands out the nonces of the transactions, it can be shared by
+	// multiple senders of the same key. If nil the sender uses its own.
+	Nonces *NonceManager
+	// Retries is the number of times a transaction rejected as expired,
+	// with an invalid nonce or because its shard is congested is rebuilt
+	// and sent again. Zero selects DefaultRetries, a negative value disables
+	// retries.
+	Retries int
+	// RetryBackoff is the delay before a transaction rejected because its
+	// shard is congested is sent again, doubled after each rejection. Zero
+	// selects DefaultRetryBackoff.
+	RetryBackoff time.Duration
+	// BlockHashes caches the block hash of the transactions, it can be
+	// shared by multiple senders. If nil the sender uses its own cache with
+	// DefaultBlockHashMaxAge.
+	BlockHashes *BlockHashCache
+}
+
+// Sender builds, signs and sends the transactions of a signer. It is safe for
+// concurrent use, the nonces are counted locally and resynced if the network
+// rejects a transaction with an invalid nonce.
+type Sender struct {
+	client       *rpc.Client
+	signer       signer.Signer
+	nonces       *NonceManager
+	retries      int
+	retryBackoff time.Duration
+	blockHashes  *BlockHashCache
+}
+
+// NewSender returns a new sender of transactions signed by s via client.
+func NewSender(client *rpc.Client, s signer.Signer) *Sender {
+	return NewSenderWithOptions(client, s, SenderOptions{})
+}
+
+// NewSenderWithOptions returns a new sender of transactions signed by s via
+// client configured with opts.
+func NewSenderWithOptions(client *rpc.Client, s signer.Signer, opts SenderOptions) *Sender {
+	sender := &Sender{
+		client:       client,
+		signer:       s,
+		nonces:       opts.Nonces,
+		retries:      opts.Retries,
+		retryBackoff: opts.RetryBackoff,
+		blockHashes:  opts.BlockHashes,
+	}
+	if sender.nonces == nil {
+		sender.nonces = NewNonceManager(client)
+	}
+	if sender.blockHashes == nil {
+		sender.blockHashes = NewBlockHashCache(client, 0)
+	}
+	if sender.retries == 0 {
+		sender.retries = DefaultRetries
+	}
+	if sender.retryBackoff == 0 {
+		sender.retryBackoff = DefaultRetryBackoff
+	}
+	return sender
+}
+
+// NewSenderFromKeyStore returns a new sender of transactions signed with the
+// key of accountID on networkID in ks.
+func NewSenderFromKeyStore(client *rpc.Client, ks keystore.KeyStore, networkID, accountID string) (*Sender, error) {
+	s, err := keystore.LoadSigner(ks, networkID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return NewSender(client, s), nil
+}
+
+// Signer returns the signer of the transactions.
+func (s *Sender) Signer() signer.Signer {
+	return s.signer
+}
+
+// Build returns a new transaction executing actions on receiverID, with the
+// next nonce of the access key of the signer and a recent final block hash.
+//
+// If the access key of the signer is a function call access key, the
+// transaction is checked against its permission with CheckPermission and an
+// error wrapping ErrAccessKeyPermission is returned if the network would
+// reject it. Invalid account IDs are rejected as well, see
+// Transaction.Validate.
+func (s *Sender) Build(ctx context.Context, receiverID string, actions ...Action) (*Transaction, error) {
+	pk := s.signer.PublicKey()
+	if err := validateAccountIDs(s.signer.AccountID(), receiverID, actions); err != nil {
+		return nil, err
+	}
+	if err := s.checkPermission(ctx, receiverID, actions); err != nil {
+		return nil, err
+	}
+	nonce, err := s.nonces.Next(ctx, s.signer.AccountID(), pk)
+	if err != nil {
+		return nil, err
+	}
+	blockHash, err := s.blockHashes.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return New(s.signer.AccountID(), pk, nonce, receiverID, blockHash, actions...), nil
+}
+
+// checkPermission checks the permission of the access key of the signer for
+// a transaction executing actions on receiverID. The gas price is only
+// fetched for function calls of keys with a limited allowance.
+func (s *Sender) checkPermission(ctx context.Context, receiverID string, actions []Action) error {
+	p, err := s.nonces.Permission(ctx, s.signer.AccountID(), s.signer.PublicKey())
+	if err != nil {
+		return err
+	}
+	var gasPrice *types.Balance
+	if p.FunctionCall != nil && p.FunctionCall.Allowance != nil &&
+		len(actions) == 1 && actions[0].Kind == ActionFunctionCall && actions[0].FunctionCall.Gas > 0 {
+		price, err := s.client.GasPrice(ctx, rpc.Final())
+		if err != nil {
+			return err
+		}
+		gasPrice = &price
+	}
+	return CheckPermission(p, receiverID, gasPrice, actions...)
+}
+
+// Call sends a transaction calling methodName of receiverID with the JSON
+// encoding of args and gas, without deposit, so it can be signed by
+// function call access keys. It waits like Send.
+func (s *Sender) Call(ctx context.Context, receiverID, methodName string, args interface{}, gas uint64, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	action, err := FunctionCallJSON(methodName, args, gas, types.Balance{})
+	if err != nil {
+		return nil, err
+	}
+	return s.Send(ctx, receiverID, waitUntil, action)
+}
+
+// Send builds and signs a transaction executing actions on receiverID and
+// sends it with send_tx. It waits until the transaction reached waitUntil
+// (the node default, EXECUTED_OPTIMISTIC, if empty), polling the transaction
+// status after node timeouts until ctx is done.
+//
+// If the transaction is rejected as expired, with an invalid nonce or because
+// its shard is congested, it is rebuilt with the latest block hash and the
+// next nonce and sent again, up to the configured number of retries. Before
+// a transaction rejected with an invalid nonce is rebuilt, the status of the
+// transactions sent before is checked by their hash, so that a transaction
+// whose nonce was used by an earlier attempt is not executed twice.
+//
+// The returned outcome may have a failure status, which is decoded by its
+// Err method; only errors of the submission are returned as error.
+func (s *Sender) Send(ctx context.Context, receiverID string, waitUntil rpc.TxExecutionStatus, actions ...Action) (*rpc.FinalExecutionOutcome, error) {
+	_, outcome, err := s.send(ctx, receiverID, waitUntil, actions)
+	return outcome, err
+}
+
+// send sends the transaction like Send and returns the hash of the last
+// transaction sent, or of the earlier one whose outcome is returned.
+func (s *Sender) send(ctx context.Context, receiverID string, waitUntil rpc.TxExecutionStatus, actions []Action) (string, *rpc.FinalExecutionOutcome, error) {
+	var hashes []string
+	backoff := s.retryBackoff
+	for retries := 0; ; retries++ {
+		tx, err := s.Build(ctx, receiverID, actions...)
+		if err != nil {
+			return "", nil, err
+		}
+		st, err := SignTransaction(tx, s.signer)
+		if err != nil {
+			return "", nil, err
+		}
+		hash, err := st.Hash()
+		if err != nil {
+			return "", nil, err
+		}
+		hashes = append(hashes, hash)
+		outcome, err := s.SendSigned(ctx, st, waitUntil)
+		if err == nil || retries >= s.retries {
+			return hash, outcome, err
+		}
+		akNonce, invalid := invalidNonce(err)
+		switch {
+		case expired(err):
+			s.blockHashes.Invalidate()
+		case invalid:
+			prevHash, prev, ok, sErr := s.sent(ctx, tx.SignerID, hashes, waitUntil)
+			if ok || sErr != nil {
+				return prevHash, prev, sErr
+			}
+			s.nonces.Update(tx.SignerID, tx.PublicKey, akNonce)
+		case congested(err):
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return hash, nil, ctx.Err()
+			}
+			backoff *= 2
+		default:
+			return hash, outcome, err
+		}
+	}
+}
+
+// congested reports whether err is the error of a transaction rejected
+// because its shard is congested.
+func congested(err error) bool {
+	e, ok := rpc.AsInvalidTxError(err)
+	return ok && e.Kind == rpc.InvalidTxShardCongested
+}
+
+// sent returns the hash and outcome of the first of the transactions with
+// hashes which is known to the network, and whether there was one.
+func (s *Sender) sent(ctx context.Context, signerID string, hashes []string, waitUntil rpc.TxExecutionStatus) (string, *rpc.FinalExecutionOutcome, bool, error) {
+	for _, hash := range hashes {
+		outcome, err := s.client.TxStatus(ctx, hash, signerID, waitUntil)
+		if errors.Is(err, rpc.ErrUnknownTransaction) {
+			continue
+		}
+		return hash, outcome, err == nil, err
+	}
+	return "", nil, false, nil
+}
+
+// SendSigned sends the signed transaction st and waits like Send. After node
+// timeouts the outcome is polled with rpc.Client.WaitForOutcome.
+func (s *Sender) SendSigned(ctx context.Context, st *SignedTransaction, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	data, err := st.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	outcome, err := s.client.SendTx(ctx, data, waitUntil)
+	if !errors.Is(err, rpc.ErrTimeout) {
+		return outcome, err
+	}
+	hash, err := st.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return s.client.WaitForOutcome(ctx, hash, st.Transaction.SignerID, waitUntil)
+}
+
+// SendTransaction builds and signs a transaction of s executing actions on
+// receiverID and sends it via client, see Sender.Send.
+func SendTransaction(ctx context.Context, client *rpc.Client, s signer.Signer, receiverID string, waitUntil rpc.TxExecutionStatus, actions ...Action) (*rpc.FinalExecutionOutcome, error) {
+	return NewSender(client, s).Send(ctx, receiverID, waitUntil, actions...)
+}
+
+// decodeHash decodes the base58 encoded 32 byte hash.
+func decodeHash(s string) ([32]byte, error) {
+	var hash [32]byte
+	b := base58.Decode(s)
+	if len(b) != len(hash) {
+		return hash, fmt.Errorf("transaction: invalid hash '%s'", s)
+	}
+	copy(hash[:], b)
+	return hash, nil
+}
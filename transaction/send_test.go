@@ -0,0 +1,209 @@
+package transaction
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/keystore"
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+var testBlockHash = [32]byte{1, 2, 3}
+
+// newTestServer returns a server answering access key and block queries for
+// a key with nonce 5.
+func newTestServer() *rpctest.Server {
+	srv := rpctest.NewServer()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce":        5,
+		"permission":   "FullAccess",
+		"block_height": 1,
+		"block_hash":   base58.Encode(testBlockHash[:]),
+	}))
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(testBlockHash[:])},
+	}))
+	return srv
+}
+
+// sentTransaction decodes the signed transaction of the send_tx request req.
+func sentTransaction(t *testing.T, req rpctest.Request) *SignedTransaction {
+	t.Helper()
+	var p struct {
+		SignedTx  string `json:"signed_tx_base64"`
+		WaitUntil string `json:"wait_until"`
+	}
+	if err := json.Unmarshal(req.Params, &p); err != nil {
+		t.Fatal(err)
+	}
+	data, err := base64.StdEncoding.DecodeString(p.SignedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := DeserializeSigned(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+func TestSend(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{
+		"final_execution_status": "FINAL",
+	}))
+	s := testSigner(t)
+	outcome, err := SendTransaction(context.Background(), srv.Client(), s, "bob.testnet",
+		rpc.TxExecutionStatusFinal, Transfer(types.NewBalance(big.NewInt(1))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.FinalExecutionStatus != rpc.TxExecutionStatusFinal {
+		t.Errorf("SendTransaction() returned status %s", outcome.FinalExecutionStatus)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 1 {
+		t.Fatalf("SendTransaction() made %d send_tx calls (want 1)", len(reqs))
+	}
+	st := sentTransaction(t, reqs[0])
+	tx := st.Transaction
+	if tx.SignerID != "alice.testnet" || tx.ReceiverID != "bob.testnet" || tx.Nonce != 6 || tx.BlockHash != testBlockHash {
+		t.Errorf("SendTransaction() sent %+v", tx)
+	}
+	if len(tx.Actions) != 1 || tx.Actions[0].Transfer.Deposit.Int64() != 1 {
+		t.Errorf("SendTransaction() sent actions %+v", tx.Actions)
+	}
+	if hash, err := st.Hash(); err != nil || outcome.Transaction.Hash != hash {
+		t.Errorf("SendTransaction() returned hash %q (want %q, %v)", outcome.Transaction.Hash, hash, err)
+	}
+}
+
+func TestSendPollsAfterTimeout(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Handle("send_tx", rpctest.Fail(rpctest.HandlerError("TIMEOUT_ERROR")))
+	// the polled node might not know the transaction yet
+	srv.Handle("tx", rpctest.Sequence(
+		rpctest.Fail(rpctest.HandlerError("UNKNOWN_TRANSACTION")),
+		rpctest.Fail(rpctest.HandlerError("TIMEOUT_ERROR")),
+		rpctest.Result(map[string]interface{}{"final_execution_status": "EXECUTED"}),
+	))
+	ks := keystore.NewInMemoryKeyStore()
+	kp, err := keystore.GenerateEd25519KeyPair("alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Put("testnet", kp); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSenderFromKeyStore(srv.Client(), ks, "testnet", "alice.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	outcome, err := s.Send(context.Background(), "bob.testnet", rpc.TxExecutionStatusExecuted, CreateAccount())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.FinalExecutionStatus != rpc.TxExecutionStatusExecuted {
+		t.Errorf("Send() returned status %s", outcome.FinalExecutionStatus)
+	}
+	hash, err := sentTransaction(t, srv.RequestsFor("send_tx")[0]).Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("tx")
+	if len(reqs) != 3 {
+		t.Fatalf("Send() made %d tx calls (want 3)", len(reqs))
+	}
+	var p map[string]string
+	if err := json.Unmarshal(reqs[2].Params, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p["tx_hash"] != hash || p["sender_account_id"] != "alice.testnet" || p["wait_until"] != "EXECUTED" {
+		t.Errorf("Send() polled with %v (want hash %s)", p, hash)
+	}
+}
+
+func TestSendError(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_access_key", rpctest.Fail(rpctest.HandlerError("UNKNOWN_ACCESS_KEY")))
+	_, err := NewSender(srv.Client(), testSigner(t)).Send(context.Background(), "bob.testnet", "", CreateAccount())
+	if err == nil {
+		t.Fatal("Send() succeeded without access key")
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 0 {
+		t.Errorf("Send() made %d send_tx calls (want 0)", n)
+	}
+}
+
+func TestSendShardCongested(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	congestedErr := rpctest.HandlerError("INVALID_TRANSACTION")
+	congestedErr.Cause.Info = json.RawMessage(`{"TxExecutionError":{"InvalidTxError":{"ShardCongested":{"shard_id":0,"congestion_level":1}}}}`)
+	srv.Handle("send_tx", rpctest.Sequence(
+		rpctest.Fail(congestedErr),
+		rpctest.Fail(congestedErr),
+		rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}),
+	))
+	s := NewSenderWithOptions(srv.Client(), testSigner(t), SenderOptions{RetryBackoff: time.Millisecond})
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 3 {
+		t.Fatalf("Send() made %d send_tx calls (want 3)", len(reqs))
+	}
+	if n := sentTransaction(t, reqs[2]).Transaction.Nonce; n != 8 {
+		t.Errorf("Send() retried with nonce %d (want 8)", n)
+	}
+
+	// retries are limited
+	srv.Handle("send_tx", rpctest.Fail(congestedErr))
+	s = NewSenderWithOptions(srv.Client(), testSigner(t), SenderOptions{Retries: 1, RetryBackoff: time.Millisecond})
+	if _, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount()); !congested(err) {
+		t.Errorf("Send() returned %v (want congestion error)", err)
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 5 {
+		t.Errorf("Send() with one retry made %d send_tx calls (want 2)", n-3)
+	}
+}
+
+func TestSendAlreadyExecuted(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	// the transaction was executed, but the node rejects a resubmission
+	srv.Handle("send_tx", rpctest.Fail(invalidNonceError(6, 6)))
+	srv.Handle("tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	s := NewSender(srv.Client(), testSigner(t))
+	outcome, err := s.Send(context.Background(), "bob.testnet", "", CreateAccount())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.FinalExecutionStatus != rpc.TxExecutionStatusFinal {
+		t.Errorf("Send() returned status %s", outcome.FinalExecutionStatus)
+	}
+	if n := len(srv.RequestsFor("send_tx")); n != 1 {
+		t.Errorf("Send() made %d send_tx calls (want 1)", n)
+	}
+	hash, err := sentTransaction(t, srv.RequestsFor("send_tx")[0]).Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p map[string]string
+	if err := json.Unmarshal(srv.RequestsFor("tx")[0].Params, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p["tx_hash"] != hash {
+		t.Errorf("Send() checked transaction %s (want %s)", p["tx_hash"], hash)
+	}
+}
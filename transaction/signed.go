@@ -0,0 +1,149 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/crypto"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/utils"
+	nearborsh "github.com/near/borsh-go"
+)
+
+// Signature is a transaction signature. It is a Borsh enum with one variant
+// per key type, the KeyType selects the variant.
+type Signature struct {
+	KeyType   borsh.Enum `borsh_enum:"true"`
+	ED25519   Ed25519Signature
+	SECP256K1 Secp256k1Signature
+}
+
+// Ed25519Signature is the data of an Ed25519 signature.
+type Ed25519Signature struct {
+	Data [64]byte
+}
+
+// Secp256k1Signature is the data of a recoverable secp256k1 signature (r, s
+// and the recovery ID v).
+type Secp256k1Signature struct {
+	Data [65]byte
+}
+
+// NewSignature returns the raw signature sig of type keyType in NEAR
+// encoding.
+func NewSignature(keyType borsh.Enum, sig []byte) (Signature, error) {
+	var signature Signature
+	signature.KeyType = keyType
+	var data []byte
+	switch keyType {
+	case utils.ED25519:
+		data = signature.ED25519.Data[:]
+	case utils.SECP256K1:
+		data = signature.SECP256K1.Data[:]
+	default:
+		return Signature{}, fmt.Errorf("transaction: unsupported signature key type %d", keyType)
+	}
+	if len(sig) != len(data) {
+		return Signature{}, fmt.Errorf("transaction: %s signature of invalid length %d",
+			utils.KeyTypeName(nearborsh.Enum(keyType)), len(sig))
+	}
+	copy(data, sig)
+	return signature, nil
+}
+
+// Bytes returns the raw signature data.
+func (s Signature) Bytes() []byte {
+	if s.KeyType == utils.SECP256K1 {
+		return s.SECP256K1.Data[:]
+	}
+	return s.ED25519.Data[:]
+}
+
+// Verify reports whether s is a valid signature of the 32 byte hash by
+// publicKey, see crypto.Verify.
+func (s Signature) Verify(publicKey utils.PublicKey, hash []byte) bool {
+	return borsh.Enum(publicKey.KeyType) == s.KeyType && crypto.Verify(publicKey, hash, s.Bytes())
+}
+
+// SignedTransaction is a transaction with the signature of its signer, as
+// submitted to the network.
+type SignedTransaction struct {
+	Transaction Transaction
+	Signature   Signature
+}
+
+// SignTransaction signs tx with s. The signature is the signature of the
+// hash of tx (see Transaction.Hash), unless s implements
+// signer.TransactionSigner, which gets the signable payload itself.
+//
+// The public key of s must be the public key of tx.
+func SignTransaction(tx *Transaction, s signer.Signer) (*SignedTransaction, error) {
+	if pk := s.PublicKey(); !pk.Equal(tx.PublicKey) {
+		return nil, fmt.Errorf("transaction: signer key %s does not match transaction key %s", pk, tx.PublicKey)
+	}
+	data, err := tx.SignablePayload()
+	if err != nil {
+		return nil, err
+	}
+	var sig []byte
+	if ts, ok := s.(signer.TransactionSigner); ok {
+		sig, err = ts.SignTransaction(data)
+	} else {
+		hash := sha256.Sum256(data)
+		sig, err = s.SignBytes(hash[:])
+	}
+	if err != nil {
+		return nil, err
+	}
+	signature, err := NewSignature(borsh.Enum(tx.PublicKey.KeyType), sig)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTransaction{Transaction: *tx, Signature: signature}, nil
+}
+
+// Serialize returns the Borsh encoding of st.
+func (st *SignedTransaction) Serialize() ([]byte, error) {
+	return borsh.Serialize(*st)
+}
+
+// Base64 returns the base64 encoding of the Borsh encoded st, as expected by
+// the RPC API.
+func (st *SignedTransaction) Base64() (string, error) {
+	data, err := st.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Hash returns the base58 encoded transaction hash, as shown by explorers
+// and used to query the transaction status, see Transaction.ID.
+func (st *SignedTransaction) Hash() (string, error) {
+	return st.Transaction.ID()
+}
+
+// Verify checks that st is signed with the public key of its transaction,
+// e.g. to check a signed transaction before broadcasting it. It does not
+// check whether the key belongs to the signer.
+func (st *SignedTransaction) Verify() error {
+	hash, err := st.Transaction.Hash()
+	if err != nil {
+		return err
+	}
+	if !st.Signature.Verify(st.Transaction.PublicKey, hash[:]) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// DeserializeSigned decodes the Borsh encoded signed transaction data.
+func DeserializeSigned(data []byte) (*SignedTransaction, error) {
+	var st SignedTransaction
+	if err := borsh.Deserialize(data, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
@@ -0,0 +1,149 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func TestSignedTransactionEncoding(t *testing.T) {
+	data, err := hex.DecodeString(signedTxHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := DeserializeSigned(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := st.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, data) {
+		t.Errorf("Serialize() returned\n%x\n(want\n%s)", enc, signedTxHex)
+	}
+	b64, err := st.Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := base64.StdEncoding.EncodeToString(data); b64 != want {
+		t.Errorf("Base64() returned %s (want %s)", b64, want)
+	}
+	hash, err := st.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the signature of the testnet transaction is the signature of its hash
+	if !ed25519.Verify(st.Transaction.PublicKey.Bytes(), base58.Decode(hash), st.Signature.Bytes()) {
+		t.Errorf("signature does not verify for hash %s", hash)
+	}
+}
+
+func testSigner(t *testing.T) *signer.Ed25519Signer {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewEd25519Signer("alice.testnet", priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSignTransaction(t *testing.T) {
+	s := testSigner(t)
+	tx := New(s.AccountID(), s.PublicKey(), 1, "bob.testnet", [32]byte{1},
+		Transfer(types.NewBalance(big.NewInt(1))))
+	st, err := SignTransaction(tx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(data)
+	if !ed25519.Verify(s.PublicKey().Bytes(), hash[:], st.Signature.Bytes()) {
+		t.Error("signature does not verify")
+	}
+	if h, err := st.Hash(); err != nil || h != base58.Encode(hash[:]) {
+		t.Errorf("Hash() returned %s, %v (want %s)", h, err, base58.Encode(hash[:]))
+	}
+	enc, err := st.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DeserializeSigned(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Signature != st.Signature || got.Transaction.Nonce != 1 {
+		t.Errorf("DeserializeSigned() returned %+v (want %+v)", got, st)
+	}
+
+	// the key of the signer must match
+	tx.PublicKey = testKey(1)
+	if _, err := SignTransaction(tx, s); err == nil {
+		t.Error("SignTransaction() accepted signer with other key")
+	}
+}
+
+// fullSigner signs the complete transaction.
+type fullSigner struct {
+	*signer.Ed25519Signer
+	tx []byte
+}
+
+func (s *fullSigner) SignTransaction(tx []byte) ([]byte, error) {
+	s.tx = tx
+	hash := sha256.Sum256(tx)
+	return s.SignBytes(hash[:])
+}
+
+func TestSignTransactionSigner(t *testing.T) {
+	s := &fullSigner{Ed25519Signer: testSigner(t)}
+	tx := New(s.AccountID(), s.PublicKey(), 1, "bob.testnet", [32]byte{1}, CreateAccount())
+	if _, err := SignTransaction(tx, s); err != nil {
+		t.Fatal(err)
+	}
+	data, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.tx, data) {
+		t.Errorf("SignTransaction() signed %x (want %x)", s.tx, data)
+	}
+}
+
+func TestNewSignature(t *testing.T) {
+	if _, err := NewSignature(0, make([]byte, 63)); err == nil {
+		t.Error("NewSignature() accepted short signature")
+	}
+	if _, err := NewSignature(2, make([]byte, 64)); err == nil {
+		t.Error("NewSignature() accepted unknown key type")
+	}
+}
+
+func TestSignedTransactionVerify(t *testing.T) {
+	s := testSigner(t)
+	st, err := SignTransaction(New("alice.testnet", s.PublicKey(), 1, "bob.testnet", testBlockHash, CreateAccount()), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Verify(); err != nil {
+		t.Errorf("Verify() returned %v", err)
+	}
+	st.Transaction.ReceiverID = "eve.testnet"
+	if err := st.Verify(); err != ErrInvalidSignature {
+		t.Errorf("Verify() of modified transaction returned %v (want ErrInvalidSignature)", err)
+	}
+}
@@ -0,0 +1,121 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+// ErrUnsuitableStakingKey is returned for validator keys which are not
+// Ed25519 keys, the only keys the network accepts for staking.
+var ErrUnsuitableStakingKey = errors.New("transaction: staking key must be an Ed25519 key")
+
+// LoadValidatorKey reads the account ID and public key of the validator key
+// file of a node at path, usually ~/.near/validator_key.json. The secret key
+// in the file is not read.
+func LoadValidatorKey(path string) (string, utils.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", utils.PublicKey{}, err
+	}
+	var kf struct {
+		AccountID string          `json:"account_id"`
+		PublicKey utils.PublicKey `json:"public_key"`
+	}
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return "", utils.PublicKey{}, fmt.Errorf("transaction: invalid validator key file %s: %w", path, err)
+	}
+	return kf.AccountID, kf.PublicKey, nil
+}
+
+func checkStakingKey(pk utils.PublicKey) error {
+	if pk.KeyType != utils.ED25519 {
+		return fmt.Errorf("%w: %s", ErrUnsuitableStakingKey, pk)
+	}
+	return nil
+}
+
+// Stake sends a transaction staking amount of the account of the signer with
+// the validator key validatorKey. The amount is the total stake of the
+// account, not an increase; staking less than the current stake unlocks the
+// difference after some epochs. It waits like Send.
+func (s *Sender) Stake(ctx context.Context, amount types.Balance, validatorKey utils.PublicKey, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	if err := checkStakingKey(validatorKey); err != nil {
+		return nil, err
+	}
+	return s.Send(ctx, s.signer.AccountID(), waitUntil, Stake(amount, validatorKey))
+}
+
+// AddStake sends a transaction staking amount in addition to the currently
+// locked balance of the account of the signer, see Stake.
+func (s *Sender) AddStake(ctx context.Context, amount types.Balance, validatorKey utils.PublicKey, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	acc, err := s.client.ViewAccount(ctx, s.signer.AccountID(), rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	var total types.Balance
+	total.Add(&acc.Locked.Int, &amount.Int)
+	return s.Stake(ctx, total, validatorKey, waitUntil)
+}
+
+// Unstake sends a transaction staking nothing, which unlocks the stake of the
+// account of the signer after some epochs. It waits like Send.
+func (s *Sender) Unstake(ctx context.Context, validatorKey utils.PublicKey, waitUntil rpc.TxExecutionStatus) (*rpc.FinalExecutionOutcome, error) {
+	return s.Stake(ctx, types.Balance{}, validatorKey, waitUntil)
+}
+
+// ValidatorStatus is the staking status of an account.
+type ValidatorStatus struct {
+	// Locked is the staked balance of the account.
+	Locked types.Balance
+	// Current, Next and Proposal are the account as validator of the
+	// current and next epoch and its staking proposal for the epoch after
+	// the next, nil if it is none.
+	Current  *rpc.CurrentEpochValidatorInfo
+	Next     *rpc.NextEpochValidatorInfo
+	Proposal *rpc.ValidatorStakeView
+	// Kickout is the reason the account was kicked out in the previous
+	// epoch, nil if it was not.
+	Kickout *rpc.ValidatorKickoutReason
+}
+
+// GetValidatorStatus returns the staking status of accountID in the current
+// epoch.
+func GetValidatorStatus(ctx context.Context, client *rpc.Client, accountID string) (*ValidatorStatus, error) {
+	acc, err := client.ViewAccount(ctx, accountID, rpc.Final())
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.Validators(ctx, rpc.CurrentEpoch())
+	if err != nil {
+		return nil, err
+	}
+	st := &ValidatorStatus{Locked: acc.Locked}
+	for i := range info.CurrentValidators {
+		if info.CurrentValidators[i].AccountID == accountID {
+			st.Current = &info.CurrentValidators[i]
+		}
+	}
+	for i := range info.NextValidators {
+		if info.NextValidators[i].AccountID == accountID {
+			st.Next = &info.NextValidators[i]
+		}
+	}
+	for i := range info.CurrentProposals {
+		if info.CurrentProposals[i].AccountID == accountID {
+			st.Proposal = &info.CurrentProposals[i]
+		}
+	}
+	for i := range info.PrevEpochKickout {
+		if info.PrevEpochKickout[i].AccountID == accountID {
+			st.Kickout = &info.PrevEpochKickout[i].Reason
+		}
+	}
+	return st, nil
+}
@@ -0,0 +1,98 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+)
+
+func TestLoadValidatorKey(t *testing.T) {
+	pk := testSigner(t).PublicKey()
+	path := filepath.Join(t.TempDir(), "validator_key.json")
+	data := `{"account_id":"node.pool.testnet","public_key":"` + pk.String() + `","secret_key":"ed25519:secret"}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	accountID, key, err := LoadValidatorKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accountID != "node.pool.testnet" || !key.Equal(pk) {
+		t.Errorf("LoadValidatorKey() returned %s, %s", accountID, key)
+	}
+	if _, _, err := LoadValidatorKey(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadValidatorKey() of missing file succeeded")
+	}
+}
+
+func TestStake(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{
+		"amount": "100",
+		"locked": "50",
+	}))
+	srv.Handle("send_tx", rpctest.Result(map[string]interface{}{"final_execution_status": "FINAL"}))
+	s := NewSender(srv.Client(), testSigner(t))
+	validatorKey := s.Signer().PublicKey()
+
+	amount, _ := types.ParseBalance("30")
+	if _, err := s.AddStake(context.Background(), amount, validatorKey, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Unstake(context.Background(), validatorKey, ""); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("send_tx")
+	if len(reqs) != 2 {
+		t.Fatalf("made %d send_tx calls (want 2)", len(reqs))
+	}
+	for i, want := range []string{"80", "0"} {
+		tx := sentTransaction(t, reqs[i]).Transaction
+		a := tx.Actions[0]
+		if tx.ReceiverID != "alice.testnet" || a.Kind != ActionStake || a.Stake.Stake.String() != want || !a.Stake.PublicKey.Equal(validatorKey) {
+			t.Errorf("sent %+v (want stake of %s)", tx, want)
+		}
+	}
+
+	secp := utils.PublicKeyFromSecp256k1(make([]byte, 64))
+	if _, err := s.Stake(context.Background(), amount, secp, ""); !errors.Is(err, ErrUnsuitableStakingKey) {
+		t.Errorf("Stake() with secp256k1 key returned %v (want ErrUnsuitableStakingKey)", err)
+	}
+}
+
+func TestGetValidatorStatus(t *testing.T) {
+	srv := rpctest.NewServer()
+	defer srv.Close()
+	srv.Query("view_account", rpctest.Result(map[string]interface{}{"amount": "100", "locked": "50"}))
+	srv.Handle("validators", rpctest.Result(map[string]interface{}{
+		"current_validators": []map[string]interface{}{
+			{"account_id": "other.testnet", "stake": "1"},
+			{"account_id": "node.testnet", "stake": "50", "num_produced_blocks": 9, "num_expected_blocks": 10},
+		},
+		"current_proposals": []map[string]interface{}{{"account_id": "node.testnet", "stake": "60"}},
+		"prev_epoch_kickout": []map[string]interface{}{
+			{"account_id": "node.testnet", "reason": map[string]interface{}{"NotEnoughBlocks": map[string]int{"produced": 1, "expected": 2}}},
+		},
+	}))
+	st, err := GetValidatorStatus(context.Background(), srv.Client(), "node.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Locked.String() != "50" || st.Current == nil || st.Current.NumProducedBlocks != 9 || st.Next != nil ||
+		st.Proposal == nil || st.Proposal.Stake.String() != "60" || st.Kickout == nil || st.Kickout.Kind != "NotEnoughBlocks" {
+		t.Errorf("GetValidatorStatus() returned %+v", st)
+	}
+
+	srv.Handle("validators", rpctest.Fail(rpctest.HandlerError("UNKNOWN_EPOCH")))
+	if _, err := GetValidatorStatus(context.Background(), srv.Client(), "node.testnet"); !errors.Is(err, rpc.ErrUnknownEpoch) {
+		t.Errorf("GetValidatorStatus() returned %v (want ErrUnknownEpoch)", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package transaction implements NEAR transactions and their actions with
+// the Borsh encoding of nearcore.
+//
+// Transactions can be signed offline in three steps: the online machine
+// builds the transaction with New, taking the nonce and block hash from the
+// network, and exports it with Transaction.Base64. The offline machine
+// decodes it with DeserializeBase64, signs it with SignTransaction and
+// exports the signature with Signature.String. The online machine parses
+// the signature with ParseSignature, attaches it with NewSignedTransaction
+// and broadcasts the result with Sender.SendSigned.
+package transaction
+
+import (
+	"crypto/sha256"
+
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// Transaction is a NEAR transaction: a list of actions the signer executes
+// on the receiver account.
+type Transaction struct {
+	// SignerID is the account ID of the signer.
+	SignerID string
+	// PublicKey is the public key of the access key signing the transaction.
+	PublicKey utils.PublicKey
+	// Nonce must be larger than the nonce of the access key, usually it is
+	// the access key nonce plus one.
+	Nonce uint64
+	// ReceiverID is the account ID the actions are executed on.
+	ReceiverID string
+	// BlockHash is the hash of a recent block, transactions expire after
+	// the transaction validity period (about 24 hours on mainnet).
+	BlockHash [32]byte
+	Actions   []Action
+}
+
+// New returns a new transaction of signerID with the access key publicKey
+// executing actions on receiverID.
+func New(signerID string, publicKey utils.PublicKey, nonce uint64, receiverID string, blockHash [32]byte, actions ...Action) *Transaction {
+	return &Transaction{
+		SignerID:   signerID,
+		PublicKey:  publicKey,
+		Nonce:      nonce,
+		ReceiverID: receiverID,
+		BlockHash:  blockHash,
+		Actions:    actions,
+	}
+}
+
+// Validate checks the account IDs of tx and its actions against the rules of
+// NEAR account IDs (see types.ParseAccountID), so invalid IDs are rejected
+// before the transaction is signed and sent.
+func (tx *Transaction) Validate() error {
+	return validateAccountIDs(tx.SignerID, tx.ReceiverID, tx.Actions)
+}
+
+func validateAccountIDs(signerID, receiverID string, actions []Action) error {
+	ids := []string{signerID, receiverID}
+	for _, a := range actions {
+		switch a.Kind {
+		case ActionAddKey:
+			if a.AddKey.AccessKey.Permission.Kind == PermissionFunctionCall {
+				ids = append(ids, a.AddKey.AccessKey.Permission.FunctionCall.ReceiverID)
+			}
+		case ActionDeleteAccount:
+			ids = append(ids, a.DeleteAccount.BeneficiaryID)
+		case ActionUseGlobalContract:
+			if a.UseGlobalContract.ContractIdentifier.Kind == GlobalContractAccountID {
+				ids = append(ids, a.UseGlobalContract.ContractIdentifier.AccountID)
+			}
+		case ActionDelegate:
+			da := a.Delegate.DelegateAction
+			if err := validateAccountIDs(da.SenderID, da.ReceiverID, da.Actions); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range ids {
+		if _, err := types.ParseAccountID(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Serialize returns the Borsh encoding of tx.
+func (tx *Transaction) Serialize() ([]byte, error) {
+	return borsh.Serialize(*tx)
+}
+
+// SignablePayload returns the bytes a signature of tx commits to: the Borsh
+// encoding of tx, whose sha256 hash (see Hash) is signed. Wallets can
+// decode the payload with Deserialize to show what is signed.
+func (tx *Transaction) SignablePayload() ([]byte, error) {
+	return tx.Serialize()
+}
+
+// Hash returns the sha256 hash of the signable payload of tx. It is the hash
+// signed by the access key and, base58 encoded (see ID), the hash the
+// network identifies the transaction by. It does not depend on the
+// signature, so it can be recorded before the transaction is signed.
+func (tx *Transaction) Hash() ([32]byte, error) {
+	data, err := tx.SignablePayload()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// ID returns the base58 encoded hash of tx, as returned by the network in
+// outcomes and used to query the transaction status.
+func (tx *Transaction) ID() (string, error) {
+	hash, err := tx.Hash()
+	if err != nil {
+		return "", err
+	}
+	return base58.Encode(hash[:]), nil
+}
+
+// Deserialize decodes the Borsh encoded transaction data.
+func Deserialize(data []byte) (*Transaction, error) {
+	var tx Transaction
+	if err := borsh.Deserialize(data, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
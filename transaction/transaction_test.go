@@ -0,0 +1,233 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	near "github.com/YuxSccc/near-api-go"
+	"github.com/YuxSccc/near-api-go/borsh"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/YuxSccc/near-api-go/utils"
+	"github.com/btcsuite/btcutil/base58"
+	nearborsh "github.com/near/borsh-go"
+)
+
+// signedTxHex is a signed transfer transaction from testnet.
+const signedTxHex = "1100000065766d2d62756c6c792e746573746e6574001a523c7d2d3434f82c0a069b883b83590fe23318ee491aeeba072b9b8e740713250000000000000014000000746573742d6163636f756e742e746573746e65747695cb1e847748021b0a7891410f458901bbbcf626e3eb6a27c1bf8e04d5e10f0100000003000040b2bac9e0191e02000000000000004ed8ac33b677616bed7430a21c190e71e1a80716cdfff9f972371ee0e08cc64c8b3fa1df79624ed987145a095be54e8a1ef38fb37640c397454619ea78f35605"
+
+func testKey(b byte) utils.PublicKey {
+	var pk utils.PublicKey
+	pk.ED25519.Data[0] = b
+	return pk
+}
+
+func TestTransactionEncoding(t *testing.T) {
+	data, err := hex.DecodeString(signedTxHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tx Transaction
+	d := borsh.NewDecoder(data)
+	if err := d.Decode(&tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.SignerID != "evm-bully.testnet" || tx.ReceiverID != "test-account.testnet" {
+		t.Errorf("Decode() returned signer %s and receiver %s", tx.SignerID, tx.ReceiverID)
+	}
+	if len(tx.Actions) != 1 || tx.Actions[0].Kind != ActionTransfer {
+		t.Fatalf("Decode() returned actions %+v (want one transfer)", tx.Actions)
+	}
+	enc, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := data[:len(data)-d.Len()]; !bytes.Equal(enc, want) {
+		t.Errorf("Serialize() returned\n%x\n(want\n%x)", enc, want)
+	}
+}
+
+func TestAllActions(t *testing.T) {
+	allowance := types.NewBalance(big.NewInt(250))
+	tx := New("alice.testnet", testKey(1), 7, "bob.testnet", [32]byte{9},
+		CreateAccount(),
+		DeployContract([]byte{0, 'a', 's', 'm'}),
+		FunctionCall("add", []byte(`{"a":1}`), 30000000000000, types.NewBalance(big.NewInt(1))),
+		Transfer(types.NewBalance(big.NewInt(1000))),
+		Stake(types.NewBalance(big.NewInt(5)), testKey(2)),
+		AddKey(testKey(3), FullAccessKey()),
+		AddKey(testKey(4), FunctionCallAccessKey("bob.testnet", []string{"add"}, &allowance)),
+		AddKey(testKey(5), FunctionCallAccessKey("bob.testnet", nil, nil)),
+		DeleteKey(testKey(6)),
+		DeleteAccount("carol.testnet"),
+	)
+	data, err := tx.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the existing borsh-go based types match nearcore
+	want, err := nearborsh.Serialize(near.Transaction{
+		SignerID:   "alice.testnet",
+		PublicKey:  testKey(1),
+		Nonce:      7,
+		ReceiverID: "bob.testnet",
+		BlockHash:  [32]byte{9},
+		Actions: []near.Action{
+			{Enum: 0},
+			{Enum: 1, DeployContract: near.DeployContract{Code: []byte{0, 'a', 's', 'm'}}},
+			{Enum: 2, FunctionCall: near.FunctionCall{MethodName: "add", Args: []byte(`{"a":1}`), Gas: 30000000000000, Deposit: *big.NewInt(1)}},
+			{Enum: 3, Transfer: near.Transfer{Deposit: *big.NewInt(1000)}},
+			{Enum: 4, Stake: near.Stake{Stake: *big.NewInt(5), PublicKey: testKey(2)}},
+			{Enum: 5, AddKey: near.AddKey{PublicKey: testKey(3), AccessKey: near.AccessKey{Permission: near.AccessKeyPermission{Enum: 1}}}},
+			{Enum: 5, AddKey: near.AddKey{PublicKey: testKey(4), AccessKey: near.AccessKey{Permission: near.AccessKeyPermission{
+				FunctionCall: near.FunctionCallPermission{Allowance: big.NewInt(250), ReceiverId: "bob.testnet", MethodNames: []string{"add"}},
+			}}}},
+			{Enum: 5, AddKey: near.AddKey{PublicKey: testKey(5), AccessKey: near.AccessKey{Permission: near.AccessKeyPermission{
+				FunctionCall: near.FunctionCallPermission{ReceiverId: "bob.testnet", MethodNames: []string{}},
+			}}}},
+			{Enum: 6, DeleteKey: near.DeleteKey{PublicKey: testKey(6)}},
+			{Enum: 7, DeleteAccount: near.DeleteAccount{BeneficiaryID: "carol.testnet"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Serialize() returned\n%x\n(want\n%x)", data, want)
+	}
+	got, err := Deserialize(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// decoding yields empty instead of nil slices
+	tx.Actions[7].AddKey.AccessKey.Permission.FunctionCall.MethodNames = []string{}
+	if !reflect.DeepEqual(got, tx) {
+		t.Errorf("Deserialize() returned %+v (want %+v)", got, tx)
+	}
+}
+
+func TestFunctionCallJSON(t *testing.T) {
+	a, err := FunctionCallJSON("set", map[string]int{"value": 1}, 1, types.Balance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(a.FunctionCall.Args) != `{"value":1}` {
+		t.Errorf("FunctionCallJSON() returned args %s", a.FunctionCall.Args)
+	}
+	if _, err := FunctionCallJSON("set", func() {}, 1, types.Balance{}); err == nil {
+		t.Error("FunctionCallJSON() accepted invalid args")
+	}
+}
+
+func TestFunctionCallBorsh(t *testing.T) {
+	a, err := FunctionCallBorsh("set", struct {
+		Value uint16
+		Name  string
+	}{7, "a"}, 1, types.Balance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{7, 0, 1, 0, 0, 0, 'a'}; !bytes.Equal(a.FunctionCall.Args, want) {
+		t.Errorf("FunctionCallBorsh() returned args %v (want %v)", a.FunctionCall.Args, want)
+	}
+	if _, err := FunctionCallBorsh("set", func() {}, 1, types.Balance{}); err == nil {
+		t.Error("FunctionCallBorsh() accepted invalid args")
+	}
+}
+
+func TestGlobalContractActions(t *testing.T) {
+	code := []byte{0, 'a', 's', 'm'}
+	hash := CodeHash(code)
+	for _, tt := range []struct {
+		action Action
+		want   []byte
+	}{
+		{DeployGlobalContract(code, GlobalContractByHash), []byte{9, 4, 0, 0, 0, 0, 'a', 's', 'm', 0}},
+		{DeployGlobalContract(code, GlobalContractByAccountID), []byte{9, 4, 0, 0, 0, 0, 'a', 's', 'm', 1}},
+		{UseGlobalContractByHash(hash), append([]byte{10, 0}, hash[:]...)},
+		{UseGlobalContractByAccountID("f.near"), []byte{10, 1, 6, 0, 0, 0, 'f', '.', 'n', 'e', 'a', 'r'}},
+	} {
+		data, err := borsh.Serialize(tt.action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, tt.want) {
+			t.Errorf("Serialize() returned %v (want %v)", data, tt.want)
+		}
+		var decoded Action
+		if err := borsh.Deserialize(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if again, err := borsh.Serialize(decoded); err != nil || !bytes.Equal(again, data) {
+			t.Errorf("Deserialize() of %v returned %+v, %v", data, decoded, err)
+		}
+	}
+}
+
+func TestTransactionHash(t *testing.T) {
+	data, err := hex.DecodeString(signedTxHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := DeserializeSigned(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &st.Transaction
+	payload, err := tx.SignablePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the signed transaction is the payload followed by the signature
+	if want := data[:len(data)-65]; !bytes.Equal(payload, want) {
+		t.Errorf("SignablePayload() returned %x (want %x)", payload, want)
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != sha256.Sum256(payload) {
+		t.Errorf("Hash() returned %x (want sha256 of payload)", hash)
+	}
+	id, err := tx.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stHash, _ := st.Hash(); id != stHash || id != base58.Encode(hash[:]) {
+		t.Errorf("ID() returned %s (want %s)", id, stHash)
+	}
+
+	// the hash changes with every field
+	tx.Nonce++
+	if changed, _ := tx.Hash(); changed == hash {
+		t.Error("Hash() did not change with the nonce")
+	}
+}
+
+func TestTransactionValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		signerID, receiver string
+		actions            []Action
+		valid              bool
+	}{
+		{"transfer", "alice.testnet", "bob.testnet", []Action{Transfer(types.Balance{})}, true},
+		{"signer", "Alice.testnet", "bob.testnet", nil, false},
+		{"receiver", "alice.testnet", "bob..testnet", nil, false},
+		{"beneficiary", "alice.testnet", "alice.testnet", []Action{DeleteAccount("bob_")}, false},
+		{"key receiver", "alice.testnet", "alice.testnet", []Action{AddKey(testKey(1), FunctionCallAccessKey("a", nil, nil))}, false},
+		{"global contract", "alice.testnet", "alice.testnet", []Action{UseGlobalContractByAccountID("x y")}, false},
+		{"delegate", "relayer.testnet", "alice.testnet", []Action{Delegate(SignedDelegateAction{
+			DelegateAction: *NewDelegateAction("alice.testnet", testKey(1), 1, "-bob", 1),
+		})}, false},
+	} {
+		tx := New(tt.signerID, testKey(1), 1, tt.receiver, [32]byte{}, tt.actions...)
+		if err := tx.Validate(); (err == nil) != tt.valid || (err != nil && !errors.Is(err, types.ErrInvalidAccountID)) {
+			t.Errorf("Validate() of %s returned %v (want valid %v)", tt.name, err, tt.valid)
+		}
+	}
+}
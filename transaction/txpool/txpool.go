@@ -0,0 +1,188 @@
+// Package txpool dispatches many transactions concurrently over a set of
+// access keys: transactions of the same key are sent one after another, so
+// their nonces stay ordered, while different keys send in parallel.
+//
+// Adding more function call or full access keys of an account raises the
+// throughput, e.g. for exchanges and airdrops sending thousands of
+// transfers.
+package txpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/YuxSccc/near-api-go/rpc"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+)
+
+// DefaultQueueSize is the default number of transactions queued per key.
+const DefaultQueueSize = 64
+
+// ErrClosed is returned by Submit after the dispatcher was closed.
+var ErrClosed = errors.New("txpool: dispatcher closed")
+
+// Config configures a dispatcher.
+type Config struct {
+	// Client sends the transactions.
+	Client *rpc.Client
+	// Signers are the keys signing the transactions, each sends one
+	// transaction at a time. At least one is required.
+	Signers []signer.Signer
+	// QueueSize is the number of transactions queued per key before Submit
+	// blocks, DefaultQueueSize if zero.
+	QueueSize int
+	// WaitUntil is the execution level waited for before the next
+	// transaction of a key is sent, the node default if empty.
+	WaitUntil rpc.TxExecutionStatus
+	// SenderOptions configure the senders of the keys. If BlockHashes is
+	// nil, all senders share one cache.
+	SenderOptions transaction.SenderOptions
+}
+
+// Request is a transaction to send.
+type Request struct {
+	// ID identifies the request in its result, e.g. a withdrawal ID. It is
+	// not used by the dispatcher.
+	ID         string
+	ReceiverID string
+	Actions    []transaction.Action
+}
+
+// Result is the result of a request.
+type Result struct {
+	Request Request
+	// SignerID and PublicKey identify the key the transaction was sent
+	// with.
+	SignerID  string
+	PublicKey string
+	// Outcome and Err are the results of Sender.Send.
+	Outcome *rpc.FinalExecutionOutcome
+	Err     error
+}
+
+type job struct {
+	ctx context.Context
+	req Request
+	res chan Result
+}
+
+// Dispatcher sends transactions with a queue and worker per key. It is safe
+// for concurrent use.
+type Dispatcher struct {
+	queues    []chan job
+	next      uint32
+	waitUntil rpc.TxExecutionStatus
+	wg        sync.WaitGroup
+
+	mtx    sync.RWMutex
+	closed bool
+}
+
+// New returns a new dispatcher configured by cfg and starts its workers,
+// which run until Close is called.
+func New(cfg Config) (*Dispatcher, error) {
+	if len(cfg.Signers) == 0 {
+		return nil, errors.New("txpool: no signers")
+	}
+	size := cfg.QueueSize
+	if size == 0 {
+		size = DefaultQueueSize
+	}
+	opts := cfg.SenderOptions
+	if opts.BlockHashes == nil {
+		opts.BlockHashes = transaction.NewBlockHashCache(cfg.Client, 0)
+	}
+	d := &Dispatcher{waitUntil: cfg.WaitUntil}
+	for _, s := range cfg.Signers {
+		q := make(chan job, size)
+		d.queues = append(d.queues, q)
+		d.wg.Add(1)
+		go d.work(transaction.NewSenderWithOptions(cfg.Client, s, opts), q)
+	}
+	return d, nil
+}
+
+func (d *Dispatcher) work(s *transaction.Sender, q <-chan job) {
+	defer d.wg.Done()
+	id, pk := s.Signer().AccountID(), s.Signer().PublicKey().String()
+	for j := range q {
+		r := Result{Request: j.req, SignerID: id, PublicKey: pk}
+		if r.Err = j.ctx.Err(); r.Err == nil {
+			r.Outcome, r.Err = s.Send(j.ctx, j.req.ReceiverID, d.waitUntil, j.req.Actions...)
+		}
+		j.res <- r
+	}
+}
+
+// Submit queues req on the key with a free slot, preferring the keys
+// round-robin, and returns the channel receiving its result. If the queues
+// of all keys are full, it blocks until a slot of the next key is free or
+// ctx is done. The transaction is sent with ctx, so it is skipped with the
+// error of ctx if ctx is done before its turn.
+func (d *Dispatcher) Submit(ctx context.Context, req Request) (<-chan Result, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	j := job{ctx: ctx, req: req, res: make(chan Result, 1)}
+	q := int((atomic.AddUint32(&d.next, 1) - 1) % uint32(len(d.queues)))
+	for i := range d.queues {
+		select {
+		case d.queues[(q+i)%len(d.queues)] <- j:
+			return j.res, nil
+		default:
+		}
+	}
+	select {
+	case d.queues[q] <- j:
+		return j.res, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SubmitAll submits reqs in order and returns a channel receiving their
+// results in the order of completion, which is closed after the last
+// result. It stops submitting if a submission fails and returns the
+// results of the submitted requests and the error.
+func (d *Dispatcher) SubmitAll(ctx context.Context, reqs []Request) (<-chan Result, error) {
+	results := make(chan Result, len(reqs))
+	var wg sync.WaitGroup
+	var err error
+	for _, req := range reqs {
+		var res <-chan Result
+		if res, err = d.Submit(ctx, req); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- <-res
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results, err
+}
+
+// Close stops accepting requests and waits until the queued requests are
+// sent.
+func (d *Dispatcher) Close() {
+	d.mtx.Lock()
+	if d.closed {
+		d.mtx.Unlock()
+		return
+	}
+	d.closed = true
+	for _, q := range d.queues {
+		close(q)
+	}
+	d.mtx.Unlock()
+	d.wg.Wait()
+}
@@ -0,0 +1,167 @@
+package txpool
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YuxSccc/near-api-go/rpc/rpctest"
+	"github.com/YuxSccc/near-api-go/signer"
+	"github.com/YuxSccc/near-api-go/transaction"
+	"github.com/YuxSccc/near-api-go/types"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+func testSigner(t *testing.T, accountID string) signer.Signer {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := signer.NewEd25519Signer(accountID, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// newTestServer returns a server whose send_tx calls are answered by sendTx
+// with the decoded transaction.
+func newTestServer(sendTx func(*transaction.Transaction) error) *rpctest.Server {
+	srv := rpctest.NewServer()
+	srv.Query("view_access_key", rpctest.Result(map[string]interface{}{
+		"nonce":      1,
+		"permission": "FullAccess",
+	}))
+	srv.Handle("block", rpctest.Result(map[string]interface{}{
+		"header": map[string]interface{}{"hash": base58.Encode(make([]byte, 32))},
+	}))
+	srv.Handle("send_tx", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			SignedTx string `json:"signed_tx_base64"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		data, err := base64.StdEncoding.DecodeString(p.SignedTx)
+		if err != nil {
+			return nil, err
+		}
+		st, err := transaction.DeserializeSigned(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := sendTx(&st.Transaction); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"final_execution_status": "FINAL"}, nil
+	})
+	return srv
+}
+
+func TestDispatcher(t *testing.T) {
+	var mtx sync.Mutex
+	nonces := make(map[string][]uint64)
+	srv := newTestServer(func(tx *transaction.Transaction) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		pk := tx.PublicKey.String()
+		nonces[pk] = append(nonces[pk], tx.Nonce)
+		return nil
+	})
+	defer srv.Close()
+	signers := []signer.Signer{testSigner(t, "alice.testnet"), testSigner(t, "alice.testnet")}
+	d, err := New(Config{Client: srv.Client(), Signers: signers, QueueSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+
+	var reqs []Request
+	for i := 0; i < 20; i++ {
+		reqs = append(reqs, Request{
+			ID:         fmt.Sprint(i),
+			ReceiverID: "bob.testnet",
+			Actions:    []transaction.Action{transaction.Transfer(types.NewBalance(big.NewInt(int64(i))))},
+		})
+	}
+	results, err := d.SubmitAll(context.Background(), reqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool)
+	for r := range results {
+		if r.Err != nil || r.Outcome == nil || r.SignerID != "alice.testnet" {
+			t.Errorf("result %+v", r)
+		}
+		ids[r.Request.ID] = true
+	}
+	if len(ids) != len(reqs) {
+		t.Errorf("SubmitAll() returned %d results (want %d)", len(ids), len(reqs))
+	}
+
+	if len(nonces) != len(signers) {
+		t.Errorf("Dispatcher sent with %d keys (want %d)", len(nonces), len(signers))
+	}
+	for pk, ns := range nonces {
+		for i, n := range ns {
+			if n != uint64(i+2) {
+				t.Errorf("Dispatcher sent nonces %v with key %s (want consecutive nonces from 2)", ns, pk)
+				break
+			}
+		}
+	}
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	srv := newTestServer(func(*transaction.Transaction) error {
+		started <- struct{}{}
+		<-release
+		return errors.New("rejected")
+	})
+	defer srv.Close()
+	d, err := New(Config{Client: srv.Client(), Signers: []signer.Signer{testSigner(t, "alice.testnet")}, QueueSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := Request{ReceiverID: "bob.testnet", Actions: []transaction.Action{transaction.CreateAccount()}}
+	first, err := d.Submit(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	second, err := d.Submit(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := d.Submit(ctx, req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit() to full queue returned %v (want context.DeadlineExceeded)", err)
+	}
+
+	close(release)
+	for _, res := range []<-chan Result{first, second} {
+		if r := <-res; r.Err == nil {
+			t.Errorf("Submit() returned result %+v (want error)", r)
+		}
+	}
+	d.Close()
+	if _, err := d.Submit(context.Background(), req); !errors.Is(err, ErrClosed) {
+		t.Errorf("Submit() after Close() returned %v (want ErrClosed)", err)
+	}
+}
+
+func TestNewWithoutSigners(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("New() without signers succeeded")
+	}
+}
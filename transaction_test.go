@@ -1,9 +1,12 @@
 package near
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/YuxSccc/near-api-go/keystore"
 	"github.com/near/borsh-go"
 )
 
@@ -19,3 +22,28 @@ func TestSignedTransactionStruct(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSignTransaction(t *testing.T) {
+	kp, err := keystore.GenerateEd25519KeyPair("test-account.testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := kp.Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blockHash [32]byte
+	txHash, signedTx, err := signTransaction("receiver.testnet", 1, []Action{{
+		Enum:     3,
+		Transfer: Transfer{Deposit: *big.NewInt(1)},
+	}}, blockHash[:], s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Transaction.SignerID != "test-account.testnet" {
+		t.Errorf("SignerID is %s", signedTx.Transaction.SignerID)
+	}
+	if !ed25519.Verify(kp.Ed25519PubKey, txHash, signedTx.Signature.ED25519.Data[:]) {
+		t.Error("transaction signature does not verify")
+	}
+}
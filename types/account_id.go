@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAccountID is returned (wrapped) by ParseAccountID for IDs which
+// violate the rules of NEAR account IDs.
+var ErrInvalidAccountID = errors.New("types: invalid account ID")
+
+// The length limits of account IDs.
+const (
+	MinAccountIDLength = 2
+	MaxAccountIDLength = 64
+)
+
+// AccountID is a valid NEAR account ID, see ParseAccountID.
+type AccountID string
+
+// ParseAccountID validates the account ID s. Account IDs are 2 to 64
+// characters long and consist of lowercase letters, digits and the
+// separators '-', '_' and '.', which may neither start or end the ID nor
+// follow each other. Parts separated by '.' are the parent accounts, e.g.
+// "alice.near" is a sub account of "near".
+//
+// For details see https://nomicon.io/DataStructures/Account#account-id-rules
+func ParseAccountID(s string) (AccountID, error) {
+	if len(s) < MinAccountIDLength || len(s) > MaxAccountIDLength {
+		return "", fmt.Errorf("%w: '%s' is not %d to %d characters long", ErrInvalidAccountID, s, MinAccountIDLength, MaxAccountIDLength)
+	}
+	separator := true // the ID must not start with a separator
+	for i, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z' || c >= '0' && c <= '9':
+			separator = false
+		case c == '-' || c == '_' || c == '.':
+			if separator {
+				return "", fmt.Errorf("%w: '%s' has misplaced separator at %d", ErrInvalidAccountID, s, i)
+			}
+			separator = true
+		default:
+			return "", fmt.Errorf("%w: '%s' contains invalid character %q", ErrInvalidAccountID, s, c)
+		}
+	}
+	if separator {
+		return "", fmt.Errorf("%w: '%s' ends with separator", ErrInvalidAccountID, s)
+	}
+	return AccountID(s), nil
+}
+
+// String returns the account ID as string.
+func (id AccountID) String() string {
+	return string(id)
+}
+
+// IsImplicit reports whether id is a NEAR implicit account, the 64 character
+// hex encoding of an Ed25519 public key.
+func (id AccountID) IsImplicit() bool {
+	return len(id) == 64 && isLowerHex(string(id))
+}
+
+// IsEthImplicit reports whether id is an ETH implicit account, a "0x"
+// prefixed 40 character hex encoding of an Ethereum address.
+func (id AccountID) IsEthImplicit() bool {
+	return len(id) == 42 && strings.HasPrefix(string(id), "0x") && isLowerHex(string(id[2:]))
+}
+
+// IsTopLevel reports whether id has no parent account, e.g. "near".
+func (id AccountID) IsTopLevel() bool {
+	return !strings.Contains(string(id), ".")
+}
+
+// Parent returns the parent account of id, empty for top-level accounts.
+func (id AccountID) Parent() AccountID {
+	if i := strings.IndexByte(string(id), '.'); i >= 0 {
+		return id[i+1:]
+	}
+	return ""
+}
+
+// IsSubAccountOf reports whether id is a direct sub account of parent.
+func (id AccountID) IsSubAccountOf(parent AccountID) bool {
+	return parent != "" && id.Parent() == parent
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
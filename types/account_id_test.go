@@ -0,0 +1,53 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseAccountID(t *testing.T) {
+	for _, s := range []string{
+		"aa", "near", "alice.near", "a-b_c.testnet", "0x1", "app.alice.testnet",
+		strings.Repeat("a", 64),
+	} {
+		if id, err := ParseAccountID(s); err != nil || id.String() != s {
+			t.Errorf("ParseAccountID(%q) returned %q, %v", s, id, err)
+		}
+	}
+	for _, s := range []string{
+		"", "a", strings.Repeat("a", 65), "Alice.near", "alice near", "alice@near",
+		".near", "alice.", "-alice", "alice_", "alice..near", "alice-.near", "a__b",
+	} {
+		if _, err := ParseAccountID(s); !errors.Is(err, ErrInvalidAccountID) {
+			t.Errorf("ParseAccountID(%q) returned %v (want ErrInvalidAccountID)", s, err)
+		}
+	}
+}
+
+func TestAccountIDKinds(t *testing.T) {
+	for _, tt := range []struct {
+		id                            AccountID
+		implicit, ethImplicit, topLev bool
+		parent                        AccountID
+	}{
+		{"near", false, false, true, ""},
+		{"alice.near", false, false, false, "near"},
+		{"app.alice.near", false, false, false, "alice.near"},
+		{AccountID(strings.Repeat("0f", 32)), true, false, true, ""},
+		{AccountID(strings.Repeat("0F", 32)), false, false, true, ""},
+		{AccountID("0x" + strings.Repeat("ab", 20)), false, true, true, ""},
+		{AccountID("0x" + strings.Repeat("ab", 19)), false, false, true, ""},
+	} {
+		if tt.id.IsImplicit() != tt.implicit || tt.id.IsEthImplicit() != tt.ethImplicit ||
+			tt.id.IsTopLevel() != tt.topLev || tt.id.Parent() != tt.parent {
+			t.Errorf("%s is implicit %v, ETH implicit %v, top-level %v with parent %q (want %v, %v, %v, %q)", tt.id,
+				tt.id.IsImplicit(), tt.id.IsEthImplicit(), tt.id.IsTopLevel(), tt.id.Parent(),
+				tt.implicit, tt.ethImplicit, tt.topLev, tt.parent)
+		}
+	}
+	if !AccountID("alice.near").IsSubAccountOf("near") || AccountID("app.alice.near").IsSubAccountOf("near") ||
+		AccountID("near").IsSubAccountOf("") {
+		t.Error("IsSubAccountOf() returned wrong result")
+	}
+}
@@ -0,0 +1,60 @@
+// Package types defines NEAR primitive types shared by the RPC client,
+// transactions and accounts.
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Balance is an amount of yoctoNEAR. It is a u128 which nearcore encodes as
+// decimal string in JSON.
+type Balance struct {
+	big.Int
+}
+
+// NewBalance returns a new balance with the value of i.
+func NewBalance(i *big.Int) Balance {
+	var b Balance
+	b.Set(i)
+	return b
+}
+
+// ParseBalance parses a balance in yoctoNEAR from the decimal string s.
+func ParseBalance(s string) (Balance, error) {
+	var b Balance
+	if _, ok := b.SetString(s, 10); !ok || b.Sign() < 0 {
+		return Balance{}, fmt.Errorf("types: invalid balance '%s'", s)
+	}
+	return b, nil
+}
+
+// BigInt returns a copy of the balance as big.Int.
+func (b Balance) BigInt() *big.Int {
+	return new(big.Int).Set(&b.Int)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Balance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decimal strings and numbers are
+// accepted.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("types: invalid balance %s", data)
+		}
+		s = n.String()
+	}
+	parsed, err := ParseBalance(s)
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestBalanceJSON(t *testing.T) {
+	var v struct {
+		Amount Balance `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"340282366920938463463374607431768211455"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	if v.Amount.Cmp(max) != 0 {
+		t.Errorf("json.Unmarshal() returned %s (want %s)", &v.Amount, max)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":"340282366920938463463374607431768211455"}` {
+		t.Errorf("json.Marshal() returned %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":42}`), &v); err != nil || v.Amount.Int64() != 42 {
+		t.Errorf("json.Unmarshal() returned %s, %v for number", &v.Amount, err)
+	}
+	for _, s := range []string{`"-1"`, `"1.5"`, `true`} {
+		if err := json.Unmarshal([]byte(s), &v.Amount); err == nil {
+			t.Errorf("json.Unmarshal() accepted %s", s)
+		}
+	}
+}
@@ -1,22 +1,161 @@
 package utils
 
-import "crypto/ed25519"
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/near/borsh-go"
+)
 
 // All supported key types
 const (
-	ED25519 = 0
+	ED25519   = 0
+	SECP256K1 = 1
+)
+
+// Length of the public key data for all supported key types.
+const (
+	Ed25519PublicKeyLength   = 32
+	Secp256k1PublicKeyLength = 64
 )
 
-// PublicKey encoding for NEAR.
+// PublicKey encoding for NEAR. It is a Borsh enum with one variant per key
+// type, the KeyType selects the variant. In JSON and other text encodings it
+// is the "<key type>:<base58>" string, see String and ParsePublicKey.
 type PublicKey struct {
-	KeyType uint8
-	Data    [32]byte
+	KeyType   borsh.Enum `borsh_enum:"true"` // treat struct as complex enum when serializing/deserializing
+	ED25519   Ed25519PublicKey
+	SECP256K1 Secp256k1PublicKey
+}
+
+// Ed25519PublicKey is the data of an Ed25519 public key.
+type Ed25519PublicKey struct {
+	Data [Ed25519PublicKeyLength]byte
+}
+
+// Secp256k1PublicKey is the data of an uncompressed secp256k1 public key
+// without the leading 0x04 byte.
+type Secp256k1PublicKey struct {
+	Data [Secp256k1PublicKeyLength]byte
 }
 
 // PublicKeyFromEd25519 derives a public key in NEAR encoding from pk.
 func PublicKeyFromEd25519(pk ed25519.PublicKey) PublicKey {
 	var pubKey PublicKey
 	pubKey.KeyType = ED25519
-	copy(pubKey.Data[:], pk)
+	copy(pubKey.ED25519.Data[:], pk)
+	return pubKey
+}
+
+// PublicKeyFromSecp256k1 derives a public key in NEAR encoding from the 64
+// byte uncompressed secp256k1 public key pk (without the leading 0x04 byte).
+func PublicKeyFromSecp256k1(pk []byte) PublicKey {
+	var pubKey PublicKey
+	pubKey.KeyType = SECP256K1
+	copy(pubKey.SECP256K1.Data[:], pk)
 	return pubKey
 }
+
+// Bytes returns the raw key data of the public key.
+func (pk PublicKey) Bytes() []byte {
+	if pk.KeyType == SECP256K1 {
+		return pk.SECP256K1.Data[:]
+	}
+	return pk.ED25519.Data[:]
+}
+
+// KeyTypeName returns the textual name of the key type keyType as used in
+// key prefixes ("ed25519" or "secp256k1").
+func KeyTypeName(keyType borsh.Enum) string {
+	switch keyType {
+	case ED25519:
+		return "ed25519"
+	case SECP256K1:
+		return "secp256k1"
+	default:
+		return fmt.Sprintf("unknown(%d)", keyType)
+	}
+}
+
+// ParseKeyTypeName returns the key type with the textual name (as returned by
+// KeyTypeName).
+func ParseKeyTypeName(name string) (borsh.Enum, error) {
+	switch name {
+	case "ed25519":
+		return ED25519, nil
+	case "secp256k1":
+		return SECP256K1, nil
+	default:
+		return 0, fmt.Errorf("utils: unknown key type '%s'", name)
+	}
+}
+
+// String returns the public key in the "<key type>:<base58>" format used by
+// NEAR tooling and RPC.
+func (pk PublicKey) String() string {
+	return KeyTypeName(pk.KeyType) + ":" + base58.Encode(pk.Bytes())
+}
+
+// Equal reports whether pk and other are the same key. Only the data of the
+// variant selected by the key type is compared.
+func (pk PublicKey) Equal(other PublicKey) bool {
+	return pk.KeyType == other.KeyType && bytes.Equal(pk.Bytes(), other.Bytes())
+}
+
+// MarshalText implements encoding.TextMarshaler, which is used for JSON as
+// well.
+func (pk PublicKey) MarshalText() ([]byte, error) {
+	if pk.KeyType != ED25519 && pk.KeyType != SECP256K1 {
+		return nil, fmt.Errorf("utils: unknown key type %d", pk.KeyType)
+	}
+	return []byte(pk.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, which is used for JSON
+// as well.
+func (pk *PublicKey) UnmarshalText(text []byte) error {
+	parsed, err := ParsePublicKey(string(text))
+	if err != nil {
+		return err
+	}
+	*pk = parsed
+	return nil
+}
+
+// ParsePublicKey parses a public key in the "<key type>:<base58>" format used
+// by NEAR tooling and RPC.
+func ParsePublicKey(s string) (PublicKey, error) {
+	keyType, data, err := parseKey(s)
+	if err != nil {
+		return PublicKey{}, fmt.Errorf("utils: invalid public key '%s': %v", s, err)
+	}
+	switch {
+	case keyType == ED25519 && len(data) == Ed25519PublicKeyLength:
+		return PublicKeyFromEd25519(data), nil
+	case keyType == SECP256K1 && len(data) == Secp256k1PublicKeyLength:
+		return PublicKeyFromSecp256k1(data), nil
+	}
+	return PublicKey{}, fmt.Errorf("utils: invalid public key '%s': invalid length %d", s, len(data))
+}
+
+// parseKey splits the key string s into the key type and the decoded data.
+func parseKey(s string) (borsh.Enum, []byte, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return 0, nil, errors.New("missing key type")
+	}
+	keyType, err := ParseKeyTypeName(s[:i])
+	if err != nil {
+		return 0, nil, errors.New("unknown key type")
+	}
+	enc := s[i+1:]
+	data := base58.Decode(enc)
+	if len(data) == 0 && len(enc) > 0 {
+		return 0, nil, errors.New("invalid base58 encoding")
+	}
+	return keyType, data, nil
+}
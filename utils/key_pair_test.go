@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/near/borsh-go"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pk := range []PublicKey{
+		PublicKeyFromEd25519(pub),
+		PublicKeyFromSecp256k1(privKey.PubKey().SerializeUncompressed()[1:]),
+	} {
+		parsed, err := ParsePublicKey(pk.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != pk {
+			t.Errorf("ParsePublicKey(%s) returned %s", pk, parsed)
+		}
+	}
+	for _, s := range []string{"", "ed25519:", "ed25519:0OIl", "rsa:abc", "ed25519:3xMCa5LzZtCgvRC1aTC6t9QeFUWLrhSiu5EJPsGfkvX"} {
+		if _, err := ParsePublicKey(s); err == nil {
+			t.Errorf("ParsePublicKey(%q) succeeded", s)
+		}
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pk := range []PrivateKey{PrivateKeyFromEd25519(priv), PrivateKeyFromSecp256k1(privKey)} {
+		parsed, err := ParsePrivateKey(pk.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.String() != pk.String() || parsed.PublicKey() != pk.PublicKey() {
+			t.Errorf("ParsePrivateKey(%s) returned %s", pk, parsed)
+		}
+	}
+	// seed
+	seed, err := NewPrivateKey(ED25519, priv.Seed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !seed.Ed25519().Equal(priv) {
+		t.Error("NewPrivateKey() of seed returned different key")
+	}
+	// public key not matching seed
+	bad := append([]byte{}, priv...)
+	bad[63] ^= 1
+	if _, err := NewPrivateKey(ED25519, bad); err == nil {
+		t.Error("NewPrivateKey() accepted inconsistent private key")
+	}
+}
+
+func TestPublicKeyCodecs(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := PublicKeyFromEd25519(pub)
+	// JSON
+	type wrapper struct {
+		PublicKey PublicKey `json:"public_key"`
+	}
+	data, err := json.Marshal(wrapper{pk})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"public_key":"`+pk.String()+`"}` {
+		t.Errorf("json.Marshal() returned %s", data)
+	}
+	var w wrapper
+	if err := json.Unmarshal(data, &w); err != nil {
+		t.Fatal(err)
+	}
+	if !w.PublicKey.Equal(pk) {
+		t.Errorf("json.Unmarshal() returned %s (want %s)", w.PublicKey, pk)
+	}
+	if err := json.Unmarshal([]byte(`{"public_key":"ed25519:invalid"}`), &w); err == nil {
+		t.Error("json.Unmarshal() accepted invalid public key")
+	}
+	// Borsh
+	buf, err := borsh.Serialize(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buf) != 1+Ed25519PublicKeyLength || buf[0] != ED25519 {
+		t.Errorf("borsh.Serialize() returned %x", buf)
+	}
+	var decoded PublicKey
+	if err := borsh.Deserialize(&decoded, buf); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(pk) {
+		t.Errorf("borsh.Deserialize() returned %s (want %s)", decoded, pk)
+	}
+	// Equal ignores the data of the other variant
+	other := pk
+	other.SECP256K1.Data[0] = 1
+	if !other.Equal(pk) {
+		t.Error("Equal() compared unused variant")
+	}
+	other.KeyType = SECP256K1
+	if other.Equal(pk) {
+		t.Error("Equal() ignored key type")
+	}
+}
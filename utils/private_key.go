@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/near/borsh-go"
+)
+
+// Secp256k1PrivateKeyLength is the length of a secp256k1 private key.
+const Secp256k1PrivateKeyLength = 32
+
+// PrivateKey is a private key of one of the supported key types. For Ed25519
+// keys Data is the 64 byte private key (seed and public key), for secp256k1
+// keys the 32 byte scalar.
+type PrivateKey struct {
+	KeyType borsh.Enum
+	Data    []byte
+}
+
+// PrivateKeyFromEd25519 returns the private key for the Ed25519 key pk.
+func PrivateKeyFromEd25519(pk ed25519.PrivateKey) PrivateKey {
+	return PrivateKey{KeyType: ED25519, Data: append([]byte{}, pk...)}
+}
+
+// PrivateKeyFromSecp256k1 returns the private key for the secp256k1 key pk.
+func PrivateKeyFromSecp256k1(pk *btcec.PrivateKey) PrivateKey {
+	return PrivateKey{KeyType: SECP256K1, Data: pk.Serialize()}
+}
+
+// ParsePrivateKey parses a private key in the "<key type>:<base58>" format
+// used by NEAR tooling. Ed25519 keys are accepted as 64 byte private key or as
+// 32 byte seed.
+func ParsePrivateKey(s string) (PrivateKey, error) {
+	keyType, data, err := parseKey(s)
+	if err != nil {
+		return PrivateKey{}, fmt.Errorf("utils: invalid private key: %v", err)
+	}
+	return NewPrivateKey(keyType, data)
+}
+
+// NewPrivateKey returns the private key of keyType with the raw key data.
+// Ed25519 keys are accepted as 64 byte private key or as 32 byte seed.
+func NewPrivateKey(keyType borsh.Enum, data []byte) (PrivateKey, error) {
+	switch {
+	case keyType == ED25519 && len(data) == ed25519.PrivateKeySize:
+		pk := ed25519.NewKeyFromSeed(data[:ed25519.SeedSize])
+		if !pk.Equal(ed25519.PrivateKey(data)) {
+			return PrivateKey{}, fmt.Errorf("utils: invalid private key: public key does not match seed")
+		}
+		return PrivateKeyFromEd25519(pk), nil
+	case keyType == ED25519 && len(data) == ed25519.SeedSize:
+		return PrivateKeyFromEd25519(ed25519.NewKeyFromSeed(data)), nil
+	case keyType == SECP256K1 && len(data) == Secp256k1PrivateKeyLength:
+		return PrivateKey{KeyType: SECP256K1, Data: append([]byte{}, data...)}, nil
+	}
+	return PrivateKey{}, fmt.Errorf("utils: invalid %s private key length %d", KeyTypeName(keyType), len(data))
+}
+
+// String returns the private key in the "<key type>:<base58>" format used by
+// NEAR tooling.
+func (pk PrivateKey) String() string {
+	return KeyTypeName(pk.KeyType) + ":" + base58.Encode(pk.Data)
+}
+
+// PublicKey returns the public key of the private key.
+func (pk PrivateKey) PublicKey() PublicKey {
+	if pk.KeyType == SECP256K1 {
+		return PublicKeyFromSecp256k1(pk.Secp256k1().PubKey().SerializeUncompressed()[1:])
+	}
+	return PublicKeyFromEd25519(pk.Ed25519().Public().(ed25519.PublicKey))
+}
+
+// Ed25519 returns the private key as Ed25519 key. It must only be called for
+// keys of type ED25519.
+func (pk PrivateKey) Ed25519() ed25519.PrivateKey {
+	return ed25519.PrivateKey(pk.Data)
+}
+
+// Secp256k1 returns the private key as secp256k1 key. It must only be called
+// for keys of type SECP256K1.
+func (pk PrivateKey) Secp256k1() *btcec.PrivateKey {
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), pk.Data)
+	return privKey
+}